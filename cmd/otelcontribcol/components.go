@@ -35,6 +35,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/stackdriverexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/dockerobserver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecsobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor"
@@ -69,6 +71,8 @@ func components() (component.Factories, error) {
 	extensions := []component.ExtensionFactory{
 		k8sobserver.NewFactory(),
 		hostobserver.NewFactory(),
+		dockerobserver.NewFactory(),
+		ecsobserver.NewFactory(),
 		httpforwarder.NewFactory(),
 	}
 
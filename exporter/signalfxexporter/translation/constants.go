@@ -32,11 +32,17 @@ translation_rules:
     k8s.daemonset.uid: kubernetes_uid
     k8s.deployment.name: kubernetes_name
     k8s.deployment.uid: kubernetes_uid
+    k8s.endpoints.name: kubernetes_name
+    k8s.endpoints.uid: kubernetes_uid
     k8s.hpa.name: kubernetes_name
     k8s.hpa.uid: kubernetes_uid
     k8s.namespace.name: kubernetes_namespace
     k8s.node.name: kubernetes_node
     k8s.node.uid: kubernetes_node_uid
+    k8s.persistentvolume.name: kubernetes_name
+    k8s.persistentvolume.uid: kubernetes_uid
+    k8s.persistentvolumeclaim.name: kubernetes_name
+    k8s.persistentvolumeclaim.uid: kubernetes_uid
     k8s.pod.name: kubernetes_pod_name
     k8s.pod.uid: kubernetes_pod_uid
     k8s.replicaset.name: kubernetes_name
@@ -45,6 +51,8 @@ translation_rules:
     k8s.replicationcontroller.uid: kubernetes_uid
     k8s.resourcequota.name: quota_name
     k8s.resourcequota.uid: kubernetes_uid
+    k8s.service.name: kubernetes_name
+    k8s.service.uid: kubernetes_uid
     k8s.statefulset.name: kubernetes_name
     k8s.statefulset.uid: kubernetes_uid
     host.name: host
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for docker observer.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// The URL of the docker server.  Default is "unix:///var/run/docker.sock"
+	Endpoint string `mapstructure:"endpoint"`
+
+	// The maximum amount of time to wait for docker API responses.  Default is 5s
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// A list of filters whose matching images are to be excluded.  Supports literals, globs, and regex.
+	ExcludedImages []string `mapstructure:"excluded_images"`
+
+	// RefreshInterval determines how often the tracked containers are
+	// checked for new or removed endpoints.  Default is 10s
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
+	// UseHostBindings makes the endpoints target the host address and port
+	// a container port is published at, when it is, rather than the
+	// container address and port.  Default is false
+	UseHostBindings bool `mapstructure:"use_host_bindings"`
+
+	// The version of the Docker API to use.  Default is "v1.22"
+	DockerAPIVersion string `mapstructure:"api_version"`
+}
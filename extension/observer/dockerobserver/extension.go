@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	dtypes "github.com/docker/docker/api/types"
+	"github.com/docker/go-connections/nat"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/containerimage"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
+)
+
+type dockerObserver struct {
+	observer.EndpointsWatcher
+	logger *zap.Logger
+	config *Config
+	cancel context.CancelFunc
+	lister *endpointsLister
+}
+
+type endpointsLister struct {
+	logger          *zap.Logger
+	observerName    string
+	useHostBindings bool
+	client          *docker.Client
+}
+
+var _ component.ServiceExtension = (*dockerObserver)(nil)
+
+func newObserver(logger *zap.Logger, config *Config) (component.ServiceExtension, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("config.Endpoint must be specified")
+	}
+
+	lister := &endpointsLister{
+		logger:          logger,
+		observerName:    config.Name(),
+		useHostBindings: config.UseHostBindings,
+	}
+	d := &dockerObserver{
+		EndpointsWatcher: observer.EndpointsWatcher{
+			RefreshInterval: config.RefreshInterval,
+			Endpointslister: lister,
+		},
+		logger: logger,
+		config: config,
+		lister: lister,
+	}
+
+	return d, nil
+}
+
+func (d *dockerObserver) Start(ctx context.Context, host component.Host) error {
+	client, err := docker.NewDockerClient(&docker.Config{
+		Endpoint:         d.config.Endpoint,
+		Timeout:          d.config.Timeout,
+		ExcludedImages:   d.config.ExcludedImages,
+		DockerAPIVersion: d.config.DockerAPIVersion,
+	}, d.logger)
+	if err != nil {
+		return err
+	}
+	d.lister.client = client
+
+	var watchCtx context.Context
+	watchCtx, d.cancel = context.WithCancel(context.Background())
+	go func() {
+		// The event loop keeps tracking the containers started afterwards
+		// even when the daemon can't be reached at first.
+		if err := client.LoadContainerList(watchCtx); err != nil {
+			d.logger.Error("Could not load the list of docker containers", zap.Error(err))
+		}
+		client.ContainerEventLoop(watchCtx)
+	}()
+
+	return nil
+}
+
+func (d *dockerObserver) Shutdown(context.Context) error {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.StopListAndWatch()
+	return nil
+}
+
+func (e *endpointsLister) ListEndpoints() []observer.Endpoint {
+	if e.client == nil {
+		return nil
+	}
+
+	var endpoints []observer.Endpoint
+	for _, c := range e.client.Containers() {
+		endpoints = append(endpoints, e.containerEndpoints(c.ContainerJSON)...)
+	}
+	return endpoints
+}
+
+// containerEndpoints returns an endpoint for each port exposed by the container.
+func (e *endpointsLister) containerEndpoints(c *dtypes.ContainerJSON) []observer.Endpoint {
+	if c.Config == nil || len(c.Config.ExposedPorts) == 0 {
+		return nil
+	}
+
+	name := strings.TrimPrefix(c.Name, "/")
+	image, tag := containerimage.Parse(c.Config.Image)
+	network, host := containerNetwork(c)
+
+	var portMap nat.PortMap
+	if c.NetworkSettings != nil {
+		portMap = c.NetworkSettings.Ports
+	}
+
+	endpoints := make([]observer.Endpoint, 0, len(c.Config.ExposedPorts))
+	for port := range c.Config.ExposedPorts {
+		portNumber, err := strconv.ParseUint(port.Port(), 10, 16)
+		if err != nil {
+			e.logger.Debug("Skipping invalid container port",
+				zap.String("id", c.ID), zap.String("port", string(port)))
+			continue
+		}
+		transport := portTransport(port.Proto())
+
+		target := host
+		targetPort := uint16(portNumber)
+		hostIP, alternatePort := hostBinding(portMap[port])
+		if e.useHostBindings && alternatePort != 0 {
+			target = hostIP
+			targetPort = alternatePort
+		}
+		if target == "" {
+			continue
+		}
+
+		endpoints = append(endpoints, observer.Endpoint{
+			ID: observer.EndpointID(
+				fmt.Sprintf("(%s)%s-%d-%s", e.observerName, c.ID, portNumber, transport),
+			),
+			Target: net.JoinHostPort(target, strconv.Itoa(int(targetPort))),
+			Details: observer.Container{
+				Name:          name,
+				Image:         image,
+				Tag:           tag,
+				ContainerID:   c.ID,
+				Port:          uint16(portNumber),
+				AlternatePort: alternatePort,
+				Transport:     transport,
+				Host:          host,
+				Network:       network,
+				Labels:        c.Config.Labels,
+			},
+		})
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].ID < endpoints[j].ID })
+	return endpoints
+}
+
+// containerNetwork returns the name of the network the container is
+// reachable at along with the container address in it. The network of the
+// network mode is preferred, falling back to the first network with an
+// address in name order.
+func containerNetwork(c *dtypes.ContainerJSON) (string, string) {
+	if c.HostConfig != nil && c.HostConfig.NetworkMode.IsHost() {
+		// Containers share the host network stack.
+		return "host", "127.0.0.1"
+	}
+	if c.NetworkSettings == nil {
+		return "", ""
+	}
+
+	networks := c.NetworkSettings.Networks
+	if c.HostConfig != nil {
+		mode := c.HostConfig.NetworkMode.NetworkName()
+		if n, ok := networks[mode]; ok && n != nil && n.IPAddress != "" {
+			return mode, n.IPAddress
+		}
+	}
+
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if n := networks[name]; n != nil && n.IPAddress != "" {
+			return name, n.IPAddress
+		}
+	}
+
+	return "", c.NetworkSettings.IPAddress
+}
+
+// hostBinding returns the host address and port of the first binding of a
+// published port, zero values when it isn't published.
+func hostBinding(bindings []nat.PortBinding) (string, uint16) {
+	for _, b := range bindings {
+		port, err := strconv.ParseUint(b.HostPort, 10, 16)
+		if err != nil || port == 0 {
+			continue
+		}
+		ip := b.HostIP
+		// An IP addr of 0.0.0.0 means it is bound on all interfaces,
+		// including localhost, so use that since we can't actually connect
+		// to 0.0.0.0.
+		if ip == "" || ip == "0.0.0.0" || ip == "::" {
+			ip = "127.0.0.1"
+		}
+		return ip, uint16(port)
+	}
+	return "", 0
+}
+
+func portTransport(proto string) observer.Transport {
+	switch proto {
+	case "tcp":
+		return observer.ProtocolTCP
+	case "udp":
+		return observer.ProtocolUDP
+	default:
+		return observer.ProtocolUnknown
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerobserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dtypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func redisContainer() *dtypes.ContainerJSON {
+	return &dtypes.ContainerJSON{
+		ContainerJSONBase: &dtypes.ContainerJSONBase{
+			ID:         "c6f8b58a2c71",
+			Name:       "/redis-cache",
+			State:      &dtypes.ContainerState{Running: true},
+			HostConfig: &container.HostConfig{NetworkMode: "default"},
+		},
+		Config: &container.Config{
+			Image: "redis:6.0",
+			ExposedPorts: nat.PortSet{
+				"6379/tcp": struct{}{},
+				"9121/tcp": struct{}{},
+			},
+			Labels: map[string]string{"app": "cache"},
+		},
+		NetworkSettings: &dtypes.NetworkSettings{
+			NetworkSettingsBase: dtypes.NetworkSettingsBase{
+				Ports: nat.PortMap{
+					"6379/tcp": []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: "16379"}},
+				},
+			},
+			Networks: map[string]*network.EndpointSettings{
+				"backend": {IPAddress: "172.18.0.2"},
+				"bridge":  {IPAddress: "172.17.0.2"},
+			},
+		},
+	}
+}
+
+func TestContainerEndpoints(t *testing.T) {
+	redisDetails := observer.Container{
+		Name:          "redis-cache",
+		Image:         "redis",
+		Tag:           "6.0",
+		ContainerID:   "c6f8b58a2c71",
+		Port:          6379,
+		AlternatePort: 16379,
+		Transport:     observer.ProtocolTCP,
+		Host:          "172.18.0.2",
+		Network:       "backend",
+		Labels:        map[string]string{"app": "cache"},
+	}
+	exporterDetails := observer.Container{
+		Name:        "redis-cache",
+		Image:       "redis",
+		Tag:         "6.0",
+		ContainerID: "c6f8b58a2c71",
+		Port:        9121,
+		Transport:   observer.ProtocolTCP,
+		Host:        "172.18.0.2",
+		Network:     "backend",
+		Labels:      map[string]string{"app": "cache"},
+	}
+
+	tests := []struct {
+		name            string
+		container       func() *dtypes.ContainerJSON
+		useHostBindings bool
+		want            []observer.Endpoint
+	}{
+		{
+			name:      "container address",
+			container: redisContainer,
+			want: []observer.Endpoint{
+				{
+					ID:      "(docker_observer)c6f8b58a2c71-6379-TCP",
+					Target:  "172.18.0.2:6379",
+					Details: redisDetails,
+				},
+				{
+					ID:      "(docker_observer)c6f8b58a2c71-9121-TCP",
+					Target:  "172.18.0.2:9121",
+					Details: exporterDetails,
+				},
+			},
+		},
+		{
+			name:            "host bindings",
+			container:       redisContainer,
+			useHostBindings: true,
+			want: []observer.Endpoint{
+				{
+					ID:      "(docker_observer)c6f8b58a2c71-6379-TCP",
+					Target:  "127.0.0.1:16379",
+					Details: redisDetails,
+				},
+				{
+					ID:      "(docker_observer)c6f8b58a2c71-9121-TCP",
+					Target:  "172.18.0.2:9121",
+					Details: exporterDetails,
+				},
+			},
+		},
+		{
+			name: "network mode",
+			container: func() *dtypes.ContainerJSON {
+				c := redisContainer()
+				c.HostConfig.NetworkMode = "bridge"
+				delete(c.Config.ExposedPorts, "9121/tcp")
+				return c
+			},
+			want: []observer.Endpoint{
+				{
+					ID:     "(docker_observer)c6f8b58a2c71-6379-TCP",
+					Target: "172.17.0.2:6379",
+					Details: func() observer.Container {
+						d := redisDetails
+						d.Host = "172.17.0.2"
+						d.Network = "bridge"
+						return d
+					}(),
+				},
+			},
+		},
+		{
+			name: "host network",
+			container: func() *dtypes.ContainerJSON {
+				c := redisContainer()
+				c.HostConfig.NetworkMode = "host"
+				c.Config.ExposedPorts = nat.PortSet{"8125/udp": struct{}{}}
+				return c
+			},
+			want: []observer.Endpoint{
+				{
+					ID:     "(docker_observer)c6f8b58a2c71-8125-UDP",
+					Target: "127.0.0.1:8125",
+					Details: observer.Container{
+						Name:        "redis-cache",
+						Image:       "redis",
+						Tag:         "6.0",
+						ContainerID: "c6f8b58a2c71",
+						Port:        8125,
+						Transport:   observer.ProtocolUDP,
+						Host:        "127.0.0.1",
+						Network:     "host",
+						Labels:      map[string]string{"app": "cache"},
+					},
+				},
+			},
+		},
+		{
+			name: "untagged image",
+			container: func() *dtypes.ContainerJSON {
+				c := redisContainer()
+				c.Config.Image = "localhost:5000/redis@sha256:5b21d0ca5a8e"
+				delete(c.Config.ExposedPorts, "9121/tcp")
+				return c
+			},
+			want: []observer.Endpoint{
+				{
+					ID:     "(docker_observer)c6f8b58a2c71-6379-TCP",
+					Target: "172.18.0.2:6379",
+					Details: func() observer.Container {
+						d := redisDetails
+						d.Image = "localhost:5000/redis"
+						d.Tag = ""
+						return d
+					}(),
+				},
+			},
+		},
+		{
+			name: "no exposed ports",
+			container: func() *dtypes.ContainerJSON {
+				c := redisContainer()
+				c.Config.ExposedPorts = nil
+				return c
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &endpointsLister{
+				logger:          zap.NewNop(),
+				observerName:    "docker_observer",
+				useHostBindings: tt.useHostBindings,
+			}
+			assert.Equal(t, tt.want, e.containerEndpoints(tt.container()))
+		})
+	}
+}
+
+type endpointSink struct {
+	sync.Mutex
+	added   []observer.Endpoint
+	removed []observer.Endpoint
+	changed []observer.Endpoint
+}
+
+func (e *endpointSink) OnAdd(added []observer.Endpoint) {
+	e.Lock()
+	defer e.Unlock()
+	e.added = append(e.added, added...)
+}
+
+func (e *endpointSink) OnRemove(removed []observer.Endpoint) {
+	e.Lock()
+	defer e.Unlock()
+	e.removed = append(e.removed, removed...)
+}
+
+func (e *endpointSink) OnChange(changed []observer.Endpoint) {
+	e.Lock()
+	defer e.Unlock()
+	e.changed = append(e.changed, changed...)
+}
+
+var _ observer.Notify = (*endpointSink)(nil)
+
+func TestObserverTracksContainers(t *testing.T) {
+	redis := redisContainer()
+	destroy := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/containers/json"):
+			json.NewEncoder(w).Encode([]dtypes.Container{{ID: redis.ID, Image: redis.Config.Image}})
+		case strings.HasSuffix(r.URL.Path, "/containers/"+redis.ID+"/json"):
+			json.NewEncoder(w).Encode(redis)
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			w.(http.Flusher).Flush()
+			select {
+			case <-destroy:
+				json.NewEncoder(w).Encode(events.Message{
+					Type:     events.ContainerEventType,
+					Action:   "destroy",
+					ID:       redis.ID,
+					TimeNano: time.Now().UnixNano(),
+				})
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+			}
+			<-r.Context().Done()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = srv.URL
+	config.RefreshInterval = 10 * time.Millisecond
+
+	ext, err := newObserver(zap.NewNop(), config)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	ext.(observer.Observable).ListAndWatch(sink)
+
+	require.Eventually(t, func() bool {
+		sink.Lock()
+		defer sink.Unlock()
+		return len(sink.added) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	close(destroy)
+	require.Eventually(t, func() bool {
+		sink.Lock()
+		defer sink.Unlock()
+		return len(sink.removed) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestShutdownWithoutListAndWatch(t *testing.T) {
+	ext, err := newObserver(zap.NewNop(), createDefaultConfig().(*Config))
+	require.NoError(t, err)
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
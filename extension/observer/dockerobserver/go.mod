@@ -0,0 +1,20 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/dockerobserver
+
+go 1.14
+
+require (
+	github.com/docker/docker v17.12.0-ce-rc1.0.20200706150819-a40b877fbb9e+incompatible
+	github.com/docker/go-connections v0.4.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer v0.0.0-00010101000000-000000000000
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.0.0-00010101000000-000000000000
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/collector v0.11.1-0.20201006165100-07236c11fb27
+	go.uber.org/zap v1.16.0
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer => ../
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/common => ../../../internal/common
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker => ../../../internal/docker
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecsobserver
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Config defines configuration for ECS observer.
+type Config struct {
+	configmodels.ExtensionSettings `mapstructure:",squash"`
+
+	// ClusterName is the name or the ARN of the ECS cluster whose tasks are
+	// observed.
+	ClusterName string `mapstructure:"cluster_name"`
+	// ClusterRegion is the AWS region of the cluster. The region is looked up
+	// like with the AWS CLI if empty, e.g. from the AWS_REGION environment
+	// variable.
+	ClusterRegion string `mapstructure:"cluster_region"`
+	// RefreshInterval determines how often to poll the ECS API for the running
+	// tasks of the cluster.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
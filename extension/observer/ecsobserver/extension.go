@@ -0,0 +1,377 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecsobserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/containerimage"
+)
+
+// The maximum number of tasks or container instances of a describe request.
+const describeBatchSize = 100
+
+type ecsObserver struct {
+	observer.EndpointsWatcher
+}
+
+type endpointsLister struct {
+	logger  *zap.Logger
+	cluster string
+	client  apiClient
+
+	// The task definitions and the IP addresses of the container instances
+	// looked up by the previous listing, by ARN.
+	taskDefinitions map[string]*ecs.TaskDefinition
+	instanceIPs     map[string]string
+	// The endpoints of the previous successful listing, returned while the
+	// AWS APIs fail so that the receivers of the endpoints aren't stopped.
+	endpoints []observer.Endpoint
+}
+
+var _ component.ServiceExtension = (*ecsObserver)(nil)
+
+func newObserver(logger *zap.Logger, config *Config, client apiClient) (component.ServiceExtension, error) {
+	e := &ecsObserver{
+		EndpointsWatcher: observer.EndpointsWatcher{
+			RefreshInterval: config.RefreshInterval,
+			Endpointslister: &endpointsLister{
+				logger:          logger,
+				cluster:         config.ClusterName,
+				client:          client,
+				taskDefinitions: map[string]*ecs.TaskDefinition{},
+				instanceIPs:     map[string]string{},
+			},
+		},
+	}
+
+	return e, nil
+}
+
+func (e *ecsObserver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (e *ecsObserver) Shutdown(context.Context) error {
+	e.StopListAndWatch()
+	return nil
+}
+
+// ListEndpoints returns an endpoint for each port of the containers of the
+// running tasks of the cluster, or the endpoints of the previous listing if
+// the AWS APIs fail.
+func (l *endpointsLister) ListEndpoints() []observer.Endpoint {
+	endpoints, err := l.listEndpoints()
+	if err != nil {
+		l.logger.Error("Could not list the ECS task endpoints, keeping the previous ones",
+			zap.String("cluster", l.cluster), zap.Int("endpoints", len(l.endpoints)), zap.Error(err))
+		return l.endpoints
+	}
+	l.endpoints = endpoints
+	return endpoints
+}
+
+func (l *endpointsLister) listEndpoints() ([]observer.Endpoint, error) {
+	tasks, err := l.listTasks()
+	if err != nil {
+		return nil, fmt.Errorf("could not list the tasks: %w", err)
+	}
+
+	taskDefinitions, err := l.describeTaskDefinitions(tasks)
+	if err != nil {
+		return nil, fmt.Errorf("could not describe the task definitions: %w", err)
+	}
+
+	instanceIPs, err := l.describeContainerInstances(tasks)
+	if err != nil {
+		return nil, fmt.Errorf("could not describe the container instances: %w", err)
+	}
+
+	l.taskDefinitions = taskDefinitions
+	l.instanceIPs = instanceIPs
+
+	var endpoints []observer.Endpoint
+	for _, task := range tasks {
+		taskDefinition := taskDefinitions[aws.StringValue(task.TaskDefinitionArn)]
+		instanceIP := instanceIPs[aws.StringValue(task.ContainerInstanceArn)]
+		endpoints = append(endpoints, taskEndpoints(task, taskDefinition, instanceIP)...)
+	}
+	return endpoints, nil
+}
+
+// listTasks returns the running tasks of the cluster.
+func (l *endpointsLister) listTasks() ([]*ecs.Task, error) {
+	var taskARNs []*string
+	err := l.client.ListTasksPages(&ecs.ListTasksInput{
+		Cluster:       aws.String(l.cluster),
+		DesiredStatus: aws.String(ecs.DesiredStatusRunning),
+	}, func(page *ecs.ListTasksOutput, lastPage bool) bool {
+		taskARNs = append(taskARNs, page.TaskArns...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*ecs.Task
+	for _, batch := range batches(taskARNs) {
+		output, err := l.client.DescribeTasks(&ecs.DescribeTasksInput{
+			Cluster: aws.String(l.cluster),
+			Tasks:   batch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		l.logFailures("Could not describe an ECS task", output.Failures)
+		for _, task := range output.Tasks {
+			// The tasks being started don't have the network settings of their
+			// containers yet.
+			if aws.StringValue(task.LastStatus) == ecs.DesiredStatusRunning {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+	return tasks, nil
+}
+
+// describeTaskDefinitions returns the definitions of the tasks by ARN. The
+// task definitions are immutable, so only the new ones are described.
+func (l *endpointsLister) describeTaskDefinitions(tasks []*ecs.Task) (map[string]*ecs.TaskDefinition, error) {
+	taskDefinitions := map[string]*ecs.TaskDefinition{}
+	for _, task := range tasks {
+		arn := aws.StringValue(task.TaskDefinitionArn)
+		if _, ok := taskDefinitions[arn]; ok {
+			continue
+		}
+		if taskDefinition, ok := l.taskDefinitions[arn]; ok {
+			taskDefinitions[arn] = taskDefinition
+			continue
+		}
+
+		output, err := l.client.DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String(arn),
+		})
+		if err != nil {
+			return nil, err
+		}
+		taskDefinitions[arn] = output.TaskDefinition
+	}
+	return taskDefinitions, nil
+}
+
+// describeContainerInstances returns the private IP addresses of the EC2
+// instances the tasks are running on by container instance ARN, only
+// describing the container instances which weren't known yet.
+func (l *endpointsLister) describeContainerInstances(tasks []*ecs.Task) (map[string]string, error) {
+	instanceIPs := map[string]string{}
+	seen := map[string]bool{}
+	var unknownARNs []*string
+	for _, task := range tasks {
+		arn := aws.StringValue(task.ContainerInstanceArn)
+		if arn == "" || seen[arn] {
+			continue
+		}
+		seen[arn] = true
+		if ip, ok := l.instanceIPs[arn]; ok {
+			instanceIPs[arn] = ip
+		} else {
+			unknownARNs = append(unknownARNs, task.ContainerInstanceArn)
+		}
+	}
+
+	for _, batch := range batches(unknownARNs) {
+		output, err := l.client.DescribeContainerInstances(&ecs.DescribeContainerInstancesInput{
+			Cluster:            aws.String(l.cluster),
+			ContainerInstances: batch,
+		})
+		if err != nil {
+			return nil, err
+		}
+		l.logFailures("Could not describe an ECS container instance", output.Failures)
+		if len(output.ContainerInstances) == 0 {
+			continue
+		}
+
+		arnsByInstanceID := map[string]string{}
+		var instanceIDs []*string
+		for _, ci := range output.ContainerInstances {
+			arnsByInstanceID[aws.StringValue(ci.Ec2InstanceId)] = aws.StringValue(ci.ContainerInstanceArn)
+			instanceIDs = append(instanceIDs, ci.Ec2InstanceId)
+		}
+
+		err = l.client.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+			InstanceIds: instanceIDs,
+		}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+			for _, reservation := range page.Reservations {
+				for _, instance := range reservation.Instances {
+					if ip := aws.StringValue(instance.PrivateIpAddress); ip != "" {
+						instanceIPs[arnsByInstanceID[aws.StringValue(instance.InstanceId)]] = ip
+					}
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return instanceIPs, nil
+}
+
+// logFailures logs the resources a describe request failed to return, e.g.
+// the tasks which were stopped since they were listed.
+func (l *endpointsLister) logFailures(msg string, failures []*ecs.Failure) {
+	for _, failure := range failures {
+		l.logger.Warn(msg,
+			zap.String("cluster", l.cluster),
+			zap.String("arn", aws.StringValue(failure.Arn)),
+			zap.String("reason", aws.StringValue(failure.Reason)),
+			zap.String("detail", aws.StringValue(failure.Detail)))
+	}
+}
+
+// taskEndpoints returns the endpoints of the ports of the containers of the
+// task. The ports of the containers are mapped to ports of the EC2 instance
+// with the bridge and host network modes, while each task has its own network
+// interface with the awsvpc network mode.
+func taskEndpoints(task *ecs.Task, taskDefinition *ecs.TaskDefinition, instanceIP string) []observer.Endpoint {
+	if taskDefinition == nil {
+		return nil
+	}
+
+	var endpoints []observer.Endpoint
+	seen := map[observer.EndpointID]bool{}
+	appendEndpoint := func(e observer.Endpoint) {
+		if !seen[e.ID] {
+			seen[e.ID] = true
+			endpoints = append(endpoints, e)
+		}
+	}
+
+	for _, container := range task.Containers {
+		containerDefinition := findContainerDefinition(taskDefinition, aws.StringValue(container.Name))
+		if containerDefinition == nil {
+			continue
+		}
+
+		if len(container.NetworkBindings) > 0 {
+			if instanceIP == "" {
+				continue
+			}
+			for _, binding := range container.NetworkBindings {
+				appendEndpoint(newEndpoint(task, taskDefinition, containerDefinition, instanceIP,
+					uint16(aws.Int64Value(binding.HostPort)), uint16(aws.Int64Value(binding.ContainerPort)),
+					aws.StringValue(binding.Protocol)))
+			}
+			continue
+		}
+
+		taskIP := containerIP(container)
+		if taskIP == "" {
+			continue
+		}
+		for _, mapping := range containerDefinition.PortMappings {
+			port := uint16(aws.Int64Value(mapping.ContainerPort))
+			appendEndpoint(newEndpoint(task, taskDefinition, containerDefinition, taskIP,
+				port, port, aws.StringValue(mapping.Protocol)))
+		}
+	}
+	return endpoints
+}
+
+func newEndpoint(
+	task *ecs.Task,
+	taskDefinition *ecs.TaskDefinition,
+	containerDefinition *ecs.ContainerDefinition,
+	host string,
+	port uint16,
+	containerPort uint16,
+	protocol string,
+) observer.Endpoint {
+	taskARN := aws.StringValue(task.TaskArn)
+	containerName := aws.StringValue(containerDefinition.Name)
+	image, tag := containerimage.Parse(aws.StringValue(containerDefinition.Image))
+
+	return observer.Endpoint{
+		ID:     observer.EndpointID(fmt.Sprintf("%s/%s/%d", taskARN, containerName, containerPort)),
+		Target: fmt.Sprintf("%s:%d", host, port),
+		Details: observer.ECSTask{
+			TaskARN:           taskARN,
+			TaskDefinitionARN: aws.StringValue(task.TaskDefinitionArn),
+			Family:            aws.StringValue(taskDefinition.Family),
+			Revision:          aws.Int64Value(taskDefinition.Revision),
+			LaunchType:        aws.StringValue(task.LaunchType),
+			ContainerName:     containerName,
+			Image:             image,
+			Tag:               tag,
+			Labels:            aws.StringValueMap(containerDefinition.DockerLabels),
+			Port:              port,
+			ContainerPort:     containerPort,
+			Transport:         transport(protocol),
+			Host:              host,
+		},
+	}
+}
+
+func findContainerDefinition(taskDefinition *ecs.TaskDefinition, name string) *ecs.ContainerDefinition {
+	for _, containerDefinition := range taskDefinition.ContainerDefinitions {
+		if aws.StringValue(containerDefinition.Name) == name {
+			return containerDefinition
+		}
+	}
+	return nil
+}
+
+// containerIP returns the private IP address of the network interface of the
+// task the container is attached to, with the awsvpc network mode.
+func containerIP(container *ecs.Container) string {
+	for _, networkInterface := range container.NetworkInterfaces {
+		if ip := aws.StringValue(networkInterface.PrivateIpv4Address); ip != "" {
+			return ip
+		}
+	}
+	return ""
+}
+
+func transport(protocol string) observer.Transport {
+	switch protocol {
+	case ecs.TransportProtocolUdp:
+		return observer.ProtocolUDP
+	default:
+		// The protocol of the port mappings defaults to TCP.
+		return observer.ProtocolTCP
+	}
+}
+
+// batches splits the ARNs in batches of the maximum size of a describe request.
+func batches(arns []*string) [][]*string {
+	var out [][]*string
+	for len(arns) > describeBatchSize {
+		out = append(out, arns[:describeBatchSize])
+		arns = arns[describeBatchSize:]
+	}
+	if len(arns) > 0 {
+		out = append(out, arns)
+	}
+	return out
+}
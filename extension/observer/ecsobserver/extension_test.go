@@ -0,0 +1,455 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecsobserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+	zapObserver "go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	bridgeTaskARN     = "arn:aws:ecs:us-west-2:123456789012:task/cluster/bridge"
+	awsvpcTaskARN     = "arn:aws:ecs:us-west-2:123456789012:task/cluster/awsvpc"
+	pendingTaskARN    = "arn:aws:ecs:us-west-2:123456789012:task/cluster/pending"
+	exporterDefARN    = "arn:aws:ecs:us-west-2:123456789012:task-definition/exporter:3"
+	appDefARN         = "arn:aws:ecs:us-west-2:123456789012:task-definition/app:1"
+	containerInstance = "arn:aws:ecs:us-west-2:123456789012:container-instance/cluster/instance"
+)
+
+// mockClient serves the tasks of a cluster, counting the requests.
+type mockClient struct {
+	sync.Mutex
+	tasks                           []*ecs.Task
+	taskDefinitions                 map[string]*ecs.TaskDefinition
+	err                             error
+	describeTaskDefinitionCalls     int
+	describeContainerInstancesCalls int
+}
+
+var _ apiClient = (*mockClient)(nil)
+
+func (m *mockClient) ListTasksPages(input *ecs.ListTasksInput, fn func(*ecs.ListTasksOutput, bool) bool) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	// one task per page
+	for i, task := range m.tasks {
+		if !fn(&ecs.ListTasksOutput{TaskArns: []*string{task.TaskArn}}, i == len(m.tasks)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func (m *mockClient) DescribeTasks(input *ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error) {
+	m.Lock()
+	defer m.Unlock()
+	output := &ecs.DescribeTasksOutput{}
+	for _, arn := range input.Tasks {
+		found := false
+		for _, task := range m.tasks {
+			if aws.StringValue(task.TaskArn) == aws.StringValue(arn) {
+				output.Tasks = append(output.Tasks, task)
+				found = true
+			}
+		}
+		if !found {
+			output.Failures = append(output.Failures, &ecs.Failure{Arn: arn, Reason: aws.String("MISSING")})
+		}
+	}
+	return output, nil
+}
+
+func (m *mockClient) DescribeTaskDefinition(input *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.describeTaskDefinitionCalls++
+	taskDefinition, ok := m.taskDefinitions[aws.StringValue(input.TaskDefinition)]
+	if !ok {
+		return nil, fmt.Errorf("unknown task definition %s", aws.StringValue(input.TaskDefinition))
+	}
+	return &ecs.DescribeTaskDefinitionOutput{TaskDefinition: taskDefinition}, nil
+}
+
+func (m *mockClient) DescribeContainerInstances(input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.describeContainerInstancesCalls++
+	output := &ecs.DescribeContainerInstancesOutput{}
+	for _, arn := range input.ContainerInstances {
+		if aws.StringValue(arn) == containerInstance {
+			output.ContainerInstances = append(output.ContainerInstances, &ecs.ContainerInstance{
+				ContainerInstanceArn: arn,
+				Ec2InstanceId:        aws.String("i-0123456789"),
+			})
+		}
+	}
+	return output, nil
+}
+
+func (m *mockClient) DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	output := &ec2.DescribeInstancesOutput{}
+	for _, id := range input.InstanceIds {
+		if aws.StringValue(id) == "i-0123456789" {
+			output.Reservations = append(output.Reservations, &ec2.Reservation{
+				Instances: []*ec2.Instance{{
+					InstanceId:       id,
+					PrivateIpAddress: aws.String("10.0.1.12"),
+				}},
+			})
+		}
+	}
+	fn(output, true)
+	return nil
+}
+
+func (m *mockClient) setTasks(tasks ...*ecs.Task) {
+	m.Lock()
+	defer m.Unlock()
+	m.tasks = tasks
+}
+
+// newMockClient returns a client serving:
+// - a task with the bridge network mode running on an EC2 instance, whose
+// exporter container port is mapped to a dynamic host port,
+// - a task with the awsvpc network mode running on Fargate, with an app
+// container whose two ports are reachable at the IP address of the task,
+// - a task being started.
+func newMockClient() *mockClient {
+	return &mockClient{
+		tasks: []*ecs.Task{
+			{
+				TaskArn:              aws.String(bridgeTaskARN),
+				TaskDefinitionArn:    aws.String(exporterDefARN),
+				ContainerInstanceArn: aws.String(containerInstance),
+				LaunchType:           aws.String(ecs.LaunchTypeEc2),
+				LastStatus:           aws.String(ecs.DesiredStatusRunning),
+				Containers: []*ecs.Container{{
+					Name: aws.String("exporter"),
+					NetworkBindings: []*ecs.NetworkBinding{{
+						BindIP:        aws.String("0.0.0.0"),
+						ContainerPort: aws.Int64(9100),
+						HostPort:      aws.Int64(32768),
+						Protocol:      aws.String(ecs.TransportProtocolTcp),
+					}},
+				}},
+			},
+			{
+				TaskArn:           aws.String(awsvpcTaskARN),
+				TaskDefinitionArn: aws.String(appDefARN),
+				LaunchType:        aws.String(ecs.LaunchTypeFargate),
+				LastStatus:        aws.String(ecs.DesiredStatusRunning),
+				Containers: []*ecs.Container{
+					{
+						Name: aws.String("app"),
+						NetworkInterfaces: []*ecs.NetworkInterface{{
+							PrivateIpv4Address: aws.String("10.0.2.34"),
+						}},
+					},
+					{
+						// not in the task definition
+						Name: aws.String("~internal~ecs~pause"),
+						NetworkInterfaces: []*ecs.NetworkInterface{{
+							PrivateIpv4Address: aws.String("10.0.2.34"),
+						}},
+					},
+				},
+			},
+			{
+				TaskArn:           aws.String(pendingTaskARN),
+				TaskDefinitionArn: aws.String(appDefARN),
+				LaunchType:        aws.String(ecs.LaunchTypeFargate),
+				LastStatus:        aws.String("PROVISIONING"),
+				Containers:        []*ecs.Container{{Name: aws.String("app")}},
+			},
+		},
+		taskDefinitions: map[string]*ecs.TaskDefinition{
+			exporterDefARN: {
+				TaskDefinitionArn: aws.String(exporterDefARN),
+				Family:            aws.String("exporter"),
+				Revision:          aws.Int64(3),
+				NetworkMode:       aws.String(ecs.NetworkModeBridge),
+				ContainerDefinitions: []*ecs.ContainerDefinition{{
+					Name:  aws.String("exporter"),
+					Image: aws.String("prom/node-exporter:v1.0.1"),
+					DockerLabels: map[string]*string{
+						"prometheus.io/scrape": aws.String("true"),
+					},
+					PortMappings: []*ecs.PortMapping{{
+						ContainerPort: aws.Int64(9100),
+						HostPort:      aws.Int64(0),
+						Protocol:      aws.String(ecs.TransportProtocolTcp),
+					}},
+				}},
+			},
+			appDefARN: {
+				TaskDefinitionArn: aws.String(appDefARN),
+				Family:            aws.String("app"),
+				Revision:          aws.Int64(1),
+				NetworkMode:       aws.String(ecs.NetworkModeAwsvpc),
+				ContainerDefinitions: []*ecs.ContainerDefinition{{
+					Name:  aws.String("app"),
+					Image: aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/app@sha256:8cf1bfb43ff5d9b05af9b6b63983440f137c6a08320fa7592197c1474ef30241"),
+					PortMappings: []*ecs.PortMapping{
+						{ContainerPort: aws.Int64(8080)},
+						{ContainerPort: aws.Int64(8125), Protocol: aws.String(ecs.TransportProtocolUdp)},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func newTestLister(client apiClient) *endpointsLister {
+	ext, _ := newObserver(zap.NewNop(), &Config{ClusterName: "cluster"}, client)
+	return ext.(*ecsObserver).Endpointslister.(*endpointsLister)
+}
+
+func TestListEndpoints(t *testing.T) {
+	lister := newTestLister(newMockClient())
+
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     observer.EndpointID(bridgeTaskARN + "/exporter/9100"),
+			Target: "10.0.1.12:32768",
+			Details: observer.ECSTask{
+				TaskARN:           bridgeTaskARN,
+				TaskDefinitionARN: exporterDefARN,
+				Family:            "exporter",
+				Revision:          3,
+				LaunchType:        "EC2",
+				ContainerName:     "exporter",
+				Image:             "prom/node-exporter",
+				Tag:               "v1.0.1",
+				Labels:            map[string]string{"prometheus.io/scrape": "true"},
+				Port:              32768,
+				ContainerPort:     9100,
+				Transport:         observer.ProtocolTCP,
+				Host:              "10.0.1.12",
+			},
+		},
+		{
+			ID:     observer.EndpointID(awsvpcTaskARN + "/app/8080"),
+			Target: "10.0.2.34:8080",
+			Details: observer.ECSTask{
+				TaskARN:           awsvpcTaskARN,
+				TaskDefinitionARN: appDefARN,
+				Family:            "app",
+				Revision:          1,
+				LaunchType:        "FARGATE",
+				ContainerName:     "app",
+				Image:             "123456789012.dkr.ecr.us-west-2.amazonaws.com/app",
+				Labels:            map[string]string{},
+				Port:              8080,
+				ContainerPort:     8080,
+				Transport:         observer.ProtocolTCP,
+				Host:              "10.0.2.34",
+			},
+		},
+		{
+			ID:     observer.EndpointID(awsvpcTaskARN + "/app/8125"),
+			Target: "10.0.2.34:8125",
+			Details: observer.ECSTask{
+				TaskARN:           awsvpcTaskARN,
+				TaskDefinitionARN: appDefARN,
+				Family:            "app",
+				Revision:          1,
+				LaunchType:        "FARGATE",
+				ContainerName:     "app",
+				Image:             "123456789012.dkr.ecr.us-west-2.amazonaws.com/app",
+				Labels:            map[string]string{},
+				Port:              8125,
+				ContainerPort:     8125,
+				Transport:         observer.ProtocolUDP,
+				Host:              "10.0.2.34",
+			},
+		},
+	}, lister.ListEndpoints())
+}
+
+func TestListEndpointsCachesDescriptions(t *testing.T) {
+	client := newMockClient()
+	lister := newTestLister(client)
+
+	require.Len(t, lister.ListEndpoints(), 3)
+	require.Len(t, lister.ListEndpoints(), 3)
+	assert.Equal(t, 2, client.describeTaskDefinitionCalls)
+	assert.Equal(t, 1, client.describeContainerInstancesCalls)
+
+	// the descriptions of the tasks which are gone are forgotten
+	client.setTasks(client.tasks[1])
+	require.Len(t, lister.ListEndpoints(), 2)
+	assert.Len(t, lister.taskDefinitions, 1)
+	assert.Len(t, lister.instanceIPs, 0)
+}
+
+func TestListEndpointsErrors(t *testing.T) {
+	client := newMockClient()
+	client.err = errors.New("throttled")
+	assert.Nil(t, newTestLister(client).ListEndpoints())
+
+	client = newMockClient()
+	delete(client.taskDefinitions, appDefARN)
+	assert.Nil(t, newTestLister(client).ListEndpoints())
+}
+
+func TestListEndpointsKeepsPreviousEndpointsOnError(t *testing.T) {
+	client := newMockClient()
+	lister := newTestLister(client)
+	endpoints := lister.ListEndpoints()
+	require.Len(t, endpoints, 3)
+
+	client.Lock()
+	client.err = errors.New("throttled")
+	client.Unlock()
+	assert.Equal(t, endpoints, lister.ListEndpoints())
+
+	client.Lock()
+	client.err = nil
+	client.Unlock()
+	client.setTasks(client.tasks[1])
+	assert.Len(t, lister.ListEndpoints(), 2)
+}
+
+func TestListEndpointsLogsDescribeFailures(t *testing.T) {
+	core, logs := zapObserver.New(zap.WarnLevel)
+	// a task stopped between its listing and its description
+	lister := newTestLister(&stoppedTaskClient{mockClient: newMockClient()})
+	lister.logger = zap.New(core)
+
+	require.Len(t, lister.ListEndpoints(), 3)
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "arn:aws:ecs:us-west-2:123456789012:task/cluster/stopped", logs.All()[0].ContextMap()["arn"])
+}
+
+// stoppedTaskClient lists a task which is gone when it is described.
+type stoppedTaskClient struct {
+	*mockClient
+}
+
+func (c *stoppedTaskClient) ListTasksPages(input *ecs.ListTasksInput, fn func(*ecs.ListTasksOutput, bool) bool) error {
+	if err := c.mockClient.ListTasksPages(input, fn); err != nil {
+		return err
+	}
+	fn(&ecs.ListTasksOutput{TaskArns: []*string{aws.String("arn:aws:ecs:us-west-2:123456789012:task/cluster/stopped")}}, true)
+	return nil
+}
+
+func TestListEndpointsWithoutInstanceIP(t *testing.T) {
+	client := newMockClient()
+	client.tasks[0].ContainerInstanceArn = aws.String("arn:aws:ecs:us-west-2:123456789012:container-instance/cluster/gone")
+
+	// the ports of the task can't be reached without the address of the instance
+	endpoints := newTestLister(client).ListEndpoints()
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, awsvpcTaskARN, endpoints[0].Details.(observer.ECSTask).TaskARN)
+}
+
+func TestBatches(t *testing.T) {
+	var arns []*string
+	for i := 0; i < 250; i++ {
+		arns = append(arns, aws.String(fmt.Sprint(i)))
+	}
+
+	out := batches(arns)
+	require.Len(t, out, 3)
+	assert.Len(t, out[0], 100)
+	assert.Len(t, out[1], 100)
+	assert.Len(t, out[2], 50)
+	assert.Equal(t, "249", aws.StringValue(out[2][49]))
+
+	assert.Nil(t, batches(nil))
+}
+
+type endpointSink struct {
+	sync.Mutex
+	added   []observer.Endpoint
+	removed []observer.Endpoint
+	changed []observer.Endpoint
+}
+
+func (e *endpointSink) OnAdd(added []observer.Endpoint) {
+	e.Lock()
+	defer e.Unlock()
+	e.added = append(e.added, added...)
+}
+
+func (e *endpointSink) OnRemove(removed []observer.Endpoint) {
+	e.Lock()
+	defer e.Unlock()
+	e.removed = append(e.removed, removed...)
+}
+
+func (e *endpointSink) OnChange(changed []observer.Endpoint) {
+	e.Lock()
+	defer e.Unlock()
+	e.changed = append(e.changed, changed...)
+}
+
+var _ observer.Notify = (*endpointSink)(nil)
+
+func TestObserverTracksTasks(t *testing.T) {
+	client := newMockClient()
+	config := createDefaultConfig().(*Config)
+	config.ClusterName = "cluster"
+	config.RefreshInterval = 10 * time.Millisecond
+
+	ext, err := newObserver(zap.NewNop(), config, client)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	ext.(observer.Observable).ListAndWatch(sink)
+
+	require.Eventually(t, func() bool {
+		sink.Lock()
+		defer sink.Unlock()
+		return len(sink.added) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the bridge task is stopped
+	client.setTasks(client.tasks[1])
+	require.Eventually(t, func() bool {
+		sink.Lock()
+		defer sink.Unlock()
+		return len(sink.removed) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, observer.EndpointID(bridgeTaskARN+"/exporter/9100"), sink.removed[0].ID)
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestShutdownWithoutListAndWatch(t *testing.T) {
+	ext, err := newObserver(zap.NewNop(), createDefaultConfig().(*Config), newMockClient())
+	require.NoError(t, err)
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -0,0 +1,16 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecsobserver
+
+go 1.14
+
+require (
+	github.com/aws/aws-sdk-go v1.35.2
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer v0.0.0-00010101000000-000000000000
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/collector v0.11.1-0.20201006165100-07236c11fb27
+	go.uber.org/zap v1.16.0
+)
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer => ../
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/common => ../../../internal/common
@@ -36,6 +36,10 @@ func (e *Endpoint) String() string {
 type Pod struct {
 	// Name of the pod.
 	Name string
+	// Namespace of the pod.
+	Namespace string
+	// UID is the unique ID in the cluster for the pod.
+	UID string
 	// Labels is a map of user-specified metadata.
 	Labels map[string]string
 	// Annotations is a map of user-specified metadata.
@@ -70,13 +74,143 @@ type HostPort struct {
 	IsIPv6 bool
 }
 
+// Container is a discovered docker container port.
+type Container struct {
+	// Name is the primary name of the container.
+	Name string
+	// Image is the name of the container image.
+	Image string
+	// Tag is the container image tag, e.g. '0.1'.
+	Tag string
+	// ContainerID is the id of the container exposing the Endpoint.
+	ContainerID string
+	// Port is the port exposed by the container.
+	Port uint16
+	// AlternatePort is the host port the exposed port is mapped to, 0 when
+	// the port isn't published.
+	AlternatePort uint16
+	// Transport is the transport protocol used by the Endpoint. (TCP or UDP).
+	Transport Transport
+	// Host is the IP address of the container in Network.
+	Host string
+	// Network is the name of the network the container is reachable at.
+	Network string
+	// Labels is a map of user-specified metadata on the container.
+	Labels map[string]string
+}
+
+// Service is a discovered k8s service port.
+type Service struct {
+	// Name of the service.
+	Name string
+	// Namespace of the service.
+	Namespace string
+	// UID is the unique ID in the cluster for the service.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// ServiceType is the type of the service, e.g. 'ClusterIP' or 'NodePort'.
+	ServiceType string
+	// ClusterIP is the IP address of the service, empty for headless services.
+	ClusterIP string
+	// PortName is the name of the service port.
+	PortName string
+	// Port number of the endpoint.
+	Port uint16
+	// Transport is the transport protocol used by the Endpoint. (TCP or UDP).
+	Transport Transport
+}
+
+// Node is a discovered k8s node.
+type Node struct {
+	// Name of the node.
+	Name string
+	// UID is the unique ID in the cluster for the node.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Hostname is the hostname reported by the node.
+	Hostname string
+	// InternalIP is the IP address of the node reachable from within the cluster.
+	InternalIP string
+	// ExternalIP is the externally routable IP address of the node, if any.
+	ExternalIP string
+	// KubeletEndpointPort is the port the kubelet listens on.
+	KubeletEndpointPort uint16
+}
+
+// Ingress is a discovered k8s ingress rule path.
+type Ingress struct {
+	// Name of the ingress.
+	Name string
+	// Namespace of the ingress.
+	Namespace string
+	// UID is the unique ID in the cluster for the ingress.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Host is the host the rule applies to, empty when it applies to all hosts.
+	Host string
+	// Path the rule matches.
+	Path string
+	// Scheme is 'https' when the host is covered by the ingress TLS settings, 'http' otherwise.
+	Scheme string
+	// ServiceName is the name of the service the path is routed to.
+	ServiceName string
+	// ServicePort is the port, number or name, of the service the path is routed to.
+	ServicePort string
+}
+
+// ECSTask is a discovered port of a container of an ECS task.
+type ECSTask struct {
+	// TaskARN is the ARN of the task.
+	TaskARN string
+	// TaskDefinitionARN is the ARN of the task definition of the task.
+	TaskDefinitionARN string
+	// Family is the family of the task definition.
+	Family string
+	// Revision is the revision of the task definition.
+	Revision int64
+	// LaunchType is the launch type of the task, 'EC2' or 'FARGATE'.
+	LaunchType string
+	// ContainerName is the name of the container in the task definition.
+	ContainerName string
+	// Image is the name of the container image.
+	Image string
+	// Tag is the container image tag, e.g. '0.1'.
+	Tag string
+	// Labels is a map of the Docker labels of the container.
+	Labels map[string]string
+	// Port is the port the Endpoint is reachable at, the host port the
+	// container port is mapped to with the bridge network mode.
+	Port uint16
+	// ContainerPort is the port exposed by the container.
+	ContainerPort uint16
+	// Transport is the transport protocol used by the Endpoint. (TCP or UDP).
+	Transport Transport
+	// Host is the IP address of the task with the awsvpc network mode, of the
+	// EC2 instance running the task otherwise.
+	Host string
+}
+
 type EndpointEnv map[string]interface{}
 
 // EndpointToEnv converts an endpoint into a map suitable for expr evaluation.
 func EndpointToEnv(endpoint Endpoint) (EndpointEnv, error) {
 	ruleTypes := map[string]interface{}{
-		"port": false,
-		"pod":  false,
+		"port":      false,
+		"pod":       false,
+		"container": false,
+		"service":   false,
+		"node":      false,
+		"ingress":   false,
+		"ecs_task":  false,
 	}
 
 	switch o := endpoint.Details.(type) {
@@ -86,6 +220,8 @@ func EndpointToEnv(endpoint Endpoint) (EndpointEnv, error) {
 			"type":        ruleTypes,
 			"endpoint":    endpoint.Target,
 			"name":        o.Name,
+			"namespace":   o.Namespace,
+			"uid":         o.UID,
 			"labels":      o.Labels,
 			"annotations": o.Annotations,
 		}, nil
@@ -98,6 +234,8 @@ func EndpointToEnv(endpoint Endpoint) (EndpointEnv, error) {
 			"port":     o.Port,
 			"pod": map[string]interface{}{
 				"name":        o.Pod.Name,
+				"namespace":   o.Pod.Namespace,
+				"uid":         o.Pod.UID,
 				"labels":      o.Pod.Labels,
 				"annotations": o.Pod.Annotations,
 			},
@@ -114,6 +252,87 @@ func EndpointToEnv(endpoint Endpoint) (EndpointEnv, error) {
 			"port":      o.Port,
 			"transport": o.Transport,
 		}, nil
+	case Container:
+		ruleTypes["container"] = true
+		return map[string]interface{}{
+			"type":           ruleTypes,
+			"endpoint":       endpoint.Target,
+			"name":           o.Name,
+			"image":          o.Image,
+			"tag":            o.Tag,
+			"container_id":   o.ContainerID,
+			"port":           o.Port,
+			"alternate_port": o.AlternatePort,
+			"transport":      o.Transport,
+			"host":           o.Host,
+			"network":        o.Network,
+			"labels":         o.Labels,
+		}, nil
+	case Service:
+		ruleTypes["service"] = true
+		return map[string]interface{}{
+			"type":         ruleTypes,
+			"endpoint":     endpoint.Target,
+			"name":         o.Name,
+			"namespace":    o.Namespace,
+			"uid":          o.UID,
+			"labels":       o.Labels,
+			"annotations":  o.Annotations,
+			"service_type": o.ServiceType,
+			"cluster_ip":   o.ClusterIP,
+			"port_name":    o.PortName,
+			"port":         o.Port,
+			"transport":    o.Transport,
+		}, nil
+	case Node:
+		ruleTypes["node"] = true
+		return map[string]interface{}{
+			"type":                  ruleTypes,
+			"endpoint":              endpoint.Target,
+			"name":                  o.Name,
+			"uid":                   o.UID,
+			"labels":                o.Labels,
+			"annotations":           o.Annotations,
+			"hostname":              o.Hostname,
+			"internal_ip":           o.InternalIP,
+			"external_ip":           o.ExternalIP,
+			"kubelet_endpoint_port": o.KubeletEndpointPort,
+		}, nil
+	case Ingress:
+		ruleTypes["ingress"] = true
+		return map[string]interface{}{
+			"type":         ruleTypes,
+			"endpoint":     endpoint.Target,
+			"name":         o.Name,
+			"namespace":    o.Namespace,
+			"uid":          o.UID,
+			"labels":       o.Labels,
+			"annotations":  o.Annotations,
+			"host":         o.Host,
+			"path":         o.Path,
+			"scheme":       o.Scheme,
+			"service_name": o.ServiceName,
+			"service_port": o.ServicePort,
+		}, nil
+	case ECSTask:
+		ruleTypes["ecs_task"] = true
+		return map[string]interface{}{
+			"type":                ruleTypes,
+			"endpoint":            endpoint.Target,
+			"task_arn":            o.TaskARN,
+			"task_definition_arn": o.TaskDefinitionARN,
+			"family":              o.Family,
+			"revision":            o.Revision,
+			"launch_type":         o.LaunchType,
+			"container_name":      o.ContainerName,
+			"image":               o.Image,
+			"tag":                 o.Tag,
+			"labels":              o.Labels,
+			"port":                o.Port,
+			"container_port":      o.ContainerPort,
+			"transport":           o.Transport,
+			"host":                o.Host,
+		}, nil
 
 	default:
 		return nil, fmt.Errorf("unknown endpoint details type %T", endpoint.Details)
@@ -32,7 +32,9 @@ func TestEndpointToEnv(t *testing.T) {
 				ID:     EndpointID("pod_id"),
 				Target: "192.68.73.2",
 				Details: Pod{
-					Name: "pod_name",
+					Name:      "pod_name",
+					Namespace: "pod_namespace",
+					UID:       "pod_uid",
 					Labels: map[string]string{
 						"label_key": "label_val",
 					},
@@ -43,11 +45,18 @@ func TestEndpointToEnv(t *testing.T) {
 			},
 			want: EndpointEnv{
 				"type": map[string]interface{}{
-					"port": false,
-					"pod":  true,
+					"port":      false,
+					"pod":       true,
+					"container": false,
+					"service":   false,
+					"node":      false,
+					"ingress":   false,
+					"ecs_task":  false,
 				},
-				"endpoint": "192.68.73.2",
-				"name":     "pod_name",
+				"endpoint":  "192.68.73.2",
+				"name":      "pod_name",
+				"namespace": "pod_namespace",
+				"uid":       "pod_uid",
 				"labels": map[string]string{
 					"label_key": "label_val",
 				},
@@ -65,7 +74,9 @@ func TestEndpointToEnv(t *testing.T) {
 				Details: Port{
 					Name: "port_name",
 					Pod: Pod{
-						Name: "pod_name",
+						Name:      "pod_name",
+						Namespace: "pod_namespace",
+						UID:       "pod_uid",
 						Labels: map[string]string{
 							"label_key": "label_val",
 						},
@@ -79,14 +90,21 @@ func TestEndpointToEnv(t *testing.T) {
 			},
 			want: EndpointEnv{
 				"type": map[string]interface{}{
-					"port": true,
-					"pod":  false,
+					"port":      true,
+					"pod":       false,
+					"container": false,
+					"service":   false,
+					"node":      false,
+					"ingress":   false,
+					"ecs_task":  false,
 				},
 				"endpoint": "192.68.73.2",
 				"name":     "port_name",
 				"port":     uint16(2379),
 				"pod": map[string]interface{}{
-					"name": "pod_name",
+					"name":      "pod_name",
+					"namespace": "pod_namespace",
+					"uid":       "pod_uid",
 					"labels": map[string]string{
 						"label_key": "label_val",
 					},
@@ -113,8 +131,13 @@ func TestEndpointToEnv(t *testing.T) {
 			},
 			want: EndpointEnv{
 				"type": map[string]interface{}{
-					"port": true,
-					"pod":  false,
+					"port":      true,
+					"pod":       false,
+					"container": false,
+					"service":   false,
+					"node":      false,
+					"ingress":   false,
+					"ecs_task":  false,
 				},
 				"endpoint":  "127.0.0.1",
 				"name":      "process_name",
@@ -125,6 +148,222 @@ func TestEndpointToEnv(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Container",
+			endpoint: Endpoint{
+				ID:     EndpointID("container_id"),
+				Target: "172.17.0.2:6379",
+				Details: Container{
+					Name:          "redis-cache",
+					Image:         "redis",
+					Tag:           "6.0",
+					ContainerID:   "c6f8b58a2c71",
+					Port:          6379,
+					AlternatePort: 16379,
+					Transport:     ProtocolTCP,
+					Host:          "172.17.0.2",
+					Network:       "bridge",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type": map[string]interface{}{
+					"port":      false,
+					"pod":       false,
+					"container": true,
+					"service":   false,
+					"node":      false,
+					"ingress":   false,
+					"ecs_task":  false,
+				},
+				"endpoint":       "172.17.0.2:6379",
+				"name":           "redis-cache",
+				"image":          "redis",
+				"tag":            "6.0",
+				"container_id":   "c6f8b58a2c71",
+				"port":           uint16(6379),
+				"alternate_port": uint16(16379),
+				"transport":      ProtocolTCP,
+				"host":           "172.17.0.2",
+				"network":        "bridge",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Service",
+			endpoint: Endpoint{
+				ID:     EndpointID("service_id"),
+				Target: "10.96.0.10:9153",
+				Details: Service{
+					Name:        "service_name",
+					Namespace:   "service_namespace",
+					UID:         "service_uid",
+					Labels:      map[string]string{"label_key": "label_val"},
+					Annotations: map[string]string{"annotation_1": "value_1"},
+					ServiceType: "ClusterIP",
+					ClusterIP:   "10.96.0.10",
+					PortName:    "metrics",
+					Port:        9153,
+					Transport:   ProtocolTCP,
+				},
+			},
+			want: EndpointEnv{
+				"type": map[string]interface{}{
+					"port":      false,
+					"pod":       false,
+					"container": false,
+					"service":   true,
+					"node":      false,
+					"ingress":   false,
+					"ecs_task":  false,
+				},
+				"endpoint":     "10.96.0.10:9153",
+				"name":         "service_name",
+				"namespace":    "service_namespace",
+				"uid":          "service_uid",
+				"labels":       map[string]string{"label_key": "label_val"},
+				"annotations":  map[string]string{"annotation_1": "value_1"},
+				"service_type": "ClusterIP",
+				"cluster_ip":   "10.96.0.10",
+				"port_name":    "metrics",
+				"port":         uint16(9153),
+				"transport":    ProtocolTCP,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Node",
+			endpoint: Endpoint{
+				ID:     EndpointID("node_id"),
+				Target: "192.168.1.10:10250",
+				Details: Node{
+					Name:                "node_name",
+					UID:                 "node_uid",
+					Labels:              map[string]string{"label_key": "label_val"},
+					Annotations:         map[string]string{"annotation_1": "value_1"},
+					Hostname:            "node-host",
+					InternalIP:          "192.168.1.10",
+					ExternalIP:          "34.1.2.3",
+					KubeletEndpointPort: 10250,
+				},
+			},
+			want: EndpointEnv{
+				"type": map[string]interface{}{
+					"port":      false,
+					"pod":       false,
+					"container": false,
+					"service":   false,
+					"node":      true,
+					"ingress":   false,
+					"ecs_task":  false,
+				},
+				"endpoint":              "192.168.1.10:10250",
+				"name":                  "node_name",
+				"uid":                   "node_uid",
+				"labels":                map[string]string{"label_key": "label_val"},
+				"annotations":           map[string]string{"annotation_1": "value_1"},
+				"hostname":              "node-host",
+				"internal_ip":           "192.168.1.10",
+				"external_ip":           "34.1.2.3",
+				"kubelet_endpoint_port": uint16(10250),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Ingress",
+			endpoint: Endpoint{
+				ID:     EndpointID("ingress_id"),
+				Target: "example.com",
+				Details: Ingress{
+					Name:        "ingress_name",
+					Namespace:   "ingress_namespace",
+					UID:         "ingress_uid",
+					Labels:      map[string]string{"label_key": "label_val"},
+					Annotations: map[string]string{"annotation_1": "value_1"},
+					Host:        "example.com",
+					Path:        "/api",
+					Scheme:      "https",
+					ServiceName: "api",
+					ServicePort: "8080",
+				},
+			},
+			want: EndpointEnv{
+				"type": map[string]interface{}{
+					"port":      false,
+					"pod":       false,
+					"container": false,
+					"service":   false,
+					"node":      false,
+					"ingress":   true,
+					"ecs_task":  false,
+				},
+				"endpoint":     "example.com",
+				"name":         "ingress_name",
+				"namespace":    "ingress_namespace",
+				"uid":          "ingress_uid",
+				"labels":       map[string]string{"label_key": "label_val"},
+				"annotations":  map[string]string{"annotation_1": "value_1"},
+				"host":         "example.com",
+				"path":         "/api",
+				"scheme":       "https",
+				"service_name": "api",
+				"service_port": "8080",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ECS task",
+			endpoint: Endpoint{
+				ID:     EndpointID("task_arn/container_name/9090"),
+				Target: "10.0.1.12:32768",
+				Details: ECSTask{
+					TaskARN:           "task_arn",
+					TaskDefinitionARN: "task_definition_arn",
+					Family:            "task_family",
+					Revision:          3,
+					LaunchType:        "EC2",
+					ContainerName:     "container_name",
+					Image:             "prom/node-exporter",
+					Tag:               "v1.0.1",
+					Labels:            map[string]string{"prometheus.io/scrape": "true"},
+					Port:              32768,
+					ContainerPort:     9090,
+					Transport:         ProtocolTCP,
+					Host:              "10.0.1.12",
+				},
+			},
+			want: EndpointEnv{
+				"type": map[string]interface{}{
+					"port":      false,
+					"pod":       false,
+					"container": false,
+					"service":   false,
+					"node":      false,
+					"ingress":   false,
+					"ecs_task":  true,
+				},
+				"endpoint":            "10.0.1.12:32768",
+				"task_arn":            "task_arn",
+				"task_definition_arn": "task_definition_arn",
+				"family":              "task_family",
+				"revision":            int64(3),
+				"launch_type":         "EC2",
+				"container_name":      "container_name",
+				"image":               "prom/node-exporter",
+				"tag":                 "v1.0.1",
+				"labels":              map[string]string{"prometheus.io/scrape": "true"},
+				"port":                uint16(32768),
+				"container_port":      uint16(9090),
+				"transport":           ProtocolTCP,
+				"host":                "10.0.1.12",
+			},
+			wantErr: false,
+		},
 		{
 			name: "Unsupported endpoint",
 			endpoint: Endpoint{
@@ -105,7 +105,10 @@ func (ew *EndpointsWatcher) refreshEndpoints(listener Notify) {
 
 // StopListAndWatch polling the ListEndpoints.
 func (ew *EndpointsWatcher) StopListAndWatch() {
-	close(ew.stop)
+	// ListAndWatch might not have been called if nothing subscribed to the observer.
+	if ew.stop != nil {
+		close(ew.stop)
+	}
 }
 
 // EndpointsLister that provides a list of endpoints.
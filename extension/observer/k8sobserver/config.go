@@ -36,4 +36,13 @@ type Config struct {
 	//
 	// Then set this value to ${K8S_NODE_NAME} in the configuration.
 	Node string `mapstructure:"node"`
+
+	// ObservePods determines whether pods and their container ports are discovered, true by default.
+	ObservePods bool `mapstructure:"observe_pods"`
+	// ObserveServices determines whether the ports of the services of the cluster are discovered.
+	ObserveServices bool `mapstructure:"observe_services"`
+	// ObserveNodes determines whether nodes are discovered, limited to Node when it is set.
+	ObserveNodes bool `mapstructure:"observe_nodes"`
+	// ObserveIngresses determines whether the paths of the ingresses of the cluster are discovered.
+	ObserveIngresses bool `mapstructure:"observe_ingresses"`
 }
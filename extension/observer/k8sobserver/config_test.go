@@ -38,7 +38,7 @@ func TestLoadConfig(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, cfg)
 
-	require.Len(t, cfg.Extensions, 2)
+	require.Len(t, cfg.Extensions, 3)
 
 	ext0 := cfg.Extensions["k8s_observer"]
 	assert.Equal(t, factory.CreateDefaultConfig(), ext0)
@@ -50,8 +50,24 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "k8s_observer",
 				NameVal: "k8s_observer/1",
 			},
-			Node:      "node-1",
-			APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+			Node:        "node-1",
+			APIConfig:   k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+			ObservePods: true,
 		},
 		ext1)
+
+	ext2 := cfg.Extensions["k8s_observer/2"]
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: configmodels.ExtensionSettings{
+				TypeVal: "k8s_observer",
+				NameVal: "k8s_observer/2",
+			},
+			APIConfig:        k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+			ObservePods:      false,
+			ObserveServices:  true,
+			ObserveNodes:     true,
+			ObserveIngresses: true,
+		},
+		ext2)
 }
@@ -20,20 +20,23 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
 )
 
 type k8sObserver struct {
-	logger   *zap.Logger
-	informer cache.SharedInformer
-	stop     chan struct{}
-	config   *Config
+	logger    *zap.Logger
+	informers []cache.SharedInformer
+	stop      chan struct{}
+	config    *Config
 }
 
 func (k *k8sObserver) Start(ctx context.Context, host component.Host) error {
-	go k.informer.Run(k.stop)
+	for _, informer := range k.informers {
+		go informer.Run(k.stop)
+	}
 	return nil
 }
 
@@ -46,11 +49,34 @@ var _ (component.ServiceExtension) = (*k8sObserver)(nil)
 
 // ListAndWatch notifies watcher with the current state and sends subsequent state changes.
 func (k *k8sObserver) ListAndWatch(listener observer.Notify) {
-	k.informer.AddEventHandler(&handler{watcher: listener, idNamespace: k.config.Name()})
+	h := &handler{watcher: listener, idNamespace: k.config.Name()}
+	for _, informer := range k.informers {
+		informer.AddEventHandler(h)
+	}
 }
 
-// newObserver creates a new k8s observer extension.
-func newObserver(logger *zap.Logger, config *Config, listWatch cache.ListerWatcher) (component.ServiceExtension, error) {
-	informer := cache.NewSharedInformer(listWatch, &v1.Pod{}, 0)
-	return &k8sObserver{logger: logger, informer: informer, stop: make(chan struct{}), config: config}, nil
+// newObserver creates a new k8s observer extension watching the objects listed by the
+// given ListerWatchers, nil ones being ignored.
+func newObserver(
+	logger *zap.Logger,
+	config *Config,
+	podListWatch cache.ListerWatcher,
+	serviceListWatch cache.ListerWatcher,
+	nodeListWatch cache.ListerWatcher,
+	ingressListWatch cache.ListerWatcher,
+) (component.ServiceExtension, error) {
+	var informers []cache.SharedInformer
+	if podListWatch != nil {
+		informers = append(informers, cache.NewSharedInformer(podListWatch, &v1.Pod{}, 0))
+	}
+	if serviceListWatch != nil {
+		informers = append(informers, cache.NewSharedInformer(serviceListWatch, &v1.Service{}, 0))
+	}
+	if nodeListWatch != nil {
+		informers = append(informers, cache.NewSharedInformer(nodeListWatch, &v1.Node{}, 0))
+	}
+	if ingressListWatch != nil {
+		informers = append(informers, cache.NewSharedInformer(ingressListWatch, &networkingv1beta1.Ingress{}, 0))
+	}
+	return &k8sObserver{logger: logger, informers: informers, stop: make(chan struct{}), config: config}, nil
 }
@@ -30,7 +30,7 @@ import (
 func TestNewExtension(t *testing.T) {
 	listWatch := framework.NewFakeControllerSource()
 	factory := &Factory{}
-	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), listWatch)
+	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), listWatch, nil, nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, ext)
 }
@@ -38,7 +38,7 @@ func TestNewExtension(t *testing.T) {
 func TestExtensionObserve(t *testing.T) {
 	listWatch := framework.NewFakeControllerSource()
 	factory := &Factory{}
-	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), listWatch)
+	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), listWatch, nil, nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, ext)
 	obs := ext.(*k8sObserver)
@@ -58,7 +58,9 @@ func TestExtensionObserve(t *testing.T) {
 		ID:     "k8s_observer/pod1-UID",
 		Target: "1.2.3.4",
 		Details: observer.Pod{
-			Name: "pod1",
+			Name:      "pod1",
+			Namespace: "default",
+			UID:       "pod1-UID",
 			Labels: map[string]string{
 				"env": "prod",
 			},
@@ -75,7 +77,9 @@ func TestExtensionObserve(t *testing.T) {
 		ID:     "k8s_observer/pod1-UID",
 		Target: "1.2.3.4",
 		Details: observer.Pod{
-			Name: "pod1",
+			Name:      "pod1",
+			Namespace: "default",
+			UID:       "pod1-UID",
 			Labels: map[string]string{
 				"env":         "prod",
 				"pod-version": "2",
@@ -85,3 +89,44 @@ func TestExtensionObserve(t *testing.T) {
 
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
+
+func TestExtensionObserveServicesAndNodes(t *testing.T) {
+	serviceListWatch := framework.NewFakeControllerSource()
+	nodeListWatch := framework.NewFakeControllerSource()
+	factory := &Factory{}
+	ext, err := newObserver(zap.NewNop(), factory.CreateDefaultConfig().(*Config), nil, serviceListWatch, nodeListWatch, nil)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+	obs := ext.(*k8sObserver)
+
+	serviceListWatch.Add(serviceWithPorts)
+	nodeListWatch.Add(node1)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	assertSink(t, sink, func() bool {
+		return len(sink.added) == 3
+	})
+
+	var ids []observer.EndpointID
+	for _, e := range sink.added {
+		ids = append(ids, e.ID)
+	}
+	assert.ElementsMatch(t, []observer.EndpointID{
+		"k8s_observer/service-1-UID/http(80)",
+		"k8s_observer/service-1-UID/metrics(9090)",
+		"k8s_observer/node-1-UID",
+	}, ids)
+
+	nodeListWatch.Delete(node1.DeepCopy())
+
+	assertSink(t, sink, func() bool {
+		return len(sink.removed) == 1
+	})
+	assert.Equal(t, observer.EndpointID("k8s_observer/node-1-UID"), sink.removed[0].ID)
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -54,7 +54,8 @@ func (f *Factory) CreateDefaultConfig() configmodels.Extension {
 			TypeVal: typeStr,
 			NameVal: string(typeStr),
 		},
-		APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		APIConfig:   k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		ObservePods: true,
 	}
 }
 
@@ -71,11 +72,30 @@ func (f *Factory) CreateExtension(
 		return nil, err
 	}
 
-	listWatch := cache.NewListWatchFromClient(
-		clientset.CoreV1().RESTClient(), "pods", v1.NamespaceAll,
-		fields.OneTermEqualSelector("spec.nodeName", config.Node))
+	var podListWatch, serviceListWatch, nodeListWatch, ingressListWatch cache.ListerWatcher
+	if config.ObservePods {
+		podListWatch = cache.NewListWatchFromClient(
+			clientset.CoreV1().RESTClient(), "pods", v1.NamespaceAll,
+			fields.OneTermEqualSelector("spec.nodeName", config.Node))
+	}
+	if config.ObserveServices {
+		serviceListWatch = cache.NewListWatchFromClient(
+			clientset.CoreV1().RESTClient(), "services", v1.NamespaceAll, fields.Everything())
+	}
+	if config.ObserveNodes {
+		nodeSelector := fields.Everything()
+		if config.Node != "" {
+			nodeSelector = fields.OneTermEqualSelector("metadata.name", config.Node)
+		}
+		nodeListWatch = cache.NewListWatchFromClient(
+			clientset.CoreV1().RESTClient(), "nodes", v1.NamespaceAll, nodeSelector)
+	}
+	if config.ObserveIngresses {
+		ingressListWatch = cache.NewListWatchFromClient(
+			clientset.NetworkingV1beta1().RESTClient(), "ingresses", v1.NamespaceAll, fields.Everything())
+	}
 
-	return newObserver(params.Logger, config, listWatch)
+	return newObserver(params.Logger, config, podListWatch, serviceListWatch, nodeListWatch, ingressListWatch)
 }
 
 // NewFactory should be called to create a factory with default values.
@@ -47,7 +47,8 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 			TypeVal: typeStr,
 			NameVal: string(typeStr),
 		},
-		APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		APIConfig:   k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		ObservePods: true,
 	},
 		cfg)
 
@@ -64,6 +65,16 @@ func TestFactory_CreateExtension(t *testing.T) {
 	ext, err := factory.CreateExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
 	require.NoError(t, err)
 	require.NotNil(t, ext)
+	assert.Len(t, ext.(*k8sObserver).informers, 1)
+
+	cfg.ObserveServices = true
+	cfg.ObserveNodes = true
+	cfg.ObserveIngresses = true
+	cfg.Node = "node-1"
+	ext, err = factory.CreateExtension(context.Background(), component.ExtensionCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+	assert.Len(t, ext.(*k8sObserver).informers, 4)
 }
 
 func TestNewFactory(t *testing.T) {
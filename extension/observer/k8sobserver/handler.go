@@ -19,6 +19,7 @@ import (
 	"reflect"
 
 	v1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
@@ -32,13 +33,29 @@ type handler struct {
 	watcher observer.Notify
 }
 
-// OnAdd is called in response to a pod being added.
+// OnAdd is called in response to an object being added.
 func (h *handler) OnAdd(obj interface{}) {
-	pod, ok := obj.(*v1.Pod)
-	if !ok {
+	endpoints := h.convertToEndpoints(obj)
+	if len(endpoints) == 0 {
 		return
 	}
-	h.watcher.OnAdd(h.convertPodToEndpoints(pod))
+	h.watcher.OnAdd(endpoints)
+}
+
+// convertToEndpoints converts the observed k8s object to endpoints, nil being returned
+// for unsupported objects.
+func (h *handler) convertToEndpoints(obj interface{}) []observer.Endpoint {
+	switch o := obj.(type) {
+	case *v1.Pod:
+		return h.convertPodToEndpoints(o)
+	case *v1.Service:
+		return h.convertServiceToEndpoints(o)
+	case *v1.Node:
+		return h.convertNodeToEndpoints(o)
+	case *networkingv1beta1.Ingress:
+		return h.convertIngressToEndpoints(o)
+	}
+	return nil
 }
 
 // convertPodToEndpoints converts a pod instance into a slice of endpoints. The endpoints
@@ -52,6 +69,8 @@ func (h *handler) convertPodToEndpoints(pod *v1.Pod) []observer.Endpoint {
 		Annotations: pod.Annotations,
 		Labels:      pod.Labels,
 		Name:        pod.Name,
+		Namespace:   pod.Namespace,
+		UID:         string(pod.UID),
 	}
 
 	endpoints := []observer.Endpoint{{
@@ -97,6 +116,154 @@ func (h *handler) convertPodToEndpoints(pod *v1.Pod) []observer.Endpoint {
 	return endpoints
 }
 
+// convertServiceToEndpoints creates an endpoint for each port of the service.
+func (h *handler) convertServiceToEndpoints(service *v1.Service) []observer.Endpoint {
+	serviceID := observer.EndpointID(fmt.Sprintf("%s/%s", h.idNamespace, service.UID))
+
+	// Headless services don't have a cluster IP, their DNS name resolves to
+	// the IPs of the backing pods instead.
+	clusterIP := service.Spec.ClusterIP
+	host := clusterIP
+	if clusterIP == v1.ClusterIPNone || clusterIP == "" {
+		clusterIP = ""
+		host = fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	}
+
+	var endpoints []observer.Endpoint
+	for _, port := range service.Spec.Ports {
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:     observer.EndpointID(fmt.Sprintf("%s/%s(%d)", serviceID, port.Name, port.Port)),
+			Target: fmt.Sprintf("%s:%d", host, port.Port),
+			Details: observer.Service{
+				Name:        service.Name,
+				Namespace:   service.Namespace,
+				UID:         string(service.UID),
+				Labels:      service.Labels,
+				Annotations: service.Annotations,
+				ServiceType: string(service.Spec.Type),
+				ClusterIP:   clusterIP,
+				PortName:    port.Name,
+				Port:        uint16(port.Port),
+				Transport:   getTransport(port.Protocol),
+			},
+		})
+	}
+
+	return endpoints
+}
+
+// convertNodeToEndpoints creates an endpoint targeting the kubelet of the node.
+func (h *handler) convertNodeToEndpoints(node *v1.Node) []observer.Endpoint {
+	details := observer.Node{
+		Name:                node.Name,
+		UID:                 string(node.UID),
+		Labels:              node.Labels,
+		Annotations:         node.Annotations,
+		KubeletEndpointPort: uint16(node.Status.DaemonEndpoints.KubeletEndpoint.Port),
+	}
+
+	for _, address := range node.Status.Addresses {
+		switch address.Type {
+		case v1.NodeHostName:
+			details.Hostname = address.Address
+		case v1.NodeInternalIP:
+			details.InternalIP = address.Address
+		case v1.NodeExternalIP:
+			details.ExternalIP = address.Address
+		}
+	}
+
+	host := details.InternalIP
+	if host == "" {
+		host = details.Hostname
+	}
+	if host == "" {
+		host = node.Name
+	}
+
+	target := host
+	if details.KubeletEndpointPort != 0 {
+		target = fmt.Sprintf("%s:%d", host, details.KubeletEndpointPort)
+	}
+
+	return []observer.Endpoint{{
+		ID:      observer.EndpointID(fmt.Sprintf("%s/%s", h.idNamespace, node.UID)),
+		Target:  target,
+		Details: details,
+	}}
+}
+
+// convertIngressToEndpoints creates an endpoint for each path of the ingress rules.
+func (h *handler) convertIngressToEndpoints(ingress *networkingv1beta1.Ingress) []observer.Endpoint {
+	ingressID := observer.EndpointID(fmt.Sprintf("%s/%s", h.idNamespace, ingress.UID))
+
+	// Rules without a host are reached through the address of the load balancer.
+	var loadBalancer string
+	if len(ingress.Status.LoadBalancer.Ingress) > 0 {
+		loadBalancer = ingress.Status.LoadBalancer.Ingress[0].IP
+		if loadBalancer == "" {
+			loadBalancer = ingress.Status.LoadBalancer.Ingress[0].Hostname
+		}
+	}
+
+	var endpoints []observer.Endpoint
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		target := rule.Host
+		if target == "" {
+			target = loadBalancer
+		}
+		if target == "" {
+			continue
+		}
+
+		scheme := "http"
+		if hasTLS(ingress, rule.Host) {
+			scheme = "https"
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:     observer.EndpointID(fmt.Sprintf("%s/%s%s", ingressID, rule.Host, path.Path)),
+				Target: target,
+				Details: observer.Ingress{
+					Name:        ingress.Name,
+					Namespace:   ingress.Namespace,
+					UID:         string(ingress.UID),
+					Labels:      ingress.Labels,
+					Annotations: ingress.Annotations,
+					Host:        rule.Host,
+					Path:        path.Path,
+					Scheme:      scheme,
+					ServiceName: path.Backend.ServiceName,
+					ServicePort: path.Backend.ServicePort.String(),
+				},
+			})
+		}
+	}
+
+	return endpoints
+}
+
+// hasTLS returns whether the host is covered by the TLS settings of the ingress, TLS
+// settings without hosts applying to all of them.
+func hasTLS(ingress *networkingv1beta1.Ingress, host string) bool {
+	for _, tls := range ingress.Spec.TLS {
+		if len(tls.Hosts) == 0 {
+			return true
+		}
+		for _, h := range tls.Hosts {
+			if h == host {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func getTransport(protocol v1.Protocol) observer.Transport {
 	switch protocol {
 	case v1.ProtocolTCP:
@@ -107,32 +274,23 @@ func getTransport(protocol v1.Protocol) observer.Transport {
 	return observer.ProtocolUnknown
 }
 
-// OnUpdate is called in response to an existing pod changing.
+// OnUpdate is called in response to an existing object changing.
 func (h *handler) OnUpdate(oldObj, newObj interface{}) {
-	oldPod, ok := oldObj.(*v1.Pod)
-	if !ok {
-		return
-	}
-	newPod, ok := newObj.(*v1.Pod)
-	if !ok {
-		return
-	}
-
 	oldEndpoints := map[observer.EndpointID]observer.Endpoint{}
 	newEndpoints := map[observer.EndpointID]observer.Endpoint{}
 
-	// Convert pods to endpoints and map by ID for easier lookup.
-	for _, e := range h.convertPodToEndpoints(oldPod) {
+	// Convert objects to endpoints and map by ID for easier lookup.
+	for _, e := range h.convertToEndpoints(oldObj) {
 		oldEndpoints[e.ID] = e
 	}
-	for _, e := range h.convertPodToEndpoints(newPod) {
+	for _, e := range h.convertToEndpoints(newObj) {
 		newEndpoints[e.ID] = e
 	}
 
 	var removedEndpoints, updatedEndpoints, addedEndpoints []observer.Endpoint
 
-	// Find endpoints that are present in oldPod and newPod and see if they've
-	// changed. Otherwise if it wasn't in oldPod it's a new endpoint.
+	// Find endpoints that are present in oldObj and newObj and see if they've
+	// changed. Otherwise if it wasn't in oldObj it's a new endpoint.
 	for _, e := range newEndpoints {
 		if existing, ok := oldEndpoints[e.ID]; ok {
 			if !reflect.DeepEqual(existing, e) {
@@ -143,7 +301,7 @@ func (h *handler) OnUpdate(oldObj, newObj interface{}) {
 		}
 	}
 
-	// If an endpoint is present in the oldPod but not in the newPod then
+	// If an endpoint is present in the oldObj but not in the newObj then
 	// send as removed.
 	for _, e := range oldEndpoints {
 		if _, ok := newEndpoints[e.ID]; !ok {
@@ -163,25 +321,25 @@ func (h *handler) OnUpdate(oldObj, newObj interface{}) {
 		h.watcher.OnAdd(addedEndpoints)
 	}
 
-	// TODO: can changes be missed where a pod is deleted but we don't
+	// TODO: can changes be missed where an object is deleted but we don't
 	// send remove notifications for some of its endpoints? If not provable
-	// then maybe keep track of pod -> endpoint association to be sure
+	// then maybe keep track of object -> endpoint association to be sure
 	// they are all cleaned up.
 }
 
-// OnDelete is called in response to a pod being deleted.
+// OnDelete is called in response to an object being deleted.
 func (h *handler) OnDelete(obj interface{}) {
-	var pod *v1.Pod
+	// Assuming we never saw the object state where new endpoints would have been created
+	// to begin with it seems that we can't leak endpoints here.
 	switch o := obj.(type) {
 	case *cache.DeletedFinalStateUnknown:
-		// Assuming we never saw the pod state where new endpoints would have been created
-		// to begin with it seems that we can't leak endpoints here.
-		pod = o.Obj.(*v1.Pod)
-	case *v1.Pod:
-		pod = o
+		obj = o.Obj
+	case cache.DeletedFinalStateUnknown:
+		obj = o.Obj
 	}
-	if pod == nil {
+	endpoints := h.convertToEndpoints(obj)
+	if len(endpoints) == 0 {
 		return
 	}
-	h.watcher.OnRemove(h.convertPodToEndpoints(pod))
+	h.watcher.OnRemove(endpoints)
 }
@@ -18,6 +18,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
 )
@@ -34,8 +37,10 @@ func TestEndpointsAdded(t *testing.T) {
 			ID:     "test-1/pod-2-UID",
 			Target: "1.2.3.4",
 			Details: observer.Pod{
-				Name:   "pod-2",
-				Labels: map[string]string{"env": "prod"},
+				Name:      "pod-2",
+				Namespace: "default",
+				UID:       "pod-2-UID",
+				Labels:    map[string]string{"env": "prod"},
 			},
 		}, {
 			ID:     "test-1/pod-2-UID/https(443)",
@@ -43,8 +48,10 @@ func TestEndpointsAdded(t *testing.T) {
 			Details: observer.Port{
 				Name: "https",
 				Pod: observer.Pod{
-					Name:   "pod-2",
-					Labels: map[string]string{"env": "prod"},
+					Name:      "pod-2",
+					Namespace: "default",
+					UID:       "pod-2-UID",
+					Labels:    map[string]string{"env": "prod"},
 				},
 				Port:      443,
 				Transport: observer.ProtocolTCP,
@@ -66,8 +73,10 @@ func TestEndpointsRemoved(t *testing.T) {
 			ID:     "test-1/pod-2-UID",
 			Target: "1.2.3.4",
 			Details: observer.Pod{
-				Name:   "pod-2",
-				Labels: map[string]string{"env": "prod"},
+				Name:      "pod-2",
+				Namespace: "default",
+				UID:       "pod-2-UID",
+				Labels:    map[string]string{"env": "prod"},
 			},
 		}, {
 			ID:     "test-1/pod-2-UID/https(443)",
@@ -75,8 +84,10 @@ func TestEndpointsRemoved(t *testing.T) {
 			Details: observer.Port{
 				Name: "https",
 				Pod: observer.Pod{
-					Name:   "pod-2",
-					Labels: map[string]string{"env": "prod"},
+					Name:      "pod-2",
+					Namespace: "default",
+					UID:       "pod-2-UID",
+					Labels:    map[string]string{"env": "prod"},
 				},
 				Port:      443,
 				Transport: observer.ProtocolTCP,
@@ -121,16 +132,135 @@ func TestEndpointsChanged(t *testing.T) {
 			ID:     "test-1/pod-2-UID",
 			Target: "1.2.3.4",
 			Details: observer.Pod{
-				Name:   "pod-2",
-				Labels: map[string]string{"env": "prod", "updated-label": "true"}}},
+				Name:      "pod-2",
+				Namespace: "default",
+				UID:       "pod-2-UID",
+				Labels:    map[string]string{"env": "prod", "updated-label": "true"}}},
 		{
 			ID:     "test-1/pod-2-UID/https(443)",
 			Target: "1.2.3.4:443",
 			Details: observer.Port{
 				Name: "https", Pod: observer.Pod{
-					Name:   "pod-2",
-					Labels: map[string]string{"env": "prod", "updated-label": "true"}},
+					Name:      "pod-2",
+					Namespace: "default",
+					UID:       "pod-2-UID",
+					Labels:    map[string]string{"env": "prod", "updated-label": "true"}},
 				Port:      443,
 				Transport: observer.ProtocolTCP}},
 	}, sink.changed)
 }
+
+func TestServiceEndpoints(t *testing.T) {
+	sink := endpointSink{}
+	h := handler{
+		idNamespace: "test-1",
+		watcher:     &sink,
+	}
+	serviceDetails := func(portName string, port uint16) observer.Service {
+		return observer.Service{
+			Name:        "service-1",
+			Namespace:   "default",
+			UID:         "service-1-UID",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"prometheus.io/scrape": "true"},
+			ServiceType: "ClusterIP",
+			ClusterIP:   "10.96.0.20",
+			PortName:    portName,
+			Port:        port,
+			Transport:   observer.ProtocolTCP,
+		}
+	}
+	h.OnAdd(serviceWithPorts)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:      "test-1/service-1-UID/http(80)",
+			Target:  "10.96.0.20:80",
+			Details: serviceDetails("http", 80),
+		}, {
+			ID:      "test-1/service-1-UID/metrics(9090)",
+			Target:  "10.96.0.20:9090",
+			Details: serviceDetails("metrics", 9090),
+		}}, sink.added)
+
+	// Headless services are targeted by DNS name.
+	sink = endpointSink{}
+	headless := serviceWithPorts.DeepCopy()
+	headless.Spec.ClusterIP = v1.ClusterIPNone
+	h.OnAdd(headless)
+	assert.Len(t, sink.added, 2)
+	assert.Equal(t, "service-1.default.svc:80", sink.added[0].Target)
+	assert.Equal(t, "", sink.added[0].Details.(observer.Service).ClusterIP)
+
+	// Port removed.
+	sink = endpointSink{}
+	singlePort := serviceWithPorts.DeepCopy()
+	singlePort.Spec.Ports = singlePort.Spec.Ports[:1]
+	h.OnUpdate(serviceWithPorts, singlePort)
+	assert.Nil(t, sink.added)
+	assert.Nil(t, sink.changed)
+	require.Len(t, sink.removed, 1)
+	assert.Equal(t, observer.EndpointID("test-1/service-1-UID/metrics(9090)"), sink.removed[0].ID)
+}
+
+func TestNodeEndpoints(t *testing.T) {
+	sink := endpointSink{}
+	h := handler{
+		idNamespace: "test-1",
+		watcher:     &sink,
+	}
+	h.OnAdd(node1)
+	assert.Equal(t, []observer.Endpoint{
+		{
+			ID:     "test-1/node-1-UID",
+			Target: "192.168.1.10:10250",
+			Details: observer.Node{
+				Name:                "node-1",
+				UID:                 "node-1-UID",
+				Labels:              map[string]string{"kubernetes.io/os": "linux"},
+				Hostname:            "node-1.local",
+				InternalIP:          "192.168.1.10",
+				ExternalIP:          "34.1.2.3",
+				KubeletEndpointPort: 10250,
+			},
+		}}, sink.added)
+
+	h.OnDelete(cache.DeletedFinalStateUnknown{Key: "node-1", Obj: node1})
+	require.Len(t, sink.removed, 1)
+	assert.Equal(t, observer.EndpointID("test-1/node-1-UID"), sink.removed[0].ID)
+}
+
+func TestIngressEndpoints(t *testing.T) {
+	sink := endpointSink{}
+	h := handler{
+		idNamespace: "test-1",
+		watcher:     &sink,
+	}
+	h.OnAdd(ingress1)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/ingress-1-UID/secure.example.com/api",
+			Target: "secure.example.com",
+			Details: observer.Ingress{
+				Name:        "ingress-1",
+				Namespace:   "default",
+				UID:         "ingress-1-UID",
+				Host:        "secure.example.com",
+				Path:        "/api",
+				Scheme:      "https",
+				ServiceName: "service-1",
+				ServicePort: "80",
+			},
+		}, {
+			ID:     "test-1/ingress-1-UID//metrics",
+			Target: "35.1.2.3",
+			Details: observer.Ingress{
+				Name:        "ingress-1",
+				Namespace:   "default",
+				UID:         "ingress-1-UID",
+				Path:        "/metrics",
+				Scheme:      "http",
+				ServiceName: "service-1",
+				ServicePort: "metrics",
+			},
+		}}, sink.added)
+}
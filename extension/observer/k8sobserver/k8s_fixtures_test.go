@@ -16,8 +16,10 @@ package k8sobserver
 
 import (
 	v1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
@@ -110,3 +112,96 @@ var podWithNamedPorts = func() *v1.Pod {
 	}
 	return pod
 }()
+
+var serviceWithPorts = &v1.Service{
+	ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "service-1",
+		UID:       types.UID("service-1-UID"),
+		Labels: map[string]string{
+			"env": "prod",
+		},
+		Annotations: map[string]string{
+			"prometheus.io/scrape": "true",
+		},
+	},
+	Spec: v1.ServiceSpec{
+		Type:      v1.ServiceTypeClusterIP,
+		ClusterIP: "10.96.0.20",
+		Ports: []v1.ServicePort{
+			{Name: "http", Port: 80, Protocol: v1.ProtocolTCP},
+			{Name: "metrics", Port: 9090, Protocol: v1.ProtocolTCP},
+		},
+	},
+}
+
+var node1 = &v1.Node{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: "node-1",
+		UID:  types.UID("node-1-UID"),
+		Labels: map[string]string{
+			"kubernetes.io/os": "linux",
+		},
+	},
+	Status: v1.NodeStatus{
+		Addresses: []v1.NodeAddress{
+			{Type: v1.NodeHostName, Address: "node-1.local"},
+			{Type: v1.NodeInternalIP, Address: "192.168.1.10"},
+			{Type: v1.NodeExternalIP, Address: "34.1.2.3"},
+		},
+		DaemonEndpoints: v1.NodeDaemonEndpoints{
+			KubeletEndpoint: v1.DaemonEndpoint{Port: 10250},
+		},
+	},
+}
+
+var ingress1 = &networkingv1beta1.Ingress{
+	ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "ingress-1",
+		UID:       types.UID("ingress-1-UID"),
+	},
+	Spec: networkingv1beta1.IngressSpec{
+		TLS: []networkingv1beta1.IngressTLS{
+			{Hosts: []string{"secure.example.com"}},
+		},
+		Rules: []networkingv1beta1.IngressRule{
+			{
+				Host: "secure.example.com",
+				IngressRuleValue: networkingv1beta1.IngressRuleValue{
+					HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+						Paths: []networkingv1beta1.HTTPIngressPath{
+							{
+								Path: "/api",
+								Backend: networkingv1beta1.IngressBackend{
+									ServiceName: "service-1",
+									ServicePort: intstr.FromInt(80),
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				IngressRuleValue: networkingv1beta1.IngressRuleValue{
+					HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+						Paths: []networkingv1beta1.HTTPIngressPath{
+							{
+								Path: "/metrics",
+								Backend: networkingv1beta1.IngressBackend{
+									ServiceName: "service-1",
+									ServicePort: intstr.FromString("metrics"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	Status: networkingv1beta1.IngressStatus{
+		LoadBalancer: v1.LoadBalancerStatus{
+			Ingress: []v1.LoadBalancerIngress{{IP: "35.1.2.3"}},
+		},
+	},
+}
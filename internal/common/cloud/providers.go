@@ -19,4 +19,6 @@ const (
 	ProviderAWS = "aws"
 	// ProviderGCP is used in cloud.provider label for GCP.
 	ProviderGCP = "gcp"
+	// ProviderAzure is used in cloud.provider label for Azure.
+	ProviderAzure = "azure"
 )
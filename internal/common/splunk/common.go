@@ -14,7 +14,13 @@
 
 package splunk
 
-import "strings"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 const (
 	SFxAccessTokenHeader  = "X-Sf-Token"
@@ -22,6 +28,14 @@ const (
 	SFxEventCategoryKey   = "com.splunk.signalfx.event_category"
 	SFxEventPropertiesKey = "com.splunk.signalfx.event_properties"
 	SourcetypeLabel       = "com.splunk.sourcetype"
+	IndexLabel            = "com.splunk.index"
+	HECTokenHeader        = "Splunk"
+	HecTokenLabel         = "com.splunk.hec.access_token"
+
+	// HecEventMetricType is the value of the "event" key of HEC metric events.
+	HecEventMetricType = "metric"
+	// HecMetricNamePrefix is the prefix of the fields holding HEC metric values.
+	HecMetricNamePrefix = "metric_name:"
 )
 
 type AccessTokenPassthroughConfig struct {
@@ -44,8 +58,65 @@ type Metric struct {
 func (m Metric) GetValues() map[string]interface{} {
 	values := map[string]interface{}{}
 	for k, v := range m.Fields {
-		if strings.HasPrefix(k, "metric_name:") {
-			values[k[12:]] = v
+		if strings.HasPrefix(k, HecMetricNamePrefix) {
+			values[k[len(HecMetricNamePrefix):]] = v
+		}
+	}
+	return values
+}
+
+// EventTime is the epoch time of a HEC event in seconds. HEC accepts it both
+// as a JSON number and as a string holding a number.
+type EventTime float64
+
+// UnmarshalJSON decodes the time from either a JSON number or string.
+func (t *EventTime) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		b = []byte(strings.TrimSpace(s))
+		if len(b) == 0 {
+			*t = 0
+			return nil
+		}
+	}
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	f, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return fmt.Errorf("invalid event time %q: %w", b, err)
+	}
+	*t = EventTime(f)
+	return nil
+}
+
+// Event represents a generic event in Splunk HEC format. The Event field
+// holds either the raw log payload or the "metric" marker for metric events.
+type Event struct {
+	Time       EventTime              `json:"time,omitempty"`       // optional epoch time - set to zero if the event timestamp is missing or unknown (will be added at indexing time)
+	Host       string                 `json:"host"`                 // hostname
+	Source     string                 `json:"source,omitempty"`     // optional description of the source of the event; typically the app's name
+	SourceType string                 `json:"sourcetype,omitempty"` // optional name of a Splunk parsing configuration; this is usually inferred by Splunk
+	Index      string                 `json:"index,omitempty"`      // optional name of the Splunk index to store the event in; not required if the token has a default index set in Splunk
+	Event      interface{}            `json:"event"`                // payload of the event.
+	Fields     map[string]interface{} `json:"fields,omitempty"`     // dimensions and metric data
+}
+
+// IsMetric returns true if the Splunk event is a metric.
+func (e Event) IsMetric() bool {
+	return e.Event == HecEventMetricType
+}
+
+// GetMetricValues extracts metric key value pairs from a Splunk HEC metric.
+func (e Event) GetMetricValues() map[string]interface{} {
+	values := map[string]interface{}{}
+	for k, v := range e.Fields {
+		if strings.HasPrefix(k, HecMetricNamePrefix) {
+			values[k[len(HecMetricNamePrefix):]] = v
 		}
 	}
 	return values
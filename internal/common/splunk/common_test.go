@@ -15,9 +15,11 @@
 package splunk
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetValues(t *testing.T) {
@@ -30,3 +32,55 @@ func TestGetValues(t *testing.T) {
 	metric.Fields["metric_name:foo2"] = "foobar"
 	assert.Equal(t, map[string]interface{}{"foo": "bar", "foo2": "foobar"}, metric.GetValues())
 }
+
+func TestIsMetric(t *testing.T) {
+	ev := Event{
+		Event: map[string]interface{}{},
+	}
+	assert.False(t, ev.IsMetric())
+	ev.Event = "log line"
+	assert.False(t, ev.IsMetric())
+	ev.Event = "metric"
+	assert.True(t, ev.IsMetric())
+}
+
+func TestGetMetricValues(t *testing.T) {
+	ev := Event{
+		Event: "metric",
+		Fields: map[string]interface{}{
+			"region": "us-west-2",
+		},
+	}
+	assert.Equal(t, map[string]interface{}{}, ev.GetMetricValues())
+	ev.Fields["metric_name:foo"] = 1.5
+	assert.Equal(t, map[string]interface{}{"foo": 1.5}, ev.GetMetricValues())
+}
+
+func TestEventTimeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    EventTime
+		wantErr bool
+	}{
+		{name: "number", json: `{"time":1600000000.123}`, want: 1600000000.123},
+		{name: "string", json: `{"time":"1600000000.123"}`, want: 1600000000.123},
+		{name: "empty string", json: `{"time":""}`, want: 0},
+		{name: "null", json: `{"time":null}`, want: 0},
+		{name: "missing", json: `{}`, want: 0},
+		{name: "invalid string", json: `{"time":"yesterday"}`, wantErr: true},
+		{name: "invalid type", json: `{"time":true}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ev Event
+			err := json.Unmarshal([]byte(tt.json), &ev)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ev.Time)
+		})
+	}
+}
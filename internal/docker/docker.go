@@ -12,7 +12,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package dockerstatsreceiver
+// Package docker provides a Docker daemon client keeping track of the running containers
+// for the components of the collector relying on the Docker API.
+package docker
 
 import (
 	"context"
@@ -24,34 +26,67 @@ import (
 	"time"
 
 	dtypes "github.com/docker/docker/api/types"
+	devents "github.com/docker/docker/api/types/events"
 	dfilters "github.com/docker/docker/api/types/filters"
 	docker "github.com/docker/docker/client"
-	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.uber.org/zap"
 )
 
 const (
-	dockerAPIVersion = "v1.22"
-	userAgent        = "OpenTelemetry-Collector Docker Stats Receiver/v0.0.1"
+	defaultDockerAPIVersion = "v1.22"
+	userAgent               = "OpenTelemetry-Collector Docker Client/v0.0.1"
 )
 
-// dockerClient provides the core metric gathering functionality from the Docker Daemon.
-// It retrieves container information in two forms to produce metric data: dtypes.ContainerJSON
-// from client.ContainerInspect() for container information (id, name, hostname, labels, and env)
-// and dtypes.StatsJSON from client.ContainerStats() for metric values.
-type dockerClient struct {
+// Config is the configuration of the Docker client.
+type Config struct {
+	// The URL of the docker server.
+	Endpoint string
+	// The maximum amount of time to wait for docker API responses.
+	Timeout time.Duration
+	// A list of filters whose matching images are to be excluded.  Supports literals, globs, and regex.
+	ExcludedImages []string
+	// The version of the Docker API to use.  Default is "v1.22".
+	DockerAPIVersion string
+	// The containers to monitor, all of them if nil.
+	Include *MatchConfig
+	// The containers not to monitor, in addition to the ones running ExcludedImages.
+	Exclude *MatchConfig
+	// EventHandler, when set, is called by ContainerEventLoop for every event of a
+	// container of interest, with the container as inspected after the event.
+	// It is not called for destroyed containers.
+	EventHandler func(event devents.Message, container *dtypes.ContainerJSON)
+}
+
+// Container is a client.ContainerInspect() response container
+// and translated environment string map for potential labels.
+type Container struct {
+	*dtypes.ContainerJSON
+	EnvMap map[string]string
+}
+
+// Client provides the core container tracking functionality from the Docker Daemon.
+// It retrieves container information in two forms: dtypes.ContainerJSON
+// from client.ContainerInspect() for container information (id, name, hostname, labels,
+// env, and network settings) and dtypes.StatsJSON from client.ContainerStats() for metric values.
+type Client struct {
 	client               *docker.Client
 	config               *Config
-	containers           map[string]DockerContainer
+	containers           map[string]Container
 	containersLock       sync.Mutex
 	excludedImageMatcher *StringMatcher
+	containerFilter      *ContainerFilter
 	logger               *zap.Logger
 }
 
-func newDockerClient(config *Config, logger *zap.Logger) (*dockerClient, error) {
+// NewDockerClient creates a Client for the Docker daemon reachable at config.Endpoint.
+func NewDockerClient(config *Config, logger *zap.Logger) (*Client, error) {
+	apiVersion := config.DockerAPIVersion
+	if apiVersion == "" {
+		apiVersion = defaultDockerAPIVersion
+	}
 	client, err := docker.NewClientWithOpts(
 		docker.WithHost(config.Endpoint),
-		docker.WithVersion(dockerAPIVersion),
+		docker.WithVersion(apiVersion),
 		docker.WithHTTPHeaders(map[string]string{"User-Agent": userAgent}),
 	)
 	if err != nil {
@@ -63,23 +98,29 @@ func newDockerClient(config *Config, logger *zap.Logger) (*dockerClient, error)
 		return nil, fmt.Errorf("could not determine docker client excluded images: %w", err)
 	}
 
-	dc := &dockerClient{
+	containerFilter, err := NewContainerFilter(config.Include, config.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine docker client container filter: %w", err)
+	}
+
+	dc := &Client{
 		client:               client,
 		config:               config,
 		logger:               logger,
-		containers:           make(map[string]DockerContainer),
+		containers:           make(map[string]Container),
 		containersLock:       sync.Mutex{},
 		excludedImageMatcher: excludedImageMatcher,
+		containerFilter:      containerFilter,
 	}
 
 	return dc, nil
 }
 
-// Provides a slice of DockerContainers to use for individual FetchContainerStats calls.
-func (dc *dockerClient) Containers() []DockerContainer {
+// Containers provides a slice of Containers to use for individual FetchContainerStats calls.
+func (dc *Client) Containers() []Container {
 	dc.containersLock.Lock()
 	defer dc.containersLock.Unlock()
-	containers := make([]DockerContainer, 0, len(dc.containers))
+	containers := make([]Container, 0, len(dc.containers))
 	for _, container := range dc.containers {
 		containers = append(containers, container)
 	}
@@ -89,7 +130,7 @@ func (dc *dockerClient) Containers() []DockerContainer {
 // LoadContainerList will load the initial running container maps for
 // inspection and establishing which containers warrant stat gathering calls
 // by the receiver.
-func (dc *dockerClient) LoadContainerList(ctx context.Context) error {
+func (dc *Client) LoadContainerList(ctx context.Context) error {
 	// Build initial container maps before starting loop
 	filters := dfilters.NewArgs()
 	filters.Add("status", "running")
@@ -126,12 +167,11 @@ func (dc *dockerClient) LoadContainerList(ctx context.Context) error {
 	return nil
 }
 
-// FetchContainerStatsAndConvertToMetrics will query the desired container stats and send
-// converted metrics to the results channel, since this is intended to be run in a goroutine.
-func (dc *dockerClient) FetchContainerStatsAndConvertToMetrics(
+// FetchContainerStatsAsJSON will query the desired container stats and return them as StatsJSON.
+func (dc *Client) FetchContainerStatsAsJSON(
 	ctx context.Context,
-	container DockerContainer,
-) (*consumerdata.MetricsData, error) {
+	container Container,
+) (*dtypes.StatsJSON, error) {
 	dc.logger.Debug("Fetching container stats.", zap.String("id", container.ID))
 	statsCtx, cancel := context.WithTimeout(ctx, dc.config.Timeout)
 	containerStats, err := dc.client.ContainerStats(statsCtx, container.ID, false)
@@ -154,26 +194,12 @@ func (dc *dockerClient) FetchContainerStatsAndConvertToMetrics(
 		return nil, err
 	}
 
-	statsJSON, err := dc.toStatsJSON(containerStats, &container)
-	if err != nil {
-		return nil, err
-	}
-
-	md, err := ContainerStatsToMetrics(statsJSON, &container, dc.config)
-	if err != nil {
-		dc.logger.Error(
-			"Could not convert docker containerStats for container id",
-			zap.String("id", container.ID),
-			zap.Error(err),
-		)
-		return nil, err
-	}
-	return md, nil
+	return dc.toStatsJSON(containerStats, &container)
 }
 
-func (dc *dockerClient) toStatsJSON(
+func (dc *Client) toStatsJSON(
 	containerStats dtypes.ContainerStats,
-	container *DockerContainer,
+	container *Container,
 ) (*dtypes.StatsJSON, error) {
 	var statsJSON dtypes.StatsJSON
 	err := json.NewDecoder(containerStats.Body).Decode(&statsJSON)
@@ -194,11 +220,17 @@ func (dc *dockerClient) toStatsJSON(
 	return &statsJSON, nil
 }
 
-func (dc *dockerClient) ContainerEventLoop(ctx context.Context) {
+// ContainerEventLoop watches the container events of the Docker daemon to keep the
+// tracked containers up to date, and forwards them to Config.EventHandler, until
+// ctx is done.
+func (dc *Client) ContainerEventLoop(ctx context.Context) {
 	filters := dfilters.NewArgs([]dfilters.KeyValuePair{
 		{Key: "type", Value: "container"},
 		{Key: "event", Value: "destroy"},
 		{Key: "event", Value: "die"},
+		{Key: "event", Value: "health_status"},
+		{Key: "event", Value: "kill"},
+		{Key: "event", Value: "oom"},
 		{Key: "event", Value: "pause"},
 		{Key: "event", Value: "stop"},
 		{Key: "event", Value: "start"},
@@ -233,6 +265,9 @@ EVENT_LOOP:
 
 					if container, ok := dc.inspectedContainerIsOfInterest(ctx, event.ID); ok {
 						dc.persistContainer(container)
+						if dc.config.EventHandler != nil {
+							dc.config.EventHandler(event, container)
+						}
 					}
 				}
 
@@ -262,7 +297,7 @@ EVENT_LOOP:
 
 // Queries inspect api and returns *ContainerJSON and true when container should be queried for stats,
 // nil and false otherwise.
-func (dc *dockerClient) inspectedContainerIsOfInterest(ctx context.Context, cid string) (*dtypes.ContainerJSON, bool) {
+func (dc *Client) inspectedContainerIsOfInterest(ctx context.Context, cid string) (*dtypes.ContainerJSON, bool) {
 	inspectCtx, cancel := context.WithTimeout(ctx, dc.config.Timeout)
 	container, err := dc.client.ContainerInspect(inspectCtx, cid)
 	defer cancel()
@@ -272,13 +307,15 @@ func (dc *dockerClient) inspectedContainerIsOfInterest(ctx context.Context, cid
 			zap.String("id", cid),
 			zap.Error(err),
 		)
-	} else if !dc.shouldBeExcluded(container.Config.Image) {
+	} else if dc.shouldBeExcluded(container.Config.Image) || !dc.containerFilter.Matches(&container) {
+		dc.logger.Debug("Not monitoring excluded container", zap.String("id", cid))
+	} else {
 		return &container, true
 	}
 	return nil, false
 }
 
-func (dc *dockerClient) persistContainer(containerJSON *dtypes.ContainerJSON) {
+func (dc *Client) persistContainer(containerJSON *dtypes.ContainerJSON) {
 	if containerJSON == nil {
 		return
 	}
@@ -293,24 +330,25 @@ func (dc *dockerClient) persistContainer(containerJSON *dtypes.ContainerJSON) {
 	dc.logger.Debug("Monitoring Docker container", zap.String("id", cid))
 	dc.containersLock.Lock()
 	defer dc.containersLock.Unlock()
-	dc.containers[cid] = DockerContainer{
+	dc.containers[cid] = Container{
 		ContainerJSON: containerJSON,
-		EnvMap:        containerEnvToMap(containerJSON.Config.Env),
+		EnvMap:        ContainerEnvToMap(containerJSON.Config.Env),
 	}
 }
 
-func (dc *dockerClient) removeContainer(cid string) {
+func (dc *Client) removeContainer(cid string) {
 	dc.containersLock.Lock()
 	defer dc.containersLock.Unlock()
 	delete(dc.containers, cid)
 	dc.logger.Debug("Removed container from stores.", zap.String("id", cid))
 }
 
-func (dc *dockerClient) shouldBeExcluded(image string) bool {
+func (dc *Client) shouldBeExcluded(image string) bool {
 	return dc.excludedImageMatcher != nil && dc.excludedImageMatcher.Matches(image)
 }
 
-func containerEnvToMap(env []string) map[string]string {
+// ContainerEnvToMap converts the KEY=value environment of a container to a map.
+func ContainerEnvToMap(env []string) map[string]string {
 	out := make(map[string]string, len(env))
 	for _, v := range env {
 		parts := strings.Split(v, "=")
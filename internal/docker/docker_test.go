@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package dockerstatsreceiver
+package docker
 
 import (
 	"context"
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	dtypes "github.com/docker/docker/api/types"
+	devents "github.com/docker/docker/api/types/events"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -40,21 +41,63 @@ func TestInvalidEndpoint(t *testing.T) {
 	config := &Config{
 		Endpoint: "$notavalidendpoint*",
 	}
-	cli, err := newDockerClient(config, zap.NewNop())
+	cli, err := NewDockerClient(config, zap.NewNop())
 	assert.Nil(t, cli)
 	require.Error(t, err)
 	assert.Equal(t, "could not create docker client: unable to parse docker host `$notavalidendpoint*`", err.Error())
 }
 
 func TestInvalidExclude(t *testing.T) {
-	config := NewFactory().CreateDefaultConfig().(*Config)
-	config.ExcludedImages = []string{"["}
-	cli, err := newDockerClient(config, zap.NewNop())
+	config := &Config{
+		Endpoint:       "unix:///var/run/docker.sock",
+		ExcludedImages: []string{"["},
+	}
+	cli, err := NewDockerClient(config, zap.NewNop())
 	assert.Nil(t, cli)
 	require.Error(t, err)
 	assert.Equal(t, "could not determine docker client excluded images: invalid glob item: unexpected end of input", err.Error())
 }
 
+func TestInvalidContainerFilterConfig(t *testing.T) {
+	config := &Config{
+		Endpoint: "unix:///var/run/docker.sock",
+		Exclude:  &MatchConfig{MatchType: MatchTypeGlob, Names: []string{"["}},
+	}
+	cli, err := NewDockerClient(config, zap.NewNop())
+	assert.Nil(t, cli)
+	require.Error(t, err)
+	assert.Equal(t, "could not determine docker client container filter: invalid exclude filter: invalid names: invalid glob item: unexpected end of input", err.Error())
+}
+
+func TestLoadContainerListFiltersContainers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/containers/json"):
+			w.Write([]byte(`[{"Id":"web","Image":"nginx"},{"Id":"db","Image":"postgres"}]`))
+		case strings.HasSuffix(r.URL.Path, "/containers/web/json"):
+			w.Write([]byte(`{"Id":"web","Name":"/web","State":{"Running":true},"Config":{"Image":"nginx","Labels":{"monitored":"true"}}}`))
+		case strings.HasSuffix(r.URL.Path, "/containers/db/json"):
+			w.Write([]byte(`{"Id":"db","Name":"/db","State":{"Running":true},"Config":{"Image":"postgres","Labels":{"monitored":"false"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := &Config{
+		Endpoint: srv.URL,
+		Timeout:  time.Second,
+		Include:  &MatchConfig{MatchType: MatchTypeStrict, Labels: map[string]string{"monitored": "true"}},
+	}
+	cli, err := NewDockerClient(config, zap.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, cli.LoadContainerList(context.Background()))
+	containers := cli.Containers()
+	require.Len(t, containers, 1)
+	assert.Equal(t, "web", containers[0].ID)
+}
+
 func tmpSock(t *testing.T) (net.Listener, string) {
 	f, err := ioutil.TempFile(os.TempDir(), "testsock")
 	if err != nil {
@@ -85,7 +128,7 @@ func TestWatchingTimeouts(t *testing.T) {
 		Timeout:  50 * time.Millisecond,
 	}
 
-	cli, err := newDockerClient(config, zap.NewNop())
+	cli, err := NewDockerClient(config, zap.NewNop())
 	assert.NotNil(t, cli)
 	assert.Nil(t, err)
 
@@ -98,7 +141,7 @@ func TestWatchingTimeouts(t *testing.T) {
 	assert.Contains(t, err.Error(), expectedError)
 
 	observed, logs := observer.New(zapcore.WarnLevel)
-	cli, err = newDockerClient(config, zap.New(observed))
+	cli, err = NewDockerClient(config, zap.New(observed))
 	assert.NotNil(t, cli)
 	assert.Nil(t, err)
 
@@ -126,7 +169,7 @@ func TestFetchingTimeouts(t *testing.T) {
 		Timeout:  50 * time.Millisecond,
 	}
 
-	cli, err := newDockerClient(config, zap.NewNop())
+	cli, err := NewDockerClient(config, zap.NewNop())
 	assert.NotNil(t, cli)
 	assert.Nil(t, err)
 
@@ -135,13 +178,13 @@ func TestFetchingTimeouts(t *testing.T) {
 	shouldHaveTaken := time.Now().Add(50 * time.Millisecond).UnixNano()
 
 	observed, logs := observer.New(zapcore.WarnLevel)
-	cli, err = newDockerClient(config, zap.New(observed))
+	cli, err = NewDockerClient(config, zap.New(observed))
 	assert.NotNil(t, cli)
 	assert.Nil(t, err)
 
-	md, err := cli.FetchContainerStatsAndConvertToMetrics(
+	statsJSON, err := cli.FetchContainerStatsAsJSON(
 		context.Background(),
-		DockerContainer{
+		Container{
 			ContainerJSON: &dtypes.ContainerJSON{
 				ContainerJSONBase: &dtypes.ContainerJSONBase{
 					ID: "notARealContainerId",
@@ -150,7 +193,7 @@ func TestFetchingTimeouts(t *testing.T) {
 		},
 	)
 
-	assert.Nil(t, md)
+	assert.Nil(t, statsJSON)
 	require.Error(t, err)
 
 	assert.Contains(t, err.Error(), expectedError)
@@ -177,11 +220,11 @@ func TestToStatsJSONErrorHandling(t *testing.T) {
 		Timeout:  50 * time.Millisecond,
 	}
 
-	cli, err := newDockerClient(config, zap.NewNop())
+	cli, err := NewDockerClient(config, zap.NewNop())
 	assert.NotNil(t, cli)
 	assert.Nil(t, err)
 
-	dc := &DockerContainer{
+	dc := &Container{
 		ContainerJSON: &dtypes.ContainerJSON{
 			ContainerJSONBase: &dtypes.ContainerJSONBase{
 				ID: "notARealContainerId",
@@ -223,7 +266,7 @@ func TestEventLoopHandlesError(t *testing.T) {
 		Timeout:  50 * time.Millisecond,
 	}
 
-	cli, err := newDockerClient(config, zap.New(observed))
+	cli, err := NewDockerClient(config, zap.New(observed))
 	assert.NotNil(t, cli)
 	assert.Nil(t, err)
 
@@ -249,3 +292,49 @@ func TestEventLoopHandlesError(t *testing.T) {
 		return
 	}
 }
+
+func TestEventLoopForwardsEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			w.Write([]byte(`{"Type":"container","Action":"destroy","id":"gone","Actor":{"ID":"gone"},"timeNano":1}` + "\n"))
+			w.Write([]byte(`{"Type":"container","Action":"die","id":"abc","Actor":{"ID":"abc","Attributes":{"exitCode":"137"}},"timeNano":2}` + "\n"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case strings.HasSuffix(r.URL.Path, "/containers/abc/json"):
+			w.Write([]byte(`{"Id":"abc","Name":"/app","State":{"Status":"exited","ExitCode":137},"Config":{"Image":"app:1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	type received struct {
+		action      string
+		containerID string
+	}
+	events := make(chan received, 2)
+	config := &Config{
+		Endpoint: srv.URL,
+		Timeout:  time.Second,
+		EventHandler: func(event devents.Message, container *dtypes.ContainerJSON) {
+			events <- received{event.Action, container.ID}
+		},
+	}
+
+	cli, err := NewDockerClient(config, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cli.ContainerEventLoop(ctx)
+
+	select {
+	case e := <-events:
+		assert.Equal(t, received{"die", "abc"}, e)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not forwarded to the handler")
+	}
+	// The stopped container is not tracked.
+	assert.Empty(t, cli.Containers())
+}
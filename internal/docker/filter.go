@@ -0,0 +1,213 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	dtypes "github.com/docker/docker/api/types"
+	"github.com/gobwas/glob"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/containerimage"
+)
+
+// MatchType defines how the patterns of a MatchConfig are matched.
+type MatchType string
+
+const (
+	// MatchTypeStrict matches the patterns as literals.
+	MatchTypeStrict MatchType = "strict"
+	// MatchTypeRegexp matches the patterns as regular expressions.
+	MatchTypeRegexp MatchType = "regexp"
+	// MatchTypeGlob matches the patterns as globs.
+	MatchTypeGlob MatchType = "glob"
+)
+
+// MatchConfig selects containers by name, image name or label.  A container is
+// selected when any of its name, image name or label values matches.
+type MatchConfig struct {
+	// MatchType is how the patterns are matched: "strict", "regexp" or "glob".
+	// Defaults to "strict".  Unlike the excluded_images items, the patterns
+	// don't support the "/regex/" and "!" negation syntax.
+	MatchType MatchType `mapstructure:"match_type"`
+	// Names are the patterns matched against the container names, without their leading "/".
+	Names []string `mapstructure:"names"`
+	// Images are the patterns matched against the container image references, e.g.
+	// "redis:6.0", and against their names without tag nor digest, e.g. "redis".
+	Images []string `mapstructure:"images"`
+	// Labels are the patterns matched against the values of the container labels
+	// with the given keys.
+	Labels map[string]string `mapstructure:"labels"`
+}
+
+// containerMatcher is a compiled MatchConfig.
+type containerMatcher struct {
+	names  Matcher
+	images Matcher
+	labels map[string]Matcher
+}
+
+func newContainerMatcher(config *MatchConfig) (*containerMatcher, error) {
+	if len(config.Names) == 0 && len(config.Images) == 0 && len(config.Labels) == 0 {
+		return nil, errors.New("at least one of names, images or labels must be set")
+	}
+
+	names, err := newPatternMatcher(config.MatchType, config.Names)
+	if err != nil {
+		return nil, fmt.Errorf("invalid names: %w", err)
+	}
+
+	images, err := newPatternMatcher(config.MatchType, config.Images)
+	if err != nil {
+		return nil, fmt.Errorf("invalid images: %w", err)
+	}
+
+	labels := make(map[string]Matcher, len(config.Labels))
+	for k, v := range config.Labels {
+		if labels[k], err = newPatternMatcher(config.MatchType, []string{v}); err != nil {
+			return nil, fmt.Errorf("invalid label %q: %w", k, err)
+		}
+	}
+
+	return &containerMatcher{
+		names:  names,
+		images: images,
+		labels: labels,
+	}, nil
+}
+
+func (m *containerMatcher) matches(container *dtypes.ContainerJSON) bool {
+	if m.names.Matches(strings.TrimPrefix(container.Name, "/")) {
+		return true
+	}
+
+	if container.Config == nil {
+		return false
+	}
+
+	if m.images.Matches(container.Config.Image) {
+		return true
+	}
+	if name, _ := containerimage.Parse(container.Config.Image); name != container.Config.Image && m.images.Matches(name) {
+		return true
+	}
+
+	for k, matcher := range m.labels {
+		if v, ok := container.Config.Labels[k]; ok && matcher.Matches(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContainerFilter selects the containers of interest from the ones included and
+// excluded.  All containers are included unless include patterns are configured,
+// and exclusions take precedence over inclusions.
+type ContainerFilter struct {
+	include *containerMatcher
+	exclude *containerMatcher
+}
+
+// NewContainerFilter compiles the include and exclude configs, either of which may be nil.
+func NewContainerFilter(include, exclude *MatchConfig) (*ContainerFilter, error) {
+	var err error
+	filter := &ContainerFilter{}
+
+	if include != nil {
+		if filter.include, err = newContainerMatcher(include); err != nil {
+			return nil, fmt.Errorf("invalid include filter: %w", err)
+		}
+	}
+
+	if exclude != nil {
+		if filter.exclude, err = newContainerMatcher(exclude); err != nil {
+			return nil, fmt.Errorf("invalid exclude filter: %w", err)
+		}
+	}
+
+	return filter, nil
+}
+
+// Matches returns whether the container is included and not excluded.
+func (f *ContainerFilter) Matches(container *dtypes.ContainerJSON) bool {
+	if f.include != nil && !f.include.matches(container) {
+		return false
+	}
+	return f.exclude == nil || !f.exclude.matches(container)
+}
+
+// patternMatcher matches strings against patterns of a MatchType.
+type patternMatcher struct {
+	strict  map[string]bool
+	regexps []*regexp.Regexp
+	globs   []glob.Glob
+}
+
+var _ Matcher = (*patternMatcher)(nil)
+
+func newPatternMatcher(matchType MatchType, patterns []string) (*patternMatcher, error) {
+	m := &patternMatcher{}
+	switch matchType {
+	case "", MatchTypeStrict:
+		m.strict = make(map[string]bool, len(patterns))
+		for _, p := range patterns {
+			m.strict[p] = true
+		}
+	case MatchTypeRegexp:
+		for _, p := range patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex item: %w", err)
+			}
+			m.regexps = append(m.regexps, re)
+		}
+	case MatchTypeGlob:
+		for _, p := range patterns {
+			g, err := glob.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid glob item: %w", err)
+			}
+			m.globs = append(m.globs, g)
+		}
+	default:
+		return nil, fmt.Errorf("unknown match type %q, must be one of %q, %q or %q",
+			matchType, MatchTypeStrict, MatchTypeRegexp, MatchTypeGlob)
+	}
+	return m, nil
+}
+
+func (m *patternMatcher) Matches(s string) bool {
+	if m.strict[s] {
+		return true
+	}
+
+	for _, re := range m.regexps {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+
+	for _, g := range m.globs {
+		if g.Match(s) {
+			return true
+		}
+	}
+
+	return false
+}
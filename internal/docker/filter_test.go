@@ -0,0 +1,139 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"testing"
+
+	dtypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testContainer(name, image string, labels map[string]string) *dtypes.ContainerJSON {
+	return &dtypes.ContainerJSON{
+		ContainerJSONBase: &dtypes.ContainerJSONBase{Name: "/" + name},
+		Config:            &container.Config{Image: image, Labels: labels},
+	}
+}
+
+func TestContainerFilter(t *testing.T) {
+	web := testContainer("web-1", "nginx:1.17", map[string]string{"team": "frontend"})
+	cache := testContainer("cache", "redis:6.0", map[string]string{"team": "backend", "tier": "cache"})
+	job := testContainer("batch-job", "registry.local/jobs:2", nil)
+
+	tests := []struct {
+		name     string
+		include  *MatchConfig
+		exclude  *MatchConfig
+		expected []*dtypes.ContainerJSON
+	}{
+		{
+			name:     "no filter",
+			expected: []*dtypes.ContainerJSON{web, cache, job},
+		},
+		{
+			name:     "include strict names",
+			include:  &MatchConfig{MatchType: MatchTypeStrict, Names: []string{"web-1", "batch-job"}},
+			expected: []*dtypes.ContainerJSON{web, job},
+		},
+		{
+			name:     "empty match type defaults to strict",
+			include:  &MatchConfig{Names: []string{"web-1", "batch-*"}},
+			expected: []*dtypes.ContainerJSON{web},
+		},
+		{
+			name:     "include regexp images",
+			include:  &MatchConfig{MatchType: MatchTypeRegexp, Images: []string{"^(nginx|redis):"}},
+			expected: []*dtypes.ContainerJSON{web, cache},
+		},
+		{
+			name:     "images match the names without tag",
+			include:  &MatchConfig{Images: []string{"redis", "nginx:1.17", "jobs"}},
+			expected: []*dtypes.ContainerJSON{web, cache},
+		},
+		{
+			name:     "exclude glob labels",
+			exclude:  &MatchConfig{MatchType: MatchTypeGlob, Labels: map[string]string{"team": "back*"}},
+			expected: []*dtypes.ContainerJSON{web, job},
+		},
+		{
+			name:     "exclude takes precedence",
+			include:  &MatchConfig{MatchType: MatchTypeGlob, Names: []string{"*"}},
+			exclude:  &MatchConfig{MatchType: MatchTypeStrict, Labels: map[string]string{"tier": "cache"}, Images: []string{"nginx:1.17"}},
+			expected: []*dtypes.ContainerJSON{job},
+		},
+		{
+			name:     "label key must be present",
+			include:  &MatchConfig{MatchType: MatchTypeRegexp, Labels: map[string]string{"tier": ".*"}},
+			expected: []*dtypes.ContainerJSON{cache},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewContainerFilter(tt.include, tt.exclude)
+			require.NoError(t, err)
+
+			var matched []*dtypes.ContainerJSON
+			for _, c := range []*dtypes.ContainerJSON{web, cache, job} {
+				if filter.Matches(c) {
+					matched = append(matched, c)
+				}
+			}
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+}
+
+func TestInvalidContainerFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  *MatchConfig
+		exclude  *MatchConfig
+		expected string
+	}{
+		{
+			name:     "unknown match type",
+			include:  &MatchConfig{MatchType: "fuzzy", Names: []string{"web"}},
+			expected: `invalid include filter: invalid names: unknown match type "fuzzy", must be one of "strict", "regexp" or "glob"`,
+		},
+		{
+			name:     "invalid regexp",
+			exclude:  &MatchConfig{MatchType: MatchTypeRegexp, Images: []string{"("}},
+			expected: "invalid exclude filter: invalid images: invalid regex item: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name:     "include without patterns",
+			include:  &MatchConfig{MatchType: MatchTypeStrict},
+			expected: "invalid include filter: at least one of names, images or labels must be set",
+		},
+		{
+			name:     "invalid glob",
+			exclude:  &MatchConfig{MatchType: MatchTypeGlob, Labels: map[string]string{"team": "["}},
+			expected: `invalid exclude filter: invalid label "team": invalid glob item: unexpected end of input`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewContainerFilter(tt.include, tt.exclude)
+			assert.Nil(t, filter)
+			require.Error(t, err)
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package k8sprocessor
 
 import (
+	"time"
+
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -28,11 +30,15 @@ import (
 
 // fakeClient is used as a replacement for WatchClient in test cases.
 type fakeClient struct {
-	Pods     map[string]*kube.Pod
-	Rules    kube.ExtractionRules
-	Filters  kube.Filters
-	Informer cache.SharedInformer
-	StopCh   chan struct{}
+	Pods         map[kube.PodIdentifier]*kube.Pod
+	Rules        kube.ExtractionRules
+	Filters      kube.Filters
+	Associations kube.Associations
+	Excludes     kube.Excludes
+	// DeleteGracePeriod is the grace period the client was created with.
+	DeleteGracePeriod time.Duration
+	Informer          cache.SharedInformer
+	StopCh            chan struct{}
 }
 
 func selectors() (labels.Selector, fields.Selector) {
@@ -41,7 +47,17 @@ func selectors() (labels.Selector, fields.Selector) {
 }
 
 // newFakeClient instantiates a new FakeClient object and satisfies the ClientProvider type
-func newFakeClient(_ *zap.Logger, apiCfg k8sconfig.APIConfig, rules kube.ExtractionRules, filters kube.Filters, _ kube.APIClientsetProvider, _ kube.InformerProvider) (kube.Client, error) {
+func newFakeClient(
+	_ *zap.Logger,
+	apiCfg k8sconfig.APIConfig,
+	rules kube.ExtractionRules,
+	filters kube.Filters,
+	associations kube.Associations,
+	excludes kube.Excludes,
+	deleteGracePeriod time.Duration,
+	_ kube.APIClientsetProvider,
+	_ kube.InformerProvider,
+) (kube.Client, error) {
 	cs, err := newFakeAPIClientset(apiCfg)
 	if err != nil {
 		return nil, err
@@ -49,17 +65,23 @@ func newFakeClient(_ *zap.Logger, apiCfg k8sconfig.APIConfig, rules kube.Extract
 
 	ls, fs := selectors()
 	return &fakeClient{
-		Pods:     map[string]*kube.Pod{},
-		Rules:    rules,
-		Filters:  filters,
-		Informer: kube.NewFakeInformer(cs, "", ls, fs),
-		StopCh:   make(chan struct{}),
+		Pods:              map[kube.PodIdentifier]*kube.Pod{},
+		Rules:             rules,
+		Filters:           filters,
+		Associations:      associations,
+		Excludes:          excludes,
+		DeleteGracePeriod: deleteGracePeriod,
+		Informer:          kube.NewFakeInformer(cs, "", ls, fs),
+		StopCh:            make(chan struct{}),
 	}, nil
 }
 
-// GetPodByIP looks up FakeClient.Pods map by the provided string.
-func (f *fakeClient) GetPodByIP(ip string) (*kube.Pod, bool) {
-	p, ok := f.Pods[ip]
+// GetPod looks up FakeClient.Pods map by the provided identifier.
+func (f *fakeClient) GetPod(id kube.PodIdentifier) (*kube.Pod, bool) {
+	p, ok := f.Pods[id]
+	if ok && p.Ignore {
+		return nil, false
+	}
 	return p, ok
 }
 
@@ -15,6 +15,8 @@
 package k8sprocessor
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -39,6 +41,46 @@ type Config struct {
 	// Filter section allows specifying filters to filter
 	// pods by labels, fields, namespaces, nodes, etc.
 	Filter FilterConfig `mapstructure:"filter"`
+
+	// PodIgnorePatterns is a list of regular expressions matched against the
+	// pod names. The pods whose name matches any of them are not tagged.
+	// When not set, the jaeger-agent and jaeger-collector pods are ignored.
+	// Set it to an empty list to not ignore any pod by name.
+	PodIgnorePatterns []string `mapstructure:"pod_ignore_patterns"`
+
+	// PodDeleteGracePeriod is the time the metadata of deleted pods is kept
+	// for, so that the data they sent last can still be tagged. Defaults to 2m.
+	PodDeleteGracePeriod time.Duration `mapstructure:"pod_delete_grace_period"`
+
+	// Association section allows specifying how the spans, metrics and logs
+	// are associated with the pods they come from. The sources are tried in
+	// order and the first one that yields a pod identifier is used. See
+	// PodAssociationConfig for more details.
+	Association []PodAssociationConfig `mapstructure:"pod_association"`
+}
+
+// PodAssociationConfig allows specifying one source of the identifier of the
+// pod the data comes from.
+//
+// The field accepts a list of maps. The map accepts two keys
+//     from and name
+//
+// - from represents the source of the identifier. The following sources are supported:
+//     connection: the IP address of the client that sent the data.
+//     resource_attribute: the pod IP held by the resource attribute set by name.
+//     pod_uid: the k8s.pod.uid resource attribute.
+//     container_id: the container.id resource attribute.
+//     pod_name: the k8s.namespace.name and k8s.pod.name resource attributes.
+//
+// - name represents the resource attribute holding the pod IP. It is only
+//   used, and required, with the resource_attribute source.
+//
+// When no source is specified, the pod IP is taken from the k8s.pod.ip or ip
+// resource attributes, from the host.hostname resource attribute for metrics,
+// then from the connection.
+type PodAssociationConfig struct {
+	From string `mapstructure:"from"`
+	Name string `mapstructure:"name"`
 }
 
 // ExtractConfig section allows specifying extraction rules to extract
@@ -50,18 +92,39 @@ type ExtractConfig struct {
 	// Metadata fields supported right now are,
 	//   namespace, podName, podUID, deployment, cluster, node and startTime
 	//
+	// as well as the following fields of the container the data comes from,
+	// when it carries the container.name, k8s.container.name or container.id
+	// resource attribute,
+	//   containerID, containerImage and containerRestartCount
+	//
+	// as well as the following fields of the workload owning the pod,
+	//   deploymentUID, replicaSet, replicaSetUID, statefulSet, statefulSetUID,
+	//   daemonSet, daemonSetUID, job, jobUID, cronJob and cronJobUID
+	//
+	// The deployment name is derived from the pod name unless
+	// watch_replica_sets is set. The deploymentUID, cronJob and cronJobUID
+	// fields require watching the replica sets or jobs owning the pods.
+	//
 	// Specifying anything other than these values will result in an error.
-	// By default all of the fields are extracted and added to spans and metrics.
+	// By default namespace, podName, podUID, deployment, cluster, node and
+	// startTime are extracted and added to spans and metrics. The container
+	// fields are only extracted when listed, the restart count changes the
+	// resource of the data each time the container restarts.
 	Metadata []string `mapstructure:"metadata"`
 
-	// Annotations allows extracting data from pod annotations and record it
-	// as resource attributes.
+	// WatchReplicaSets looks up the deployment owning the pods through their
+	// replica sets, which requires watching the replica sets of the cluster.
+	// Otherwise the deployment name is derived from the pod name.
+	WatchReplicaSets bool `mapstructure:"watch_replica_sets"`
+
+	// Annotations allows extracting data from pod, namespace or node annotations
+	// and record it as resource attributes.
 	// It is a list of FieldExtractConfig type. See FieldExtractConfig
 	// documentation for more details.
 	Annotations []FieldExtractConfig `mapstructure:"annotations"`
 
-	// Annotations allows extracting data from pod labels and record it
-	// as resource attributes.
+	// Labels allows extracting data from pod, namespace or node labels and
+	// record it as resource attributes.
 	// It is a list of FieldExtractConfig type. See FieldExtractConfig
 	// documentation for more details.
 	Labels []FieldExtractConfig `mapstructure:"labels"`
@@ -69,13 +132,13 @@ type ExtractConfig struct {
 
 // FieldExtractConfig allows specifying an extraction rule to extract a value from exactly one field.
 //
-// The field accepts a list FilterExtractConfig map. The map accepts three keys
-//     tag_name, key and regex
+// The field accepts a list FilterExtractConfig map. The map accepts four keys
+//     tag_name, key, regex and from
 //
 // - tag_name represents the name of the tag that will be added to the span.
 //   When not specified a default tag name will be used of the format:
-//       k8s.pod.annotations.<annotation key>
-//       k8s.pod.labels.<label key>
+//       k8s.<from>.annotations.<annotation key>
+//       k8s.<from>.labels.<label key>
 //   For example, if tag_name is not specified and the key is git_sha,
 //   then the attribute name will be `k8s.pod.annotations.git_sha`.
 //
@@ -101,10 +164,14 @@ type ExtractConfig struct {
 //           regex: JENKINS=(?P<value>[\w]+)
 //
 //   this will add the `git.sha` and `ci.build` tags to the spans or metrics.
+//
+// - from represents the object the field is extracted from: pod, namespace or node.
+//   The namespace and node of the pod are watched when they are used. It defaults to pod.
 type FieldExtractConfig struct {
 	TagName string `mapstructure:"tag_name"`
 	Key     string `mapstructure:"key"`
 	Regex   string `mapstructure:"regex"`
+	From    string `mapstructure:"from"`
 }
 
 // FilterConfig section allows specifying filters to filter
@@ -17,6 +17,7 @@ package k8sprocessor
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,7 +54,8 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "k8s_tagger",
 				NameVal: "k8s_tagger",
 			},
-			APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+			APIConfig:            k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+			PodDeleteGracePeriod: 2 * time.Minute,
 		})
 
 	p1 := config.Processors["k8s_tagger/2"]
@@ -66,7 +68,9 @@ func TestLoadConfig(t *testing.T) {
 			APIConfig:   k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
 			Passthrough: false,
 			Extract: ExtractConfig{
-				Metadata: []string{"podName", "podUID", "deployment", "cluster", "namespace", "node", "startTime"},
+				Metadata: []string{"podName", "podUID", "deployment", "cluster", "namespace", "node", "startTime",
+					"deploymentUID", "statefulSet", "daemonSet", "cronJob"},
+				WatchReplicaSets: true,
 				Annotations: []FieldExtractConfig{
 					{TagName: "a1", Key: "annotation-one"},
 					{TagName: "a2", Key: "annotation-two", Regex: "field=(?P<value>.+)"},
@@ -74,6 +78,7 @@ func TestLoadConfig(t *testing.T) {
 				Labels: []FieldExtractConfig{
 					{TagName: "l1", Key: "label1"},
 					{TagName: "l2", Key: "label2", Regex: "field=(?P<value>.+)"},
+					{TagName: "l3", Key: "label3", From: "namespace"},
 				},
 			},
 			Filter: FilterConfig{
@@ -89,5 +94,14 @@ func TestLoadConfig(t *testing.T) {
 					{Key: "key2", Value: "value2", Op: "not-equals"},
 				},
 			},
+			PodIgnorePatterns:    []string{"^istio-", "-canary$"},
+			PodDeleteGracePeriod: 30 * time.Second,
+			Association: []PodAssociationConfig{
+				{From: "resource_attribute", Name: "k8s.pod.ip"},
+				{From: "pod_uid"},
+				{From: "container_id"},
+				{From: "pod_name"},
+				{From: "connection"},
+			},
 		})
 }
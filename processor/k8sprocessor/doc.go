@@ -23,9 +23,99 @@
 // that sent the telemetry data.
 // If a match is found, the cached metadata is added to the data as resource attributes.
 //
+// Pod association
+//
+// The IP address does not identify the pod when the telemetry data goes through a service mesh or NAT. The
+// "pod_association" config option lists the sources of the pod identifier to try in order instead. The first
+// one that yields an identifier is used, and the data isn't tagged if that pod is unknown or ignored:
+//
+//    k8s_tagger:
+//      pod_association:
+//        - from: resource_attribute # the pod IP held by the resource attribute set by name
+//          name: k8s.pod.ip
+//        - from: pod_uid # the k8s.pod.uid resource attribute
+//        - from: container_id # the container.id resource attribute
+//        - from: pod_name # the k8s.namespace.name and k8s.pod.name resource attributes
+//        - from: connection # the source IP address of the service that sent the data
+//
+// The processor only indexes the discovered pods by the identifiers the configured sources need.
+//
 // RBAC
 //
-// TODO: mention the required RBAC rules.
+// The processor watches pods, so the service account of the OpenTelemetry agent or collector must be allowed to
+// get, watch and list them. The namespaces and nodes are only watched when labels or annotations are extracted
+// from them, the replica sets when "watch_replica_sets" is set or the deployment UID is extracted and the jobs when
+// the cron job is extracted:
+//
+//    apiVersion: rbac.authorization.k8s.io/v1
+//    kind: ClusterRole
+//    metadata:
+//      name: otel-collector
+//    rules:
+//    - apiGroups: [""]
+//      resources: ["pods", "namespaces", "nodes"]
+//      verbs: ["get", "watch", "list"]
+//    - apiGroups: ["apps"]
+//      resources: ["replicasets"]
+//      verbs: ["get", "watch", "list"]
+//    - apiGroups: ["batch"]
+//      resources: ["jobs"]
+//      verbs: ["get", "watch", "list"]
+//
+// Owner metadata
+//
+// The deployment, stateful set, daemon set, job and cron job metadata is resolved by following the owner references
+// of the pods, through their job for cron jobs. The deployment name is derived from the name of the pods unless
+// "watch_replica_sets" is set, to look it up through their replica set, which the deployment UID requires:
+//
+//    k8s_tagger:
+//      extract:
+//        watch_replica_sets: true
+//        metadata:
+//          - podName
+//          - deployment
+//          - deploymentUID
+//          - statefulSet
+//          - daemonSet
+//          - cronJob
+//
+// Namespace and node metadata
+//
+// Labels and annotations can be extracted from the namespace of the pods or the node they run on. The metadata of
+// the namespaces and nodes is refreshed when the pods are resynced:
+//
+//    k8s_tagger:
+//      extract:
+//        labels:
+//          - key: team
+//            from: namespace # adds the k8s.namespace.labels.team resource attribute
+//          - tag_name: zone
+//            key: topology.kubernetes.io/zone
+//            from: node
+//
+// Container metadata
+//
+// When the resource identifies a container of the pod by the "container.name", "k8s.container.name" or
+// "container.id" attribute, the container name is added too, as well as the container id, image name and tag and
+// restart count when listed. The restart count changes the resource of the data each time the container restarts:
+//
+//    k8s_tagger:
+//      extract:
+//        metadata:
+//          - podName
+//          - containerID
+//          - containerImage
+//          - containerRestartCount
+//
+// Ignored and deleted pods
+//
+// Pods whose name matches one of the "pod_ignore_patterns" regular expressions are not tracked. It defaults to the
+// jaeger-agent and jaeger-collector pods, an empty list tracks all pods. The metadata of deleted pods is kept for
+// "pod_delete_grace_period", 2 minutes by default, so late telemetry data of the pods is still tagged:
+//
+//    k8s_tagger:
+//      pod_ignore_patterns: ["^istio-", "-canary$"]
+//      pod_delete_grace_period: 30s
 //
 // Config
 //
@@ -105,9 +195,9 @@
 //
 // Host networking mode
 //
-// The processor cannot correct identify pods running in the host network mode and
-// enriching telemetry data generated by such pods is not supported at the moment, unless the attributes contain
-// information about the source IP.
+// Pods running in the host network mode share the IP address of their node, which therefore does not identify
+// them. Such pods are not indexed by IP and enriching telemetry data generated by them requires one of the
+// pod_uid, container_id or pod_name pod association sources.
 //
 // As a sidecar
 //
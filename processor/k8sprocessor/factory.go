@@ -16,6 +16,7 @@ package k8sprocessor
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -29,8 +30,12 @@ import (
 const (
 	// The value of "type" key in configuration.
 	typeStr = "k8s_tagger"
+
+	defaultPodDeleteGracePeriod = 2 * time.Minute
 )
 
+var defaultPodIgnorePatterns = []string{"jaeger-agent", "jaeger-collector"}
+
 var kubeClientProvider = kube.ClientProvider(nil)
 var processorCapabilities = component.ProcessorCapabilities{MutatesConsumedData: true}
 
@@ -51,7 +56,8 @@ func createDefaultConfig() configmodels.Processor {
 			TypeVal: configmodels.Type(typeStr),
 			NameVal: typeStr,
 		},
-		APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		APIConfig:            k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		PodDeleteGracePeriod: defaultPodDeleteGracePeriod,
 	}
 }
 
@@ -180,6 +186,9 @@ func createProcessorOpts(cfg configmodels.Processor) []Option {
 
 	// extraction rules
 	opts = append(opts, WithExtractMetadata(oCfg.Extract.Metadata...))
+	if oCfg.Extract.WatchReplicaSets {
+		opts = append(opts, WithWatchReplicaSets())
+	}
 	opts = append(opts, WithExtractLabels(oCfg.Extract.Labels...))
 	opts = append(opts, WithExtractAnnotations(oCfg.Extract.Annotations...))
 
@@ -188,6 +197,15 @@ func createProcessorOpts(cfg configmodels.Processor) []Option {
 	opts = append(opts, WithFilterNamespace(oCfg.Filter.Namespace))
 	opts = append(opts, WithFilterLabels(oCfg.Filter.Labels...))
 	opts = append(opts, WithFilterFields(oCfg.Filter.Fields...))
+	opts = append(opts, WithExtractPodAssociations(oCfg.Association...))
+
+	// An empty list of patterns does not ignore any pod by name, unlike an unset one.
+	ignorePatterns := oCfg.PodIgnorePatterns
+	if ignorePatterns == nil {
+		ignorePatterns = defaultPodIgnorePatterns
+	}
+	opts = append(opts, WithPodIgnorePatterns(ignorePatterns...))
+	opts = append(opts, WithPodDeleteGracePeriod(oCfg.PodDeleteGracePeriod))
 	opts = append(opts, WithAPIConfig(oCfg.APIConfig))
 
 	return opts
@@ -17,8 +17,10 @@ package k8sprocessor
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/exporter/exportertest"
@@ -71,3 +73,24 @@ func TestCreateProcessor(t *testing.T) {
 	// Switch it back so other tests run afterwards will not fail on unexpected state
 	kubeClientProvider = realClient
 }
+
+func TestCreateProcessorExcludes(t *testing.T) {
+	var kp *kubernetesprocessor
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	_, err := newTraceProcessor(cfg, exportertest.NewNopTraceExporter(), withExtractKubernetesProcessorInto(&kp))
+	require.NoError(t, err)
+	fc := kp.kc.(*fakeClient)
+	require.Len(t, fc.Excludes.PodNames, 2)
+	assert.Equal(t, "jaeger-agent", fc.Excludes.PodNames[0].String())
+	assert.Equal(t, "jaeger-collector", fc.Excludes.PodNames[1].String())
+	assert.Equal(t, 2*time.Minute, fc.DeleteGracePeriod)
+
+	// An empty list does not ignore any pod by name.
+	cfg.PodIgnorePatterns = []string{}
+	cfg.PodDeleteGracePeriod = 10 * time.Second
+	_, err = newTraceProcessor(cfg, exportertest.NewNopTraceExporter(), withExtractKubernetesProcessorInto(&kp))
+	require.NoError(t, err)
+	fc = kp.kc.(*fakeClient)
+	assert.Len(t, fc.Excludes.PodNames, 0)
+	assert.Equal(t, 10*time.Second, fc.DeleteGracePeriod)
+}
@@ -23,31 +23,41 @@ import (
 
 	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/containerimage"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor/observability"
 )
 
 // WatchClient is the main interface provided by this package to a kubernetes cluster.
 type WatchClient struct {
-	m               sync.RWMutex
-	deleteMut       sync.Mutex
-	logger          *zap.Logger
-	kc              kubernetes.Interface
-	informer        cache.SharedInformer
-	deploymentRegex *regexp.Regexp
-	deleteQueue     []deleteRequest
-	stopCh          chan struct{}
-
-	Pods    map[string]*Pod
-	Rules   ExtractionRules
-	Filters Filters
+	m                  sync.RWMutex
+	deleteMut          sync.Mutex
+	logger             *zap.Logger
+	kc                 kubernetes.Interface
+	informer           cache.SharedInformer
+	namespaceInformer  cache.SharedInformer
+	nodeInformer       cache.SharedInformer
+	replicaSetInformer cache.SharedInformer
+	jobInformer        cache.SharedInformer
+	deleteQueue        []deleteRequest
+	stopCh             chan struct{}
+	deploymentRegex    *regexp.Regexp
+
+	Pods         map[PodIdentifier]*Pod
+	Rules        ExtractionRules
+	Filters      Filters
+	Associations Associations
+	Excludes     Excludes
 }
 
 // Extract deployment name from the pod name. Pod name is created using
@@ -55,11 +65,25 @@ type WatchClient struct {
 var dRegex = regexp.MustCompile(`^(.*)-[0-9a-zA-Z]*-[0-9a-zA-Z]*$`)
 
 // New initializes a new k8s Client.
-func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, newClientSet APIClientsetProvider, newInformer InformerProvider) (Client, error) {
-	c := &WatchClient{logger: logger, Rules: rules, Filters: filters, deploymentRegex: dRegex, stopCh: make(chan struct{})}
-	go c.deleteLoop(time.Second*30, defaultPodDeleteGracePeriod)
-
-	c.Pods = map[string]*Pod{}
+// Deleted pods are forgotten once the delete grace period is over.
+func New(
+	logger *zap.Logger,
+	apiCfg k8sconfig.APIConfig,
+	rules ExtractionRules,
+	filters Filters,
+	associations Associations,
+	excludes Excludes,
+	deleteGracePeriod time.Duration,
+	newClientSet APIClientsetProvider,
+	newInformer InformerProvider,
+) (Client, error) {
+	if associations == (Associations{}) {
+		associations.IP = true
+	}
+	c := &WatchClient{logger: logger, Rules: rules, Filters: filters, Associations: associations, Excludes: excludes, deploymentRegex: dRegex, stopCh: make(chan struct{})}
+	go c.deleteLoop(time.Second*30, deleteGracePeriod)
+
+	c.Pods = map[PodIdentifier]*Pod{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -84,11 +108,27 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 	}
 
 	c.informer = newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
+
+	// The objects other than pods the metadata is extracted from are only
+	// watched when the extraction rules require them.
+	if c.Rules.needsFrom(MetadataFromNamespace) {
+		c.namespaceInformer = newNamespaceSharedInformer(c.kc, c.Filters.Namespace)
+	}
+	if c.Rules.needsFrom(MetadataFromNode) {
+		c.nodeInformer = newNodeSharedInformer(c.kc, c.Filters.Node)
+	}
+	if c.Rules.needsReplicaSets() {
+		c.replicaSetInformer = newReplicaSetSharedInformer(c.kc, c.Filters.Namespace)
+	}
+	if c.Rules.needsJobs() {
+		c.jobInformer = newJobSharedInformer(c.kc, c.Filters.Namespace)
+	}
 	return c, err
 }
 
 // Start registers pod event handlers and starts watching the kubernetes cluster for pod changes.
 func (c *WatchClient) Start() {
+	c.startObjectInformers()
 	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
 		UpdateFunc: c.handlePodUpdate,
@@ -102,6 +142,35 @@ func (c *WatchClient) Stop() {
 	close(c.stopCh)
 }
 
+// startObjectInformers starts watching the namespaces, nodes, replica sets and
+// jobs without waiting for them to be cached. The pods added before are updated
+// once they are, so that the metadata taken from them is not missing until the
+// pods are resynced.
+func (c *WatchClient) startObjectInformers() {
+	var synced []cache.InformerSynced
+	for _, informer := range []cache.SharedInformer{c.namespaceInformer, c.nodeInformer, c.replicaSetInformer, c.jobInformer} {
+		if informer == nil {
+			continue
+		}
+		go informer.Run(c.stopCh)
+		synced = append(synced, informer.HasSynced)
+	}
+	if len(synced) == 0 {
+		return
+	}
+
+	go func() {
+		if !cache.WaitForCacheSync(c.stopCh, synced...) {
+			return
+		}
+		for _, obj := range c.informer.GetStore().List() {
+			if pod, ok := obj.(*api_v1.Pod); ok {
+				c.addOrUpdatePod(pod)
+			}
+		}
+	}()
+}
+
 func (c *WatchClient) handlePodAdd(obj interface{}) {
 	observability.RecordPodAdded()
 	if pod, ok := obj.(*api_v1.Pod); ok {
@@ -116,6 +185,11 @@ func (c *WatchClient) handlePodUpdate(old, new interface{}) {
 	if pod, ok := new.(*api_v1.Pod); ok {
 		// TODO: update or remove based on whether container is ready/unready?.
 		c.addOrUpdatePod(pod)
+		// Identifiers such as the IDs of restarted containers that no longer
+		// belong to the pod are forgotten.
+		if oldPod, ok := old.(*api_v1.Pod); ok {
+			c.forgetIdentifiers(oldPod.Name, staleIdentifiers(c.podIdentifiers(oldPod), c.podIdentifiers(pod)))
+		}
 	} else {
 		c.logger.Error("object received was not of type api_v1.Pod", zap.Any("received", new))
 	}
@@ -152,11 +226,11 @@ func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Durati
 
 			c.m.Lock()
 			for _, d := range toDelete {
-				if p, ok := c.Pods[d.ip]; ok {
+				if p, ok := c.Pods[d.id]; ok {
 					// Sanity check: make sure we are deleting the same pod
-					// and the underlying state (ip<>pod mapping) has not changed.
+					// and the underlying state (id<>pod mapping) has not changed.
 					if p.Name == d.name {
-						delete(c.Pods, d.ip)
+						delete(c.Pods, d.id)
 					}
 				}
 			}
@@ -168,10 +242,10 @@ func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Durati
 	}
 }
 
-// GetPodByIP takes an IP address and returns the pod the IP address is associated with.
-func (c *WatchClient) GetPodByIP(ip string) (*Pod, bool) {
+// GetPod takes an identifier and returns the pod the identifier is associated with.
+func (c *WatchClient) GetPod(id PodIdentifier) (*Pod, bool) {
 	c.m.RLock()
-	pod, ok := c.Pods[ip]
+	pod, ok := c.Pods[id]
 	c.m.RUnlock()
 	if ok {
 		if pod.Ignore {
@@ -205,13 +279,7 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 		tags[conventions.AttributeK8sPodUID] = string(uid)
 	}
 
-	if c.Rules.Deployment {
-		// format: [deployment-name]-[Random-String-For-ReplicaSet]-[Random-String-For-Pod]
-		parts := c.deploymentRegex.FindStringSubmatch(pod.Name)
-		if len(parts) == 2 {
-			tags[conventions.AttributeK8sDeployment] = parts[1]
-		}
-	}
+	c.extractOwnerAttributes(pod, tags)
 
 	if c.Rules.Node {
 		tags[tagNodeName] = pod.Spec.NodeName
@@ -225,19 +293,134 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 	}
 
 	for _, r := range c.Rules.Labels {
-		if v, ok := pod.Labels[r.Key]; ok {
-			tags[r.Name] = c.extractField(v, r)
+		if meta := c.objectMeta(pod, r.From); meta != nil {
+			if v, ok := meta.Labels[r.Key]; ok {
+				tags[r.Name] = c.extractField(v, r)
+			}
 		}
 	}
 
 	for _, r := range c.Rules.Annotations {
-		if v, ok := pod.Annotations[r.Key]; ok {
-			tags[r.Name] = c.extractField(v, r)
+		if meta := c.objectMeta(pod, r.From); meta != nil {
+			if v, ok := meta.Annotations[r.Key]; ok {
+				tags[r.Name] = c.extractField(v, r)
+			}
 		}
 	}
 	return tags
 }
 
+// extractPodContainers returns the containers of the pod, with the image from
+// their spec and the ID and restart count from their status.
+func extractPodContainers(pod *api_v1.Pod) map[string]*Container {
+	containers := map[string]*Container{}
+	for _, specs := range [][]api_v1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, spec := range specs {
+			container := &Container{Name: spec.Name}
+			container.ImageName, container.ImageTag = containerimage.Parse(spec.Image)
+			containers[spec.Name] = container
+		}
+	}
+	for _, statuses := range [][]api_v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if container, ok := containers[status.Name]; ok {
+				container.ID = containerID(status.ContainerID)
+				container.RestartCount = status.RestartCount
+			}
+		}
+	}
+	return containers
+}
+
+// extractOwnerAttributes follows the controller owner references of the pod to
+// tag it with the workload it belongs to.
+func (c *WatchClient) extractOwnerAttributes(pod *api_v1.Pod, tags map[string]string) {
+	owner := meta_v1.GetControllerOf(pod)
+	if owner == nil {
+		return
+	}
+
+	switch owner.Kind {
+	case "ReplicaSet":
+		setOwnerTags(tags, owner, c.Rules.ReplicaSet, c.Rules.ReplicaSetUID, conventions.AttributeK8sReplicaSet, conventions.AttributeK8sReplicaSetUID)
+		if c.Rules.needsReplicaSets() {
+			rs, ok := getObject(c.replicaSetInformer, pod.Namespace, owner.Name).(*apps_v1.ReplicaSet)
+			if ok && rs.UID == owner.UID {
+				if deployment := meta_v1.GetControllerOf(rs); deployment != nil && deployment.Kind == "Deployment" {
+					setOwnerTags(tags, deployment, c.Rules.Deployment, c.Rules.DeploymentUID, conventions.AttributeK8sDeployment, conventions.AttributeK8sDeploymentUID)
+					return
+				}
+			}
+		}
+		// Without the replica set, the deployment name is derived from the pod name.
+		if c.Rules.Deployment {
+			if parts := c.deploymentRegex.FindStringSubmatch(pod.Name); len(parts) == 2 {
+				tags[conventions.AttributeK8sDeployment] = parts[1]
+			}
+		}
+	case "StatefulSet":
+		setOwnerTags(tags, owner, c.Rules.StatefulSet, c.Rules.StatefulSetUID, conventions.AttributeK8sStatefulSet, conventions.AttributeK8sStatefulSetUID)
+	case "DaemonSet":
+		setOwnerTags(tags, owner, c.Rules.DaemonSet, c.Rules.DaemonSetUID, conventions.AttributeK8sDaemonSet, conventions.AttributeK8sDaemonSetUID)
+	case "Job":
+		setOwnerTags(tags, owner, c.Rules.Job, c.Rules.JobUID, conventions.AttributeK8sJob, conventions.AttributeK8sJobUID)
+		if !c.Rules.needsJobs() {
+			return
+		}
+		job, ok := getObject(c.jobInformer, pod.Namespace, owner.Name).(*batch_v1.Job)
+		if !ok || job.UID != owner.UID {
+			return
+		}
+		if cronJob := meta_v1.GetControllerOf(job); cronJob != nil && cronJob.Kind == "CronJob" {
+			setOwnerTags(tags, cronJob, c.Rules.CronJob, c.Rules.CronJobUID, conventions.AttributeK8sCronJob, conventions.AttributeK8sCronJobUID)
+		}
+	}
+}
+
+func setOwnerTags(tags map[string]string, owner *meta_v1.OwnerReference, name, uid bool, nameTag, uidTag string) {
+	if name {
+		tags[nameTag] = owner.Name
+	}
+	if uid {
+		tags[uidTag] = string(owner.UID)
+	}
+}
+
+// objectMeta returns the metadata of the pod, or of its namespace or node, a field
+// is extracted from. It returns nil when the namespace or node is not cached.
+func (c *WatchClient) objectMeta(pod *api_v1.Pod, from string) *meta_v1.ObjectMeta {
+	switch from {
+	case MetadataFromNamespace:
+		if ns, ok := getObject(c.namespaceInformer, "", pod.Namespace).(*api_v1.Namespace); ok {
+			return &ns.ObjectMeta
+		}
+		return nil
+	case MetadataFromNode:
+		if node, ok := getObject(c.nodeInformer, "", pod.Spec.NodeName).(*api_v1.Node); ok {
+			return &node.ObjectMeta
+		}
+		return nil
+	default:
+		return &pod.ObjectMeta
+	}
+}
+
+// getObject returns the object with the given namespace and name cached by the informer, if any.
+func getObject(informer cache.SharedInformer, namespace, name string) interface{} {
+	if informer == nil {
+		return nil
+	}
+	key := name
+	if namespace != "" {
+		key = namespace + "/" + name
+	}
+	obj, ok, err := informer.GetStore().GetByKey(key)
+	if err != nil || !ok {
+		return nil
+	}
+	return obj
+}
+
 func (c *WatchClient) extractField(v string, r FieldExtractionRule) string {
 	// Check if a subset of the field should be extracted with a regular expression
 	// instead of the whole field.
@@ -253,21 +436,11 @@ func (c *WatchClient) extractField(v string, r FieldExtractionRule) string {
 }
 
 func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
-	if pod.Status.PodIP == "" {
+	ids := c.podIdentifiers(pod)
+	if len(ids) == 0 {
 		return
 	}
 
-	c.m.Lock()
-	defer c.m.Unlock()
-	// compare initial scheduled timestamp for existing pod and new pod with same IP
-	// and only replace old pod if scheduled time of new pod is newer? This should fix
-	// the case where scheduler has assigned the same IP to a new pod but update event for
-	// the old pod came in later
-	if p, ok := c.Pods[pod.Status.PodIP]; ok {
-		if p.StartTime != nil && pod.Status.StartTime.Before(p.StartTime) {
-			return
-		}
-	}
 	newPod := &Pod{
 		Name:      pod.Name,
 		Address:   pod.Status.PodIP,
@@ -278,39 +451,106 @@ func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
 		newPod.Ignore = true
 	} else {
 		newPod.Attributes = c.extractPodAttributes(pod)
+		if c.Rules.needsContainers() {
+			newPod.Containers = extractPodContainers(pod)
+		}
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	for _, id := range ids {
+		// compare initial scheduled timestamp for existing pod and new pod with same identifier
+		// and only replace old pod if scheduled time of new pod is newer? This should fix
+		// the case where scheduler has assigned the same IP to a new pod but update event for
+		// the old pod came in later
+		if p, ok := c.Pods[id]; ok {
+			if p.StartTime != nil && pod.Status.StartTime.Before(p.StartTime) {
+				continue
+			}
+		}
+		c.Pods[id] = newPod
 	}
-	c.Pods[pod.Status.PodIP] = newPod
 }
 
 func (c *WatchClient) forgetPod(pod *api_v1.Pod) {
-	if pod.Status.PodIP == "" {
-		return
+	c.forgetIdentifiers(pod.Name, c.podIdentifiers(pod))
+}
+
+// forgetIdentifiers queues the identifiers still associated with the named pod
+// for deletion once the grace period is over.
+func (c *WatchClient) forgetIdentifiers(name string, ids []PodIdentifier) {
+	now := time.Now()
+	for _, id := range ids {
+		c.m.RLock()
+		p, ok := c.Pods[id]
+		c.m.RUnlock()
+
+		if ok && p.Name == name {
+			c.deleteMut.Lock()
+			c.deleteQueue = append(c.deleteQueue, deleteRequest{
+				id:   id,
+				name: name,
+				ts:   now,
+			})
+			c.deleteMut.Unlock()
+		}
 	}
-	c.m.RLock()
-	p, ok := c.GetPodByIP(pod.Status.PodIP)
-	c.m.RUnlock()
+}
 
-	if ok && p.Name == pod.Name {
-		c.deleteMut.Lock()
-		c.deleteQueue = append(c.deleteQueue, deleteRequest{
-			ip:   pod.Status.PodIP,
-			name: pod.Name,
-			ts:   time.Now(),
-		})
-		c.deleteMut.Unlock()
+// podIdentifiers returns the identifiers the pod is indexed by.
+func (c *WatchClient) podIdentifiers(pod *api_v1.Pod) []PodIdentifier {
+	var ids []PodIdentifier
+	// Pods in the host network share the IP of their node, which therefore
+	// does not identify them.
+	if c.Associations.IP && pod.Status.PodIP != "" && !pod.Spec.HostNetwork {
+		ids = append(ids, PodIPIdentifier(pod.Status.PodIP))
+	}
+	if c.Associations.PodUID && pod.UID != "" {
+		ids = append(ids, PodUIDIdentifier(string(pod.UID)))
+	}
+	if c.Associations.PodName && pod.Name != "" {
+		ids = append(ids, PodNameIdentifier(pod.Namespace, pod.Name))
+	}
+	if c.Associations.ContainerID {
+		for _, statuses := range [][]api_v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, s := range statuses {
+				if id := containerID(s.ContainerID); id != "" {
+					ids = append(ids, ContainerIDIdentifier(id))
+				}
+			}
+		}
 	}
+	return ids
 }
 
-func (c *WatchClient) shouldIgnorePod(pod *api_v1.Pod) bool {
-	// Host network mode is not supported right now with IP based
-	// tagging as all pods in host network get same IP addresses.
-	// Such pods are very rare and usually are used to monitor or control
-	// host traffic (e.g, linkerd, flannel) instead of service business needs.
-	// We plan to support host network pods in future.
-	if pod.Spec.HostNetwork {
-		return true
+// containerID strips the container runtime prefix, e.g. docker://, from the
+// container ID reported in the pod status.
+func containerID(id string) string {
+	if i := strings.Index(id, "://"); i >= 0 {
+		return id[i+len("://"):]
 	}
+	return id
+}
 
+// staleIdentifiers returns the identifiers in old that are not in current.
+func staleIdentifiers(old, current []PodIdentifier) []PodIdentifier {
+	var stale []PodIdentifier
+	for _, id := range old {
+		found := false
+		for _, c := range current {
+			if c == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			stale = append(stale, id)
+		}
+	}
+	return stale
+}
+
+func (c *WatchClient) shouldIgnorePod(pod *api_v1.Pod) bool {
 	// Check if user requested the pod to be ignored through annotations
 	if v, ok := pod.Annotations[ignoreAnnotation]; ok {
 		if strings.ToLower(strings.TrimSpace(v)) == "true" {
@@ -318,8 +558,8 @@ func (c *WatchClient) shouldIgnorePod(pod *api_v1.Pod) bool {
 		}
 	}
 
-	// Check well known names that should be ignored
-	for _, rexp := range podNameIgnorePatterns {
+	// Check the names that should be ignored
+	for _, rexp := range c.Excludes.PodNames {
 		if rexp.MatchString(pod.Name) {
 			return true
 		}
@@ -26,11 +26,14 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -54,7 +57,7 @@ func podAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj interfac
 	pod.Status.PodIP = "1.1.1.1"
 	handler(pod)
 	assert.Equal(t, len(c.Pods), 1)
-	got := c.Pods["1.1.1.1"]
+	got := c.Pods[PodIPIdentifier("1.1.1.1")]
 	assert.Equal(t, got.Address, "1.1.1.1")
 	assert.Equal(t, got.Name, "podA")
 
@@ -63,18 +66,18 @@ func podAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj interfac
 	pod.Status.PodIP = "1.1.1.1"
 	handler(pod)
 	assert.Equal(t, len(c.Pods), 1)
-	got = c.Pods["1.1.1.1"]
+	got = c.Pods[PodIPIdentifier("1.1.1.1")]
 	assert.Equal(t, got.Address, "1.1.1.1")
 	assert.Equal(t, got.Name, "podB")
 }
 
 func TestDefaultClientset(t *testing.T) {
-	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, nil, nil)
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, Associations{}, Excludes{}, time.Minute, nil, nil)
 	assert.Error(t, err)
 	assert.Equal(t, "invalid authType for kubernetes: ", err.Error())
 	assert.Nil(t, c)
 
-	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, newFakeAPIClientset, nil)
+	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, Associations{}, Excludes{}, time.Minute, newFakeAPIClientset, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
 }
@@ -85,6 +88,9 @@ func TestBadFilters(t *testing.T) {
 		k8sconfig.APIConfig{},
 		ExtractionRules{},
 		Filters{Fields: []FieldFilter{{Op: selection.Exists}}},
+		Associations{},
+		Excludes{},
+		time.Minute,
 		newFakeAPIClientset,
 		NewFakeInformer,
 	)
@@ -122,7 +128,7 @@ func TestConstructorErrors(t *testing.T) {
 			gotAPIConfig = c
 			return nil, fmt.Errorf("error creating k8s client")
 		}
-		c, err := New(zap.NewNop(), apiCfg, er, ff, clientProvider, NewFakeInformer)
+		c, err := New(zap.NewNop(), apiCfg, er, ff, Associations{}, Excludes{}, time.Minute, clientProvider, NewFakeInformer)
 		assert.Nil(t, c)
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "error creating k8s client")
@@ -144,11 +150,67 @@ func TestPodHostNetwork(t *testing.T) {
 	pod.Status.PodIP = "1.1.1.1"
 	pod.Spec.HostNetwork = true
 	c.handlePodAdd(pod)
+	// The IP of host network pods is the IP of the node.
+	assert.Equal(t, len(c.Pods), 0)
+
+	c, _ = newTestClientWithAssociations(t, ExtractionRules{}, Filters{}, Associations{IP: true, PodUID: true})
+	pod.UID = "pod-uid"
+	c.handlePodAdd(pod)
 	assert.Equal(t, len(c.Pods), 1)
-	got := c.Pods["1.1.1.1"]
+	got := c.Pods[PodUIDIdentifier("pod-uid")]
 	assert.Equal(t, got.Address, "1.1.1.1")
 	assert.Equal(t, got.Name, "podA")
-	assert.True(t, got.Ignore)
+	assert.False(t, got.Ignore)
+}
+
+func TestPodAssociations(t *testing.T) {
+	c, _ := newTestClientWithAssociations(t, ExtractionRules{}, Filters{}, Associations{
+		IP:          true,
+		PodUID:      true,
+		ContainerID: true,
+		PodName:     true,
+	})
+
+	pod := &api_v1.Pod{}
+	pod.Name = "podA"
+	pod.Namespace = "ns"
+	pod.UID = "pod-uid"
+	pod.Status.PodIP = "1.1.1.1"
+	pod.Status.InitContainerStatuses = []api_v1.ContainerStatus{{ContainerID: "containerd://init-id"}}
+	pod.Status.ContainerStatuses = []api_v1.ContainerStatus{
+		{ContainerID: "docker://container-1"},
+		// Not started yet.
+		{ContainerID: ""},
+	}
+	c.handlePodAdd(pod)
+	assert.Equal(t, len(c.Pods), 5)
+	for _, id := range []PodIdentifier{
+		PodIPIdentifier("1.1.1.1"),
+		PodUIDIdentifier("pod-uid"),
+		PodNameIdentifier("ns", "podA"),
+		ContainerIDIdentifier("init-id"),
+		ContainerIDIdentifier("container-1"),
+	} {
+		got, ok := c.GetPod(id)
+		require.True(t, ok, id)
+		assert.Equal(t, "podA", got.Name)
+	}
+	// The source is part of the identifier.
+	_, ok := c.GetPod(ContainerIDIdentifier("pod-uid"))
+	assert.False(t, ok)
+
+	// The container restarted.
+	updated := pod.DeepCopy()
+	updated.Status.ContainerStatuses = []api_v1.ContainerStatus{{ContainerID: "docker://container-2"}}
+	c.handlePodUpdate(pod, updated)
+	assert.Equal(t, len(c.Pods), 6)
+	_, ok = c.GetPod(ContainerIDIdentifier("container-2"))
+	assert.True(t, ok)
+	require.Equal(t, len(c.deleteQueue), 1)
+	assert.Equal(t, ContainerIDIdentifier("container-1"), c.deleteQueue[0].id)
+
+	c.handlePodDelete(updated)
+	assert.Equal(t, len(c.deleteQueue), 6)
 }
 
 func TestPodAddOutOfSync(t *testing.T) {
@@ -162,7 +224,7 @@ func TestPodAddOutOfSync(t *testing.T) {
 	pod.Status.StartTime = &startTime
 	c.handlePodAdd(pod)
 	assert.Equal(t, len(c.Pods), 1)
-	got := c.Pods["1.1.1.1"]
+	got := c.Pods[PodIPIdentifier("1.1.1.1")]
 	assert.Equal(t, got.Address, "1.1.1.1")
 	assert.Equal(t, got.Name, "podA")
 
@@ -173,7 +235,7 @@ func TestPodAddOutOfSync(t *testing.T) {
 	pod.Status.StartTime = &startTime2
 	c.handlePodAdd(pod)
 	assert.Equal(t, len(c.Pods), 1)
-	got = c.Pods["1.1.1.1"]
+	got = c.Pods[PodIPIdentifier("1.1.1.1")]
 	assert.Equal(t, got.Address, "1.1.1.1")
 	assert.Equal(t, got.Name, "podA")
 }
@@ -190,7 +252,7 @@ func TestPodDelete(t *testing.T) {
 	c, _ := newTestClient(t)
 	podAddAndUpdateTest(t, c, c.handlePodAdd)
 	assert.Equal(t, len(c.Pods), 1)
-	assert.Equal(t, c.Pods["1.1.1.1"].Address, "1.1.1.1")
+	assert.Equal(t, c.Pods[PodIPIdentifier("1.1.1.1")].Address, "1.1.1.1")
 
 	// delete empty IP pod
 	c.handlePodDelete(&api_v1.Pod{})
@@ -200,7 +262,7 @@ func TestPodDelete(t *testing.T) {
 	pod.Status.PodIP = "9.9.9.9"
 	c.handlePodDelete(pod)
 	assert.Equal(t, len(c.Pods), 1)
-	got := c.Pods["1.1.1.1"]
+	got := c.Pods[PodIPIdentifier("1.1.1.1")]
 	assert.Equal(t, got.Address, "1.1.1.1")
 	assert.Equal(t, len(c.deleteQueue), 0)
 
@@ -208,7 +270,7 @@ func TestPodDelete(t *testing.T) {
 	pod = &api_v1.Pod{}
 	pod.Status.PodIP = "1.1.1.1"
 	c.handlePodDelete(pod)
-	got = c.Pods["1.1.1.1"]
+	got = c.Pods[PodIPIdentifier("1.1.1.1")]
 	assert.Equal(t, len(c.Pods), 1)
 	assert.Equal(t, got.Address, "1.1.1.1")
 	assert.Equal(t, len(c.deleteQueue), 0)
@@ -222,7 +284,7 @@ func TestPodDelete(t *testing.T) {
 	assert.Equal(t, len(c.Pods), 1)
 	assert.Equal(t, len(c.deleteQueue), 1)
 	deleteRequest := c.deleteQueue[0]
-	assert.Equal(t, deleteRequest.ip, "1.1.1.1")
+	assert.Equal(t, deleteRequest.id, PodIPIdentifier("1.1.1.1"))
 	assert.Equal(t, deleteRequest.name, "podB")
 	assert.True(t, deleteRequest.ts.After(tsBeforeDelete))
 	assert.True(t, deleteRequest.ts.Before(time.Now()))
@@ -232,7 +294,7 @@ func TestDeleteQueue(t *testing.T) {
 	c, _ := newTestClient(t)
 	podAddAndUpdateTest(t, c, c.handlePodAdd)
 	assert.Equal(t, len(c.Pods), 1)
-	assert.Equal(t, c.Pods["1.1.1.1"].Address, "1.1.1.1")
+	assert.Equal(t, c.Pods[PodIPIdentifier("1.1.1.1")].Address, "1.1.1.1")
 
 	// delete pod
 	pod := &api_v1.Pod{}
@@ -285,8 +347,8 @@ func TestGetIgnoredPod(t *testing.T) {
 	pod := &api_v1.Pod{}
 	pod.Status.PodIP = "1.1.1.1"
 	c.handlePodAdd(pod)
-	c.Pods[pod.Status.PodIP].Ignore = true
-	got, ok := c.GetPodByIP(pod.Status.PodIP)
+	c.Pods[PodIPIdentifier(pod.Status.PodIP)].Ignore = true
+	got, ok := c.GetPod(PodIPIdentifier(pod.Status.PodIP))
 	assert.Nil(t, got)
 	assert.False(t, ok)
 }
@@ -304,7 +366,39 @@ func TestHandlerWrongType(t *testing.T) {
 }
 
 func TestExtractionRules(t *testing.T) {
-	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+	// The rules are replaced by each test case, the informers of the objects
+	// other than pods are created by the initial ones.
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{
+		Deployment:       true,
+		WatchReplicaSets: true,
+		Labels: []FieldExtractionRule{
+			{From: MetadataFromNamespace},
+			{From: MetadataFromNode},
+		},
+	}, Filters{})
+	require.NoError(t, c.replicaSetInformer.GetStore().Add(&apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "auth-service-abc12",
+			Namespace: "ns1",
+			UID:       "rs-uid",
+			OwnerReferences: []meta_v1.OwnerReference{
+				controllerRef("Deployment", "auth-service", "deployment-uid"),
+			},
+		},
+	}))
+	require.NoError(t, c.namespaceInformer.GetStore().Add(&api_v1.Namespace{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "ns1",
+			Labels:      map[string]string{"team": "auth"},
+			Annotations: map[string]string{"owner": "auth@example.com"},
+		},
+	}))
+	require.NoError(t, c.nodeInformer.GetStore().Add(&api_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "us-west-2a"},
+		},
+	}))
 
 	pod := &api_v1.Pod{
 		ObjectMeta: meta_v1.ObjectMeta{
@@ -320,6 +414,9 @@ func TestExtractionRules(t *testing.T) {
 			Annotations: map[string]string{
 				"annotation1": "av1",
 			},
+			OwnerReferences: []meta_v1.OwnerReference{
+				controllerRef("ReplicaSet", "auth-service-abc12", "rs-uid"),
+			},
 		},
 		Spec: api_v1.PodSpec{
 			NodeName: "node1",
@@ -345,6 +442,22 @@ func TestExtractionRules(t *testing.T) {
 		attributes: map[string]string{
 			"k8s.deployment.name": "auth-service",
 		},
+	}, {
+		name: "owners",
+		rules: ExtractionRules{
+			Deployment:    true,
+			DeploymentUID: true,
+			ReplicaSet:    true,
+			ReplicaSetUID: true,
+			// The pod is not owned by a stateful set.
+			StatefulSet: true,
+		},
+		attributes: map[string]string{
+			"k8s.deployment.name": "auth-service",
+			"k8s.deployment.uid":  "deployment-uid",
+			"k8s.replicaset.name": "auth-service-abc12",
+			"k8s.replicaset.uid":  "rs-uid",
+		},
 	}, {
 		name: "metadata",
 		rules: ExtractionRules{
@@ -388,13 +501,42 @@ func TestExtractionRules(t *testing.T) {
 			"l2": "v5",
 			"a1": "av1",
 		},
+	}, {
+		name: "namespace-and-node",
+		rules: ExtractionRules{
+			Annotations: []FieldExtractionRule{{
+				Name: "ns.owner",
+				Key:  "owner",
+				From: MetadataFromNamespace,
+			},
+			},
+			Labels: []FieldExtractionRule{{
+				Name: "ns.team",
+				Key:  "team",
+				From: MetadataFromNamespace,
+			}, {
+				Name: "node.zone",
+				Key:  "topology.kubernetes.io/zone",
+				From: MetadataFromNode,
+			}, {
+				Name: "pod.team",
+				Key:  "team",
+				From: MetadataFromPod,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"ns.owner":  "auth@example.com",
+			"ns.team":   "auth",
+			"node.zone": "us-west-2a",
+		},
 	},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			c.Rules = tc.rules
 			c.handlePodAdd(pod)
-			p, ok := c.GetPodByIP(pod.Status.PodIP)
+			p, ok := c.GetPod(PodIPIdentifier(pod.Status.PodIP))
 			require.True(t, ok)
 
 			assert.Equal(t, len(tc.attributes), len(p.Attributes))
@@ -407,6 +549,212 @@ func TestExtractionRules(t *testing.T) {
 	}
 }
 
+func TestExtractOwnerAttributes(t *testing.T) {
+	rules := ExtractionRules{
+		Deployment:       true,
+		WatchReplicaSets: true,
+		ReplicaSet:       true,
+		StatefulSet:      true,
+		StatefulSetUID:   true,
+		DaemonSet:        true,
+		DaemonSetUID:     true,
+		Job:              true,
+		JobUID:           true,
+		CronJob:          true,
+		CronJobUID:       true,
+	}
+	c, _ := newTestClientWithRulesAndFilters(t, rules, Filters{})
+	require.NoError(t, c.jobInformer.GetStore().Add(&batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "report-1600000000",
+			Namespace: "ns1",
+			UID:       "job-uid",
+			OwnerReferences: []meta_v1.OwnerReference{
+				controllerRef("CronJob", "report", "cronjob-uid"),
+			},
+		},
+	}))
+	// The replica set is not cached, the deployment is unknown.
+	assert.Len(t, c.replicaSetInformer.GetStore().List(), 0)
+
+	testCases := []struct {
+		name       string
+		owners     []meta_v1.OwnerReference
+		attributes map[string]string
+	}{{
+		name:       "no-owner",
+		attributes: map[string]string{},
+	}, {
+		name: "not-controller",
+		owners: []meta_v1.OwnerReference{
+			{Kind: "StatefulSet", Name: "db", UID: "statefulset-uid"},
+		},
+		attributes: map[string]string{},
+	}, {
+		name:   "replicaset",
+		owners: []meta_v1.OwnerReference{controllerRef("ReplicaSet", "web-abc12", "rs-uid")},
+		attributes: map[string]string{
+			"k8s.replicaset.name": "web-abc12",
+		},
+	}, {
+		name:   "statefulset",
+		owners: []meta_v1.OwnerReference{controllerRef("StatefulSet", "db", "statefulset-uid")},
+		attributes: map[string]string{
+			"k8s.statefulset.name": "db",
+			"k8s.statefulset.uid":  "statefulset-uid",
+		},
+	}, {
+		name:   "daemonset",
+		owners: []meta_v1.OwnerReference{controllerRef("DaemonSet", "agent", "daemonset-uid")},
+		attributes: map[string]string{
+			"k8s.daemonset.name": "agent",
+			"k8s.daemonset.uid":  "daemonset-uid",
+		},
+	}, {
+		name:   "cronjob",
+		owners: []meta_v1.OwnerReference{controllerRef("Job", "report-1600000000", "job-uid")},
+		attributes: map[string]string{
+			"k8s.job.name":     "report-1600000000",
+			"k8s.job.uid":      "job-uid",
+			"k8s.cronjob.name": "report",
+			"k8s.cronjob.uid":  "cronjob-uid",
+		},
+	}, {
+		name:   "job-recreated",
+		owners: []meta_v1.OwnerReference{controllerRef("Job", "report-1600000000", "other-job-uid")},
+		attributes: map[string]string{
+			"k8s.job.name": "report-1600000000",
+			"k8s.job.uid":  "other-job-uid",
+		},
+	},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:            "pod",
+					Namespace:       "ns1",
+					OwnerReferences: tc.owners,
+				},
+			}
+			assert.Equal(t, tc.attributes, c.extractPodAttributes(pod))
+		})
+	}
+}
+
+func TestDeploymentFromPodName(t *testing.T) {
+	// The replica sets are not watched by default.
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{Deployment: true}, Filters{})
+	assert.Nil(t, c.replicaSetInformer)
+
+	testCases := []struct {
+		name       string
+		podName    string
+		owners     []meta_v1.OwnerReference
+		attributes map[string]string
+	}{{
+		name:    "replicaset",
+		podName: "web-7d4b9c8f5-x2x8q",
+		owners:  []meta_v1.OwnerReference{controllerRef("ReplicaSet", "web-7d4b9c8f5", "rs-uid")},
+		attributes: map[string]string{
+			"k8s.deployment.name": "web",
+		},
+	}, {
+		name:       "statefulset",
+		podName:    "db-cluster-0",
+		owners:     []meta_v1.OwnerReference{controllerRef("StatefulSet", "db-cluster", "statefulset-uid")},
+		attributes: map[string]string{},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:            tc.podName,
+					Namespace:       "ns1",
+					OwnerReferences: tc.owners,
+				},
+			}
+			assert.Equal(t, tc.attributes, c.extractPodAttributes(pod))
+		})
+	}
+
+	// The deployment name falls back to the pod name when the replica set is not cached.
+	c, _ = newTestClientWithRulesAndFilters(t, ExtractionRules{Deployment: true, WatchReplicaSets: true}, Filters{})
+	require.NotNil(t, c.replicaSetInformer)
+	pod := &api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "web-7d4b9c8f5-x2x8q",
+			Namespace:       "ns1",
+			OwnerReferences: []meta_v1.OwnerReference{controllerRef("ReplicaSet", "web-7d4b9c8f5", "rs-uid")},
+		},
+	}
+	assert.Equal(t, map[string]string{"k8s.deployment.name": "web"}, c.extractPodAttributes(pod))
+}
+
+func TestStartUpdatesPodsOnceObjectsAreCached(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&api_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns1", Labels: map[string]string{"team": "auth"}}},
+		&api_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "podA", Namespace: "ns1"},
+			Status:     api_v1.PodStatus{PodIP: "1.1.1.1"},
+		},
+	)
+	client, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{
+		Labels: []FieldExtractionRule{{Name: "team", Key: "team", From: MetadataFromNamespace}},
+	}, Filters{Namespace: "ns1"}, Associations{}, Excludes{}, time.Minute,
+		func(k8sconfig.APIConfig) (kubernetes.Interface, error) { return clientset, nil },
+		newSharedInformer)
+	require.NoError(t, err)
+	c := client.(*WatchClient)
+
+	done := make(chan struct{})
+	go func() {
+		c.Start()
+		close(done)
+	}()
+	defer func() {
+		c.Stop()
+		<-done
+	}()
+
+	assert.Eventually(t, func() bool {
+		pod, ok := c.GetPod(PodIPIdentifier("1.1.1.1"))
+		return ok && pod.Attributes["team"] == "auth"
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestObjectInformers(t *testing.T) {
+	c, _ := newTestClient(t)
+	assert.Nil(t, c.namespaceInformer)
+	assert.Nil(t, c.nodeInformer)
+	assert.Nil(t, c.replicaSetInformer)
+	assert.Nil(t, c.jobInformer)
+
+	c, _ = newTestClientWithRulesAndFilters(t, ExtractionRules{
+		DeploymentUID: true,
+		CronJobUID:    true,
+		Annotations:   []FieldExtractionRule{{From: MetadataFromNamespace}},
+		Labels:        []FieldExtractionRule{{From: MetadataFromNode}},
+	}, Filters{})
+	assert.NotNil(t, c.namespaceInformer)
+	assert.NotNil(t, c.nodeInformer)
+	assert.NotNil(t, c.replicaSetInformer)
+	assert.NotNil(t, c.jobInformer)
+
+	done := make(chan struct{})
+	go func() {
+		c.Start()
+		close(done)
+	}()
+	c.Stop()
+	<-done
+}
+
+func controllerRef(kind, name, uid string) meta_v1.OwnerReference {
+	controller := true
+	return meta_v1.OwnerReference{Kind: kind, Name: name, UID: types.UID(uid), Controller: &controller}
+}
+
 func TestFilters(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -480,7 +828,7 @@ func TestPodIgnorePatterns(t *testing.T) {
 		ignore: false,
 		pod:    api_v1.Pod{},
 	}, {
-		ignore: true,
+		ignore: false,
 		pod: api_v1.Pod{
 			Spec: api_v1.PodSpec{
 				HostNetwork: true,
@@ -540,9 +888,60 @@ func TestPodIgnorePatterns(t *testing.T) {
 	}
 
 	c, _ := newTestClient(t)
+	c.Excludes = Excludes{PodNames: []*regexp.Regexp{
+		regexp.MustCompile(`jaeger-agent`),
+		regexp.MustCompile(`jaeger-collector`),
+	}}
 	for _, tc := range testCases {
 		assert.Equal(t, tc.ignore, c.shouldIgnorePod(&tc.pod))
 	}
+
+	// No pod name is ignored by default.
+	c, _ = newTestClient(t)
+	assert.False(t, c.shouldIgnorePod(&api_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "jaeger-agent"}}))
+}
+
+func TestPodContainers(t *testing.T) {
+	c, _ := newTestClient(t)
+	pod := &api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "podA"},
+		Spec: api_v1.PodSpec{
+			InitContainers: []api_v1.Container{
+				{Name: "init", Image: "busybox"},
+			},
+			Containers: []api_v1.Container{
+				{Name: "app", Image: "registry.example.com:5000/team/app:1.2.3"},
+				{Name: "sidecar", Image: "envoyproxy/envoy@sha256:abcdef"},
+				{Name: "pending", Image: "redis:6.0"},
+			},
+		},
+		Status: api_v1.PodStatus{
+			PodIP: "1.1.1.1",
+			InitContainerStatuses: []api_v1.ContainerStatus{
+				{Name: "init", ContainerID: "containerd://init-id"},
+			},
+			ContainerStatuses: []api_v1.ContainerStatus{
+				{Name: "app", ContainerID: "docker://app-id", RestartCount: 3},
+				{Name: "sidecar", ContainerID: "docker://sidecar-id"},
+			},
+		},
+	}
+
+	c.handlePodAdd(pod)
+	got, ok := c.GetPod(PodIPIdentifier("1.1.1.1"))
+	require.True(t, ok)
+	assert.Nil(t, got.Containers)
+
+	c.Rules = ExtractionRules{ContainerRestartCount: true}
+	c.handlePodAdd(pod)
+	got, ok = c.GetPod(PodIPIdentifier("1.1.1.1"))
+	require.True(t, ok)
+	assert.Equal(t, map[string]*Container{
+		"init":    {Name: "init", ID: "init-id", ImageName: "busybox"},
+		"app":     {Name: "app", ID: "app-id", ImageName: "registry.example.com:5000/team/app", ImageTag: "1.2.3", RestartCount: 3},
+		"sidecar": {Name: "sidecar", ID: "sidecar-id", ImageName: "envoyproxy/envoy"},
+		"pending": {Name: "pending", ImageName: "redis", ImageTag: "6.0"},
+	}, got.Containers)
 }
 
 func Test_extractField(t *testing.T) {
@@ -635,9 +1034,13 @@ func Test_selectorsFromFilters(t *testing.T) {
 }
 
 func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters) (*WatchClient, *observer.ObservedLogs) {
+	return newTestClientWithAssociations(t, e, f, Associations{})
+}
+
+func newTestClientWithAssociations(t *testing.T, e ExtractionRules, f Filters, a Associations) (*WatchClient, *observer.ObservedLogs) {
 	observedLogger, logs := observer.New(zapcore.WarnLevel)
 	logger := zap.New(observedLogger)
-	c, err := New(logger, k8sconfig.APIConfig{}, e, f, newFakeAPIClientset, NewFakeInformer)
+	c, err := New(logger, k8sconfig.APIConfig{}, e, f, a, Excludes{}, time.Minute, newFakeAPIClientset, NewFakeInformer)
 	require.NoError(t, err)
 	return c.(*WatchClient), logs
 }
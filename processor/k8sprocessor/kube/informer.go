@@ -17,6 +17,8 @@ package kube
 import (
 	"context"
 
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -69,3 +71,79 @@ func informerWatchFuncWithSelectors(client kubernetes.Interface, namespace strin
 		return client.CoreV1().Pods(namespace).Watch(context.Background(), opts)
 	}
 }
+
+// newNamespaceSharedInformer returns an informer watching the given namespace,
+// or all namespaces when namespace is empty.
+func newNamespaceSharedInformer(client kubernetes.Interface, namespace string) cache.SharedInformer {
+	fs := fields.Everything()
+	if namespace != "" {
+		fs = fields.OneTermEqualSelector("metadata.name", namespace)
+	}
+	return cache.NewSharedInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				opts.FieldSelector = fs.String()
+				return client.CoreV1().Namespaces().List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				opts.FieldSelector = fs.String()
+				return client.CoreV1().Namespaces().Watch(context.Background(), opts)
+			},
+		},
+		&api_v1.Namespace{},
+		watchSyncPeriod,
+	)
+}
+
+// newNodeSharedInformer returns an informer watching the given node, or all
+// nodes when node is empty.
+func newNodeSharedInformer(client kubernetes.Interface, node string) cache.SharedInformer {
+	fs := fields.Everything()
+	if node != "" {
+		fs = fields.OneTermEqualSelector("metadata.name", node)
+	}
+	return cache.NewSharedInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				opts.FieldSelector = fs.String()
+				return client.CoreV1().Nodes().List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				opts.FieldSelector = fs.String()
+				return client.CoreV1().Nodes().Watch(context.Background(), opts)
+			},
+		},
+		&api_v1.Node{},
+		watchSyncPeriod,
+	)
+}
+
+func newReplicaSetSharedInformer(client kubernetes.Interface, namespace string) cache.SharedInformer {
+	return cache.NewSharedInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.AppsV1().ReplicaSets(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.AppsV1().ReplicaSets(namespace).Watch(context.Background(), opts)
+			},
+		},
+		&apps_v1.ReplicaSet{},
+		watchSyncPeriod,
+	)
+}
+
+func newJobSharedInformer(client kubernetes.Interface, namespace string) cache.SharedInformer {
+	return cache.NewSharedInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.BatchV1().Jobs(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.BatchV1().Jobs(namespace).Watch(context.Background(), opts)
+			},
+		},
+		&batch_v1.Job{},
+		watchSyncPeriod,
+	)
+}
@@ -37,6 +37,26 @@ func Test_newSharedInformer(t *testing.T) {
 	assert.NotNil(t, informer)
 }
 
+func Test_newObjectSharedInformers(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	informers := []cache.SharedInformer{
+		newNamespaceSharedInformer(client, ""),
+		newNamespaceSharedInformer(client, "testns"),
+		newNodeSharedInformer(client, ""),
+		newNodeSharedInformer(client, "node1"),
+		newReplicaSetSharedInformer(client, "testns"),
+		newJobSharedInformer(client, "testns"),
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	for _, informer := range informers {
+		require.NotNil(t, informer)
+		go informer.Run(stopCh)
+		assert.True(t, cache.WaitForCacheSync(stopCh, informer.HasSynced))
+	}
+}
+
 func Test_informerListFuncWithSelectors(t *testing.T) {
 	ls, fs, err := selectorsFromFilters(Filters{
 		Fields: []FieldFilter{
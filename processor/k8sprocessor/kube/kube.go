@@ -32,32 +32,82 @@ const (
 
 	tagNodeName  = "k8s.node.name"
 	tagStartTime = "k8s.pod.startTime"
+
+	// MetadataFromPod is used to specify to extract metadata from the pod.
+	MetadataFromPod = "pod"
+	// MetadataFromNamespace is used to specify to extract metadata from the namespace of the pod.
+	MetadataFromNamespace = "namespace"
+	// MetadataFromNode is used to specify to extract metadata from the node the pod runs on.
+	MetadataFromNode = "node"
 )
 
 var (
-	// TODO: move these to config with default values
-	podNameIgnorePatterns = []*regexp.Regexp{
-		regexp.MustCompile(`jaeger-agent`),
-		regexp.MustCompile(`jaeger-collector`),
-	}
-	defaultPodDeleteGracePeriod = time.Second * 120
-	watchSyncPeriod             = time.Minute * 5
+	watchSyncPeriod = time.Minute * 5
 )
 
 // Client defines the main interface that allows querying pods by metadata.
 type Client interface {
-	GetPodByIP(string) (*Pod, bool)
+	GetPod(PodIdentifier) (*Pod, bool)
 	Start()
 	Stop()
 }
 
 // ClientProvider defines a func type that returns a new Client.
-type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, APIClientsetProvider, InformerProvider) (Client, error)
+type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, Associations, Excludes, time.Duration, APIClientsetProvider, InformerProvider) (Client, error)
 
 // APIClientsetProvider defines a func type that initializes and return a new kubernetes
 // Clientset object.
 type APIClientsetProvider func(config k8sconfig.APIConfig) (kubernetes.Interface, error)
 
+// PodIdentifierSource is the kind of value a PodIdentifier holds.
+type PodIdentifierSource string
+
+const (
+	// PodIPSource identifies a pod by its IP address.
+	PodIPSource PodIdentifierSource = "ip"
+	// PodUIDSource identifies a pod by its UID.
+	PodUIDSource PodIdentifierSource = "pod_uid"
+	// ContainerIDSource identifies a pod by the ID of one of its containers.
+	ContainerIDSource PodIdentifierSource = "container_id"
+	// PodNameSource identifies a pod by its namespace and name.
+	PodNameSource PodIdentifierSource = "pod_name"
+)
+
+// PodIdentifier is a key a pod can be looked up by: its IP address, its UID,
+// the ID of one of its containers or its namespace and name.  The source is
+// part of the key, so that e.g. a pod UID never matches a container ID.
+type PodIdentifier struct {
+	Source PodIdentifierSource
+	Value  string
+}
+
+// IsEmpty returns whether the identifier has no value.
+func (id PodIdentifier) IsEmpty() bool {
+	return id.Value == ""
+}
+
+// PodIPIdentifier returns the identifier of the pod with the given IP address.
+func PodIPIdentifier(ip string) PodIdentifier {
+	return PodIdentifier{Source: PodIPSource, Value: ip}
+}
+
+// PodUIDIdentifier returns the identifier of the pod with the given UID.
+func PodUIDIdentifier(uid string) PodIdentifier {
+	return PodIdentifier{Source: PodUIDSource, Value: uid}
+}
+
+// ContainerIDIdentifier returns the identifier of the pod running the
+// container with the given ID.
+func ContainerIDIdentifier(id string) PodIdentifier {
+	return PodIdentifier{Source: ContainerIDSource, Value: id}
+}
+
+// PodNameIdentifier returns the identifier of the pod with the given name in
+// the given namespace.
+func PodNameIdentifier(namespace, name string) PodIdentifier {
+	return PodIdentifier{Source: PodNameSource, Value: namespace + "/" + name}
+}
+
 // Pod represents a kubernetes pod.
 type Pod struct {
 	Name       string
@@ -65,16 +115,45 @@ type Pod struct {
 	Attributes map[string]string
 	StartTime  *metav1.Time
 	Ignore     bool
+	// Containers maps the names of the containers of the pod to their metadata.
+	// It is only set when the extraction rules require container metadata.
+	Containers map[string]*Container
 
 	DeletedAt time.Time
 }
 
+// Container represents a container of a kubernetes pod.
+type Container struct {
+	Name string
+	// ID is the ID of the container, without the container runtime prefix.
+	// It is empty until the container is started.
+	ID           string
+	ImageName    string
+	ImageTag     string
+	RestartCount int32
+}
+
 type deleteRequest struct {
-	ip   string
+	id   PodIdentifier
 	name string
 	ts   time.Time
 }
 
+// Excludes is used to specify the pods the client does not extract metadata from.
+type Excludes struct {
+	// PodNames are regular expressions matched against the names of the pods.
+	PodNames []*regexp.Regexp
+}
+
+// Associations is used to specify the identifiers the client indexes pods by.
+// Pods are indexed by IP when none is set.
+type Associations struct {
+	IP          bool
+	PodUID      bool
+	ContainerID bool
+	PodName     bool
+}
+
 // Filters is used to instruct the client on how to filter out k8s pods.
 // Right now only filters supported are the ones supported by k8s API itself
 // for performance reasons. We can support adding additional custom filters
@@ -102,18 +181,65 @@ type FieldFilter struct {
 // ExtractionRules is used to specify the information that needs to be extracted
 // from pods and added to the spans as tags.
 type ExtractionRules struct {
-	Deployment bool
-	Namespace  bool
-	PodName    bool
-	PodUID     bool
-	Node       bool
-	Cluster    bool
-	StartTime  bool
+	Deployment     bool
+	DeploymentUID  bool
+	ReplicaSet     bool
+	ReplicaSetUID  bool
+	StatefulSet    bool
+	StatefulSetUID bool
+	DaemonSet      bool
+	DaemonSetUID   bool
+	Job            bool
+	JobUID         bool
+	CronJob        bool
+	CronJobUID     bool
+	Namespace      bool
+	PodName        bool
+	PodUID         bool
+	Node           bool
+	Cluster        bool
+	StartTime      bool
+
+	ContainerID           bool
+	ContainerImage        bool
+	ContainerRestartCount bool
+
+	// WatchReplicaSets looks up the deployment of the pods through the replica
+	// sets owning them, rather than deriving its name from the pod names.
+	WatchReplicaSets bool
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
 }
 
+// needsFrom returns whether a label or annotation rule extracts metadata from the given source.
+func (r ExtractionRules) needsFrom(from string) bool {
+	for _, rules := range [][]FieldExtractionRule{r.Labels, r.Annotations} {
+		for _, rule := range rules {
+			if rule.From == from {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// needsContainers returns whether the metadata of the containers of the pods needs to be extracted.
+func (r ExtractionRules) needsContainers() bool {
+	return r.ContainerID || r.ContainerImage || r.ContainerRestartCount
+}
+
+// needsReplicaSets returns whether the replica sets owning the pods need to be looked up.
+// The deployment UID can only be found in the replica sets.
+func (r ExtractionRules) needsReplicaSets() bool {
+	return r.DeploymentUID || (r.Deployment && r.WatchReplicaSets)
+}
+
+// needsJobs returns whether the jobs owning the pods need to be looked up.
+func (r ExtractionRules) needsJobs() bool {
+	return r.CronJob || r.CronJobUID
+}
+
 // FieldExtractionRule is used to specify which fields to extract from pod fields
 // and inject into spans as attributes.
 type FieldExtractionRule struct {
@@ -124,4 +250,7 @@ type FieldExtractionRule struct {
 	// Regex is a regular expression used to extract a sub-part of a field value.
 	// Full value is extracted when no regexp is provided.
 	Regex *regexp.Regexp
+	// From is the object the field is extracted from: MetadataFromPod,
+	// MetadataFromNamespace or MetadataFromNode.
+	From string
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"time"
 
 	"k8s.io/apimachinery/pkg/selection"
 
@@ -38,6 +39,28 @@ const (
 	metadataDeployment = "deployment"
 	metadataCluster    = "cluster"
 	metadataNode       = "node"
+
+	metadataDeploymentUID  = "deploymentUID"
+	metadataReplicaSet     = "replicaSet"
+	metadataReplicaSetUID  = "replicaSetUID"
+	metadataStatefulSet    = "statefulSet"
+	metadataStatefulSetUID = "statefulSetUID"
+	metadataDaemonSet      = "daemonSet"
+	metadataDaemonSetUID   = "daemonSetUID"
+	metadataJob            = "job"
+	metadataJobUID         = "jobUID"
+	metadataCronJob        = "cronJob"
+	metadataCronJobUID     = "cronJobUID"
+
+	metadataContainerID           = "containerID"
+	metadataContainerImage        = "containerImage"
+	metadataContainerRestartCount = "containerRestartCount"
+
+	associationFromConnection        = "connection"
+	associationFromResourceAttribute = "resource_attribute"
+	associationFromPodUID            = "pod_uid"
+	associationFromContainerID       = "container_id"
+	associationFromPodName           = "pod_name"
 )
 
 // Option represents a configuration option that can be passes.
@@ -93,6 +116,34 @@ func WithExtractMetadata(fields ...string) Option {
 				p.rules.Cluster = true
 			case metadataNode:
 				p.rules.Node = true
+			case metadataDeploymentUID:
+				p.rules.DeploymentUID = true
+			case metadataReplicaSet:
+				p.rules.ReplicaSet = true
+			case metadataReplicaSetUID:
+				p.rules.ReplicaSetUID = true
+			case metadataStatefulSet:
+				p.rules.StatefulSet = true
+			case metadataStatefulSetUID:
+				p.rules.StatefulSetUID = true
+			case metadataDaemonSet:
+				p.rules.DaemonSet = true
+			case metadataDaemonSetUID:
+				p.rules.DaemonSetUID = true
+			case metadataJob:
+				p.rules.Job = true
+			case metadataJobUID:
+				p.rules.JobUID = true
+			case metadataCronJob:
+				p.rules.CronJob = true
+			case metadataCronJobUID:
+				p.rules.CronJobUID = true
+			case metadataContainerID:
+				p.rules.ContainerID = true
+			case metadataContainerImage:
+				p.rules.ContainerImage = true
+			case metadataContainerRestartCount:
+				p.rules.ContainerRestartCount = true
 			default:
 				return fmt.Errorf("\"%s\" is not a supported metadata field", field)
 			}
@@ -101,6 +152,15 @@ func WithExtractMetadata(fields ...string) Option {
 	}
 }
 
+// WithWatchReplicaSets looks up the deployment of the pods through the replica
+// sets owning them instead of deriving it from the pod names.
+func WithWatchReplicaSets() Option {
+	return func(p *kubernetesprocessor) error {
+		p.rules.WatchReplicaSets = true
+		return nil
+	}
+}
+
 // WithExtractLabels allows specifying options to control extraction of pod labels.
 func WithExtractLabels(labels ...FieldExtractConfig) Option {
 	return func(p *kubernetesprocessor) error {
@@ -128,9 +188,18 @@ func WithExtractAnnotations(annotations ...FieldExtractConfig) Option {
 func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.FieldExtractionRule, error) {
 	rules := []kube.FieldExtractionRule{}
 	for _, a := range fields {
+		from := a.From
+		switch from {
+		case "":
+			from = kube.MetadataFromPod
+		case kube.MetadataFromPod, kube.MetadataFromNamespace, kube.MetadataFromNode:
+		default:
+			return rules, fmt.Errorf("\"%s\" is not a supported source of %s", from, fieldType)
+		}
+
 		name := a.TagName
 		if name == "" {
-			name = fmt.Sprintf("k8s.pod.%s.%s", fieldType, a.Key)
+			name = fmt.Sprintf("k8s.%s.%s.%s", from, fieldType, a.Key)
 		}
 
 		var r *regexp.Regexp
@@ -147,7 +216,7 @@ func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.F
 		}
 
 		rules = append(rules, kube.FieldExtractionRule{
-			Name: name, Key: a.Key, Regex: r,
+			Name: name, Key: a.Key, Regex: r, From: from,
 		})
 	}
 	return rules, nil
@@ -234,3 +303,64 @@ func WithFilterFields(filters ...FieldFilterConfig) Option {
 		return nil
 	}
 }
+
+// WithExtractPodAssociations allows specifying the sources of the identifier of the
+// pod the data comes from. The sources are tried in the given order.
+func WithExtractPodAssociations(associations ...PodAssociationConfig) Option {
+	return func(p *kubernetesprocessor) error {
+		extractors := []podAssociation{}
+		for _, a := range associations {
+			switch a.From {
+			case associationFromConnection:
+				p.associations.IP = true
+				extractors = append(extractors, podIPFromConnection())
+			case associationFromResourceAttribute:
+				if a.Name == "" {
+					return fmt.Errorf("name must be set for the pod association from %s", a.From)
+				}
+				p.associations.IP = true
+				extractors = append(extractors, podIPFromAttribute(a.Name))
+			case associationFromPodUID:
+				p.associations.PodUID = true
+				extractors = append(extractors, podUIDFromAttributes())
+			case associationFromContainerID:
+				p.associations.ContainerID = true
+				extractors = append(extractors, containerIDFromAttributes())
+			case associationFromPodName:
+				p.associations.PodName = true
+				extractors = append(extractors, podNameFromAttributes())
+			default:
+				return fmt.Errorf("\"%s\" is not a supported pod association source", a.From)
+			}
+		}
+		p.podAssociations = extractors
+		return nil
+	}
+}
+
+// WithPodIgnorePatterns allows specifying regular expressions matching the names of the pods to not tag.
+func WithPodIgnorePatterns(patterns ...string) Option {
+	return func(p *kubernetesprocessor) error {
+		podNames := []*regexp.Regexp{}
+		for _, pattern := range patterns {
+			r, err := regexp.Compile(pattern)
+			if err != nil {
+				return err
+			}
+			podNames = append(podNames, r)
+		}
+		p.excludes.PodNames = podNames
+		return nil
+	}
+}
+
+// WithPodDeleteGracePeriod allows specifying the time the metadata of deleted pods is kept for.
+func WithPodDeleteGracePeriod(gracePeriod time.Duration) Option {
+	return func(p *kubernetesprocessor) error {
+		if gracePeriod < 0 {
+			return fmt.Errorf("pod delete grace period must not be negative: %v", gracePeriod)
+		}
+		p.podDeleteGracePeriod = gracePeriod
+		return nil
+	}
+}
@@ -15,12 +15,18 @@
 package k8sprocessor
 
 import (
+	"context"
 	"os"
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -69,6 +75,28 @@ func TestWithPassthrough(t *testing.T) {
 	assert.True(t, p.passthroughMode)
 }
 
+func TestWithPodIgnorePatterns(t *testing.T) {
+	p := &kubernetesprocessor{}
+	assert.NoError(t, WithPodIgnorePatterns()(p))
+	assert.Len(t, p.excludes.PodNames, 0)
+
+	assert.NoError(t, WithPodIgnorePatterns("^istio-", "-canary$")(p))
+	require.Len(t, p.excludes.PodNames, 2)
+	assert.True(t, p.excludes.PodNames[0].MatchString("istio-ingressgateway"))
+	assert.True(t, p.excludes.PodNames[1].MatchString("app-canary"))
+
+	assert.Error(t, WithPodIgnorePatterns("[")(p))
+}
+
+func TestWithPodDeleteGracePeriod(t *testing.T) {
+	p := &kubernetesprocessor{}
+	assert.NoError(t, WithPodDeleteGracePeriod(time.Minute)(p))
+	assert.Equal(t, time.Minute, p.podDeleteGracePeriod)
+
+	err := WithPodDeleteGracePeriod(-time.Second)(p)
+	assert.EqualError(t, err, "pod delete grace period must not be negative: -1s")
+}
+
 func TestWithExtractAnnotations(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -108,6 +136,7 @@ func TestWithExtractAnnotations(t *testing.T) {
 					Name:  "tag1",
 					Key:   "key1",
 					Regex: regexp.MustCompile(`field=(?P<value>.+)`),
+					From:  kube.MetadataFromPod,
 				},
 			},
 			"",
@@ -170,6 +199,7 @@ func TestWithExtractLabels(t *testing.T) {
 					Name:  "tag1",
 					Key:   "key1",
 					Regex: regexp.MustCompile(`field=(?P<value>.+)`),
+					From:  kube.MetadataFromPod,
 				},
 			},
 			"",
@@ -205,6 +235,10 @@ func TestWithExtractMetadata(t *testing.T) {
 	assert.True(t, p.rules.Deployment)
 	assert.True(t, p.rules.Cluster)
 	assert.True(t, p.rules.Node)
+	assert.False(t, p.rules.ContainerID)
+	assert.False(t, p.rules.ContainerImage)
+	assert.False(t, p.rules.ContainerRestartCount)
+	assert.False(t, p.rules.ReplicaSet)
 
 	p = &kubernetesprocessor{}
 	err := WithExtractMetadata("randomfield")(p)
@@ -219,6 +253,63 @@ func TestWithExtractMetadata(t *testing.T) {
 	assert.False(t, p.rules.StartTime)
 	assert.False(t, p.rules.Deployment)
 	assert.False(t, p.rules.Node)
+
+	p = &kubernetesprocessor{}
+	assert.NoError(t, WithExtractMetadata(
+		"deploymentUID", "replicaSet", "replicaSetUID", "statefulSet", "statefulSetUID",
+		"daemonSet", "daemonSetUID", "job", "jobUID", "cronJob", "cronJobUID",
+	)(p))
+	assert.Equal(t, kube.ExtractionRules{
+		DeploymentUID:  true,
+		ReplicaSet:     true,
+		ReplicaSetUID:  true,
+		StatefulSet:    true,
+		StatefulSetUID: true,
+		DaemonSet:      true,
+		DaemonSetUID:   true,
+		Job:            true,
+		JobUID:         true,
+		CronJob:        true,
+		CronJobUID:     true,
+	}, p.rules)
+}
+
+func TestWithExtractPodAssociations(t *testing.T) {
+	p := &kubernetesprocessor{}
+	assert.NoError(t, WithExtractPodAssociations()(p))
+	assert.Equal(t, kube.Associations{}, p.associations)
+	assert.Len(t, p.podAssociations, 0)
+
+	p = &kubernetesprocessor{}
+	assert.NoError(t, WithExtractPodAssociations(
+		PodAssociationConfig{From: "pod_uid"},
+		PodAssociationConfig{From: "pod_name"},
+	)(p))
+	assert.Equal(t, kube.Associations{PodUID: true, PodName: true}, p.associations)
+	assert.Len(t, p.podAssociations, 2)
+
+	p = &kubernetesprocessor{}
+	assert.NoError(t, WithExtractPodAssociations(
+		PodAssociationConfig{From: "resource_attribute", Name: "ip"},
+		PodAssociationConfig{From: "container_id"},
+		PodAssociationConfig{From: "connection"},
+	)(p))
+	assert.Equal(t, kube.Associations{IP: true, ContainerID: true}, p.associations)
+	require.Len(t, p.podAssociations, 3)
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString("ip", "1.1.1.1")
+	resource.Attributes().InsertString(conventions.AttributeContainerID, "container-1")
+	ctx := client.NewContext(context.Background(), &client.Client{IP: "2.2.2.2"})
+	assert.Equal(t, kube.PodIPIdentifier("1.1.1.1"), p.podAssociations[0].extract(ctx, resource))
+	assert.Equal(t, kube.ContainerIDIdentifier("container-1"), p.podAssociations[1].extract(ctx, resource))
+	assert.Equal(t, kube.PodIPIdentifier("2.2.2.2"), p.podAssociations[2].extract(ctx, resource))
+
+	err := WithExtractPodAssociations(PodAssociationConfig{From: "resource_attribute"})(&kubernetesprocessor{})
+	assert.EqualError(t, err, "name must be set for the pod association from resource_attribute")
+
+	err = WithExtractPodAssociations(PodAssociationConfig{From: "random"})(&kubernetesprocessor{})
+	assert.EqualError(t, err, `"random" is not a supported pod association source`)
 }
 
 func TestWithFilterLabels(t *testing.T) {
@@ -506,10 +597,49 @@ func Test_extractFieldRules(t *testing.T) {
 				{
 					Name: "k8s.pod.labels.key",
 					Key:  "key",
+					From: kube.MetadataFromPod,
+				},
+			},
+			false,
+		},
+		{
+			"namespace-and-node",
+			args{"labels", []FieldExtractConfig{
+				{
+					Key:  "key",
+					From: "namespace",
+				},
+				{
+					TagName: "name",
+					Key:     "key",
+					From:    "node",
+				},
+			}},
+			[]kube.FieldExtractionRule{
+				{
+					Name: "k8s.namespace.labels.key",
+					Key:  "key",
+					From: kube.MetadataFromNamespace,
+				},
+				{
+					Name: "name",
+					Key:  "key",
+					From: kube.MetadataFromNode,
 				},
 			},
 			false,
 		},
+		{
+			"bad-from",
+			args{"labels", []FieldExtractConfig{
+				{
+					Key:  "key",
+					From: "deployment",
+				},
+			}},
+			[]kube.FieldExtractionRule{},
+			true,
+		},
 		{
 			"basic",
 			args{"field", []FieldExtractConfig{
@@ -522,6 +652,7 @@ func Test_extractFieldRules(t *testing.T) {
 				{
 					Name: "name",
 					Key:  "key",
+					From: kube.MetadataFromPod,
 				},
 			},
 			false,
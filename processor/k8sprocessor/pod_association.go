@@ -0,0 +1,95 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sprocessor/kube"
+)
+
+// podAssociation looks up the identifier of the pod a resource comes from.
+type podAssociation struct {
+	// extract returns the pod identifier, or an empty identifier when it cannot be found.
+	extract func(ctx context.Context, resource pdata.Resource) kube.PodIdentifier
+}
+
+// fromAttributes looks up the pod identifier in the resource attributes.
+func fromAttributes(extract func(attrs pdata.AttributeMap) kube.PodIdentifier) podAssociation {
+	return podAssociation{
+		extract: func(_ context.Context, resource pdata.Resource) kube.PodIdentifier {
+			if resource.IsNil() {
+				return kube.PodIdentifier{}
+			}
+			return extract(resource.Attributes())
+		},
+	}
+}
+
+// podIPFromAttributes uses the pod IP found by the ipExtractor in the resource attributes.
+func podIPFromAttributes(extractor ipExtractor) podAssociation {
+	return fromAttributes(func(attrs pdata.AttributeMap) kube.PodIdentifier {
+		return kube.PodIPIdentifier(extractor(attrs))
+	})
+}
+
+// podIPFromAttribute uses the pod IP held by the given resource attribute.
+func podIPFromAttribute(name string) podAssociation {
+	return fromAttributes(func(attrs pdata.AttributeMap) kube.PodIdentifier {
+		return kube.PodIPIdentifier(stringAttributeFromMap(attrs, name))
+	})
+}
+
+// podIPFromConnection uses the IP address of the client that sent the data.
+func podIPFromConnection() podAssociation {
+	return podAssociation{
+		extract: func(ctx context.Context, _ pdata.Resource) kube.PodIdentifier {
+			if c, ok := client.FromContext(ctx); ok {
+				return kube.PodIPIdentifier(c.IP)
+			}
+			return kube.PodIdentifier{}
+		},
+	}
+}
+
+// podUIDFromAttributes uses the pod UID set as resource attribute.
+func podUIDFromAttributes() podAssociation {
+	return fromAttributes(func(attrs pdata.AttributeMap) kube.PodIdentifier {
+		return kube.PodUIDIdentifier(stringAttributeFromMap(attrs, conventions.AttributeK8sPodUID))
+	})
+}
+
+// containerIDFromAttributes uses the container ID set as resource attribute.
+func containerIDFromAttributes() podAssociation {
+	return fromAttributes(func(attrs pdata.AttributeMap) kube.PodIdentifier {
+		return kube.ContainerIDIdentifier(stringAttributeFromMap(attrs, conventions.AttributeContainerID))
+	})
+}
+
+// podNameFromAttributes uses the namespace and pod name set as resource attributes.
+func podNameFromAttributes() podAssociation {
+	return fromAttributes(func(attrs pdata.AttributeMap) kube.PodIdentifier {
+		namespace := stringAttributeFromMap(attrs, conventions.AttributeK8sNamespace)
+		name := stringAttributeFromMap(attrs, conventions.AttributeK8sPod)
+		if namespace == "" || name == "" {
+			return kube.PodIdentifier{}
+		}
+		return kube.PodNameIdentifier(namespace, name)
+	})
+}
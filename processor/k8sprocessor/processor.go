@@ -16,10 +16,11 @@ package k8sprocessor
 
 import (
 	"context"
+	"time"
 
-	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -29,6 +30,8 @@ import (
 const (
 	k8sIPLabelName    string = "k8s.pod.ip"
 	clientIPLabelName string = "ip"
+
+	k8sContainerRestartCountLabelName string = "k8s.container.restart_count"
 )
 
 type kubernetesprocessor struct {
@@ -38,6 +41,11 @@ type kubernetesprocessor struct {
 	passthroughMode bool
 	rules           kube.ExtractionRules
 	filters         kube.Filters
+	associations    kube.Associations
+	podAssociations []podAssociation
+	excludes        kube.Excludes
+
+	podDeleteGracePeriod time.Duration
 }
 
 func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kube.ClientProvider) error {
@@ -45,7 +53,7 @@ func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kub
 		kubeClient = kube.New
 	}
 	if !kp.passthroughMode {
-		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, nil, nil)
+		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.associations, kp.excludes, kp.podDeleteGracePeriod, nil, nil)
 		if err != nil {
 			return err
 		}
@@ -68,7 +76,8 @@ func (kp *kubernetesprocessor) Shutdown(context.Context) error {
 	return nil
 }
 
-// ProcessTraces process traces and add k8s metadata using resource IP or incoming IP as pod origin.
+// ProcessTraces process traces and add k8s metadata using the configured pod associations,
+// resource IP or incoming IP as pod origin.
 func (kp *kubernetesprocessor) ProcessTraces(ctx context.Context, td pdata.Traces) (pdata.Traces, error) {
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
@@ -77,13 +86,14 @@ func (kp *kubernetesprocessor) ProcessTraces(ctx context.Context, td pdata.Trace
 			continue
 		}
 
-		kp.processResource(ctx, rs.Resource(), k8sIPFromAttributes())
+		kp.processResource(ctx, rs.Resource(), podIPFromAttributes(k8sIPFromAttributes()), podIPFromConnection())
 	}
 
 	return td, nil
 }
 
-// ProcessMetrics process metrics and add k8s metadata using resource IP, hostname or incoming IP as pod origin.
+// ProcessMetrics process metrics and add k8s metadata using the configured pod associations,
+// resource IP, hostname or incoming IP as pod origin.
 func (kp *kubernetesprocessor) ProcessMetrics(ctx context.Context, md pdata.Metrics) (pdata.Metrics, error) {
 	rm := md.ResourceMetrics()
 	for i := 0; i < rm.Len(); i++ {
@@ -92,13 +102,14 @@ func (kp *kubernetesprocessor) ProcessMetrics(ctx context.Context, md pdata.Metr
 			continue
 		}
 
-		kp.processResource(ctx, ms.Resource(), k8sIPFromAttributes(), k8sIPFromHostnameAttributes())
+		kp.processResource(ctx, ms.Resource(), podIPFromAttributes(k8sIPFromAttributes()), podIPFromAttributes(k8sIPFromHostnameAttributes()), podIPFromConnection())
 	}
 
 	return md, nil
 }
 
-// ProcessLogs process logs and add k8s metadata using resource IP, hostname or incoming IP as pod origin.
+// ProcessLogs process logs and add k8s metadata using the configured pod associations,
+// resource IP or incoming IP as pod origin.
 func (kp *kubernetesprocessor) ProcessLogs(ctx context.Context, ld pdata.Logs) (pdata.Logs, error) {
 	rl := ld.ResourceLogs()
 	for i := 0; i < rl.Len(); i++ {
@@ -107,40 +118,40 @@ func (kp *kubernetesprocessor) ProcessLogs(ctx context.Context, ld pdata.Logs) (
 			continue
 		}
 
-		kp.processResource(ctx, ls.Resource(), k8sIPFromAttributes())
+		kp.processResource(ctx, ls.Resource(), podIPFromAttributes(k8sIPFromAttributes()), podIPFromConnection())
 	}
 
 	return ld, nil
 }
 
-func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pdata.Resource, attributeExtractors ...ipExtractor) {
-	var podIP string
-
-	if !resource.IsNil() {
-		for _, extractor := range attributeExtractors {
-			podIP = extractor(resource.Attributes())
-			if podIP != "" {
-				break
-			}
-		}
+// processResource tags the resource with the metadata of the pod identified by the
+// configured pod associations, or by the given default ones when none is configured.
+// Only the first association that yields an identifier is used: when that pod is
+// unknown or ignored the resource isn't tagged, rather than falling back to another
+// source, e.g. the connection of an agent forwarding it.
+func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pdata.Resource, defaultAssociations ...podAssociation) {
+	associations := kp.podAssociations
+	if len(associations) == 0 {
+		associations = defaultAssociations
 	}
 
-	// Check if the receiver detected client IP.
-	if podIP == "" {
-		if c, ok := client.FromContext(ctx); ok {
-			podIP = c.IP
+	var podID kube.PodIdentifier
+	for _, association := range associations {
+		if podID = association.extract(ctx, resource); !podID.IsEmpty() {
+			break
 		}
 	}
-
-	// If IP is still not available by this point, nothing can be tagged here. Return.
-	if podIP == "" {
+	if podID.IsEmpty() {
 		return
 	}
 
 	if resource.IsNil() {
 		resource.InitEmpty()
 	}
-	resource.Attributes().InsertString(k8sIPLabelName, podIP)
+	attrs := resource.Attributes()
+	if podID.Source == kube.PodIPSource {
+		attrs.InsertString(k8sIPLabelName, podID.Value)
+	}
 
 	// Don't invoke any k8s client functionality in passthrough mode.
 	// Just tag the IP and forward the batch.
@@ -148,22 +159,56 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pda
 		return
 	}
 
-	// add k8s tags to resource
-	attrsToAdd := kp.getAttributesForPodIP(podIP)
-	if len(attrsToAdd) == 0 {
+	pod, ok := kp.kc.GetPod(podID)
+	if !ok {
 		return
 	}
 
-	attrs := resource.Attributes()
-	for k, v := range attrsToAdd {
+	// add k8s tags to resource
+	for k, v := range pod.Attributes {
 		attrs.InsertString(k, v)
 	}
+
+	if container := findContainer(pod, attrs); container != nil {
+		kp.addContainerAttributes(attrs, container)
+	}
 }
 
-func (kp *kubernetesprocessor) getAttributesForPodIP(ip string) map[string]string {
-	pod, ok := kp.kc.GetPodByIP(ip)
-	if !ok {
+// findContainer returns the container of the pod identified by the container.name,
+// k8s.container.name or container.id resource attribute.
+func findContainer(pod *kube.Pod, attrs pdata.AttributeMap) *kube.Container {
+	if len(pod.Containers) == 0 {
 		return nil
 	}
-	return pod.Attributes
+	for _, key := range []string{conventions.AttributeContainerName, conventions.AttributeK8sContainer} {
+		if name := stringAttributeFromMap(attrs, key); name != "" {
+			if container, ok := pod.Containers[name]; ok {
+				return container
+			}
+		}
+	}
+	if id := stringAttributeFromMap(attrs, conventions.AttributeContainerID); id != "" {
+		for _, container := range pod.Containers {
+			if container.ID == id {
+				return container
+			}
+		}
+	}
+	return nil
+}
+
+func (kp *kubernetesprocessor) addContainerAttributes(attrs pdata.AttributeMap, container *kube.Container) {
+	attrs.InsertString(conventions.AttributeK8sContainer, container.Name)
+	if kp.rules.ContainerID && container.ID != "" {
+		attrs.InsertString(conventions.AttributeContainerID, container.ID)
+	}
+	if kp.rules.ContainerImage {
+		attrs.InsertString(conventions.AttributeContainerImage, container.ImageName)
+		if container.ImageTag != "" {
+			attrs.InsertString(conventions.AttributeContainerTag, container.ImageTag)
+		}
+	}
+	if kp.rules.ContainerRestartCount {
+		attrs.InsertInt(k8sContainerRestartCountLabelName, int64(container.RestartCount))
+	}
 }
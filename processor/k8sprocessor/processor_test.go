@@ -217,7 +217,7 @@ func TestProcessorBadConfig(t *testing.T) {
 }
 
 func TestProcessorBadClientProvider(t *testing.T) {
-	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ kube.APIClientsetProvider, _ kube.InformerProvider) (kube.Client, error) {
+	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ kube.Associations, _ kube.Excludes, _ time.Duration, _ kube.APIClientsetProvider, _ kube.InformerProvider) (kube.Client, error) {
 		return nil, fmt.Errorf("bad client error")
 	}
 
@@ -345,7 +345,7 @@ func TestProcessorNoAttrs(t *testing.T) {
 
 	// pod doesn't have attrs to add
 	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
-		kp.kc.(*fakeClient).Pods["1.1.1.1"] = &kube.Pod{Name: "PodA"}
+		kp.kc.(*fakeClient).Pods[kube.PodIPIdentifier("1.1.1.1")] = &kube.Pod{Name: "PodA"}
 	})
 
 	m.testConsume(
@@ -363,7 +363,7 @@ func TestProcessorNoAttrs(t *testing.T) {
 
 	// attrs should be added now
 	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
-		kp.kc.(*fakeClient).Pods["1.1.1.1"] = &kube.Pod{
+		kp.kc.(*fakeClient).Pods[kube.PodIPIdentifier("1.1.1.1")] = &kube.Pod{
 			Name: "PodA",
 			Attributes: map[string]string{
 				"k":  "v",
@@ -509,7 +509,7 @@ func TestProcessorAddLabels(t *testing.T) {
 	}
 	for ip, attrs := range tests {
 		m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
-			kp.kc.(*fakeClient).Pods[ip] = &kube.Pod{Attributes: attrs}
+			kp.kc.(*fakeClient).Pods[kube.PodIPIdentifier(ip)] = &kube.Pod{Attributes: attrs}
 		})
 	}
 
@@ -547,7 +547,7 @@ func TestProcessorPicksUpPassthoughPodIp(t *testing.T) {
 	)
 
 	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
-		kp.kc.(*fakeClient).Pods["2.2.2.2"] = &kube.Pod{
+		kp.kc.(*fakeClient).Pods[kube.PodIPIdentifier("2.2.2.2")] = &kube.Pod{
 			Name: "PodA",
 			Attributes: map[string]string{
 				"k": "v",
@@ -576,6 +576,264 @@ func TestProcessorPicksUpPassthoughPodIp(t *testing.T) {
 	})
 }
 
+func TestProcessorPodAssociations(t *testing.T) {
+	m := newMultiTest(
+		t,
+		NewFactory().CreateDefaultConfig(),
+		nil,
+		WithExtractPodAssociations(
+			PodAssociationConfig{From: "resource_attribute", Name: "custom.ip"},
+			PodAssociationConfig{From: "pod_uid"},
+			PodAssociationConfig{From: "container_id"},
+			PodAssociationConfig{From: "pod_name"},
+			PodAssociationConfig{From: "connection"},
+		),
+	)
+
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		assert.Equal(t, kube.Associations{IP: true, PodUID: true, ContainerID: true, PodName: true}, kp.kc.(*fakeClient).Associations)
+		pods := kp.kc.(*fakeClient).Pods
+		pods[kube.PodIPIdentifier("1.1.1.1")] = &kube.Pod{Attributes: map[string]string{"pod": "ip"}}
+		pods[kube.PodUIDIdentifier("pod-uid")] = &kube.Pod{Attributes: map[string]string{"pod": "uid"}}
+		pods[kube.ContainerIDIdentifier("container-id")] = &kube.Pod{Attributes: map[string]string{"pod": "container"}}
+		pods[kube.PodNameIdentifier("ns", "pod-name")] = &kube.Pod{Attributes: map[string]string{"pod": "name"}}
+		pods[kube.PodIPIdentifier("3.3.3.3")] = &kube.Pod{Attributes: map[string]string{"pod": "connection"}}
+		pods[kube.PodIPIdentifier("4.4.4.4")] = &kube.Pod{Attributes: map[string]string{"pod": "ignored"}, Ignore: true}
+	})
+
+	testCases := []struct {
+		name          string
+		attrs         map[string]string
+		expectedAttrs map[string]string
+	}{
+		{
+			name:  "resource attribute",
+			attrs: map[string]string{"custom.ip": "1.1.1.1", conventions.AttributeK8sPodUID: "pod-uid"},
+			expectedAttrs: map[string]string{
+				"custom.ip":                    "1.1.1.1",
+				conventions.AttributeK8sPodUID: "pod-uid",
+				k8sIPLabelName:                 "1.1.1.1",
+				"pod":                          "ip",
+			},
+		},
+		{
+			name:  "pod uid",
+			attrs: map[string]string{conventions.AttributeK8sPodUID: "pod-uid", conventions.AttributeContainerID: "container-id"},
+			expectedAttrs: map[string]string{
+				conventions.AttributeK8sPodUID:   "pod-uid",
+				conventions.AttributeContainerID: "container-id",
+				"pod":                            "uid",
+			},
+		},
+		{
+			name:  "container id",
+			attrs: map[string]string{conventions.AttributeContainerID: "container-id"},
+			expectedAttrs: map[string]string{
+				conventions.AttributeContainerID: "container-id",
+				"pod":                            "container",
+			},
+		},
+		{
+			name:  "pod name",
+			attrs: map[string]string{conventions.AttributeK8sNamespace: "ns", conventions.AttributeK8sPod: "pod-name"},
+			expectedAttrs: map[string]string{
+				conventions.AttributeK8sNamespace: "ns",
+				conventions.AttributeK8sPod:       "pod-name",
+				"pod":                             "name",
+			},
+		},
+		{
+			name:  "unknown pod uid does not fall back to the container id",
+			attrs: map[string]string{conventions.AttributeK8sPodUID: "unknown-uid", conventions.AttributeContainerID: "container-id"},
+			expectedAttrs: map[string]string{
+				conventions.AttributeK8sPodUID:   "unknown-uid",
+				conventions.AttributeContainerID: "container-id",
+			},
+		},
+		{
+			name:  "unknown ip does not fall back to the connection",
+			attrs: map[string]string{"custom.ip": "9.9.9.9"},
+			expectedAttrs: map[string]string{
+				"custom.ip":    "9.9.9.9",
+				k8sIPLabelName: "9.9.9.9",
+			},
+		},
+		{
+			name:  "ignored pod does not fall back to the connection",
+			attrs: map[string]string{"custom.ip": "4.4.4.4"},
+			expectedAttrs: map[string]string{
+				"custom.ip":    "4.4.4.4",
+				k8sIPLabelName: "4.4.4.4",
+			},
+		},
+		{
+			name:  "pod name without namespace",
+			attrs: map[string]string{conventions.AttributeK8sPod: "pod-name"},
+			expectedAttrs: map[string]string{
+				conventions.AttributeK8sPod: "pod-name",
+				k8sIPLabelName:              "3.3.3.3",
+				"pod":                       "connection",
+			},
+		},
+	}
+
+	ctx := client.NewContext(context.Background(), &client.Client{IP: "3.3.3.3"})
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			withAttrs := func(res pdata.Resource) {
+				for k, v := range tc.attrs {
+					res.Attributes().InsertString(k, v)
+				}
+			}
+			m.testConsume(ctx, generateTraces(withAttrs), generateMetrics(withAttrs), generateLogs(withAttrs), nil)
+
+			m.assertBatchesLen(i + 1)
+			m.assertResourceObjectLen(i, 1)
+			m.assertResource(i, 0, func(res pdata.Resource) {
+				require.False(t, res.IsNil())
+				assert.Equal(t, len(tc.expectedAttrs), res.Attributes().Len())
+				for k, v := range tc.expectedAttrs {
+					assertResourceHasStringAttribute(t, res, k, v)
+				}
+			})
+		})
+	}
+}
+
+func TestProcessorContainerAttributes(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Extract.Metadata = []string{"podName", "containerID", "containerImage", "containerRestartCount"}
+	m := newMultiTest(
+		t,
+		cfg,
+		nil,
+	)
+
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.kc.(*fakeClient).Pods[kube.PodIPIdentifier("1.1.1.1")] = &kube.Pod{
+			Name:       "PodA",
+			Attributes: map[string]string{"k8s.pod.name": "PodA"},
+			Containers: map[string]*kube.Container{
+				"app": {
+					Name:         "app",
+					ID:           "app-id",
+					ImageName:    "team/app",
+					ImageTag:     "1.2.3",
+					RestartCount: 2,
+				},
+				"sidecar": {
+					Name:      "sidecar",
+					ImageName: "envoyproxy/envoy",
+				},
+			},
+		}
+	})
+
+	testCases := []struct {
+		name          string
+		attrs         map[string]string
+		expectedAttrs map[string]interface{}
+	}{
+		{
+			name:  "no container",
+			attrs: map[string]string{},
+			expectedAttrs: map[string]interface{}{
+				k8sIPLabelName: "1.1.1.1",
+				"k8s.pod.name": "PodA",
+			},
+		},
+		{
+			name:  "container name",
+			attrs: map[string]string{conventions.AttributeContainerName: "app"},
+			expectedAttrs: map[string]interface{}{
+				conventions.AttributeContainerName:  "app",
+				k8sIPLabelName:                      "1.1.1.1",
+				"k8s.pod.name":                      "PodA",
+				conventions.AttributeK8sContainer:   "app",
+				conventions.AttributeContainerID:    "app-id",
+				conventions.AttributeContainerImage: "team/app",
+				conventions.AttributeContainerTag:   "1.2.3",
+				k8sContainerRestartCountLabelName:   int64(2),
+			},
+		},
+		{
+			name:  "container id",
+			attrs: map[string]string{conventions.AttributeContainerID: "app-id"},
+			expectedAttrs: map[string]interface{}{
+				k8sIPLabelName:                      "1.1.1.1",
+				"k8s.pod.name":                      "PodA",
+				conventions.AttributeK8sContainer:   "app",
+				conventions.AttributeContainerID:    "app-id",
+				conventions.AttributeContainerImage: "team/app",
+				conventions.AttributeContainerTag:   "1.2.3",
+				k8sContainerRestartCountLabelName:   int64(2),
+			},
+		},
+		{
+			name:  "container id when the name is unknown",
+			attrs: map[string]string{conventions.AttributeContainerName: "renamed", conventions.AttributeContainerID: "app-id"},
+			expectedAttrs: map[string]interface{}{
+				conventions.AttributeContainerName:  "renamed",
+				k8sIPLabelName:                      "1.1.1.1",
+				"k8s.pod.name":                      "PodA",
+				conventions.AttributeK8sContainer:   "app",
+				conventions.AttributeContainerID:    "app-id",
+				conventions.AttributeContainerImage: "team/app",
+				conventions.AttributeContainerTag:   "1.2.3",
+				k8sContainerRestartCountLabelName:   int64(2),
+			},
+		},
+		{
+			name:  "not started container",
+			attrs: map[string]string{conventions.AttributeK8sContainer: "sidecar"},
+			expectedAttrs: map[string]interface{}{
+				k8sIPLabelName:                      "1.1.1.1",
+				"k8s.pod.name":                      "PodA",
+				conventions.AttributeK8sContainer:   "sidecar",
+				conventions.AttributeContainerImage: "envoyproxy/envoy",
+				k8sContainerRestartCountLabelName:   int64(0),
+			},
+		},
+		{
+			name:  "unknown container",
+			attrs: map[string]string{conventions.AttributeContainerName: "unknown"},
+			expectedAttrs: map[string]interface{}{
+				conventions.AttributeContainerName: "unknown",
+				k8sIPLabelName:                     "1.1.1.1",
+				"k8s.pod.name":                     "PodA",
+			},
+		},
+	}
+
+	ctx := client.NewContext(context.Background(), &client.Client{IP: "1.1.1.1"})
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			withAttrs := func(res pdata.Resource) {
+				for k, v := range tc.attrs {
+					res.Attributes().InsertString(k, v)
+				}
+			}
+			m.testConsume(ctx, generateTraces(withAttrs), generateMetrics(withAttrs), generateLogs(withAttrs), nil)
+
+			m.assertBatchesLen(i + 1)
+			m.assertResource(i, 0, func(res pdata.Resource) {
+				require.False(t, res.IsNil())
+				assert.Equal(t, len(tc.expectedAttrs), res.Attributes().Len())
+				for k, v := range tc.expectedAttrs {
+					got, ok := res.Attributes().Get(k)
+					require.True(t, ok, k)
+					switch v := v.(type) {
+					case int64:
+						assert.Equal(t, v, got.IntVal(), k)
+					default:
+						assert.Equal(t, v, got.StringVal(), k)
+					}
+				}
+			})
+		})
+	}
+}
+
 func TestMetricsProcessorHostname(t *testing.T) {
 	next := &exportertest.SinkMetricsExporter{}
 	var kp *kubernetesprocessor
@@ -589,7 +847,7 @@ func TestMetricsProcessorHostname(t *testing.T) {
 	kc := kp.kc.(*fakeClient)
 
 	// invalid ip should not be used to lookup k8s pod
-	kc.Pods["invalid-ip"] = &kube.Pod{
+	kc.Pods[kube.PodIPIdentifier("invalid-ip")] = &kube.Pod{
 		Name: "PodA",
 		Attributes: map[string]string{
 			"k":  "v",
@@ -597,7 +855,7 @@ func TestMetricsProcessorHostname(t *testing.T) {
 			"aa": "b",
 		},
 	}
-	kc.Pods["3.3.3.3"] = &kube.Pod{
+	kc.Pods[kube.PodIPIdentifier("3.3.3.3")] = &kube.Pod{
 		Name: "PodA",
 		Attributes: map[string]string{
 			"kk": "vv",
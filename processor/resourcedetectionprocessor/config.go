@@ -18,6 +18,9 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
 )
 
 // Config defines configuration for Resource processor.
@@ -32,4 +35,24 @@ type Config struct {
 	// Override indicates whether any existing resource attributes
 	// should be overridden or preserved. Defaults to true.
 	Override bool `mapstructure:"override"`
+	// DetectorConfig holds the settings of the detectors which have any.
+	DetectorConfig DetectorConfig `mapstructure:",squash"`
+}
+
+// DetectorConfig contains the settings of the detectors, by detector type.
+type DetectorConfig struct {
+	// K8sNodeConfig configures the connection of the k8snode detector to the
+	// K8s API. Defaults to the service account of the pod.
+	K8sNodeConfig k8snode.Config `mapstructure:"k8snode"`
+}
+
+// GetConfigFromType returns the settings of the given detector type, nil if
+// it has none.
+func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) internal.DetectorConfig {
+	switch detectorType {
+	case k8snode.TypeStr:
+		return d.K8sNodeConfig
+	default:
+		return nil
+	}
 }
@@ -23,6 +23,9 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -48,6 +51,9 @@ func TestLoadConfig(t *testing.T) {
 		Detectors: []string{"env", "gce"},
 		Timeout:   2 * time.Second,
 		Override:  false,
+		DetectorConfig: DetectorConfig{
+			K8sNodeConfig: k8snode.CreateDefaultConfig(),
+		},
 	})
 
 	p3 := cfg.Processors["resourcedetection/ec2"]
@@ -59,5 +65,66 @@ func TestLoadConfig(t *testing.T) {
 		Detectors: []string{"env", "ec2"},
 		Timeout:   2 * time.Second,
 		Override:  false,
+		DetectorConfig: DetectorConfig{
+			K8sNodeConfig: k8snode.CreateDefaultConfig(),
+		},
+	})
+
+	p4 := cfg.Processors["resourcedetection/ecs"]
+	assert.Equal(t, p4, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "resourcedetection",
+			NameVal: "resourcedetection/ecs",
+		},
+		Detectors: []string{"env", "ecs"},
+		Timeout:   2 * time.Second,
+		Override:  false,
+		DetectorConfig: DetectorConfig{
+			K8sNodeConfig: k8snode.CreateDefaultConfig(),
+		},
+	})
+
+	p5 := cfg.Processors["resourcedetection/azure"]
+	assert.Equal(t, p5, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "resourcedetection",
+			NameVal: "resourcedetection/azure",
+		},
+		Detectors: []string{"env", "azure"},
+		Timeout:   2 * time.Second,
+		Override:  false,
+		DetectorConfig: DetectorConfig{
+			K8sNodeConfig: k8snode.CreateDefaultConfig(),
+		},
+	})
+
+	p6 := cfg.Processors["resourcedetection/system"]
+	assert.Equal(t, p6, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "resourcedetection",
+			NameVal: "resourcedetection/system",
+		},
+		Detectors: []string{"env", "system"},
+		Timeout:   2 * time.Second,
+		Override:  false,
+		DetectorConfig: DetectorConfig{
+			K8sNodeConfig: k8snode.CreateDefaultConfig(),
+		},
+	})
+
+	p7 := cfg.Processors["resourcedetection/k8snode"]
+	assert.Equal(t, p7, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: "resourcedetection",
+			NameVal: "resourcedetection/k8snode",
+		},
+		Detectors: []string{"env", "k8snode"},
+		Timeout:   2 * time.Second,
+		Override:  false,
+		DetectorConfig: DetectorConfig{
+			K8sNodeConfig: k8snode.Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+			},
+		},
 	})
 }
@@ -28,8 +28,15 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ecs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/eks"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/elasticbeanstalk"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/env"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp/gce"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
 const (
@@ -51,9 +58,16 @@ type factory struct {
 // NewFactory creates a new factory for ResourceDetection processor.
 func NewFactory() component.ProcessorFactory {
 	resourceProviderFactory := internal.NewProviderFactory(map[internal.DetectorType]internal.DetectorFactory{
-		env.TypeStr: env.NewDetector,
-		gce.TypeStr: gce.NewDetector,
-		ec2.TypeStr: ec2.NewDetector,
+		env.TypeStr:              env.NewDetector,
+		gce.TypeStr:              gce.NewDetector,
+		ec2.TypeStr:              ec2.NewDetector,
+		ecs.TypeStr:              ecs.NewDetector,
+		eks.TypeStr:              eks.NewDetector,
+		elasticbeanstalk.TypeStr: elasticbeanstalk.NewDetector,
+		azure.TypeStr:            azure.NewDetector,
+		system.TypeStr:           system.NewDetector,
+		docker.TypeStr:           docker.NewDetector,
+		k8snode.TypeStr:          k8snode.NewDetector,
 	})
 
 	f := &factory{
@@ -83,6 +97,9 @@ func createDefaultConfig() configmodels.Processor {
 		Detectors: []string{env.TypeStr},
 		Timeout:   5 * time.Second,
 		Override:  true,
+		DetectorConfig: DetectorConfig{
+			K8sNodeConfig: k8snode.CreateDefaultConfig(),
+		},
 	}
 }
 
@@ -149,7 +166,7 @@ func (f *factory) getResourceDetectionProcessor(
 ) (*resourceDetectionProcessor, error) {
 	oCfg := cfg.(*Config)
 
-	provider, err := f.getResourceProvider(logger, cfg.Name(), oCfg.Timeout, oCfg.Detectors)
+	provider, err := f.getResourceProvider(logger, cfg.Name(), oCfg.Timeout, oCfg.Detectors, &oCfg.DetectorConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +182,7 @@ func (f *factory) getResourceProvider(
 	processorName string,
 	timeout time.Duration,
 	configuredDetectors []string,
+	detectorConfigs internal.ResourceDetectorConfig,
 ) (*internal.ResourceProvider, error) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -178,7 +196,7 @@ func (f *factory) getResourceProvider(
 		detectorTypes = append(detectorTypes, internal.DetectorType(strings.TrimSpace(key)))
 	}
 
-	provider, err := f.resourceProviderFactory.CreateResourceProvider(logger, timeout, detectorTypes...)
+	provider, err := f.resourceProviderFactory.CreateResourceProvider(logger, timeout, detectorConfigs, detectorTypes...)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +35,7 @@ type Detector struct {
 	provider ec2MetadataProvider
 }
 
-func NewDetector() (internal.Detector, error) {
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
 	sess, err := session.NewSession()
 	if err != nil {
 		return nil, err
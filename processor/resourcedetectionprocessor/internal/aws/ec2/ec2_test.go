@@ -47,7 +47,7 @@ func (mm mockMetadata) get(ctx context.Context) (ec2metadata.EC2InstanceIdentity
 }
 
 func TestNewDetector(t *testing.T) {
-	detector, err := NewDetector()
+	detector, err := NewDetector(nil)
 	assert.NotNil(t, detector)
 	assert.NoError(t, err)
 }
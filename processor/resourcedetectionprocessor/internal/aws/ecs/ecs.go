@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ecs provides a detector that loads resource information from
+// the ECS task metadata endpoint v4, or v3 on older ECS agents
+package ecs
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cloud"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "ecs"
+
+	// Environment variables set by the ECS agent in the containers of the
+	// tasks, the v4 one since agent 1.39.0.
+	metadataEndpointEnvVar   = "ECS_CONTAINER_METADATA_URI_V4"
+	metadataEndpointV3EnvVar = "ECS_CONTAINER_METADATA_URI"
+
+	attributeECSCluster      = "aws.ecs.cluster.name"
+	attributeECSClusterARN   = "aws.ecs.cluster.arn"
+	attributeECSTaskARN      = "aws.ecs.task.arn"
+	attributeECSTaskFamily   = "aws.ecs.task.family"
+	attributeECSTaskRevision = "aws.ecs.task.revision"
+	attributeECSLaunchType   = "aws.ecs.launchtype"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider ecsMetadataProvider
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{provider: &ecsMetadataImpl{
+		endpoint: metadataEndpoint(),
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}}, nil
+}
+
+// metadataEndpoint returns the task metadata endpoint v4, falling back to v3.
+func metadataEndpoint() string {
+	if endpoint := os.Getenv(metadataEndpointEnvVar); endpoint != "" {
+		return endpoint
+	}
+	return os.Getenv(metadataEndpointV3EnvVar)
+}
+
+// Detect detects the cluster and task of the ECS task the collector runs in,
+// it returns an empty resource outside of ECS.  The availability zone and the
+// launch type are only returned by the v4 endpoint.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	if !d.provider.available() {
+		return res, nil
+	}
+
+	meta, err := d.provider.get(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, cloud.ProviderAWS)
+	// The task ARN has the arn:aws:ecs:<region>:<account>:task/... form.
+	if parts := strings.SplitN(meta.TaskARN, ":", 6); len(parts) == 6 {
+		attr.InsertString(conventions.AttributeCloudRegion, parts[3])
+		attr.InsertString(conventions.AttributeCloudAccount, parts[4])
+	}
+	if meta.AvailabilityZone != "" {
+		attr.InsertString(conventions.AttributeCloudZone, meta.AvailabilityZone)
+	}
+
+	// The cluster is either its ARN or its short name.
+	if strings.HasPrefix(meta.Cluster, "arn:") {
+		attr.InsertString(attributeECSClusterARN, meta.Cluster)
+		attr.InsertString(attributeECSCluster, meta.Cluster[strings.LastIndex(meta.Cluster, "/")+1:])
+	} else {
+		attr.InsertString(attributeECSCluster, meta.Cluster)
+	}
+	attr.InsertString(attributeECSTaskARN, meta.TaskARN)
+	attr.InsertString(attributeECSTaskFamily, meta.Family)
+	attr.InsertString(attributeECSTaskRevision, meta.Revision)
+	if meta.LaunchType != "" {
+		attr.InsertString(attributeECSLaunchType, strings.ToLower(meta.LaunchType))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const fargateTask = `{
+  "Cluster": "arn:aws:ecs:us-west-2:123456789012:cluster/default",
+  "TaskARN": "arn:aws:ecs:us-west-2:123456789012:task/default/febee046097849aba589d4435207c04a",
+  "Family": "query-metadata",
+  "Revision": "7",
+  "DesiredStatus": "RUNNING",
+  "KnownStatus": "RUNNING",
+  "AvailabilityZone": "us-west-2a",
+  "LaunchType": "FARGATE"
+}`
+
+func newMetadataServer(t *testing.T, status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/task", r.URL.Path)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestNewDetector(t *testing.T) {
+	os.Setenv(metadataEndpointV3EnvVar, "http://169.254.170.2/v3/abc")
+	defer os.Unsetenv(metadataEndpointV3EnvVar)
+
+	detector, err := NewDetector(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://169.254.170.2/v3/abc", detector.(*Detector).provider.(*ecsMetadataImpl).endpoint)
+
+	os.Setenv(metadataEndpointEnvVar, "http://169.254.170.2/v4/abc")
+	defer os.Unsetenv(metadataEndpointEnvVar)
+
+	detector, err = NewDetector(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://169.254.170.2/v4/abc", detector.(*Detector).provider.(*ecsMetadataImpl).endpoint)
+}
+
+func TestDetector_Detect(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "fargate",
+			status: http.StatusOK,
+			body:   fargateTask,
+			want: map[string]interface{}{
+				"cloud.provider":        "aws",
+				"cloud.region":          "us-west-2",
+				"cloud.account.id":      "123456789012",
+				"cloud.zone":            "us-west-2a",
+				"aws.ecs.cluster.arn":   "arn:aws:ecs:us-west-2:123456789012:cluster/default",
+				"aws.ecs.cluster.name":  "default",
+				"aws.ecs.task.arn":      "arn:aws:ecs:us-west-2:123456789012:task/default/febee046097849aba589d4435207c04a",
+				"aws.ecs.task.family":   "query-metadata",
+				"aws.ecs.task.revision": "7",
+				"aws.ecs.launchtype":    "fargate",
+			},
+		},
+		{
+			name:   "v3 endpoint with cluster name",
+			status: http.StatusOK,
+			body:   `{"Cluster": "default", "TaskARN": "arn:aws:ecs:us-east-1:123456789012:task/0123", "Family": "app", "Revision": "1"}`,
+			want: map[string]interface{}{
+				"cloud.provider":        "aws",
+				"cloud.region":          "us-east-1",
+				"cloud.account.id":      "123456789012",
+				"aws.ecs.cluster.name":  "default",
+				"aws.ecs.task.arn":      "arn:aws:ecs:us-east-1:123456789012:task/0123",
+				"aws.ecs.task.family":   "app",
+				"aws.ecs.task.revision": "1",
+			},
+		},
+		{
+			name:    "endpoint fails",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+		{
+			name:    "invalid metadata",
+			status:  http.StatusOK,
+			body:    `{"Cluster": `,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newMetadataServer(t, tt.status, tt.body)
+			defer server.Close()
+
+			d := &Detector{provider: &ecsMetadataImpl{endpoint: server.URL, client: server.Client()}}
+			got, err := d.Detect(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, internal.IsEmptyResource(got))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, internal.AttributesToMap(got.Attributes()))
+			}
+		})
+	}
+}
+
+func TestDetector_DetectNotOnECS(t *testing.T) {
+	d := &Detector{provider: &ecsMetadataImpl{client: http.DefaultClient}}
+	got, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(got))
+}
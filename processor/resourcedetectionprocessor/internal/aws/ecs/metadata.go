@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// taskMetadata is the subset of the task metadata returned by the v4 and v3
+// endpoints the detector relies on, AvailabilityZone and LaunchType are v4 only.
+type taskMetadata struct {
+	Cluster          string `json:"Cluster"`
+	TaskARN          string `json:"TaskARN"`
+	Family           string `json:"Family"`
+	Revision         string `json:"Revision"`
+	AvailabilityZone string `json:"AvailabilityZone"`
+	LaunchType       string `json:"LaunchType"`
+}
+
+type ecsMetadataProvider interface {
+	// available returns whether the task metadata endpoint is set, meaning
+	// the collector runs in an ECS task.
+	available() bool
+	get(ctx context.Context) (taskMetadata, error)
+}
+
+type ecsMetadataImpl struct {
+	// endpoint is the base URI of the task metadata endpoint v4 or v3.
+	endpoint string
+	client   *http.Client
+}
+
+var _ ecsMetadataProvider = (*ecsMetadataImpl)(nil)
+
+func (md *ecsMetadataImpl) available() bool {
+	return md.endpoint != ""
+}
+
+func (md *ecsMetadataImpl) get(ctx context.Context) (taskMetadata, error) {
+	var meta taskMetadata
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, md.endpoint+"/task", nil)
+	if err != nil {
+		return meta, err
+	}
+
+	resp, err := md.client.Do(req)
+	if err != nil {
+		return meta, fmt.Errorf("failed fetching task metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return meta, fmt.Errorf("failed fetching task metadata: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return meta, fmt.Errorf("failed reading task metadata: %w", err)
+	}
+
+	if err := json.Unmarshal(body, &meta); err != nil {
+		return meta, fmt.Errorf("failed parsing task metadata: %w", err)
+	}
+	return meta, nil
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eks provides a detector that loads resource information from
+// the EKS cluster the collector runs in
+package eks
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cloud"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "eks"
+
+	// The cloud.infrastructure_service attribute isn't part of the semantic
+	// conventions of the collector yet.
+	attributeCloudInfrastructureService = "cloud.infrastructure_service"
+	cloudInfrastructureServiceEKS       = "aws_eks"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider eksMetadataProvider
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{provider: &eksMetadataImpl{
+		apiConfig:  k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		makeClient: k8sconfig.MakeClient,
+		inCluster:  func() bool { return os.Getenv("KUBERNETES_SERVICE_HOST") != "" },
+		sess:       sess,
+	}}, nil
+}
+
+// Detect detects the name of the EKS cluster the collector runs in, it returns
+// an empty resource outside of EKS.  The cluster name is omitted when it cannot
+// be fetched, e.g. when the instance metadata service is unreachable or the
+// DescribeTags permission is missing.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	isEKS, err := d.provider.isEKS(ctx)
+	if err != nil || !isEKS {
+		return res, err
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, cloud.ProviderAWS)
+	attr.InsertString(attributeCloudInfrastructureService, cloudInfrastructureServiceEKS)
+
+	if clusterName, err := d.provider.clusterName(ctx); err == nil && clusterName != "" {
+		attr.InsertString(conventions.AttributeK8sCluster, clusterName)
+	}
+
+	return res, nil
+}
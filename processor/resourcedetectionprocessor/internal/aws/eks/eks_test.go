@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadata struct {
+	eks        bool
+	eksErr     error
+	cluster    string
+	clusterErr error
+}
+
+var _ eksMetadataProvider = (*mockMetadata)(nil)
+
+func (m *mockMetadata) isEKS(context.Context) (bool, error) {
+	return m.eks, m.eksErr
+}
+
+func (m *mockMetadata) clusterName(context.Context) (string, error) {
+	return m.cluster, m.clusterErr
+}
+
+func TestNewDetector(t *testing.T) {
+	detector, err := NewDetector(nil)
+	assert.NotNil(t, detector)
+	assert.NoError(t, err)
+}
+
+func TestDetector_Detect(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider *mockMetadata
+		want     map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "eks",
+			provider: &mockMetadata{eks: true, cluster: "prod"},
+			want: map[string]interface{}{
+				"cloud.provider":               "aws",
+				"cloud.infrastructure_service": "aws_eks",
+				"k8s.cluster.name":             "prod",
+			},
+		},
+		{
+			name:     "untagged instance",
+			provider: &mockMetadata{eks: true},
+			want: map[string]interface{}{
+				"cloud.provider":               "aws",
+				"cloud.infrastructure_service": "aws_eks",
+			},
+		},
+		{
+			name:     "not on eks",
+			provider: &mockMetadata{clusterErr: errors.New("should not be called")},
+			want:     map[string]interface{}{},
+		},
+		{
+			name:     "eks check fails",
+			provider: &mockMetadata{eksErr: errors.New("forbidden")},
+			wantErr:  true,
+		},
+		{
+			name:     "cluster name fails",
+			provider: &mockMetadata{eks: true, clusterErr: errors.New("unauthorized")},
+			want: map[string]interface{}{
+				"cloud.provider":               "aws",
+				"cloud.infrastructure_service": "aws_eks",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Detector{provider: tt.provider}
+			got, err := d.Detect(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, internal.IsEmptyResource(got))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, internal.AttributesToMap(got.Attributes()))
+			}
+		})
+	}
+}
+
+func TestIsEKS(t *testing.T) {
+	newProvider := func(inCluster bool, objects ...corev1.ConfigMap) *eksMetadataImpl {
+		client := fake.NewSimpleClientset()
+		for i := range objects {
+			_, err := client.CoreV1().ConfigMaps(objects[i].Namespace).Create(context.Background(), &objects[i], metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+		return &eksMetadataImpl{
+			apiConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+			makeClient: func(k8sconfig.APIConfig) (k8s.Interface, error) {
+				return client, nil
+			},
+			inCluster: func() bool { return inCluster },
+		}
+	}
+	awsAuth := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "aws-auth"}}
+
+	isEKS, err := newProvider(true, awsAuth).isEKS(context.Background())
+	require.NoError(t, err)
+	assert.True(t, isEKS)
+
+	isEKS, err = newProvider(true).isEKS(context.Background())
+	require.NoError(t, err)
+	assert.False(t, isEKS)
+
+	isEKS, err = newProvider(false, awsAuth).isEKS(context.Background())
+	require.NoError(t, err)
+	assert.False(t, isEKS)
+
+	forbidden := fake.NewSimpleClientset()
+	forbidden.PrependReactor("get", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(corev1.Resource("configmaps"), "aws-auth", errors.New("rbac"))
+	})
+	provider := newProvider(true)
+	provider.makeClient = func(k8sconfig.APIConfig) (k8s.Interface, error) {
+		return forbidden, nil
+	}
+	isEKS, err = provider.isEKS(context.Background())
+	require.NoError(t, err)
+	assert.False(t, isEKS)
+
+	provider = newProvider(true)
+	provider.makeClient = func(k8sconfig.APIConfig) (k8s.Interface, error) {
+		return nil, errors.New("no service account")
+	}
+	_, err = provider.isEKS(context.Background())
+	assert.EqualError(t, err, "failed creating K8s API client: no service account")
+}
+
+const identityDocument = `{
+  "instanceId": "i-1234567890abcdef0",
+  "region": "us-west-2",
+  "accountId": "123456789012"
+}`
+
+func describeTagsResponse(tags ...[2]string) string {
+	items := ""
+	for _, tag := range tags {
+		items += "<item><resourceId>i-1234567890abcdef0</resourceId><resourceType>instance</resourceType>" +
+			"<key>" + tag[0] + "</key><value>" + tag[1] + "</value></item>"
+	}
+	return `<DescribeTagsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">` +
+		`<requestId>7a62c49f-347e-4fc4-9331-6e8eEXAMPLE</requestId><tagSet>` + items + `</tagSet></DescribeTagsResponse>`
+}
+
+// newAWSServer stands in for both the EC2 instance metadata endpoint and the EC2 API.
+func newAWSServer(t *testing.T, tags string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest/api/token":
+			_, _ = w.Write([]byte("token"))
+		case "/latest/dynamic/instance-identity/document":
+			_, _ = w.Write([]byte(identityDocument))
+		case "/":
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "DescribeTags", r.Form.Get("Action"))
+			assert.Equal(t, "resource-id", r.Form.Get("Filter.1.Name"))
+			assert.Equal(t, "i-1234567890abcdef0", r.Form.Get("Filter.1.Value.1"))
+			w.Header().Set("Content-Type", "text/xml")
+			_, _ = w.Write([]byte(tags))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClusterName(t *testing.T) {
+	tests := []struct {
+		name string
+		tags string
+		want string
+	}{
+		{
+			name: "managed node group",
+			tags: describeTagsResponse(
+				[2]string{"kubernetes.io/cluster/other", "owned"},
+				[2]string{"eks:cluster-name", "prod"},
+			),
+			want: "prod",
+		},
+		{
+			name: "cluster tag",
+			tags: describeTagsResponse([2]string{"Name", "node"}, [2]string{"kubernetes.io/cluster/prod", "owned"}),
+			want: "prod",
+		},
+		{
+			name: "untagged",
+			tags: describeTagsResponse([2]string{"Name", "node"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newAWSServer(t, tt.tags)
+			defer server.Close()
+
+			sess, err := session.NewSession(&aws.Config{
+				Endpoint:    aws.String(server.URL),
+				Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+			})
+			require.NoError(t, err)
+
+			name, err := (&eksMetadataImpl{sess: sess}).clusterName(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, name)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+const (
+	// The aws-auth configmap maps the IAM roles of the nodes to K8s users, it
+	// is created in the kube-system namespace of every EKS cluster.
+	authConfigMapNamespace = "kube-system"
+	authConfigMapName      = "aws-auth"
+
+	// Tags set on the EC2 instances of the clusters, the first one by managed
+	// node groups and the second one by eksctl and the cluster autoscaler.
+	clusterNameTag       = "eks:cluster-name"
+	clusterNameTagPrefix = "kubernetes.io/cluster/"
+)
+
+type eksMetadataProvider interface {
+	// isEKS returns whether the collector runs in an EKS cluster.
+	isEKS(ctx context.Context) (bool, error)
+
+	// clusterName returns the name of the cluster from the tags of the EC2
+	// instance the collector runs on, or an empty string if not tagged.
+	clusterName(ctx context.Context) (string, error)
+}
+
+type eksMetadataImpl struct {
+	apiConfig k8sconfig.APIConfig
+	// makeClient creates the client of the K8s API, it is only called when
+	// detecting so the detector can be created outside of a cluster.
+	makeClient func(k8sconfig.APIConfig) (k8s.Interface, error)
+	// inCluster returns whether the collector runs in a K8s pod.
+	inCluster func() bool
+	sess      *session.Session
+}
+
+var _ eksMetadataProvider = (*eksMetadataImpl)(nil)
+
+func (md *eksMetadataImpl) isEKS(ctx context.Context) (bool, error) {
+	if !md.inCluster() {
+		return false, nil
+	}
+
+	client, err := md.makeClient(md.apiConfig)
+	if err != nil {
+		return false, fmt.Errorf("failed creating K8s API client: %w", err)
+	}
+
+	// The configmap is only readable by the collector when given access to it,
+	// so not being allowed to read it is treated as not running in EKS.
+	_, err = client.CoreV1().ConfigMaps(authConfigMapNamespace).Get(ctx, authConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed fetching %s configmap: %w", authConfigMapName, err)
+	}
+	return true, nil
+}
+
+func (md *eksMetadataImpl) clusterName(ctx context.Context) (string, error) {
+	doc, err := ec2metadata.New(md.sess).GetInstanceIdentityDocumentWithContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed fetching instance identity document: %w", err)
+	}
+
+	input := &ec2.DescribeTagsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("resource-id"), Values: []*string{aws.String(doc.InstanceID)}},
+			{Name: aws.String("resource-type"), Values: []*string{aws.String("instance")}},
+		},
+	}
+
+	var name string
+	err = ec2.New(md.sess, aws.NewConfig().WithRegion(doc.Region)).DescribeTagsPagesWithContext(ctx, input,
+		func(output *ec2.DescribeTagsOutput, _ bool) bool {
+			for _, tag := range output.Tags {
+				key := aws.StringValue(tag.Key)
+				switch {
+				case key == clusterNameTag:
+					name = aws.StringValue(tag.Value)
+					return false
+				case strings.HasPrefix(key, clusterNameTagPrefix):
+					name = strings.TrimPrefix(key, clusterNameTagPrefix)
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return "", fmt.Errorf("failed fetching instance tags: %w", err)
+	}
+	return name, nil
+}
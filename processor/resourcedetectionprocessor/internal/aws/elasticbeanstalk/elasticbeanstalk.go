@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package elasticbeanstalk provides a detector that loads resource information
+// from the environment configuration file of Elastic Beanstalk instances
+package elasticbeanstalk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cloud"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "elastic_beanstalk"
+
+	linuxPath   = "/var/elasticbeanstalk/xray/environment.conf"
+	windowsPath = "C:\\Program Files\\Amazon\\XRay\\environment.conf"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	fs fileSystem
+}
+
+// environmentConfig is the content of the environment configuration file
+// written by Elastic Beanstalk on its instances.
+type environmentConfig struct {
+	DeploymentID    int    `json:"deployment_id"`
+	EnvironmentName string `json:"environment_name"`
+	VersionLabel    string `json:"version_label"`
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{fs: &ebFileSystem{}}, nil
+}
+
+// Detect detects the Elastic Beanstalk environment and deployment of the
+// instance, it returns an empty resource when the configuration file does not exist.
+func (d *Detector) Detect(context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	path := linuxPath
+	if d.fs.IsWindows() {
+		path = windowsPath
+	}
+
+	file, err := d.fs.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return res, nil
+		}
+		return res, fmt.Errorf("failed opening Elastic Beanstalk configuration file: %w", err)
+	}
+	defer file.Close()
+
+	var conf environmentConfig
+	if err := json.NewDecoder(file).Decode(&conf); err != nil {
+		return res, fmt.Errorf("failed parsing Elastic Beanstalk configuration file: %w", err)
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, cloud.ProviderAWS)
+	attr.InsertString(conventions.AttributeServiceInstance, strconv.Itoa(conf.DeploymentID))
+	attr.InsertString(conventions.AttributeDeploymentEnvironment, conf.EnvironmentName)
+	attr.InsertString(conventions.AttributeServiceVersion, conf.VersionLabel)
+
+	return res, nil
+}
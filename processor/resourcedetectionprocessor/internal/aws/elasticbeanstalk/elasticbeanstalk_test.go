@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticbeanstalk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const xrayConf = `{"deployment_id":23,"version_label":"env-version-1234","environment_name":"BETA"}`
+
+type mockFileSystem struct {
+	windows  bool
+	path     string
+	contents string
+	err      error
+}
+
+var _ fileSystem = (*mockFileSystem)(nil)
+
+func (m *mockFileSystem) Open(path string) (io.ReadCloser, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if path != m.path {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+	return ioutil.NopCloser(strings.NewReader(m.contents)), nil
+}
+
+func (m *mockFileSystem) IsWindows() bool {
+	return m.windows
+}
+
+func TestNewDetector(t *testing.T) {
+	detector, err := NewDetector(nil)
+	assert.NotNil(t, detector)
+	assert.NoError(t, err)
+}
+
+func TestDetector_Detect(t *testing.T) {
+	want := map[string]interface{}{
+		"cloud.provider":         "aws",
+		"service.instance.id":    "23",
+		"service.version":        "env-version-1234",
+		"deployment.environment": "BETA",
+	}
+
+	tests := []struct {
+		name    string
+		fs      *mockFileSystem
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "linux",
+			fs:   &mockFileSystem{path: linuxPath, contents: xrayConf},
+			want: want,
+		},
+		{
+			name: "windows",
+			fs:   &mockFileSystem{windows: true, path: windowsPath, contents: xrayConf},
+			want: want,
+		},
+		{
+			name: "not on elastic beanstalk",
+			fs:   &mockFileSystem{path: "/other", contents: xrayConf},
+			want: map[string]interface{}{},
+		},
+		{
+			name:    "open fails",
+			fs:      &mockFileSystem{err: errors.New("permission denied")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid configuration",
+			fs:      &mockFileSystem{path: linuxPath, contents: `{"deployment_id":`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Detector{fs: tt.fs}
+			got, err := d.Detect(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, internal.IsEmptyResource(got))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, internal.AttributesToMap(got.Attributes()))
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package azure provides a detector that loads resource information from
+// the Azure Instance Metadata Service
+package azure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/cloud"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "azure"
+
+	attributeAzureVMName        = "azure.vm.name"
+	attributeAzureResourceGroup = "azure.resourcegroup.name"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider azureMetadataProvider
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{provider: &azureMetadataImpl{
+		endpoint: imdsEndpoint,
+		client:   &http.Client{Timeout: 2 * time.Second},
+	}}, nil
+}
+
+// Detect detects the Azure VM the collector runs on. IMDS is only reachable
+// from Azure VMs, so an empty resource is returned when it cannot be connected
+// to or doesn't return the VM ID, while unparsable metadata is returned as an error.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	meta, err := d.provider.get(ctx)
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return res, nil
+	}
+	if err != nil {
+		return res, err
+	}
+	if meta.VMID == "" {
+		return res, nil
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeCloudProvider, cloud.ProviderAzure)
+	attr.InsertString(conventions.AttributeCloudRegion, meta.Location)
+	attr.InsertString(conventions.AttributeCloudAccount, meta.SubscriptionID)
+	attr.InsertString(conventions.AttributeHostID, meta.VMID)
+	attr.InsertString(conventions.AttributeHostName, meta.Name)
+	attr.InsertString(conventions.AttributeHostType, meta.VMSize)
+	attr.InsertString(attributeAzureVMName, meta.Name)
+	attr.InsertString(attributeAzureResourceGroup, meta.ResourceGroupName)
+
+	return res, nil
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const compute = `{
+  "location": "westeurope",
+  "name": "vm-1",
+  "osType": "Linux",
+  "resourceGroupName": "rg-1",
+  "subscriptionId": "8d10da13-8125-4ba9-a717-bf7490507b3d",
+  "vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+  "vmSize": "Standard_A3"
+}`
+
+func newIMDSServer(t *testing.T, status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get("Metadata"))
+		assert.Equal(t, apiVersion, r.URL.Query().Get("api-version"))
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestNewDetector(t *testing.T) {
+	detector, err := NewDetector(nil)
+	assert.NotNil(t, detector)
+	assert.NoError(t, err)
+}
+
+func TestDetector_Detect(t *testing.T) {
+	server := newIMDSServer(t, http.StatusOK, compute)
+	defer server.Close()
+
+	d := &Detector{provider: &azureMetadataImpl{endpoint: server.URL, client: server.Client()}}
+	got, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"cloud.provider":           "azure",
+		"cloud.region":             "westeurope",
+		"cloud.account.id":         "8d10da13-8125-4ba9-a717-bf7490507b3d",
+		"host.id":                  "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+		"host.name":                "vm-1",
+		"host.type":                "Standard_A3",
+		"azure.vm.name":            "vm-1",
+		"azure.resourcegroup.name": "rg-1",
+	}, internal.AttributesToMap(got.Attributes()))
+}
+
+func TestDetector_DetectNotOnAzure(t *testing.T) {
+	server := newIMDSServer(t, http.StatusOK, compute)
+	server.Close()
+
+	d := &Detector{provider: &azureMetadataImpl{endpoint: server.URL, client: http.DefaultClient}}
+	got, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.True(t, internal.IsEmptyResource(got))
+}
+
+func TestDetector_DetectNoMetadata(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{
+			name:   "unexpected status",
+			status: http.StatusNotFound,
+			body:   compute,
+		},
+		{
+			name:   "no vm id",
+			status: http.StatusOK,
+			body:   `{"location": "westeurope", "name": "vm-1"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newIMDSServer(t, tt.status, tt.body)
+			defer server.Close()
+
+			d := &Detector{provider: &azureMetadataImpl{endpoint: server.URL, client: server.Client()}}
+			got, err := d.Detect(context.Background())
+			require.NoError(t, err)
+			assert.True(t, internal.IsEmptyResource(got))
+		})
+	}
+}
+
+func TestDetector_DetectInvalidResponse(t *testing.T) {
+	server := newIMDSServer(t, http.StatusOK, "not json")
+	defer server.Close()
+
+	d := &Detector{provider: &azureMetadataImpl{endpoint: server.URL, client: server.Client()}}
+	got, err := d.Detect(context.Background())
+	assert.EqualError(t, err, "failed parsing compute metadata: invalid character 'o' in literal null (expecting 'u')")
+	assert.True(t, internal.IsEmptyResource(got))
+}
+
+func TestAzureMetadataUnreachable(t *testing.T) {
+	server := newIMDSServer(t, http.StatusOK, compute)
+	server.Close()
+
+	provider := &azureMetadataImpl{endpoint: server.URL, client: http.DefaultClient}
+	_, err := provider.get(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// imdsEndpoint is the Azure Instance Metadata Service endpoint of the compute metadata.
+	imdsEndpoint = "http://169.254.169.254/metadata/instance/compute"
+	apiVersion   = "2020-09-01"
+)
+
+// computeMetadata is the subset of the compute metadata returned by IMDS the
+// detector relies on.
+type computeMetadata struct {
+	Location          string `json:"location"`
+	Name              string `json:"name"`
+	VMID              string `json:"vmId"`
+	VMSize            string `json:"vmSize"`
+	SubscriptionID    string `json:"subscriptionId"`
+	ResourceGroupName string `json:"resourceGroupName"`
+}
+
+type azureMetadataProvider interface {
+	get(ctx context.Context) (computeMetadata, error)
+}
+
+type azureMetadataImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+var _ azureMetadataProvider = (*azureMetadataImpl)(nil)
+
+// get returns the compute metadata of the VM, which is empty when IMDS doesn't
+// answer with a 200 status.
+func (md *azureMetadataImpl) get(ctx context.Context) (computeMetadata, error) {
+	var meta computeMetadata
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, md.endpoint, nil)
+	if err != nil {
+		return meta, err
+	}
+	req.Header.Set("Metadata", "true")
+	q := req.URL.Query()
+	q.Set("api-version", apiVersion)
+	q.Set("format", "json")
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := md.client.Do(req)
+	if err != nil {
+		return meta, fmt.Errorf("failed fetching compute metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Other services may answer on the IMDS address outside of Azure, so any
+	// other status is reported as no metadata rather than an error.
+	if resp.StatusCode != http.StatusOK {
+		return meta, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return meta, fmt.Errorf("failed reading compute metadata: %w", err)
+	}
+
+	if err := json.Unmarshal(body, &meta); err != nil {
+		return meta, fmt.Errorf("failed parsing compute metadata: %w", err)
+	}
+	return meta, nil
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package docker provides a detector that loads resource information from
+// the Docker daemon running on the host
+package docker
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "docker"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider dockerMetadata
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	provider, err := newDockerMetadata()
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{provider: provider}, nil
+}
+
+// Detect detects the host name and OS type of the host the Docker daemon runs on.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	osType, err := d.provider.OSType(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	hostname, err := d.provider.Hostname(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeHostName, hostname)
+	attr.InsertString(conventions.AttributeOSType, osType)
+
+	return res, nil
+}
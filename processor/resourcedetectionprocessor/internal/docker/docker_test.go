@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	docker "github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadata struct {
+	hostname string
+	osType   string
+	err      error
+}
+
+var _ dockerMetadata = (*mockMetadata)(nil)
+
+func (m *mockMetadata) Hostname(context.Context) (string, error) {
+	return m.hostname, m.err
+}
+
+func (m *mockMetadata) OSType(context.Context) (string, error) {
+	return m.osType, m.err
+}
+
+func TestNewDetector(t *testing.T) {
+	detector, err := NewDetector(nil)
+	assert.NotNil(t, detector)
+	assert.NoError(t, err)
+}
+
+func TestDetector_Detect(t *testing.T) {
+	d := &Detector{provider: &mockMetadata{hostname: "docker-host", osType: "linux"}}
+	res, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"host.name": "docker-host",
+		"os.type":   "linux",
+	}, internal.AttributesToMap(res.Attributes()))
+
+	d = &Detector{provider: &mockMetadata{err: errors.New("daemon not reachable")}}
+	res, err = d.Detect(context.Background())
+	require.EqualError(t, err, "daemon not reachable")
+	assert.True(t, internal.IsEmptyResource(res))
+}
+
+func TestDockerMetadata(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/v1.22/info", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Name":"docker-host","OSType":"linux"}`))
+	}))
+	defer server.Close()
+
+	client, err := docker.NewClientWithOpts(docker.WithHost(server.URL), docker.WithVersion("1.22"))
+	require.NoError(t, err)
+	provider := &dockerMetadataImpl{client: client}
+
+	hostname, err := provider.Hostname(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "docker-host", hostname)
+
+	osType, err := provider.OSType(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "linux", osType)
+	assert.Equal(t, 1, requests, "the info should be fetched once")
+
+	server.Close()
+	_, err = (&dockerMetadataImpl{client: client}).Hostname(context.Background())
+	assert.Error(t, err)
+}
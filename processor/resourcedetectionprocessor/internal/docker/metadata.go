@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	docker "github.com/docker/docker/client"
+)
+
+type dockerMetadata interface {
+	// Hostname returns the hostname of the host the Docker daemon runs on.
+	Hostname(ctx context.Context) (string, error)
+
+	// OSType returns the operating system of the host the Docker daemon runs on.
+	OSType(ctx context.Context) (string, error)
+}
+
+type dockerMetadataImpl struct {
+	client *docker.Client
+
+	mu sync.Mutex
+	// info is the cached daemon information, fetched once on success.
+	info *types.Info
+}
+
+var _ dockerMetadata = (*dockerMetadataImpl)(nil)
+
+func newDockerMetadata() (*dockerMetadataImpl, error) {
+	// The client connects to the local socket unless overridden by the
+	// DOCKER_HOST environment variable.
+	client, err := docker.NewClientWithOpts(docker.FromEnv, docker.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, fmt.Errorf("could not create docker client: %w", err)
+	}
+	return &dockerMetadataImpl{client: client}, nil
+}
+
+// fetchInfo returns the Docker daemon information, querying the daemon only
+// until it succeeds once.
+func (d *dockerMetadataImpl) fetchInfo(ctx context.Context) (*types.Info, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.info != nil {
+		return d.info, nil
+	}
+
+	info, err := d.client.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Docker information: %w", err)
+	}
+	d.info = &info
+	return d.info, nil
+}
+
+func (d *dockerMetadataImpl) Hostname(ctx context.Context) (string, error) {
+	info, err := d.fetchInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.Name, nil
+}
+
+func (d *dockerMetadataImpl) OSType(ctx context.Context) (string, error) {
+	info, err := d.fetchInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.OSType, nil
+}
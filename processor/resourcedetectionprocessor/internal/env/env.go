@@ -40,7 +40,7 @@ var _ internal.Detector = (*Detector)(nil)
 
 type Detector struct{}
 
-func NewDetector() (internal.Detector, error) {
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
 	return &Detector{}, nil
 }
 
@@ -27,7 +27,7 @@ import (
 )
 
 func TestNewDetector(t *testing.T) {
-	d, err := NewDetector()
+	d, err := NewDetector(nil)
 	assert.NotNil(t, d)
 	assert.NoError(t, err)
 }
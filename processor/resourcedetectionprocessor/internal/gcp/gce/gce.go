@@ -37,7 +37,7 @@ type Detector struct {
 	metadata gceMetadata
 }
 
-func NewDetector() (internal.Detector, error) {
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
 	return &Detector{metadata: &gceMetadataImpl{}}, nil
 }
 
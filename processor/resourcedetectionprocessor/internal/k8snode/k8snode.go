@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package k8snode provides a detector that loads resource information from
+// the K8s node the collector runs on
+package k8snode
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "k8snode"
+
+	// Environment variable holding the name of the node, usually set from
+	// the spec.nodeName field with the downward API.
+	nodeNameEnvVar = "K8S_NODE_NAME"
+
+	k8sNodeNameAttribute        = "k8s.node.name"
+	k8sNodeLabelAttributePrefix = "k8s.node.labels."
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Config defines how the detector connects to the K8s API.
+type Config struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+}
+
+// CreateDefaultConfig returns the default configuration of the detector,
+// authenticating with the service account of the pod.
+func CreateDefaultConfig() Config {
+	return Config{
+		APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+	}
+}
+
+type Detector struct {
+	provider nodeMetadata
+	nodeName func() string
+}
+
+func NewDetector(dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg, ok := dcfg.(Config)
+	if !ok {
+		cfg = CreateDefaultConfig()
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Detector{
+		provider: &nodeMetadataImpl{
+			apiConfig:  cfg.APIConfig,
+			makeClient: k8sconfig.MakeClient,
+		},
+		nodeName: func() string { return os.Getenv(nodeNameEnvVar) },
+	}, nil
+}
+
+// Detect detects the name of the node and its labels.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	nodeName := d.nodeName()
+	if nodeName == "" {
+		return res, fmt.Errorf("the %s environment variable is not set", nodeNameEnvVar)
+	}
+
+	labels, err := d.provider.NodeLabels(ctx, nodeName)
+	if err != nil {
+		return res, err
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(k8sNodeNameAttribute, nodeName)
+	for k, v := range labels {
+		attr.InsertString(k8sNodeLabelAttributePrefix+k, v)
+	}
+
+	return res, nil
+}
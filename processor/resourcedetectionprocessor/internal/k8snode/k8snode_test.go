@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8snode
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadata struct {
+	labels map[string]string
+	err    error
+}
+
+var _ nodeMetadata = (*mockMetadata)(nil)
+
+func (m *mockMetadata) NodeLabels(context.Context, string) (map[string]string, error) {
+	return m.labels, m.err
+}
+
+func TestNewDetector(t *testing.T) {
+	detector, err := NewDetector(nil)
+	assert.NotNil(t, detector)
+	assert.NoError(t, err)
+	assert.Equal(t, k8sconfig.AuthTypeServiceAccount, detector.(*Detector).provider.(*nodeMetadataImpl).apiConfig.AuthType)
+
+	detector, err = NewDetector(Config{APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig}})
+	assert.NoError(t, err)
+	assert.Equal(t, k8sconfig.AuthTypeKubeConfig, detector.(*Detector).provider.(*nodeMetadataImpl).apiConfig.AuthType)
+
+	_, err = NewDetector(Config{APIConfig: k8sconfig.APIConfig{AuthType: "unknown"}})
+	assert.EqualError(t, err, "invalid authType for kubernetes: unknown")
+}
+
+func TestDetector_Detect(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodeName string
+		provider *mockMetadata
+		want     map[string]interface{}
+		wantErr  string
+	}{
+		{
+			name:     "success",
+			nodeName: "node-1",
+			provider: &mockMetadata{labels: map[string]string{"topology.kubernetes.io/zone": "us-west-2a"}},
+			want: map[string]interface{}{
+				"k8s.node.name": "node-1",
+				"k8s.node.labels.topology.kubernetes.io/zone": "us-west-2a",
+			},
+		},
+		{
+			name:     "no node name",
+			provider: &mockMetadata{},
+			wantErr:  "the K8S_NODE_NAME environment variable is not set",
+		},
+		{
+			name:     "labels fail",
+			nodeName: "node-1",
+			provider: &mockMetadata{err: errors.New("forbidden")},
+			wantErr:  "forbidden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Detector{
+				provider: tt.provider,
+				nodeName: func() string { return tt.nodeName },
+			}
+			got, err := d.Detect(context.Background())
+
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.True(t, internal.IsEmptyResource(got))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, internal.AttributesToMap(got.Attributes()))
+			}
+		})
+	}
+}
+
+func TestNodeLabels(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{"kubernetes.io/os": "linux"},
+		},
+	})
+	provider := &nodeMetadataImpl{
+		apiConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+		makeClient: func(k8sconfig.APIConfig) (k8s.Interface, error) {
+			return client, nil
+		},
+	}
+
+	labels, err := provider.NodeLabels(context.Background(), "node-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, labels)
+
+	_, err = provider.NodeLabels(context.Background(), "node-2")
+	assert.Error(t, err)
+
+	provider.makeClient = func(k8sconfig.APIConfig) (k8s.Interface, error) {
+		return nil, errors.New("not in cluster")
+	}
+	_, err = provider.NodeLabels(context.Background(), "node-1")
+	assert.EqualError(t, err, "failed creating K8s API client: not in cluster")
+}
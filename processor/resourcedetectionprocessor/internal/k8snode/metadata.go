@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8snode
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+type nodeMetadata interface {
+	// NodeLabels returns the labels of the node with the given name.
+	NodeLabels(ctx context.Context, nodeName string) (map[string]string, error)
+}
+
+type nodeMetadataImpl struct {
+	apiConfig k8sconfig.APIConfig
+	// makeClient creates the client of the K8s API, it is only called when
+	// the labels are first fetched so the detector can be created outside
+	// of a cluster.
+	makeClient func(k8sconfig.APIConfig) (k8s.Interface, error)
+}
+
+var _ nodeMetadata = (*nodeMetadataImpl)(nil)
+
+func (m *nodeMetadataImpl) NodeLabels(ctx context.Context, nodeName string) (map[string]string, error) {
+	client, err := m.makeClient(m.apiConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating K8s API client: %w", err)
+	}
+
+	node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching node %q: %w", nodeName, err)
+	}
+	return node.Labels, nil
+}
@@ -32,7 +32,16 @@ type Detector interface {
 	Detect(ctx context.Context) (pdata.Resource, error)
 }
 
-type DetectorFactory func() (Detector, error)
+// DetectorConfig is the configuration of a detector, nil for the detectors
+// without any.
+type DetectorConfig interface{}
+
+// ResourceDetectorConfig returns the configuration of each detector type.
+type ResourceDetectorConfig interface {
+	GetConfigFromType(DetectorType) DetectorConfig
+}
+
+type DetectorFactory func(DetectorConfig) (Detector, error)
 
 type ResourceProviderFactory struct {
 	// detectors holds all possible detector types.
@@ -43,8 +52,13 @@ func NewProviderFactory(detectors map[DetectorType]DetectorFactory) *ResourcePro
 	return &ResourceProviderFactory{detectors: detectors}
 }
 
-func (f *ResourceProviderFactory) CreateResourceProvider(logger *zap.Logger, timeout time.Duration, detectorTypes ...DetectorType) (*ResourceProvider, error) {
-	detectors, err := f.getDetectors(detectorTypes)
+func (f *ResourceProviderFactory) CreateResourceProvider(
+	logger *zap.Logger,
+	timeout time.Duration,
+	detectorsConfig ResourceDetectorConfig,
+	detectorTypes ...DetectorType,
+) (*ResourceProvider, error) {
+	detectors, err := f.getDetectors(detectorsConfig, detectorTypes)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +67,7 @@ func (f *ResourceProviderFactory) CreateResourceProvider(logger *zap.Logger, tim
 	return provider, nil
 }
 
-func (f *ResourceProviderFactory) getDetectors(detectorTypes []DetectorType) ([]Detector, error) {
+func (f *ResourceProviderFactory) getDetectors(detectorsConfig ResourceDetectorConfig, detectorTypes []DetectorType) ([]Detector, error) {
 	detectors := make([]Detector, 0, len(detectorTypes))
 	for _, detectorType := range detectorTypes {
 		detectorFactory, ok := f.detectors[detectorType]
@@ -61,7 +75,7 @@ func (f *ResourceProviderFactory) getDetectors(detectorTypes []DetectorType) ([]
 			return nil, fmt.Errorf("invalid detector key: %v", detectorType)
 		}
 
-		detector, err := detectorFactory()
+		detector, err := detectorFactory(detectorsConfig.GetConfigFromType(detectorType))
 		if err != nil {
 			return nil, fmt.Errorf("failed creating detector type %q: %w", detectorType, err)
 		}
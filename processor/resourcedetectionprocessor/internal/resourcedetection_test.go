@@ -38,6 +38,12 @@ func (p *MockDetector) Detect(ctx context.Context) (pdata.Resource, error) {
 	return args.Get(0).(pdata.Resource), args.Error(1)
 }
 
+type mockDetectorConfig struct{}
+
+func (d *mockDetectorConfig) GetConfigFromType(DetectorType) DetectorConfig {
+	return nil
+}
+
 func TestDetect(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -81,14 +87,14 @@ func TestDetect(t *testing.T) {
 				md.On("Detect").Return(res, nil)
 
 				mockDetectorType := DetectorType(fmt.Sprintf("mockdetector%v", i))
-				mockDetectors[mockDetectorType] = func() (Detector, error) {
+				mockDetectors[mockDetectorType] = func(DetectorConfig) (Detector, error) {
 					return md, nil
 				}
 				mockDetectorTypes = append(mockDetectorTypes, mockDetectorType)
 			}
 
 			f := NewProviderFactory(mockDetectors)
-			p, err := f.CreateResourceProvider(zap.NewNop(), time.Second, mockDetectorTypes...)
+			p, err := f.CreateResourceProvider(zap.NewNop(), time.Second, &mockDetectorConfig{}, mockDetectorTypes...)
 			require.NoError(t, err)
 
 			got, err := p.Get(context.Background())
@@ -104,18 +110,18 @@ func TestDetect(t *testing.T) {
 func TestDetectResource_InvalidDetectorType(t *testing.T) {
 	mockDetectorKey := DetectorType("mock")
 	p := NewProviderFactory(map[DetectorType]DetectorFactory{})
-	_, err := p.CreateResourceProvider(zap.NewNop(), time.Second, mockDetectorKey)
+	_, err := p.CreateResourceProvider(zap.NewNop(), time.Second, &mockDetectorConfig{}, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("invalid detector key: %v", mockDetectorKey))
 }
 
 func TestDetectResource_DetectoryFactoryError(t *testing.T) {
 	mockDetectorKey := DetectorType("mock")
 	p := NewProviderFactory(map[DetectorType]DetectorFactory{
-		mockDetectorKey: func() (Detector, error) {
+		mockDetectorKey: func(DetectorConfig) (Detector, error) {
 			return nil, errors.New("creation failed")
 		},
 	})
-	_, err := p.CreateResourceProvider(zap.NewNop(), time.Second, mockDetectorKey)
+	_, err := p.CreateResourceProvider(zap.NewNop(), time.Second, &mockDetectorConfig{}, mockDetectorKey)
 	require.EqualError(t, err, fmt.Sprintf("failed creating detector type %q: %v", mockDetectorKey, "creation failed"))
 }
 
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// machineIDPaths are the files the machine ID is read from, in order.
+var machineIDPaths = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+
+type systemMetadata interface {
+	// Hostname returns the OS hostname.
+	Hostname() (string, error)
+
+	// FQDN returns the fully qualified domain name of the host.
+	FQDN(ctx context.Context) (string, error)
+
+	// OSType returns the host operating system.
+	OSType() string
+
+	// HostID returns the unique ID of the host, the machine ID on Linux.
+	HostID() (string, error)
+}
+
+type systemMetadataImpl struct {
+	resolver *net.Resolver
+}
+
+var _ systemMetadata = (*systemMetadataImpl)(nil)
+
+func (*systemMetadataImpl) Hostname() (string, error) {
+	return os.Hostname()
+}
+
+func (m *systemMetadataImpl) FQDN(ctx context.Context) (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+
+	cname, err := m.resolver.LookupCNAME(ctx, hostname)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(cname, "."), nil
+}
+
+func (*systemMetadataImpl) OSType() string {
+	return runtime.GOOS
+}
+
+func (*systemMetadataImpl) HostID() (string, error) {
+	for _, path := range machineIDPaths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if id := strings.TrimSpace(string(content)); id != "" {
+			return id, nil
+		}
+	}
+	return "", errors.New("no machine ID found")
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package system provides a detector that loads resource information from
+// the host operating system
+package system
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+const (
+	TypeStr = "system"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+type Detector struct {
+	provider systemMetadata
+}
+
+func NewDetector(internal.DetectorConfig) (internal.Detector, error) {
+	return &Detector{provider: &systemMetadataImpl{resolver: net.DefaultResolver}}, nil
+}
+
+// Detect detects the host name, preferring the FQDN and falling back to the
+// OS hostname when it cannot be resolved, the OS type and the host ID when
+// the machine ID is available.
+func (d *Detector) Detect(ctx context.Context) (pdata.Resource, error) {
+	res := pdata.NewResource()
+	res.InitEmpty()
+
+	hostname, err := d.provider.FQDN(ctx)
+	if err != nil {
+		if hostname, err = d.provider.Hostname(); err != nil {
+			return res, fmt.Errorf("failed getting host name: %w", err)
+		}
+	}
+
+	attr := res.Attributes()
+	attr.InsertString(conventions.AttributeHostName, hostname)
+	attr.InsertString(conventions.AttributeOSType, d.provider.OSType())
+
+	if hostID, err := d.provider.HostID(); err == nil {
+		attr.InsertString(conventions.AttributeHostID, hostID)
+	}
+
+	return res, nil
+}
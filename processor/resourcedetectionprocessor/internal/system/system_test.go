@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+)
+
+type mockMetadata struct {
+	hostname    string
+	hostnameErr error
+	fqdn        string
+	fqdnErr     error
+	osType      string
+	hostID      string
+	hostIDErr   error
+}
+
+var _ systemMetadata = (*mockMetadata)(nil)
+
+func (m *mockMetadata) Hostname() (string, error) {
+	return m.hostname, m.hostnameErr
+}
+
+func (m *mockMetadata) FQDN(context.Context) (string, error) {
+	return m.fqdn, m.fqdnErr
+}
+
+func (m *mockMetadata) OSType() string {
+	return m.osType
+}
+
+func (m *mockMetadata) HostID() (string, error) {
+	return m.hostID, m.hostIDErr
+}
+
+func TestNewDetector(t *testing.T) {
+	detector, err := NewDetector(nil)
+	assert.NotNil(t, detector)
+	assert.NoError(t, err)
+}
+
+func TestDetector_Detect(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider *mockMetadata
+		want     map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "fqdn",
+			provider: &mockMetadata{hostname: "host", fqdn: "host.example.com", osType: "linux", hostID: "3f1a9c0e8b5d4e2f"},
+			want: map[string]interface{}{
+				"host.name": "host.example.com",
+				"host.id":   "3f1a9c0e8b5d4e2f",
+				"os.type":   "linux",
+			},
+		},
+		{
+			name:     "fqdn fails",
+			provider: &mockMetadata{hostname: "host", fqdnErr: errors.New("lookup failed"), osType: "windows", hostID: "3f1a9c0e8b5d4e2f"},
+			want: map[string]interface{}{
+				"host.name": "host",
+				"host.id":   "3f1a9c0e8b5d4e2f",
+				"os.type":   "windows",
+			},
+		},
+		{
+			name:     "no machine id",
+			provider: &mockMetadata{hostname: "host", fqdn: "host.example.com", osType: "darwin", hostIDErr: errors.New("no machine ID found")},
+			want: map[string]interface{}{
+				"host.name": "host.example.com",
+				"os.type":   "darwin",
+			},
+		},
+		{
+			name: "hostname fails",
+			provider: &mockMetadata{
+				fqdnErr:     errors.New("lookup failed"),
+				hostnameErr: errors.New("hostname failed"),
+				osType:      "linux",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Detector{provider: tt.provider}
+			got, err := d.Detect(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, internal.IsEmptyResource(got))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, internal.AttributesToMap(got.Attributes()))
+			}
+		})
+	}
+}
@@ -26,11 +26,22 @@ type Config struct {
 	// Optional.
 	DefaultExporters []string `mapstructure:"default_exporters"`
 
-	// FromAttribute contains the attribute name to look up the route value. This attribute should be part of the context propagated
+	// AttributeSource defines where the attribute defined in FromAttribute is looked up: either "context", the default,
+	// or "resource".
+	// Optional.
+	AttributeSource string `mapstructure:"attribute_source"`
+
+	// FromAttribute contains the attribute name to look up the route value.
+	//
+	// When AttributeSource is "context", this attribute should be part of the context propagated
 	// down from the previous receivers and/or processors. If all the receivers and processors are propagating the entire context correctly,
 	// this could be the HTTP/gRPC header from the original request/RPC. Typically, aggregation processors (batch, groupbytrace)
 	// will create a new context, so, those should be avoided when using this processor.Although the HTTP spec allows headers to be repeated,
-	// this processor will only use the first value.
+	// this processor will only use the first value. The "net.peer.ip" attribute refers to the IP address of the client, as recorded by
+	// the receivers in the context.
+	//
+	// When AttributeSource is "resource", this is the name of a resource attribute. The incoming data is split per resource, so that each
+	// resource is routed according to its own attribute value.
 	// Required.
 	FromAttribute string `mapstructure:"from_attribute"`
 
@@ -39,14 +50,31 @@ type Config struct {
 	Table []RoutingTableItem `mapstructure:"table"`
 }
 
-// RoutingTableItem specifies how data should be routed to the different exporters
+// RoutingTableItem specifies how data should be routed to the different exporters. Items are evaluated in order and
+// the first matching item is used. Exactly one of Value, Regex or Expression must be specified.
 type RoutingTableItem struct {
-	// Value represents a possible value for the field specified under FromAttribute. Required.
+	// Name identifies the item in the routing metrics and logs. It must be unique and can't be "default", which
+	// identifies the DefaultExporters. Defaults to the index of the item in the table, starting at 0.
+	// Optional.
+	Name string `mapstructure:"name"`
+
+	// Value represents a possible value for the field specified under FromAttribute.
 	Value string `mapstructure:"value"`
 
+	// Regex is a regular expression the value of the field specified under FromAttribute must match. The expression
+	// isn't anchored: use ^ and $ to match the whole value.
+	Regex string `mapstructure:"regex"`
+
+	// Expression is a condition, in the expr language (https://github.com/antonmedv/expr), which must evaluate to a
+	// boolean. The value of the field specified under FromAttribute is available as `value` and, when the
+	// attribute source is "resource", the resource attributes are available as `attributes`, for instance:
+	// `attributes["k8s.namespace.name"] startsWith "team-"`.
+	Expression string `mapstructure:"expression"`
+
 	// Exporters contains the list of exporters to use when the value from the FromAttribute field matches this table item.
-	// When no exporters are specified, the ones specified under DefaultExporters are used, if any.
+	// At least one of them must support the data type of each pipeline the processor is part of: the data matching
+	// this table item is never sent to the DefaultExporters.
 	// The routing processor will fail upon the first failure from these exporters.
-	// Optional.
+	// Required.
 	Exporters []string `mapstructure:"exporters"`
 }
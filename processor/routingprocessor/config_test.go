@@ -51,6 +51,7 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "routing",
 			},
 			DefaultExporters: []string{"otlp"},
+			AttributeSource:  "context",
 			FromAttribute:    "X-Tenant",
 			Table: []RoutingTableItem{
 				{
@@ -63,4 +64,31 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		})
+
+	parsed = cfg.Processors["routing/resource"]
+	assert.Equal(t, parsed,
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				NameVal: "routing/resource",
+				TypeVal: "routing",
+			},
+			DefaultExporters: []string{"otlp"},
+			AttributeSource:  "resource",
+			FromAttribute:    "tenant",
+			Table: []RoutingTableItem{
+				{
+					Value:     "acme",
+					Exporters: []string{"otlp/acme"},
+				},
+				{
+					Regex:     "^globex-",
+					Exporters: []string{"otlp/globex"},
+				},
+				{
+					Name:       "globex-namespace",
+					Expression: `attributes["k8s.namespace.name"] == "globex"`,
+					Exporters:  []string{"otlp/globex"},
+				},
+			},
+		})
 }
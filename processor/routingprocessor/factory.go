@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
 )
 
 const (
@@ -34,6 +35,8 @@ func NewFactory() component.ProcessorFactory {
 		typeStr,
 		createDefaultConfig,
 		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor),
 	)
 }
 
@@ -43,13 +46,28 @@ func createDefaultConfig() configmodels.Processor {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
+		AttributeSource: contextAttributeSource,
 	}
 }
 
 func createTraceProcessor(_ context.Context, params component.ProcessorCreateParams, cfg configmodels.Processor, nextConsumer consumer.TraceConsumer) (component.TraceProcessor, error) {
+	warnIfNotLastInPipeline(nextConsumer, params.Logger)
+	return newProcessor(params.Logger, cfg, configmodels.TracesDataType)
+}
+
+func createMetricsProcessor(_ context.Context, params component.ProcessorCreateParams, cfg configmodels.Processor, nextConsumer consumer.MetricsConsumer) (component.MetricsProcessor, error) {
+	warnIfNotLastInPipeline(nextConsumer, params.Logger)
+	return newProcessor(params.Logger, cfg, configmodels.MetricsDataType)
+}
+
+func createLogsProcessor(_ context.Context, params component.ProcessorCreateParams, cfg configmodels.Processor, nextConsumer consumer.LogsConsumer) (component.LogsProcessor, error) {
+	warnIfNotLastInPipeline(nextConsumer, params.Logger)
+	return newProcessor(params.Logger, cfg, configmodels.LogsDataType)
+}
+
+func warnIfNotLastInPipeline(nextConsumer interface{}, logger *zap.Logger) {
 	_, ok := nextConsumer.(component.Processor)
 	if ok {
-		params.Logger.Warn("another processor has been defined after the routing processor: it will NOT receive any data!")
+		logger.Warn("another processor has been defined after the routing processor: it will NOT receive any data!")
 	}
-	return newProcessor(params.Logger, cfg)
 }
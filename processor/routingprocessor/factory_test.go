@@ -56,6 +56,36 @@ func TestProcessorGetsCreatedWithValidConfiguration(t *testing.T) {
 	assert.NotNil(t, exp)
 }
 
+func TestMetricsAndLogsProcessorsGetCreatedWithValidConfiguration(t *testing.T) {
+	// prepare
+	factory := NewFactory()
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	cfg := &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "routing",
+			TypeVal: "routing",
+		},
+		DefaultExporters: []string{"otlp"},
+		FromAttribute:    "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp"},
+			},
+		},
+	}
+
+	// test
+	metricsExp, metricsErr := factory.CreateMetricsProcessor(context.Background(), creationParams, cfg, exportertest.NewNopMetricsExporter())
+	logsExp, logsErr := factory.CreateLogsProcessor(context.Background(), creationParams, cfg, exportertest.NewNopLogsExporter())
+
+	// verify
+	assert.NoError(t, metricsErr)
+	assert.NotNil(t, metricsExp)
+	assert.NoError(t, logsErr)
+	assert.NotNil(t, logsExp)
+}
+
 func TestFailOnEmptyConfiguration(t *testing.T) {
 	// prepare
 	factory := NewFactory()
@@ -144,6 +174,33 @@ func TestProcessorFailsWithNoFromAttribute(t *testing.T) {
 	assert.Nil(t, exp)
 }
 
+func TestProcessorFailsWithInvalidAttributeSource(t *testing.T) {
+	// prepare
+	factory := NewFactory()
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	cfg := &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "routing",
+			TypeVal: "routing",
+		},
+		AttributeSource: "invalid",
+		FromAttribute:   "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp"},
+			},
+		},
+	}
+
+	// test
+	exp, err := factory.CreateTraceProcessor(context.Background(), creationParams, cfg, exportertest.NewNopTraceExporter())
+
+	// verify
+	assert.True(t, errors.Is(err, errInvalidAttributeSource))
+	assert.Nil(t, exp)
+}
+
 func TestShouldNotFailWhenNextIsProcessor(t *testing.T) {
 	// prepare
 	factory := NewFactory()
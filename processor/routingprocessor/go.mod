@@ -3,7 +3,9 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/processor/routi
 go 1.14
 
 require (
+	github.com/antonmedv/expr v1.8.9
 	github.com/stretchr/testify v1.6.1
+	go.opencensus.io v0.22.4
 	go.opentelemetry.io/collector v0.11.1-0.20201006165100-07236c11fb27
 	go.uber.org/zap v1.16.0
 	google.golang.org/grpc v1.32.0
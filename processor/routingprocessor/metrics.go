@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+// defaultRouteName is the value of the route tag for the data sent to the default exporters
+const defaultRouteName = "default"
+
+var (
+	tagProcessorKey, _ = tag.NewKey("processor")
+	tagRouteKey, _     = tag.NewKey("route")
+
+	mRoutedSpans       = stats.Int64("routed_spans", "Number of spans routed, per route", stats.UnitDimensionless)
+	mRoutedMetricsData = stats.Int64("routed_metric_points", "Number of metric data points routed, per route", stats.UnitDimensionless)
+	mRoutedLogRecords  = stats.Int64("routed_log_records", "Number of log records routed, per route", stats.UnitDimensionless)
+)
+
+func init() {
+	// TODO: re-think if processor should register it's own telemetry views or if some other
+	// mechanism should be used by the collector to discover views from all components
+	_ = view.Register(metricViews()...)
+}
+
+// metricViews returns the metrics views related to routing.
+func metricViews() []*view.View {
+	tagKeys := []tag.Key{tagProcessorKey, tagRouteKey}
+
+	return []*view.View{
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mRoutedSpans.Name()),
+			Measure:     mRoutedSpans,
+			Description: mRoutedSpans.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mRoutedMetricsData.Name()),
+			Measure:     mRoutedMetricsData,
+			Description: mRoutedMetricsData.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, mRoutedLogRecords.Name()),
+			Measure:     mRoutedLogRecords,
+			Description: mRoutedLogRecords.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+	}
+}
+
+// recordRouted records the number of items sent to the exporters of the given route, an empty route standing for
+// the default exporters.
+func (e *processorImp) recordRouted(ctx context.Context, route string, measure *stats.Int64Measure, count int) {
+	if route == "" {
+		route = defaultRouteName
+	}
+	ctx = obsreport.ProcessorContext(ctx, e.config.Name())
+	if err := stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(tagRouteKey, route)}, measure.M(int64(count))); err != nil {
+		e.logger.Warn("failed to record the routed data", zap.String("route", route), zap.String("measure", measure.Name()), zap.Error(err))
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/vm"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var (
+	errNoRouteCondition        = errors.New("one of value, regex or expression must be specified")
+	errMultipleRouteConditions = errors.New("only one of value, regex or expression can be specified")
+	errExpressionNotBoolean    = errors.New("expression did not return a boolean")
+)
+
+// route is a compiled item of the routing table.
+type route struct {
+	// name identifies the route, it is also used to tag the routing metrics
+	name       string
+	value      string
+	regex      *regexp.Regexp
+	expression *vm.Program
+}
+
+// routeEnv holds the data the routes are matched against.
+type routeEnv struct {
+	// Value is the value of the attribute specified under FromAttribute, empty when not found
+	Value string
+	// Attributes are the resource attributes, empty when the attribute source is the context
+	Attributes map[string]interface{}
+}
+
+// exprEnv returns the environment the route expressions are evaluated against.
+func (e routeEnv) exprEnv() map[string]interface{} {
+	attributes := e.Attributes
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"value":      e.Value,
+		"attributes": attributes,
+	}
+}
+
+// newRoute compiles the routing table item at the given index, which must have exactly one of a value, a regex or
+// an expression. The route is named after the item, or after its index when the item has no name.
+func newRoute(index int, item RoutingTableItem) (route, error) {
+	conditions := 0
+	r := route{name: item.Name}
+	if r.name == "" {
+		r.name = strconv.Itoa(index)
+	}
+	if item.Value != "" {
+		conditions++
+		r.value = item.Value
+	}
+	if item.Regex != "" {
+		conditions++
+		re, err := regexp.Compile(item.Regex)
+		if err != nil {
+			return route{}, fmt.Errorf("invalid regex %q: %w", item.Regex, err)
+		}
+		r.regex = re
+	}
+	if item.Expression != "" {
+		conditions++
+		program, err := expr.Compile(item.Expression, expr.Env(routeEnv{}.exprEnv()))
+		if err != nil {
+			return route{}, fmt.Errorf("invalid expression %q: %w", item.Expression, err)
+		}
+		r.expression = program
+	}
+
+	switch conditions {
+	case 0:
+		return route{}, errNoRouteCondition
+	case 1:
+		return r, nil
+	default:
+		return route{}, errMultipleRouteConditions
+	}
+}
+
+// matches checks the route against the value of the attribute specified under FromAttribute and, for expressions,
+// against the resource attributes.
+func (r *route) matches(env routeEnv) (bool, error) {
+	switch {
+	case r.regex != nil:
+		return r.regex.MatchString(env.Value), nil
+	case r.expression != nil:
+		res, err := expr.Run(r.expression, env.exprEnv())
+		if err != nil {
+			return false, err
+		}
+		if ret, ok := res.(bool); ok {
+			return ret, nil
+		}
+		return false, errExpressionNotBoolean
+	default:
+		return r.value != "" && r.value == env.Value, nil
+	}
+}
+
+// attributesToMap converts the attributes to the map used by the route expressions.
+func attributesToMap(attributes pdata.AttributeMap) map[string]interface{} {
+	m := make(map[string]interface{}, attributes.Len())
+	attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		switch v.Type() {
+		case pdata.AttributeValueSTRING:
+			m[k] = v.StringVal()
+		case pdata.AttributeValueINT:
+			m[k] = v.IntVal()
+		case pdata.AttributeValueDOUBLE:
+			m[k] = v.DoubleVal()
+		case pdata.AttributeValueBOOL:
+			m[k] = v.BoolVal()
+		}
+	})
+	return m
+}
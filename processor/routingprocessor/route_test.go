@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestNewRouteValidation(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		item     RoutingTableItem
+		wantName string
+		wantErr  string
+	}{
+		{
+			name:     "value",
+			item:     RoutingTableItem{Value: "acme"},
+			wantName: "3",
+		},
+		{
+			name:     "regex",
+			item:     RoutingTableItem{Regex: "^acme-.*"},
+			wantName: "3",
+		},
+		{
+			name:     "expression",
+			item:     RoutingTableItem{Name: "acme", Expression: `value == "acme"`},
+			wantName: "acme",
+		},
+		{
+			name:    "no condition",
+			item:    RoutingTableItem{},
+			wantErr: errNoRouteCondition.Error(),
+		},
+		{
+			name:    "multiple conditions",
+			item:    RoutingTableItem{Value: "acme", Regex: "acme"},
+			wantErr: errMultipleRouteConditions.Error(),
+		},
+		{
+			name:    "invalid regex",
+			item:    RoutingTableItem{Regex: "acme("},
+			wantErr: "invalid regex \"acme(\": error parsing regexp: missing closing ): `acme(`",
+		},
+		{
+			name: "invalid expression",
+			item: RoutingTableItem{Expression: `unknown == "acme"`},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newRoute(3, tt.item)
+			if tt.name == "invalid expression" {
+				assert.Error(t, err)
+				return
+			}
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, r.name)
+		})
+	}
+}
+
+func TestRouteMatches(t *testing.T) {
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString("k8s.namespace.name", "team-acme")
+	attrs.InsertInt("priority", 2)
+	attrs.InsertBool("canary", true)
+	env := routeEnv{
+		Value:      "acme-prod",
+		Attributes: attributesToMap(attrs),
+	}
+
+	for _, tt := range []struct {
+		name    string
+		item    RoutingTableItem
+		matches bool
+	}{
+		{
+			name:    "value matching",
+			item:    RoutingTableItem{Value: "acme-prod"},
+			matches: true,
+		},
+		{
+			name: "value not matching",
+			item: RoutingTableItem{Value: "acme"},
+		},
+		{
+			name:    "regex matching",
+			item:    RoutingTableItem{Regex: "^acme-"},
+			matches: true,
+		},
+		{
+			name: "regex not matching",
+			item: RoutingTableItem{Regex: "^globex-"},
+		},
+		{
+			name:    "expression on string attribute",
+			item:    RoutingTableItem{Expression: `attributes["k8s.namespace.name"] startsWith "team-"`},
+			matches: true,
+		},
+		{
+			name:    "expression on typed attributes",
+			item:    RoutingTableItem{Expression: `attributes["priority"] > 1 && attributes["canary"] == true`},
+			matches: true,
+		},
+		{
+			name: "expression on missing attribute",
+			item: RoutingTableItem{Expression: `attributes["missing"] == "acme"`},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newRoute(3, tt.item)
+			require.NoError(t, err)
+
+			matches, err := r.matches(env)
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, matches)
+		})
+	}
+}
+
+func TestRouteExpressionMustReturnBoolean(t *testing.T) {
+	r, err := newRoute(0, RoutingTableItem{Expression: `value + "-suffix"`})
+	require.NoError(t, err)
+
+	_, err = r.matches(routeEnv{Value: "acme"})
+	assert.True(t, errors.Is(err, errExpressionNotBoolean))
+}
@@ -20,41 +20,85 @@ import (
 	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
 )
 
+const (
+	// contextAttributeSource looks up the attribute from the gRPC metadata or the client information in the context
+	contextAttributeSource = "context"
+	// resourceAttributeSource looks up the attribute from the resource attributes
+	resourceAttributeSource = "resource"
+)
+
 var (
+	errInvalidAttributeSource = errors.New("invalid attribute source, must be either \"context\" or \"resource\"")
 	errNoExporters            = errors.New("no exporters defined for the route")
 	errNoTableItems           = errors.New("the routing table is empty")
 	errNoMissingFromAttribute = errors.New("the FromAttribute property is empty")
 	errExporterNotFound       = errors.New("exporter not found")
+	errDuplicateRouteName     = errors.New("the route name is already used")
+	errNoExportersForDataType = errors.New("none of the exporters of the route supports the data type of the pipeline")
 )
 
 var _ component.TraceProcessor = (*processorImp)(nil)
+var _ component.MetricsProcessor = (*processorImp)(nil)
+var _ component.LogsProcessor = (*processorImp)(nil)
 
 type processorImp struct {
 	logger *zap.Logger
 	config Config
+	// dataType is the data type of the pipeline the processor is part of
+	dataType configmodels.DataType
+
+	// routes are the compiled items of the routing table, in the same order
+	routes []route
 
 	defaultTraceExporters []component.TraceExporter
 	traceExporters        map[string][]component.TraceExporter
+
+	defaultMetricsExporters []component.MetricsExporter
+	metricsExporters        map[string][]component.MetricsExporter
+
+	defaultLogsExporters []component.LogsExporter
+	logsExporters        map[string][]component.LogsExporter
+}
+
+// availableExporters holds the exporters of each data type known to the host, by name
+type availableExporters struct {
+	traces  map[string]component.TraceExporter
+	metrics map[string]component.MetricsExporter
+	logs    map[string]component.LogsExporter
 }
 
 // Crete new processor
-func newProcessor(logger *zap.Logger, cfg configmodels.Exporter) (*processorImp, error) {
+func newProcessor(logger *zap.Logger, cfg configmodels.Exporter, dataType configmodels.DataType) (*processorImp, error) {
 	logger.Info("building processor")
 
 	oCfg := cfg.(*Config)
 
-	// validate that every route has at least one exporter
-	for _, item := range oCfg.Table {
+	// validate that every route has a single condition and at least one exporter
+	routes := make([]route, 0, len(oCfg.Table))
+	names := map[string]bool{defaultRouteName: true}
+	for i, item := range oCfg.Table {
+		r, err := newRoute(i, item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route #%d: %w", i, err)
+		}
+		if names[r.name] {
+			return nil, fmt.Errorf("invalid route %s: %w", r.name, errDuplicateRouteName)
+		}
+		names[r.name] = true
 		if len(item.Exporters) == 0 {
-			return nil, fmt.Errorf("invalid route %s: %w", item.Value, errNoExporters)
+			return nil, fmt.Errorf("invalid route %s: %w", r.name, errNoExporters)
 		}
+		routes = append(routes, r)
 	}
 
 	// validate that there's at least one item in the table
@@ -67,59 +111,145 @@ func newProcessor(logger *zap.Logger, cfg configmodels.Exporter) (*processorImp,
 		return nil, fmt.Errorf("invalid attribute to read the route's value from: %w", errNoMissingFromAttribute)
 	}
 
+	config := *oCfg
+	switch config.AttributeSource {
+	case "":
+		config.AttributeSource = contextAttributeSource
+	case contextAttributeSource, resourceAttributeSource:
+	default:
+		return nil, fmt.Errorf("invalid attribute source %q: %w", config.AttributeSource, errInvalidAttributeSource)
+	}
+
 	return &processorImp{
-		logger:         logger,
-		config:         *oCfg,
-		traceExporters: make(map[string][]component.TraceExporter),
+		logger:           logger,
+		config:           config,
+		dataType:         dataType,
+		routes:           routes,
+		traceExporters:   make(map[string][]component.TraceExporter),
+		metricsExporters: make(map[string][]component.MetricsExporter),
+		logsExporters:    make(map[string][]component.LogsExporter),
 	}, nil
 }
 
 func (e *processorImp) Start(_ context.Context, host component.Host) error {
-	// first, let's build a map of exporter names with the exporter instances
+	// first, let's build a map of exporter names with the exporter instances, per data type
+	available, err := buildAvailableExporters(host)
+	if err != nil {
+		return err
+	}
+
+	// default exporters
+	if err := e.registerExportersForDefaultRoute(available, e.config.DefaultExporters); err != nil {
+		return err
+	}
+
+	// exporters for each defined route
+	for i, item := range e.config.Table {
+		if err := e.registerExportersForRoute(e.routes[i].name, available, item.Exporters); err != nil {
+			return err
+		}
+		// a matching route is final, there's no fallback to the default exporters
+		if !e.hasExportersForDataType(e.routes[i].name) {
+			return fmt.Errorf("invalid route %s for %s: %w", e.routes[i].name, e.dataType, errNoExportersForDataType)
+		}
+	}
+
+	return nil
+}
+
+// hasExportersForDataType checks that the route has at least one exporter for the data type of the pipeline
+func (e *processorImp) hasExportersForDataType(route string) bool {
+	switch e.dataType {
+	case configmodels.TracesDataType:
+		return len(e.traceExporters[route]) > 0
+	case configmodels.MetricsDataType:
+		return len(e.metricsExporters[route]) > 0
+	case configmodels.LogsDataType:
+		return len(e.logsExporters[route]) > 0
+	}
+	return true
+}
+
+func buildAvailableExporters(host component.Host) (availableExporters, error) {
 	source := host.GetExporters()
-	availableExporters := map[string]component.TraceExporter{}
+	available := availableExporters{
+		traces:  map[string]component.TraceExporter{},
+		metrics: map[string]component.MetricsExporter{},
+		logs:    map[string]component.LogsExporter{},
+	}
+
 	for k, exp := range source[configmodels.TracesDataType] {
 		traceExp, ok := exp.(component.TraceExporter)
 		if !ok {
-			return fmt.Errorf("the exporter %q isn't a trace exporter", k.Name())
+			return available, fmt.Errorf("the exporter %q isn't a trace exporter", k.Name())
 		}
-		availableExporters[k.Name()] = traceExp
+		available.traces[k.Name()] = traceExp
 	}
 
-	// default exporters
-	if err := e.registerExportersForDefaultRoute(availableExporters, e.config.DefaultExporters); err != nil {
-		return err
+	for k, exp := range source[configmodels.MetricsDataType] {
+		metricsExp, ok := exp.(component.MetricsExporter)
+		if !ok {
+			return available, fmt.Errorf("the exporter %q isn't a metrics exporter", k.Name())
+		}
+		available.metrics[k.Name()] = metricsExp
 	}
 
-	// exporters for each defined value
-	for _, item := range e.config.Table {
-		if err := e.registerExportersForRoute(item.Value, availableExporters, item.Exporters); err != nil {
-			return err
+	for k, exp := range source[configmodels.LogsDataType] {
+		logsExp, ok := exp.(component.LogsExporter)
+		if !ok {
+			return available, fmt.Errorf("the exporter %q isn't a logs exporter", k.Name())
 		}
+		available.logs[k.Name()] = logsExp
 	}
 
-	return nil
+	return available, nil
 }
 
-func (e *processorImp) registerExportersForDefaultRoute(available map[string]component.TraceExporter, requested []string) error {
+// registerExportersForDefaultRoute registers the requested exporters for each data type they support. An exporter has
+// to be available for at least one data type.
+func (e *processorImp) registerExportersForDefaultRoute(available availableExporters, requested []string) error {
 	for _, exp := range requested {
-		v, ok := available[exp]
-		if !ok {
+		found := false
+		if v, ok := available.traces[exp]; ok {
+			e.defaultTraceExporters = append(e.defaultTraceExporters, v)
+			found = true
+		}
+		if v, ok := available.metrics[exp]; ok {
+			e.defaultMetricsExporters = append(e.defaultMetricsExporters, v)
+			found = true
+		}
+		if v, ok := available.logs[exp]; ok {
+			e.defaultLogsExporters = append(e.defaultLogsExporters, v)
+			found = true
+		}
+		if !found {
 			return fmt.Errorf("error registering default exporter %q: %w", exp, errExporterNotFound)
 		}
-		e.defaultTraceExporters = append(e.defaultTraceExporters, v)
 	}
 
 	return nil
 }
 
-func (e *processorImp) registerExportersForRoute(route string, available map[string]component.TraceExporter, requested []string) error {
+// registerExportersForRoute registers the requested exporters for each data type they support. An exporter has
+// to be available for at least one data type.
+func (e *processorImp) registerExportersForRoute(route string, available availableExporters, requested []string) error {
 	for _, exp := range requested {
-		v, ok := available[exp]
-		if !ok {
+		found := false
+		if v, ok := available.traces[exp]; ok {
+			e.traceExporters[route] = append(e.traceExporters[route], v)
+			found = true
+		}
+		if v, ok := available.metrics[exp]; ok {
+			e.metricsExporters[route] = append(e.metricsExporters[route], v)
+			found = true
+		}
+		if v, ok := available.logs[exp]; ok {
+			e.logsExporters[route] = append(e.logsExporters[route], v)
+			found = true
+		}
+		if !found {
 			return fmt.Errorf("error registering route %q for exporter %q: %w", route, exp, errExporterNotFound)
 		}
-		e.traceExporters[route] = append(e.traceExporters[route], v)
 	}
 
 	return nil
@@ -130,26 +260,173 @@ func (e *processorImp) Shutdown(context.Context) error {
 }
 
 func (e *processorImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
-	value := e.extractValueFromContext(ctx)
-	if len(value) == 0 {
-		// the attribute's value hasn't been found, send data to the default exporter
-		return e.pushDataToExporters(ctx, td, e.defaultTraceExporters)
+	if e.config.AttributeSource == resourceAttributeSource {
+		return e.routeTracesByResource(ctx, td)
+	}
+	route := e.findRoute(routeEnv{Value: e.extractValueFromContext(ctx)})
+	e.recordRouted(ctx, route, mRoutedSpans, td.SpanCount())
+	return e.pushTracesToExporters(ctx, td, e.traceExportersForRoute(route))
+}
+
+func (e *processorImp) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if e.config.AttributeSource == resourceAttributeSource {
+		return e.routeMetricsByResource(ctx, md)
 	}
+	route := e.findRoute(routeEnv{Value: e.extractValueFromContext(ctx)})
+	e.recordRouted(ctx, route, mRoutedMetricsData, dataPointCount(md))
+	return e.pushMetricsToExporters(ctx, md, e.metricsExportersForRoute(route))
+}
 
-	if _, ok := e.traceExporters[value]; !ok {
-		// the value has been found, but there are no exporters for the value
-		return e.pushDataToExporters(ctx, td, e.defaultTraceExporters)
+func (e *processorImp) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if e.config.AttributeSource == resourceAttributeSource {
+		return e.routeLogsByResource(ctx, ld)
 	}
+	route := e.findRoute(routeEnv{Value: e.extractValueFromContext(ctx)})
+	e.recordRouted(ctx, route, mRoutedLogRecords, ld.LogRecordCount())
+	return e.pushLogsToExporters(ctx, ld, e.logsExportersForRoute(route))
+}
 
-	// found the appropriate router, using it
-	return e.pushDataToExporters(ctx, td, e.traceExporters[value])
+// findRoute returns the name of the first route matching the environment, or an empty string when no route matches.
+// Every route has exporters for the data type of the pipeline, as checked when starting, so the first matching
+// route is always the one the data is sent to.
+func (e *processorImp) findRoute(env routeEnv) string {
+	for i := range e.routes {
+		matches, err := e.routes[i].matches(env)
+		if err != nil {
+			e.logger.Debug("failed to evaluate the route", zap.String("route", e.routes[i].name), zap.Error(err))
+			continue
+		}
+		if matches {
+			return e.routes[i].name
+		}
+	}
+	return ""
+}
+
+// traceExportersForRoute returns the exporters for the given route, or the default exporters when no route
+// has been found
+func (e *processorImp) traceExportersForRoute(route string) []component.TraceExporter {
+	if route == "" {
+		return e.defaultTraceExporters
+	}
+	return e.traceExporters[route]
+}
+
+// metricsExportersForRoute is the metrics counterpart of traceExportersForRoute
+func (e *processorImp) metricsExportersForRoute(route string) []component.MetricsExporter {
+	if route == "" {
+		return e.defaultMetricsExporters
+	}
+	return e.metricsExporters[route]
+}
+
+// logsExportersForRoute is the logs counterpart of traceExportersForRoute
+func (e *processorImp) logsExportersForRoute(route string) []component.LogsExporter {
+	if route == "" {
+		return e.defaultLogsExporters
+	}
+	return e.logsExporters[route]
+}
+
+// routeTracesByResource splits the traces per resource and groups them by route, so that each resource is sent
+// to the exporters of its own route. All the routes are attempted, even if some of them fail.
+func (e *processorImp) routeTracesByResource(ctx context.Context, td pdata.Traces) error {
+	var routes []string
+	groups := map[string]pdata.Traces{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		// an empty route stands for the default exporters
+		route := e.findRoute(e.resourceRouteEnv(rs.Resource()))
+		group, ok := groups[route]
+		if !ok {
+			group = pdata.NewTraces()
+			groups[route] = group
+			routes = append(routes, route)
+		}
+		group.ResourceSpans().Append(rs)
+	}
+
+	var errs []error
+	for _, route := range routes {
+		e.recordRouted(ctx, route, mRoutedSpans, groups[route].SpanCount())
+		if err := e.pushTracesToExporters(ctx, groups[route], e.traceExportersForRoute(route)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// routeMetricsByResource is the metrics counterpart of routeTracesByResource
+func (e *processorImp) routeMetricsByResource(ctx context.Context, md pdata.Metrics) error {
+	var routes []string
+	groups := map[string]pdata.Metrics{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
+			continue
+		}
+		// an empty route stands for the default exporters
+		route := e.findRoute(e.resourceRouteEnv(rm.Resource()))
+		group, ok := groups[route]
+		if !ok {
+			group = pdata.NewMetrics()
+			groups[route] = group
+			routes = append(routes, route)
+		}
+		group.ResourceMetrics().Append(rm)
+	}
+
+	var errs []error
+	for _, route := range routes {
+		e.recordRouted(ctx, route, mRoutedMetricsData, dataPointCount(groups[route]))
+		if err := e.pushMetricsToExporters(ctx, groups[route], e.metricsExportersForRoute(route)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// routeLogsByResource is the logs counterpart of routeTracesByResource
+func (e *processorImp) routeLogsByResource(ctx context.Context, ld pdata.Logs) error {
+	var routes []string
+	groups := map[string]pdata.Logs{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		// an empty route stands for the default exporters
+		route := e.findRoute(e.resourceRouteEnv(rl.Resource()))
+		group, ok := groups[route]
+		if !ok {
+			group = pdata.NewLogs()
+			groups[route] = group
+			routes = append(routes, route)
+		}
+		group.ResourceLogs().Append(rl)
+	}
+
+	var errs []error
+	for _, route := range routes {
+		e.recordRouted(ctx, route, mRoutedLogRecords, groups[route].LogRecordCount())
+		if err := e.pushLogsToExporters(ctx, groups[route], e.logsExportersForRoute(route)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
 }
 
 func (e *processorImp) GetCapabilities() component.ProcessorCapabilities {
 	return component.ProcessorCapabilities{MutatesConsumedData: false}
 }
 
-func (e *processorImp) pushDataToExporters(ctx context.Context, td pdata.Traces, exporters []component.TraceExporter) error {
+func (e *processorImp) pushTracesToExporters(ctx context.Context, td pdata.Traces, exporters []component.TraceExporter) error {
 	// TODO: determine the proper action when errors happen
 	for _, exp := range exporters {
 		if err := exp.ConsumeTraces(ctx, td); err != nil {
@@ -160,9 +437,37 @@ func (e *processorImp) pushDataToExporters(ctx context.Context, td pdata.Traces,
 	return nil
 }
 
+func (e *processorImp) pushMetricsToExporters(ctx context.Context, md pdata.Metrics, exporters []component.MetricsExporter) error {
+	// TODO: determine the proper action when errors happen
+	for _, exp := range exporters {
+		if err := exp.ConsumeMetrics(ctx, md); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *processorImp) pushLogsToExporters(ctx context.Context, ld pdata.Logs, exporters []component.LogsExporter) error {
+	// TODO: determine the proper action when errors happen
+	for _, exp := range exporters {
+		if err := exp.ConsumeLogs(ctx, ld); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (e *processorImp) extractValueFromContext(ctx context.Context) string {
-	// right now, we only support looking up attributes from requests that have gone through the gRPC server
-	// in that case, it will add the HTTP headers as context metadata
+	// receivers record the client information in the context, HTTP receivers included, but it only holds the client's IP
+	if strings.EqualFold(e.config.FromAttribute, conventions.AttributeNetPeerIP) {
+		if c, ok := client.FromContext(ctx); ok {
+			return c.IP
+		}
+	}
+
+	// requests that have gone through the gRPC server have the HTTP headers as context metadata
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return ""
@@ -180,3 +485,23 @@ func (e *processorImp) extractValueFromContext(ctx context.Context) string {
 
 	return values[0]
 }
+
+// resourceRouteEnv builds the environment to match the routes against from the resource attributes
+func (e *processorImp) resourceRouteEnv(resource pdata.Resource) routeEnv {
+	if resource.IsNil() {
+		return routeEnv{}
+	}
+
+	env := routeEnv{
+		Attributes: attributesToMap(resource.Attributes()),
+	}
+	if value, ok := resource.Attributes().Get(e.config.FromAttribute); ok && value.Type() == pdata.AttributeValueSTRING {
+		env.Value = value.StringVal()
+	}
+	return env
+}
+
+func dataPointCount(md pdata.Metrics) int {
+	_, count := md.MetricAndDataPointCount()
+	return count
+}
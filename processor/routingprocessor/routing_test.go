@@ -22,12 +22,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
 )
@@ -42,6 +45,7 @@ func TestRouteIsFoundForGRPCContexts(t *testing.T) {
 			FromAttribute: "X-Tenant",
 		},
 		logger: zap.NewNop(),
+		routes: []route{{name: "acme", value: "acme"}},
 		traceExporters: map[string][]component.TraceExporter{
 			"acme": {
 				&mockExporter{
@@ -110,6 +114,446 @@ func TestDefaultRouteIsUsedWhenRouteCantBeDetermined(t *testing.T) {
 	}
 }
 
+func TestMetricsAreRoutedForGRPCContexts(t *testing.T) {
+	// prepare
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	exp := &processorImp{
+		config: Config{
+			FromAttribute: "X-Tenant",
+		},
+		logger: zap.NewNop(),
+		routes: []route{{name: "acme", value: "acme"}},
+		metricsExporters: map[string][]component.MetricsExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeMetricsFunc: func(context.Context, pdata.Metrics) error {
+						wg.Done()
+						return nil
+					},
+				},
+			},
+		},
+	}
+	metrics := pdata.NewMetrics()
+
+	// test
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "acme"))
+	err := exp.ConsumeMetrics(ctx, metrics)
+
+	// verify
+	wg.Wait() // ensure that the exporter has been called
+	assert.NoError(t, err)
+}
+
+func TestLogsAreRoutedForGRPCContexts(t *testing.T) {
+	// prepare
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	exp := &processorImp{
+		config: Config{
+			FromAttribute: "X-Tenant",
+		},
+		logger: zap.NewNop(),
+		routes: []route{{name: "acme", value: "acme"}},
+		logsExporters: map[string][]component.LogsExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeLogsFunc: func(context.Context, pdata.Logs) error {
+						wg.Done()
+						return nil
+					},
+				},
+			},
+		},
+	}
+	logs := pdata.NewLogs()
+
+	// test
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "acme"))
+	err := exp.ConsumeLogs(ctx, logs)
+
+	// verify
+	wg.Wait() // ensure that the exporter has been called
+	assert.NoError(t, err)
+}
+
+func TestDefaultRouteIsUsedForMetricsAndLogs(t *testing.T) {
+	// prepare
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+
+	exp := &processorImp{
+		config: Config{
+			FromAttribute: "X-Tenant",
+		},
+		logger: zap.NewNop(),
+		defaultMetricsExporters: []component.MetricsExporter{
+			&mockExporter{
+				ConsumeMetricsFunc: func(context.Context, pdata.Metrics) error {
+					wg.Done()
+					return nil
+				},
+			},
+		},
+		defaultLogsExporters: []component.LogsExporter{
+			&mockExporter{
+				ConsumeLogsFunc: func(context.Context, pdata.Logs) error {
+					wg.Done()
+					return nil
+				},
+			},
+		},
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "globex"))
+
+	// test
+	metricsErr := exp.ConsumeMetrics(ctx, pdata.NewMetrics())
+	logsErr := exp.ConsumeLogs(ctx, pdata.NewLogs())
+
+	// verify
+	wg.Wait() // ensure that the exporters have been called
+	assert.NoError(t, metricsErr)
+	assert.NoError(t, logsErr)
+}
+
+func TestTracesAreSplitByResource(t *testing.T) {
+	// prepare
+	var acmeTraces, defaultTraces []pdata.Traces
+	exp := &processorImp{
+		config: Config{
+			AttributeSource: resourceAttributeSource,
+			FromAttribute:   "tenant",
+		},
+		logger: zap.NewNop(),
+		routes: []route{{name: "acme", value: "acme"}},
+		traceExporters: map[string][]component.TraceExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+						acmeTraces = append(acmeTraces, td)
+						return nil
+					},
+				},
+			},
+		},
+		defaultTraceExporters: []component.TraceExporter{
+			&mockExporter{
+				ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+					defaultTraces = append(defaultTraces, td)
+					return nil
+				},
+			},
+		},
+	}
+
+	traces := pdata.NewTraces()
+	rss := traces.ResourceSpans()
+	rss.Resize(4)
+	rss.At(0).Resource().InitEmpty()
+	rss.At(0).Resource().Attributes().InsertString("tenant", "acme")
+	rss.At(1).Resource().InitEmpty()
+	rss.At(1).Resource().Attributes().InsertString("tenant", "globex")
+	rss.At(2).Resource().InitEmpty()
+	rss.At(2).Resource().Attributes().InsertString("tenant", "acme")
+
+	// test
+	err := exp.ConsumeTraces(context.Background(), traces)
+
+	// verify
+	require.NoError(t, err)
+	require.Len(t, acmeTraces, 1)
+	assert.Equal(t, 2, acmeTraces[0].ResourceSpans().Len())
+	for i := 0; i < 2; i++ {
+		v, _ := acmeTraces[0].ResourceSpans().At(i).Resource().Attributes().Get("tenant")
+		assert.Equal(t, "acme", v.StringVal())
+	}
+	require.Len(t, defaultTraces, 1)
+	assert.Equal(t, 2, defaultTraces[0].ResourceSpans().Len())
+}
+
+func TestMetricsAndLogsAreSplitByResource(t *testing.T) {
+	// prepare
+	var acmeMetrics, defaultMetrics int
+	var acmeLogs, defaultLogs int
+	exp := &processorImp{
+		config: Config{
+			AttributeSource: resourceAttributeSource,
+			FromAttribute:   "tenant",
+		},
+		logger: zap.NewNop(),
+		routes: []route{{name: "acme", value: "acme"}},
+		metricsExporters: map[string][]component.MetricsExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeMetricsFunc: func(_ context.Context, md pdata.Metrics) error {
+						acmeMetrics += md.ResourceMetrics().Len()
+						return nil
+					},
+				},
+			},
+		},
+		defaultMetricsExporters: []component.MetricsExporter{
+			&mockExporter{
+				ConsumeMetricsFunc: func(_ context.Context, md pdata.Metrics) error {
+					defaultMetrics += md.ResourceMetrics().Len()
+					return nil
+				},
+			},
+		},
+		logsExporters: map[string][]component.LogsExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeLogsFunc: func(_ context.Context, ld pdata.Logs) error {
+						acmeLogs += ld.ResourceLogs().Len()
+						return nil
+					},
+				},
+			},
+		},
+		defaultLogsExporters: []component.LogsExporter{
+			&mockExporter{
+				ConsumeLogsFunc: func(_ context.Context, ld pdata.Logs) error {
+					defaultLogs += ld.ResourceLogs().Len()
+					return nil
+				},
+			},
+		},
+	}
+
+	metrics := pdata.NewMetrics()
+	rms := metrics.ResourceMetrics()
+	rms.Resize(2)
+	rms.At(0).Resource().InitEmpty()
+	rms.At(0).Resource().Attributes().InsertString("tenant", "acme")
+	rms.At(1).Resource().InitEmpty()
+	rms.At(1).Resource().Attributes().InsertString("tenant", "globex")
+
+	logs := pdata.NewLogs()
+	rls := logs.ResourceLogs()
+	rls.Resize(3)
+	rls.At(0).Resource().InitEmpty()
+	rls.At(0).Resource().Attributes().InsertString("tenant", "acme")
+	rls.At(1).Resource().InitEmpty()
+	rls.At(1).Resource().Attributes().InsertInt("tenant", 1)
+
+	// test
+	metricsErr := exp.ConsumeMetrics(context.Background(), metrics)
+	logsErr := exp.ConsumeLogs(context.Background(), logs)
+
+	// verify
+	assert.NoError(t, metricsErr)
+	assert.NoError(t, logsErr)
+	assert.Equal(t, 1, acmeMetrics)
+	assert.Equal(t, 1, defaultMetrics)
+	assert.Equal(t, 1, acmeLogs)
+	assert.Equal(t, 2, defaultLogs)
+}
+
+func TestAllResourceRoutesAreAttemptedOnFailure(t *testing.T) {
+	// prepare
+	expectedErr := errors.New("some error")
+	defaultCalled := false
+	exp := &processorImp{
+		config: Config{
+			AttributeSource: resourceAttributeSource,
+			FromAttribute:   "tenant",
+		},
+		logger: zap.NewNop(),
+		routes: []route{{name: "acme", value: "acme"}},
+		traceExporters: map[string][]component.TraceExporter{
+			"acme": {
+				&mockExporter{
+					ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
+						return expectedErr
+					},
+				},
+			},
+		},
+		defaultTraceExporters: []component.TraceExporter{
+			&mockExporter{
+				ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
+					defaultCalled = true
+					return nil
+				},
+			},
+		},
+	}
+
+	traces := pdata.NewTraces()
+	rss := traces.ResourceSpans()
+	rss.Resize(2)
+	rss.At(0).Resource().InitEmpty()
+	rss.At(0).Resource().Attributes().InsertString("tenant", "acme")
+
+	// test
+	err := exp.ConsumeTraces(context.Background(), traces)
+
+	// verify
+	assert.Equal(t, expectedErr, err)
+	assert.True(t, defaultCalled)
+}
+
+func TestFirstMatchingRouteWins(t *testing.T) {
+	// prepare
+	exp, err := newProcessor(zap.NewNop(), &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "routing/first_match",
+			TypeVal: "routing",
+		},
+		AttributeSource:  resourceAttributeSource,
+		FromAttribute:    "tenant",
+		DefaultExporters: []string{"default"},
+		Table: []RoutingTableItem{
+			{
+				Name:       "dev",
+				Expression: `attributes["env"] == "dev"`,
+				Exporters:  []string{"dev"},
+			},
+			{
+				Regex:     "^team-",
+				Exporters: []string{"teams"},
+			},
+			{
+				Value:     "team-acme",
+				Exporters: []string{"acme"},
+			},
+		},
+	}, configmodels.TracesDataType)
+	require.NoError(t, err)
+
+	counts := map[string]int{}
+	host := &mockHost{
+		GetExportersFunc: func() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+			exporters := map[configmodels.Exporter]component.Exporter{}
+			for _, name := range []string{"default", "dev", "teams", "acme"} {
+				name := name
+				cfg := &otlpexporter.Config{
+					ExporterSettings: configmodels.ExporterSettings{
+						NameVal: name,
+						TypeVal: "otlp",
+					},
+				}
+				exporters[cfg] = &mockExporter{
+					ConsumeTracesFunc: func(_ context.Context, td pdata.Traces) error {
+						counts[name] += td.SpanCount()
+						return nil
+					},
+				}
+			}
+			return map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{
+				configmodels.TracesDataType: exporters,
+			}
+		},
+	}
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	traces := pdata.NewTraces()
+	rss := traces.ResourceSpans()
+	rss.Resize(3)
+	for i, attrs := range []map[string]string{
+		{"tenant": "team-acme", "env": "dev"},
+		{"tenant": "team-acme", "env": "prod"},
+		{"tenant": "globex", "env": "prod"},
+	} {
+		rs := rss.At(i)
+		rs.Resource().InitEmpty()
+		for k, v := range attrs {
+			rs.Resource().Attributes().InsertString(k, v)
+		}
+		rs.InstrumentationLibrarySpans().Resize(1)
+		rs.InstrumentationLibrarySpans().At(0).Spans().Resize(1)
+	}
+
+	// test
+	err = exp.ConsumeTraces(context.Background(), traces)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"dev": 1, "teams": 1, "default": 1}, counts)
+
+	rows, err := view.RetrieveData(obsreport.BuildProcessorCustomMetricName(typeStr, "routed_spans"))
+	require.NoError(t, err)
+	routed := map[string]float64{}
+	for _, row := range rows {
+		var processor, route string
+		for _, tag := range row.Tags {
+			switch tag.Key {
+			case tagProcessorKey:
+				processor = tag.Value
+			case tagRouteKey:
+				route = tag.Value
+			}
+		}
+		if processor == "routing/first_match" {
+			routed[route] = row.Data.(*view.SumData).Value
+		}
+	}
+	assert.Equal(t, map[string]float64{
+		"dev":     1,
+		"1":       1,
+		"default": 1,
+	}, routed)
+}
+
+func TestErrorDuplicateRouteName(t *testing.T) {
+	for _, name := range []string{"1", "default"} {
+		_, err := newProcessor(zap.NewNop(), &Config{
+			FromAttribute: "X-Tenant",
+			Table: []RoutingTableItem{
+				{
+					Value:     "acme",
+					Exporters: []string{"otlp"},
+				},
+				{
+					Value:     "globex",
+					Exporters: []string{"otlp"},
+				},
+				{
+					Name:      name,
+					Value:     "initech",
+					Exporters: []string{"otlp"},
+				},
+			},
+		}, configmodels.TracesDataType)
+		assert.True(t, errors.Is(err, errDuplicateRouteName))
+	}
+}
+
+func TestRouteIsFoundForClientIP(t *testing.T) {
+	// prepare
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	exp := &processorImp{
+		config: Config{
+			FromAttribute: "net.peer.ip",
+		},
+		logger: zap.NewNop(),
+		routes: []route{{name: "10.0.0.1", value: "10.0.0.1"}},
+		traceExporters: map[string][]component.TraceExporter{
+			"10.0.0.1": {
+				&mockExporter{
+					ConsumeTracesFunc: func(context.Context, pdata.Traces) error {
+						wg.Done()
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	// test
+	ctx := client.NewContext(context.Background(), &client.Client{IP: "10.0.0.1"})
+	err := exp.ConsumeTraces(ctx, pdata.NewTraces())
+
+	// verify
+	wg.Wait() // ensure that the exporter has been called
+	assert.NoError(t, err)
+}
+
 func TestRegisterExportersForValidRoute(t *testing.T) {
 	//  prepare
 	exp, err := newProcessor(zap.NewNop(), &Config{
@@ -121,7 +565,7 @@ func TestRegisterExportersForValidRoute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 
 	otlpExpFactory := otlpexporter.NewFactory()
@@ -151,7 +595,72 @@ func TestRegisterExportersForValidRoute(t *testing.T) {
 	exp.Start(context.Background(), host)
 
 	// verify
-	assert.Contains(t, exp.traceExporters["acme"], otlpExp)
+	assert.Contains(t, exp.traceExporters["0"], otlpExp)
+}
+
+func TestRegisterExportersPerDataType(t *testing.T) {
+	//  prepare
+	exp, err := newProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		FromAttribute:    "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp", "metrics/acme", "logs/acme"},
+			},
+		},
+	}, configmodels.TracesDataType)
+	require.NoError(t, err)
+
+	otlpConfig := &otlpexporter.Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "otlp",
+			TypeVal: "otlp",
+		},
+	}
+	metricsConfig := &otlpexporter.Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "metrics/acme",
+			TypeVal: "otlp",
+		},
+	}
+	logsConfig := &otlpexporter.Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "logs/acme",
+			TypeVal: "otlp",
+		},
+	}
+	otlpExp := &mockExporter{}
+	metricsExp := &mockExporter{}
+	logsExp := &mockExporter{}
+	host := &mockHost{
+		GetExportersFunc: func() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+			return map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{
+				configmodels.TracesDataType: {
+					otlpConfig: otlpExp,
+				},
+				configmodels.MetricsDataType: {
+					otlpConfig:    otlpExp,
+					metricsConfig: metricsExp,
+				},
+				configmodels.LogsDataType: {
+					logsConfig: logsExp,
+				},
+			}
+		},
+	}
+
+	// test
+	err = exp.Start(context.Background(), host)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []component.TraceExporter{otlpExp}, exp.defaultTraceExporters)
+	assert.Equal(t, []component.MetricsExporter{otlpExp}, exp.defaultMetricsExporters)
+	assert.Empty(t, exp.defaultLogsExporters)
+	assert.Equal(t, []component.TraceExporter{otlpExp}, exp.traceExporters["0"])
+	assert.Equal(t, []component.MetricsExporter{otlpExp, metricsExp}, exp.metricsExporters["0"])
+	assert.Equal(t, []component.LogsExporter{logsExp}, exp.logsExporters["0"])
 }
 
 func TestErrorRequestedExporterNotFoundForRoute(t *testing.T) {
@@ -164,7 +673,7 @@ func TestErrorRequestedExporterNotFoundForRoute(t *testing.T) {
 				Exporters: []string{"non-existing"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	host := &mockHost{}
 
@@ -175,6 +684,53 @@ func TestErrorRequestedExporterNotFoundForRoute(t *testing.T) {
 	assert.True(t, errors.Is(err, errExporterNotFound))
 }
 
+func TestErrorNoExportersForDataType(t *testing.T) {
+	//  prepare
+	exp, err := newProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		FromAttribute:    "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"jaeger/acme"},
+			},
+		},
+	}, configmodels.MetricsDataType)
+	require.NoError(t, err)
+
+	otlpConfig := &otlpexporter.Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "otlp",
+			TypeVal: "otlp",
+		},
+	}
+	jaegerConfig := &otlpexporter.Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "jaeger/acme",
+			TypeVal: "jaeger",
+		},
+	}
+	host := &mockHost{
+		GetExportersFunc: func() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+			return map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{
+				configmodels.TracesDataType: {
+					otlpConfig:   &mockExporter{},
+					jaegerConfig: &mockExporter{},
+				},
+				configmodels.MetricsDataType: {
+					otlpConfig: &mockExporter{},
+				},
+			}
+		},
+	}
+
+	// test
+	err = exp.Start(context.Background(), host)
+
+	// verify
+	assert.True(t, errors.Is(err, errNoExportersForDataType))
+}
+
 func TestErrorRequestedExporterNotFoundForDefaultRoute(t *testing.T) {
 	//  prepare
 	exp, err := newProcessor(zap.NewNop(), &Config{
@@ -186,7 +742,7 @@ func TestErrorRequestedExporterNotFoundForDefaultRoute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 
 	otlpExpFactory := otlpexporter.NewFactory()
@@ -230,7 +786,7 @@ func TestInvalidExporter(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 
 	otlpConfig := &otlpexporter.Config{
@@ -256,6 +812,43 @@ func TestInvalidExporter(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestInvalidMetricsExporter(t *testing.T) {
+	//  prepare
+	exp, err := newProcessor(zap.NewNop(), &Config{
+		DefaultExporters: []string{"otlp"},
+		FromAttribute:    "X-Tenant",
+		Table: []RoutingTableItem{
+			{
+				Value:     "acme",
+				Exporters: []string{"otlp"},
+			},
+		},
+	}, configmodels.TracesDataType)
+	require.NoError(t, err)
+
+	otlpConfig := &otlpexporter.Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			NameVal: "otlp",
+			TypeVal: "otlp",
+		},
+	}
+	host := &mockHost{
+		GetExportersFunc: func() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+			return map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{
+				configmodels.MetricsDataType: {
+					otlpConfig: &mockComponent{},
+				},
+			}
+		},
+	}
+
+	// test
+	err = exp.Start(context.Background(), host)
+
+	// verify
+	assert.EqualError(t, err, `the exporter "otlp" isn't a metrics exporter`)
+}
+
 func TestValueFromExistingGRPCAttribute(t *testing.T) {
 	// prepare
 	exp, err := newProcessor(zap.NewNop(), &Config{
@@ -267,7 +860,7 @@ func TestValueFromExistingGRPCAttribute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "acme"))
 
@@ -289,7 +882,7 @@ func TestMultipleValuesFromExistingGRPCAttribute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", "globex", "X-Tenant", "acme"))
 
@@ -311,7 +904,7 @@ func TestNoValuesFromExistingGRPCAttribute(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", ""))
 
@@ -333,7 +926,7 @@ func TestAttributeFromExistingGRPCContext(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{}))
 
@@ -355,7 +948,7 @@ func TestNoAttributeInContext(t *testing.T) {
 				Exporters: []string{"otlp"},
 			},
 		},
-	})
+	}, configmodels.TracesDataType)
 	require.NoError(t, err)
 
 	// test
@@ -392,7 +985,7 @@ func TestFailedToPushDataToExporter(t *testing.T) {
 	traces := pdata.NewTraces()
 
 	// test
-	err := exp.pushDataToExporters(context.Background(), traces, exp.traceExporters["acme"])
+	err := exp.pushTracesToExporters(context.Background(), traces, exp.traceExporters["acme"])
 
 	// verify
 	wg.Wait() // ensure that the exporter has been called
@@ -404,12 +997,13 @@ func TestProcessorCapabilities(t *testing.T) {
 	config := &Config{
 		FromAttribute: "X-Tenant",
 		Table: []RoutingTableItem{{
+			Value:     "acme",
 			Exporters: []string{"otlp"},
 		}},
 	}
 
 	// test
-	p, err := newProcessor(zap.NewNop(), config)
+	p, err := newProcessor(zap.NewNop(), config, configmodels.TracesDataType)
 	caps := p.GetCapabilities()
 
 	// verify
@@ -441,7 +1035,9 @@ func (m *mockComponent) Shutdown(context.Context) error {
 
 type mockExporter struct {
 	mockComponent
-	ConsumeTracesFunc func(ctx context.Context, td pdata.Traces) error
+	ConsumeTracesFunc  func(ctx context.Context, td pdata.Traces) error
+	ConsumeMetricsFunc func(ctx context.Context, md pdata.Metrics) error
+	ConsumeLogsFunc    func(ctx context.Context, ld pdata.Logs) error
 }
 
 func (m *mockExporter) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
@@ -450,3 +1046,17 @@ func (m *mockExporter) ConsumeTraces(ctx context.Context, td pdata.Traces) error
 	}
 	return nil
 }
+
+func (m *mockExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if m.ConsumeMetricsFunc != nil {
+		return m.ConsumeMetricsFunc(ctx, md)
+	}
+	return nil
+}
+
+func (m *mockExporter) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if m.ConsumeLogsFunc != nil {
+		return m.ConsumeLogsFunc(ctx, ld)
+	}
+	return nil
+}
@@ -17,38 +17,37 @@ package awsecscontainermetrics
 import (
 	"time"
 
-	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
-	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
-	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 // metricDataAccumulator defines the accumulator
 type metricDataAccumulator struct {
-	md []*consumerdata.MetricsData
+	mds []pdata.Metrics
 }
 
 // getMetricsData generates OT Metrics data from task metadata and docker stats
 func (acc *metricDataAccumulator) getMetricsData(containerStatsMap map[string]ContainerStats, metadata TaskMetadata) {
 
 	taskMetrics := ECSMetrics{}
-	timestamp := timestampProto(time.Now())
+	timestamp := pdata.TimestampUnixNano(uint64(time.Now().UnixNano()))
 	taskResource := taskResource(metadata)
 
 	for _, containerMetadata := range metadata.Containers {
 		stats := containerStatsMap[containerMetadata.DockerID]
 		containerMetrics := getContainerMetrics(stats)
-		containerMetrics.MemoryReserved = *containerMetadata.Limits.Memory
-		containerMetrics.CPUReserved = *containerMetadata.Limits.CPU
+		if containerMetadata.Limits.Memory != nil {
+			containerMetrics.MemoryReserved = *containerMetadata.Limits.Memory
+		}
+		if containerMetadata.Limits.CPU != nil {
+			containerMetrics.CPUReserved = *containerMetadata.Limits.CPU
+		}
 
 		containerResource := containerResource(containerMetadata)
-		for k, v := range taskResource.Labels {
-			containerResource.Labels[k] = v
-		}
+		taskResource.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+			containerResource.Attributes().Upsert(k, v)
+		})
 
-		acc.accumulate(
-			containerResource,
-			convertToOCMetrics(ContainerPrefix, containerMetrics, nil, nil, timestamp),
-		)
+		acc.accumulate(convertToOTLPMetrics(ContainerPrefix, containerMetrics, containerResource, timestamp))
 
 		aggregateTaskMetrics(&taskMetrics, containerMetrics)
 	}
@@ -65,27 +64,9 @@ func (acc *metricDataAccumulator) getMetricsData(containerStatsMap map[string]Co
 		taskMetrics.CPUReserved = *metadata.Limits.CPU
 	}
 
-	acc.accumulate(
-		taskResource,
-		convertToOCMetrics(TaskPrefix, taskMetrics, nil, nil, timestamp),
-	)
+	acc.accumulate(convertToOTLPMetrics(TaskPrefix, taskMetrics, taskResource, timestamp))
 }
 
-func (acc *metricDataAccumulator) accumulate(
-	r *resourcepb.Resource,
-	m ...[]*metricspb.Metric,
-) {
-	var resourceMetrics []*metricspb.Metric
-	for _, metrics := range m {
-		for _, metric := range metrics {
-			if metric != nil {
-				resourceMetrics = append(resourceMetrics, metric)
-			}
-		}
-	}
-
-	acc.md = append(acc.md, &consumerdata.MetricsData{
-		Metrics:  resourceMetrics,
-		Resource: r,
-	})
+func (acc *metricDataAccumulator) accumulate(md pdata.Metrics) {
+	acc.mds = append(acc.mds, md)
 }
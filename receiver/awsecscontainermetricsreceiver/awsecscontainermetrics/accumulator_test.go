@@ -18,7 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 func TestGetMetricsData(t *testing.T) {
@@ -101,11 +101,11 @@ func TestGetMetricsData(t *testing.T) {
 	cstats := make(map[string]ContainerStats)
 	cstats["001"] = containerStats
 
-	var mds []*consumerdata.MetricsData
+	var mds []pdata.Metrics
 	acc := metricDataAccumulator{
-		md: mds,
+		mds: mds,
 	}
 
 	acc.getMetricsData(cstats, tm)
-	require.Less(t, 0, len(acc.md))
+	require.Less(t, 0, len(acc.mds))
 }
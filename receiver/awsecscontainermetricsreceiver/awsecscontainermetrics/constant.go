@@ -15,22 +15,26 @@
 package awsecscontainermetrics
 
 const (
-	AttributeECSDockerName   = "ecs.docker-name"
-	AttributeECSCluster      = "ecs.cluster"
-	AttributeECSTaskARN      = "ecs.task-arn"
-	AttributeECSTaskID       = "ecs.task-id"
-	AttributeECSTaskFamily   = "ecs.task-definition-family"
-	AttributeECSTaskRevesion = "ecs.task-definition-version"
-	AttributeECSServiceName  = "ecs.service"
+	AttributeECSDockerName           = "aws.ecs.docker.name"
+	AttributeECSContainerKnownStatus = "aws.ecs.container.known_status"
+	AttributeECSCluster              = "aws.ecs.cluster.name"
+	AttributeECSClusterARN           = "aws.ecs.cluster.arn"
+	AttributeECSTaskARN              = "aws.ecs.task.arn"
+	AttributeECSTaskID               = "aws.ecs.task.id"
+	AttributeECSTaskFamily           = "aws.ecs.task.family"
+	AttributeECSTaskRevision         = "aws.ecs.task.revision"
+	AttributeECSTaskKnownStatus      = "aws.ecs.task.known_status"
+	AttributeECSLaunchType           = "aws.ecs.launchtype"
+	AttributeECSServiceName          = "aws.ecs.service.name"
 
 	CPUsInVCpu = 1024
 	BytesInMiB = 1024 * 1024
 
-	TaskPrefix         = "ecs.task."
-	ContainerPrefix    = "container."
-	MetricResourceType = "aoc.ecs"
+	TaskPrefix      = "ecs.task."
+	ContainerPrefix = "container."
 
 	EndpointEnvKey   = "ECS_CONTAINER_METADATA_URI_V4"
+	EndpointV3EnvKey = "ECS_CONTAINER_METADATA_URI"
 	TaskStatsPath    = "/task/stats"
 	TaskMetadataPath = "/task"
 
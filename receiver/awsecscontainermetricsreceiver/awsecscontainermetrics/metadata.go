@@ -16,10 +16,14 @@ package awsecscontainermetrics
 
 // TaskMetadata defines task metadata for a task
 type TaskMetadata struct {
-	Cluster  string `json:"Cluster,omitempty"`
-	TaskARN  string `json:"TaskARN,omitempty"`
-	Family   string `json:"Family,omitempty"`
-	Revision string `json:"Revision,omitempty"`
+	Cluster     string `json:"Cluster,omitempty"`
+	TaskARN     string `json:"TaskARN,omitempty"`
+	Family      string `json:"Family,omitempty"`
+	Revision    string `json:"Revision,omitempty"`
+	KnownStatus string `json:"KnownStatus,omitempty"`
+	// Only reported by the task metadata endpoint v4.
+	AvailabilityZone string `json:"AvailabilityZone,omitempty"`
+	LaunchType       string `json:"LaunchType,omitempty"`
 
 	Limits     Limit               `json:"Limits,omitempty"`
 	Containers []ContainerMetadata `json:"Containers,omitempty"`
@@ -31,6 +35,7 @@ type ContainerMetadata struct {
 	ContainerName string            `json:"Name,omitempty"`
 	DockerName    string            `json:"DockerName,omitempty"`
 	Image         string            `json:"Image,omitempty"`
+	KnownStatus   string            `json:"KnownStatus,omitempty"`
 	Labels        map[string]string `json:"Labels,omitempty"`
 	Limits        Limit             `json:"Limits,omitempty"`
 }
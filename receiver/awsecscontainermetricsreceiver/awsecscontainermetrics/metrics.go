@@ -15,12 +15,14 @@
 package awsecscontainermetrics
 
 import (
-	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
-func MetricsData(containerStatsMap map[string]ContainerStats, metadata TaskMetadata) []*consumerdata.MetricsData {
+// MetricsData generates the metrics of the task and of each of its containers,
+// with a resource describing the task and the container.
+func MetricsData(containerStatsMap map[string]ContainerStats, metadata TaskMetadata) []pdata.Metrics {
 	acc := &metricDataAccumulator{}
 	acc.getMetricsData(containerStatsMap, metadata)
 
-	return acc.md
+	return acc.mds
 }
@@ -14,14 +14,25 @@
 
 package awsecscontainermetrics
 
-// getContainerMetrics generate ECS Container metrics from Container stats
+// getContainerMetrics generate ECS Container metrics from Container stats. The
+// stats missing from the response, e.g. the network rates which the task
+// metadata endpoint v3 doesn't report, or the stats of a stopped container, are
+// reported as zero.
 func getContainerMetrics(stats ContainerStats) ECSMetrics {
-	memoryUtilizedInMb := (*stats.Memory.Usage - stats.Memory.Stats["cache"]) / BytesInMiB
+	// The page cache can momentarily exceed the usage, don't let the difference
+	// wrap around.
+	var memoryUtilizedInMb uint64
+	if usage, cache := uint64Value(stats.Memory.Usage), stats.Memory.Stats["cache"]; usage > cache {
+		memoryUtilizedInMb = (usage - cache) / BytesInMiB
+	}
 
 	numOfCores := (uint64)(len(stats.CPU.CPUUsage.PerCPUUsage))
 
 	// TODO: match with ECS Agent calculation and modify if needed
-	cpuUtilized := (float64)(*stats.CPU.CPUUsage.TotalUsage / numOfCores / 1024)
+	var cpuUtilized float64
+	if numOfCores > 0 {
+		cpuUtilized = (float64)(uint64Value(stats.CPU.CPUUsage.TotalUsage) / numOfCores / 1024)
+	}
 
 	netStatArray := getNetworkStats(stats.Network)
 
@@ -29,21 +40,21 @@ func getContainerMetrics(stats ContainerStats) ECSMetrics {
 
 	m := ECSMetrics{}
 
-	m.MemoryUsage = *stats.Memory.Usage
-	m.MemoryMaxUsage = *stats.Memory.MaxUsage
-	m.MemoryLimit = *stats.Memory.Limit
+	m.MemoryUsage = uint64Value(stats.Memory.Usage)
+	m.MemoryMaxUsage = uint64Value(stats.Memory.MaxUsage)
+	m.MemoryLimit = uint64Value(stats.Memory.Limit)
 	m.MemoryUtilized = memoryUtilizedInMb
 
-	m.CPUTotalUsage = *stats.CPU.CPUUsage.TotalUsage
-	m.CPUUsageInKernelmode = *stats.CPU.CPUUsage.UsageInKernelmode
-	m.CPUUsageInUserMode = *stats.CPU.CPUUsage.UsageInUserMode
+	m.CPUTotalUsage = uint64Value(stats.CPU.CPUUsage.TotalUsage)
+	m.CPUUsageInKernelmode = uint64Value(stats.CPU.CPUUsage.UsageInKernelmode)
+	m.CPUUsageInUserMode = uint64Value(stats.CPU.CPUUsage.UsageInUserMode)
 	m.NumOfCPUCores = numOfCores
-	m.CPUOnlineCpus = *stats.CPU.OnlineCpus
-	m.SystemCPUUsage = *stats.CPU.SystemCPUUsage
+	m.CPUOnlineCpus = uint64Value(stats.CPU.OnlineCpus)
+	m.SystemCPUUsage = uint64Value(stats.CPU.SystemCPUUsage)
 	m.CPUUtilized = cpuUtilized
 
-	m.NetworkRateRxBytesPerSecond = *stats.NetworkRate.TxBytesPerSecond
-	m.NetworkRateTxBytesPerSecond = *stats.NetworkRate.TxBytesPerSecond
+	m.NetworkRateRxBytesPerSecond = float64Value(stats.NetworkRate.RxBytesPerSecond)
+	m.NetworkRateTxBytesPerSecond = float64Value(stats.NetworkRate.TxBytesPerSecond)
 
 	m.NetworkRxBytes = netStatArray[0]
 	m.NetworkRxPackets = netStatArray[1]
@@ -65,15 +76,15 @@ func getContainerMetrics(stats ContainerStats) ECSMetrics {
 func getNetworkStats(stats map[string]NetworkStats) [8]uint64 {
 	var netStatArray [8]uint64
 	for _, netStat := range stats {
-		netStatArray[0] += *netStat.RxBytes
-		netStatArray[1] += *netStat.RxPackets
-		netStatArray[2] += *netStat.RxErrors
-		netStatArray[3] += *netStat.RxDropped
-
-		netStatArray[4] += *netStat.TxBytes
-		netStatArray[5] += *netStat.TxPackets
-		netStatArray[6] += *netStat.TxErrors
-		netStatArray[7] += *netStat.TxDropped
+		netStatArray[0] += uint64Value(netStat.RxBytes)
+		netStatArray[1] += uint64Value(netStat.RxPackets)
+		netStatArray[2] += uint64Value(netStat.RxErrors)
+		netStatArray[3] += uint64Value(netStat.RxDropped)
+
+		netStatArray[4] += uint64Value(netStat.TxBytes)
+		netStatArray[5] += uint64Value(netStat.TxPackets)
+		netStatArray[6] += uint64Value(netStat.TxErrors)
+		netStatArray[7] += uint64Value(netStat.TxDropped)
 	}
 	return netStatArray
 }
@@ -89,9 +100,9 @@ func extractStorageUsage(stats *DiskStats) (uint64, uint64) {
 	for _, blockStat := range stats.IoServiceBytesRecursives {
 		switch op := blockStat.Op; op {
 		case "Read":
-			readBytes = *blockStat.Value
+			readBytes = uint64Value(blockStat.Value)
 		case "Write":
-			writeBytes = *blockStat.Value
+			writeBytes = uint64Value(blockStat.Value)
 		default:
 			//ignoring "Async", "Total", "Sum", etc
 			continue
@@ -132,3 +143,17 @@ func aggregateTaskMetrics(taskMetrics *ECSMetrics, conMetrics ECSMetrics) {
 	taskMetrics.StorageReadBytes += conMetrics.StorageReadBytes
 	taskMetrics.StorageWriteBytes += conMetrics.StorageWriteBytes
 }
+
+func uint64Value(v *uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func float64Value(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
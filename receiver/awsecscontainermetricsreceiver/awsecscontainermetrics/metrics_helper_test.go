@@ -138,3 +138,22 @@ func TestGetNetworkStats(t *testing.T) {
 	}
 	require.EqualValues(t, 800, sum)
 }
+
+func TestGetContainerMetricsWithMissingStats(t *testing.T) {
+	// e.g. the stats of a stopped container, or the network rates which the
+	// task metadata endpoint v3 doesn't report
+	containerMetrics := getContainerMetrics(ContainerStats{})
+	require.EqualValues(t, ECSMetrics{}, containerMetrics)
+}
+
+func TestGetContainerMetricsCacheAboveUsage(t *testing.T) {
+	usage := uint64(1024)
+	containerMetrics := getContainerMetrics(ContainerStats{
+		Memory: MemoryStats{
+			Usage: &usage,
+			Stats: map[string]uint64{"cache": 2048},
+		},
+	})
+	require.EqualValues(t, 0, containerMetrics.MemoryUtilized)
+	require.EqualValues(t, 1024, containerMetrics.MemoryUsage)
+}
@@ -17,34 +17,54 @@ package awsecscontainermetrics
 import (
 	"strings"
 
-	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/containerimage"
 )
 
-func containerResource(cm ContainerMetadata) *resourcepb.Resource {
-	labels := map[string]string{}
+func containerResource(cm ContainerMetadata) pdata.Resource {
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	attrs := resource.Attributes()
 
-	labels[conventions.AttributeContainerName] = cm.ContainerName
-	labels[conventions.AttributeContainerID] = cm.DockerID
-	labels[AttributeECSDockerName] = cm.DockerName
-	return &resourcepb.Resource{
-		Type:   MetricResourceType,
-		Labels: labels,
+	attrs.UpsertString(conventions.AttributeContainerName, cm.ContainerName)
+	attrs.UpsertString(conventions.AttributeContainerID, cm.DockerID)
+	attrs.UpsertString(AttributeECSDockerName, cm.DockerName)
+	if cm.Image != "" {
+		name, tag := containerimage.Parse(cm.Image)
+		attrs.UpsertString(conventions.AttributeContainerImage, name)
+		if tag != "" {
+			attrs.UpsertString(conventions.AttributeContainerTag, tag)
+		}
 	}
+	insertIfNotEmpty(attrs, AttributeECSContainerKnownStatus, cm.KnownStatus)
+	return resource
+}
+
+func taskResource(tm TaskMetadata) pdata.Resource {
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	attrs := resource.Attributes()
+
+	attrs.UpsertString(AttributeECSCluster, getNameFromCluster(tm.Cluster))
+	insertIfNotEmpty(attrs, AttributeECSClusterARN, getClusterARN(tm.Cluster, tm.TaskARN))
+	attrs.UpsertString(AttributeECSTaskARN, tm.TaskARN)
+	attrs.UpsertString(AttributeECSTaskID, getTaskIDFromARN(tm.TaskARN))
+	attrs.UpsertString(AttributeECSTaskFamily, tm.Family)
+	attrs.UpsertString(AttributeECSTaskRevision, tm.Revision)
+	attrs.UpsertString(AttributeECSServiceName, "undefined")
+	insertIfNotEmpty(attrs, AttributeECSTaskKnownStatus, tm.KnownStatus)
+	// The launch type and the availability zone are only reported by the task
+	// metadata endpoint v4.
+	insertIfNotEmpty(attrs, AttributeECSLaunchType, strings.ToLower(tm.LaunchType))
+	insertIfNotEmpty(attrs, conventions.AttributeCloudZone, tm.AvailabilityZone)
+	return resource
 }
 
-func taskResource(tm TaskMetadata) *resourcepb.Resource {
-	labels := map[string]string{}
-
-	labels[AttributeECSCluster] = tm.Cluster
-	labels[AttributeECSTaskARN] = tm.TaskARN
-	labels[AttributeECSTaskID] = getTaskIDFromARN(tm.TaskARN)
-	labels[AttributeECSTaskFamily] = tm.Family
-	labels[AttributeECSTaskRevesion] = tm.Revision
-	labels[AttributeECSServiceName] = "undefined"
-	return &resourcepb.Resource{
-		Type:   MetricResourceType,
-		Labels: labels,
+func insertIfNotEmpty(attrs pdata.AttributeMap, key string, value string) {
+	if value != "" {
+		attrs.UpsertString(key, value)
 	}
 }
 
@@ -56,3 +76,27 @@ func getTaskIDFromARN(arn string) string {
 
 	return splits[len(splits)-1]
 }
+
+// getNameFromCluster returns the name of the cluster, which the task metadata
+// endpoint reports either by name or by ARN.
+func getNameFromCluster(cluster string) string {
+	if !strings.HasPrefix(cluster, "arn:aws") {
+		return cluster
+	}
+	splits := strings.Split(cluster, "/")
+
+	return splits[len(splits)-1]
+}
+
+// getClusterARN returns the ARN of the cluster, building it from the partition,
+// region and account of the task if the cluster is reported by name.
+func getClusterARN(cluster string, taskARN string) string {
+	if cluster == "" || strings.HasPrefix(cluster, "arn:aws") {
+		return cluster
+	}
+	i := strings.Index(taskARN, ":task/")
+	if i < 0 || !strings.HasPrefix(taskARN, "arn:aws") {
+		return ""
+	}
+	return taskARN[:i] + ":cluster/" + cluster
+}
@@ -18,27 +18,80 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/translator/conventions"
 )
 
+func attributesToMap(attrs pdata.AttributeMap) map[string]string {
+	out := map[string]string{}
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		out[k] = v.StringVal()
+	})
+	return out
+}
+
 func TestContainerResource(t *testing.T) {
 	cm := ContainerMetadata{
 		ContainerName: "container-1",
 		DockerID:      "001",
 		DockerName:    "docker-container-1",
+		Image:         "111122223333.dkr.ecr.us-west-2.amazonaws.com/app:1.2",
+		KnownStatus:   "RUNNING",
 	}
 	r := containerResource(cm)
-	require.NotNil(t, r)
+	require.False(t, r.IsNil())
 
-	labels := r.Labels
-	require.EqualValues(t, 3, len(labels))
+	require.EqualValues(t, map[string]string{
+		conventions.AttributeContainerName:  "container-1",
+		conventions.AttributeContainerID:    "001",
+		AttributeECSDockerName:              "docker-container-1",
+		conventions.AttributeContainerImage: "111122223333.dkr.ecr.us-west-2.amazonaws.com/app",
+		conventions.AttributeContainerTag:   "1.2",
+		AttributeECSContainerKnownStatus:    "RUNNING",
+	}, attributesToMap(r.Attributes()))
+}
 
-	require.EqualValues(t, "container-1", labels[conventions.AttributeContainerName])
-	require.EqualValues(t, "001", labels[conventions.AttributeContainerID])
-	require.EqualValues(t, "docker-container-1", labels[AttributeECSDockerName])
+func TestContainerResourceWithoutImage(t *testing.T) {
+	cm := ContainerMetadata{
+		ContainerName: "container-1",
+		DockerID:      "001",
+		DockerName:    "docker-container-1",
+	}
+	r := containerResource(cm)
+	require.False(t, r.IsNil())
+	require.EqualValues(t, 3, r.Attributes().Len())
 }
 
 func TestTaskResource(t *testing.T) {
+	tm := TaskMetadata{
+		Cluster:          "cluster-1",
+		TaskARN:          "arn:aws:ecs:us-west-2:111122223333:task/cluster-1/001",
+		Family:           "task-def-family-1",
+		Revision:         "task-def-version-1",
+		KnownStatus:      "RUNNING",
+		AvailabilityZone: "us-west-2a",
+		LaunchType:       "FARGATE",
+	}
+	r := taskResource(tm)
+	require.False(t, r.IsNil())
+
+	require.EqualValues(t, map[string]string{
+		AttributeECSCluster:            "cluster-1",
+		AttributeECSClusterARN:         "arn:aws:ecs:us-west-2:111122223333:cluster/cluster-1",
+		AttributeECSTaskARN:            "arn:aws:ecs:us-west-2:111122223333:task/cluster-1/001",
+		AttributeECSTaskID:             "001",
+		AttributeECSTaskFamily:         "task-def-family-1",
+		AttributeECSTaskRevision:       "task-def-version-1",
+		AttributeECSServiceName:        "undefined",
+		AttributeECSTaskKnownStatus:    "RUNNING",
+		AttributeECSLaunchType:         "fargate",
+		conventions.AttributeCloudZone: "us-west-2a",
+	}, attributesToMap(r.Attributes()))
+}
+
+func TestTaskResourceV3(t *testing.T) {
+	// The task metadata endpoint v3 doesn't report the launch type and the
+	// availability zone.
 	tm := TaskMetadata{
 		Cluster:  "cluster-1",
 		TaskARN:  "arn:aws:some-value/001",
@@ -46,16 +99,15 @@ func TestTaskResource(t *testing.T) {
 		Revision: "task-def-version-1",
 	}
 	r := taskResource(tm)
-	require.NotNil(t, r)
+	require.False(t, r.IsNil())
 
-	labels := r.Labels
-	require.EqualValues(t, 6, len(labels))
-
-	require.EqualValues(t, "cluster-1", labels[AttributeECSCluster])
-	require.EqualValues(t, "arn:aws:some-value/001", labels[AttributeECSTaskARN])
-	require.EqualValues(t, "001", labels[AttributeECSTaskID])
-	require.EqualValues(t, "task-def-family-1", labels[AttributeECSTaskFamily])
-	require.EqualValues(t, "task-def-version-1", labels[AttributeECSTaskRevesion])
+	attrs := attributesToMap(r.Attributes())
+	require.EqualValues(t, 6, len(attrs))
+	require.EqualValues(t, "cluster-1", attrs[AttributeECSCluster])
+	require.EqualValues(t, "arn:aws:some-value/001", attrs[AttributeECSTaskARN])
+	require.EqualValues(t, "001", attrs[AttributeECSTaskID])
+	require.EqualValues(t, "task-def-family-1", attrs[AttributeECSTaskFamily])
+	require.EqualValues(t, "task-def-version-1", attrs[AttributeECSTaskRevision])
 }
 
 func TestGetTaskIDFromARN(t *testing.T) {
@@ -68,3 +120,15 @@ func TestGetTaskIDFromARN(t *testing.T) {
 	id = getTaskIDFromARN("")
 	require.LessOrEqual(t, 0, len(id))
 }
+
+func TestGetClusterARN(t *testing.T) {
+	taskARN := "arn:aws:ecs:us-west-2:111122223333:task/default/001"
+
+	require.EqualValues(t, "arn:aws:ecs:us-west-2:111122223333:cluster/default", getClusterARN("default", taskARN))
+	require.EqualValues(t, "arn:aws:ecs:us-west-2:111122223333:cluster/other", getClusterARN("arn:aws:ecs:us-west-2:111122223333:cluster/other", taskARN))
+	require.EqualValues(t, "", getClusterARN("default", "not-an-arn"))
+	require.EqualValues(t, "", getClusterARN("", taskARN))
+
+	require.EqualValues(t, "other", getNameFromCluster("arn:aws:ecs:us-west-2:111122223333:cluster/other"))
+	require.EqualValues(t, "default", getNameFromCluster("default"))
+}
@@ -15,107 +15,92 @@
 package awsecscontainermetrics
 
 import (
-	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
-	"github.com/golang/protobuf/ptypes/timestamp"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
-func convertToOCMetrics(prefix string, m ECSMetrics, labelKeys []*metricspb.LabelKey, labelValues []*metricspb.LabelValue, timestamp *timestamp.Timestamp) []*metricspb.Metric {
-
-	return applyTimestamp([]*metricspb.Metric{
-		intGauge(prefix+AttributeMemoryUsage, UnitBytes, &m.MemoryUsage, labelKeys, labelValues),
-		intGauge(prefix+AttributeMemoryMaxUsage, UnitBytes, &m.MemoryMaxUsage, labelKeys, labelValues),
-		intGauge(prefix+AttributeMemoryLimit, UnitBytes, &m.MemoryLimit, labelKeys, labelValues),
-		intGauge(prefix+AttributeMemoryUtilized, UnitMegaBytes, &m.MemoryUtilized, labelKeys, labelValues),
-		intGauge(prefix+AttributeMemoryReserved, UnitMegaBytes, &m.MemoryReserved, labelKeys, labelValues),
-
-		intCumulative(prefix+AttributeCPUTotalUsage, UnitNanoSecond, &m.CPUTotalUsage, labelKeys, labelValues),
-		intCumulative(prefix+AttributeCPUKernelModeUsage, UnitNanoSecond, &m.CPUUsageInKernelmode, labelKeys, labelValues),
-		intCumulative(prefix+AttributeCPUUserModeUsage, UnitNanoSecond, &m.CPUUsageInUserMode, labelKeys, labelValues),
-		intGauge(prefix+AttributeCPUCores, UnitCount, &m.NumOfCPUCores, labelKeys, labelValues),
-		intGauge(prefix+AttributeCPUOnlines, UnitCount, &m.CPUOnlineCpus, labelKeys, labelValues),
-		intCumulative(prefix+AttributeCPUSystemUsage, UnitNanoSecond, &m.SystemCPUUsage, labelKeys, labelValues),
-		doubleGauge(prefix+AttributeCPUUtilized, UnitVCpu, &m.CPUUtilized, labelKeys, labelValues),
-		doubleGauge(prefix+AttributeCPUReserved, UnitVCpu, &m.CPUReserved, labelKeys, labelValues),
-
-		doubleGauge(prefix+AttributeNetworkRateRx, UnitBytesPerSec, &m.NetworkRateRxBytesPerSecond, labelKeys, labelValues),
-		doubleGauge(prefix+AttributeNetworkRateTx, UnitBytesPerSec, &m.NetworkRateTxBytesPerSecond, labelKeys, labelValues),
-
-		intCumulative(prefix+AttributeNetworkRxBytes, UnitBytes, &m.NetworkRxBytes, labelKeys, labelValues),
-		intCumulative(prefix+AttributeNetworkRxPackets, UnitCount, &m.NetworkRxPackets, labelKeys, labelValues),
-		intCumulative(prefix+AttributeNetworkRxErrors, UnitCount, &m.NetworkRxErrors, labelKeys, labelValues),
-		intCumulative(prefix+AttributeNetworkRxDropped, UnitCount, &m.NetworkRxDropped, labelKeys, labelValues),
-		intCumulative(prefix+AttributeNetworkTxBytes, UnitBytes, &m.NetworkTxBytes, labelKeys, labelValues),
-		intCumulative(prefix+AttributeNetworkTxPackets, UnitCount, &m.NetworkTxPackets, labelKeys, labelValues),
-		intCumulative(prefix+AttributeNetworkTxErrors, UnitCount, &m.NetworkTxErrors, labelKeys, labelValues),
-		intCumulative(prefix+AttributeNetworkTxDropped, UnitCount, &m.NetworkTxDropped, labelKeys, labelValues),
-
-		intCumulative(prefix+AttributeStorageRead, UnitBytes, &m.StorageReadBytes, labelKeys, labelValues),
-		intCumulative(prefix+AttributeStorageWrite, UnitBytes, &m.StorageWriteBytes, labelKeys, labelValues),
-	}, timestamp)
+func convertToOTLPMetrics(prefix string, m ECSMetrics, r pdata.Resource, timestamp pdata.TimestampUnixNano) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rms := md.ResourceMetrics()
+	rms.Resize(1)
+	rm := rms.At(0)
+	r.CopyTo(rm.Resource())
+
+	ilms := rm.InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+
+	intGauge(metrics, prefix+AttributeMemoryUsage, UnitBytes, int64(m.MemoryUsage), timestamp)
+	intGauge(metrics, prefix+AttributeMemoryMaxUsage, UnitBytes, int64(m.MemoryMaxUsage), timestamp)
+	intGauge(metrics, prefix+AttributeMemoryLimit, UnitBytes, int64(m.MemoryLimit), timestamp)
+	intGauge(metrics, prefix+AttributeMemoryUtilized, UnitMegaBytes, int64(m.MemoryUtilized), timestamp)
+	intGauge(metrics, prefix+AttributeMemoryReserved, UnitMegaBytes, int64(m.MemoryReserved), timestamp)
+
+	intCumulative(metrics, prefix+AttributeCPUTotalUsage, UnitNanoSecond, int64(m.CPUTotalUsage), timestamp)
+	intCumulative(metrics, prefix+AttributeCPUKernelModeUsage, UnitNanoSecond, int64(m.CPUUsageInKernelmode), timestamp)
+	intCumulative(metrics, prefix+AttributeCPUUserModeUsage, UnitNanoSecond, int64(m.CPUUsageInUserMode), timestamp)
+	intGauge(metrics, prefix+AttributeCPUCores, UnitCount, int64(m.NumOfCPUCores), timestamp)
+	intGauge(metrics, prefix+AttributeCPUOnlines, UnitCount, int64(m.CPUOnlineCpus), timestamp)
+	intCumulative(metrics, prefix+AttributeCPUSystemUsage, UnitNanoSecond, int64(m.SystemCPUUsage), timestamp)
+	doubleGauge(metrics, prefix+AttributeCPUUtilized, UnitVCpu, m.CPUUtilized, timestamp)
+	doubleGauge(metrics, prefix+AttributeCPUReserved, UnitVCpu, m.CPUReserved, timestamp)
+
+	doubleGauge(metrics, prefix+AttributeNetworkRateRx, UnitBytesPerSec, m.NetworkRateRxBytesPerSecond, timestamp)
+	doubleGauge(metrics, prefix+AttributeNetworkRateTx, UnitBytesPerSec, m.NetworkRateTxBytesPerSecond, timestamp)
+
+	intCumulative(metrics, prefix+AttributeNetworkRxBytes, UnitBytes, int64(m.NetworkRxBytes), timestamp)
+	intCumulative(metrics, prefix+AttributeNetworkRxPackets, UnitCount, int64(m.NetworkRxPackets), timestamp)
+	intCumulative(metrics, prefix+AttributeNetworkRxErrors, UnitCount, int64(m.NetworkRxErrors), timestamp)
+	intCumulative(metrics, prefix+AttributeNetworkRxDropped, UnitCount, int64(m.NetworkRxDropped), timestamp)
+	intCumulative(metrics, prefix+AttributeNetworkTxBytes, UnitBytes, int64(m.NetworkTxBytes), timestamp)
+	intCumulative(metrics, prefix+AttributeNetworkTxPackets, UnitCount, int64(m.NetworkTxPackets), timestamp)
+	intCumulative(metrics, prefix+AttributeNetworkTxErrors, UnitCount, int64(m.NetworkTxErrors), timestamp)
+	intCumulative(metrics, prefix+AttributeNetworkTxDropped, UnitCount, int64(m.NetworkTxDropped), timestamp)
+
+	intCumulative(metrics, prefix+AttributeStorageRead, UnitBytes, int64(m.StorageReadBytes), timestamp)
+	intCumulative(metrics, prefix+AttributeStorageWrite, UnitBytes, int64(m.StorageWriteBytes), timestamp)
+
+	return md
+}
+
+func newMetric(metrics pdata.MetricSlice, metricName string, unit string, dataType pdata.MetricDataType) pdata.Metric {
+	metric := pdata.NewMetric()
+	metric.InitEmpty()
+	metric.SetName(metricName)
+	metric.SetUnit(unit)
+	metric.SetDataType(dataType)
+	metrics.Append(metric)
+	return metric
 }
 
-func intGauge(metricName string, unit string, value *uint64, labelKeys []*metricspb.LabelKey, labelValues []*metricspb.LabelValue) *metricspb.Metric {
-	if value == nil {
-		return nil
-	}
-	return &metricspb.Metric{
-		MetricDescriptor: &metricspb.MetricDescriptor{
-			Name:      metricName,
-			Unit:      unit,
-			Type:      metricspb.MetricDescriptor_GAUGE_INT64,
-			LabelKeys: labelKeys,
-		},
-		Timeseries: []*metricspb.TimeSeries{{
-			LabelValues: labelValues,
-			Points: []*metricspb.Point{{
-				Value: &metricspb.Point_Int64Value{
-					Int64Value: int64(*value),
-				},
-			}},
-		}},
-	}
+func intGauge(metrics pdata.MetricSlice, metricName string, unit string, value int64, ts pdata.TimestampUnixNano) {
+	gauge := newMetric(metrics, metricName, unit, pdata.MetricDataTypeIntGauge).IntGauge()
+	gauge.InitEmpty()
+	appendIntDataPoint(gauge.DataPoints(), value, ts)
+}
+
+func doubleGauge(metrics pdata.MetricSlice, metricName string, unit string, value float64, ts pdata.TimestampUnixNano) {
+	gauge := newMetric(metrics, metricName, unit, pdata.MetricDataTypeDoubleGauge).DoubleGauge()
+	gauge.InitEmpty()
+
+	dp := pdata.NewDoubleDataPoint()
+	dp.InitEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetValue(value)
+	gauge.DataPoints().Append(dp)
 }
 
-func doubleGauge(metricName string, unit string, value *float64, labelKeys []*metricspb.LabelKey, labelValues []*metricspb.LabelValue) *metricspb.Metric {
-	if value == nil {
-		return nil
-	}
-	return &metricspb.Metric{
-		MetricDescriptor: &metricspb.MetricDescriptor{
-			Name:      metricName,
-			Unit:      unit,
-			Type:      metricspb.MetricDescriptor_GAUGE_DOUBLE,
-			LabelKeys: labelKeys,
-		},
-		Timeseries: []*metricspb.TimeSeries{{
-			LabelValues: labelValues,
-			Points: []*metricspb.Point{{
-				Value: &metricspb.Point_DoubleValue{
-					DoubleValue: *value,
-				},
-			}},
-		}},
-	}
+func intCumulative(metrics pdata.MetricSlice, metricName string, unit string, value int64, ts pdata.TimestampUnixNano) {
+	sum := newMetric(metrics, metricName, unit, pdata.MetricDataTypeIntSum).IntSum()
+	sum.InitEmpty()
+	sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	appendIntDataPoint(sum.DataPoints(), value, ts)
 }
 
-func intCumulative(metricName string, unit string, value *uint64, labelKeys []*metricspb.LabelKey, labelValues []*metricspb.LabelValue) *metricspb.Metric {
-	if value == nil {
-		return nil
-	}
-	return &metricspb.Metric{
-		MetricDescriptor: &metricspb.MetricDescriptor{
-			Name:      metricName,
-			Unit:      unit,
-			Type:      metricspb.MetricDescriptor_CUMULATIVE_INT64,
-			LabelKeys: labelKeys,
-		},
-		Timeseries: []*metricspb.TimeSeries{{
-			LabelValues: labelValues,
-			Points: []*metricspb.Point{{
-				Value: &metricspb.Point_Int64Value{
-					Int64Value: int64(*value),
-				},
-			}},
-		}},
-	}
+func appendIntDataPoint(dps pdata.IntDataPointSlice, value int64, ts pdata.TimestampUnixNano) {
+	dp := pdata.NewIntDataPoint()
+	dp.InitEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetValue(value)
+	dps.Append(dp)
 }
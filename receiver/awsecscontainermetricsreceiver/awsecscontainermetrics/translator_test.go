@@ -17,12 +17,12 @@ import (
 	"testing"
 	"time"
 
-	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 func TestConvertToOTMetrics(t *testing.T) {
-	timestamp := timestampProto(time.Now())
+	timestamp := pdata.TimestampUnixNano(uint64(time.Now().UnixNano()))
 	m := ECSMetrics{}
 
 	m.MemoryUsage = 100
@@ -31,66 +31,66 @@ func TestConvertToOTMetrics(t *testing.T) {
 	m.MemoryReserved = 100
 	m.CPUTotalUsage = 100
 
-	labelKeys := []*metricspb.LabelKey{
-		{Key: "label_key_1"},
-	}
-	labelValues := []*metricspb.LabelValue{
-		{Value: "label_value_1"},
-	}
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString("resource_key_1", "resource_value_1")
 
-	metrics := convertToOCMetrics("container.", m, labelKeys, labelValues, timestamp)
-	require.EqualValues(t, 25, len(metrics))
-}
-
-func TestIntGauge(t *testing.T) {
-	intValue := uint64(100)
+	md := convertToOTLPMetrics("container.", m, resource, timestamp)
+	require.EqualValues(t, 1, md.ResourceMetrics().Len())
 
-	labelKeys := []*metricspb.LabelKey{
-		{Key: "label_key_1"},
-	}
-	labelValues := []*metricspb.LabelValue{
-		{Value: "label_value_1"},
-	}
+	rm := md.ResourceMetrics().At(0)
+	value, ok := rm.Resource().Attributes().Get("resource_key_1")
+	require.True(t, ok)
+	require.EqualValues(t, "resource_value_1", value.StringVal())
 
-	m := intGauge("cpu_utilized", "Count", &intValue, labelKeys, labelValues)
-	require.NotNil(t, m)
+	metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+	require.EqualValues(t, 25, metrics.Len())
+	require.EqualValues(t, "container.memory.usage", metrics.At(0).Name())
+	require.EqualValues(t, 100, metrics.At(0).IntGauge().DataPoints().At(0).Value())
+}
 
-	m = intGauge("cpu_utilized", "Count", nil, labelKeys, labelValues)
-	require.Nil(t, m)
+func TestIntGauge(t *testing.T) {
+	timestamp := pdata.TimestampUnixNano(uint64(time.Now().UnixNano()))
+	metrics := pdata.NewMetricSlice()
+
+	intGauge(metrics, "cpu_utilized", "Count", 100, timestamp)
+	require.EqualValues(t, 1, metrics.Len())
+
+	m := metrics.At(0)
+	require.EqualValues(t, "cpu_utilized", m.Name())
+	require.EqualValues(t, "Count", m.Unit())
+	require.EqualValues(t, pdata.MetricDataTypeIntGauge, m.DataType())
+	dp := m.IntGauge().DataPoints().At(0)
+	require.EqualValues(t, 100, dp.Value())
+	require.EqualValues(t, timestamp, dp.Timestamp())
 }
 
 func TestDoubleGauge(t *testing.T) {
-	floatValue := float64(100.01)
-
-	labelKeys := []*metricspb.LabelKey{
-		{Key: "label_key_1"},
-	}
-	labelValues := []*metricspb.LabelValue{
-		{Value: "label_value_1"},
-	}
+	timestamp := pdata.TimestampUnixNano(uint64(time.Now().UnixNano()))
+	metrics := pdata.NewMetricSlice()
 
-	m := doubleGauge("cpu_utilized", "Count", &floatValue, labelKeys, labelValues)
-	require.NotNil(t, m)
-
-	m = doubleGauge("cpu_utilized", "Count", nil, labelKeys, labelValues)
-	require.Nil(t, m)
+	doubleGauge(metrics, "cpu_utilized", "Count", 100.01, timestamp)
+	require.EqualValues(t, 1, metrics.Len())
 
+	m := metrics.At(0)
+	require.EqualValues(t, pdata.MetricDataTypeDoubleGauge, m.DataType())
+	dp := m.DoubleGauge().DataPoints().At(0)
+	require.EqualValues(t, 100.01, dp.Value())
+	require.EqualValues(t, timestamp, dp.Timestamp())
 }
 
 func TestIntCumulative(t *testing.T) {
-	floatValue := uint64(100)
-
-	labelKeys := []*metricspb.LabelKey{
-		{Key: "label_key_1"},
-	}
-	labelValues := []*metricspb.LabelValue{
-		{Value: "label_value_1"},
-	}
-
-	m := intCumulative("cpu_utilized", "Count", &floatValue, labelKeys, labelValues)
-	require.NotNil(t, m)
-
-	m = intCumulative("cpu_utilized", "Count", nil, labelKeys, labelValues)
-	require.Nil(t, m)
-
+	timestamp := pdata.TimestampUnixNano(uint64(time.Now().UnixNano()))
+	metrics := pdata.NewMetricSlice()
+
+	intCumulative(metrics, "cpu_utilized", "Count", 100, timestamp)
+	require.EqualValues(t, 1, metrics.Len())
+
+	m := metrics.At(0)
+	require.EqualValues(t, pdata.MetricDataTypeIntSum, m.DataType())
+	require.EqualValues(t, pdata.AggregationTemporalityCumulative, m.IntSum().AggregationTemporality())
+	require.True(t, m.IntSum().IsMonotonic())
+	dp := m.IntSum().DataPoints().At(0)
+	require.EqualValues(t, 100, dp.Value())
+	require.EqualValues(t, timestamp, dp.Timestamp())
 }
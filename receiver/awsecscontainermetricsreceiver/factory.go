@@ -65,12 +65,12 @@ func createMetricsReceiver(
 	baseCfg configmodels.Receiver,
 	consumer consumer.MetricsConsumer,
 ) (component.MetricsReceiver, error) {
-	ecsTaskMetadataEndpointV4 := os.Getenv(awsecscontainermetrics.EndpointEnvKey)
-	if ecsTaskMetadataEndpointV4 == "" {
-		return nil, fmt.Errorf("no environment variable found for %s", awsecscontainermetrics.EndpointEnvKey)
+	ecsTaskMetadataEndpoint, err := taskMetadataEndpoint()
+	if err != nil {
+		return nil, err
 	}
 
-	endpoint, err := url.ParseRequestURI(ecsTaskMetadataEndpointV4)
+	endpoint, err := url.ParseRequestURI(ecsTaskMetadataEndpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -88,3 +88,15 @@ func restClient(logger *zap.Logger, endpoint url.URL) awsecscontainermetrics.Res
 
 	return rest
 }
+
+// taskMetadataEndpoint returns the URI of the task metadata endpoint v4, falling
+// back to the endpoint v3 with the container agents which don't provide the v4.
+func taskMetadataEndpoint() (string, error) {
+	for _, envKey := range []string{awsecscontainermetrics.EndpointEnvKey, awsecscontainermetrics.EndpointV3EnvKey} {
+		if endpoint := os.Getenv(envKey); endpoint != "" {
+			return endpoint, nil
+		}
+	}
+	return "", fmt.Errorf("no environment variable found for %s or %s",
+		awsecscontainermetrics.EndpointEnvKey, awsecscontainermetrics.EndpointV3EnvKey)
+}
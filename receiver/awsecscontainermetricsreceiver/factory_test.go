@@ -58,6 +58,41 @@ func TestCreateMetricsReceiverWithEnv(t *testing.T) {
 	require.NotNil(t, metricsReceiver)
 }
 
+func TestCreateMetricsReceiverWithV3Env(t *testing.T) {
+	os.Unsetenv(awsecscontainermetrics.EndpointEnvKey)
+	os.Setenv(awsecscontainermetrics.EndpointV3EnvKey, "http://www.test.com")
+	defer os.Unsetenv(awsecscontainermetrics.EndpointV3EnvKey)
+
+	metricsReceiver, err := createMetricsReceiver(
+		context.Background(),
+		component.ReceiverCreateParams{Logger: zap.NewNop()},
+		createDefaultConfig(),
+		&testbed.MockMetricConsumer{},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, metricsReceiver)
+}
+
+func TestTaskMetadataEndpoint(t *testing.T) {
+	os.Setenv(awsecscontainermetrics.EndpointEnvKey, "http://v4.test.com")
+	os.Setenv(awsecscontainermetrics.EndpointV3EnvKey, "http://v3.test.com")
+	defer os.Unsetenv(awsecscontainermetrics.EndpointEnvKey)
+	defer os.Unsetenv(awsecscontainermetrics.EndpointV3EnvKey)
+
+	endpoint, err := taskMetadataEndpoint()
+	require.NoError(t, err)
+	require.Equal(t, "http://v4.test.com", endpoint)
+
+	os.Unsetenv(awsecscontainermetrics.EndpointEnvKey)
+	endpoint, err = taskMetadataEndpoint()
+	require.NoError(t, err)
+	require.Equal(t, "http://v3.test.com", endpoint)
+
+	os.Unsetenv(awsecscontainermetrics.EndpointV3EnvKey)
+	_, err = taskMetadataEndpoint()
+	require.EqualError(t, err, "no environment variable found for ECS_CONTAINER_METADATA_URI_V4 or ECS_CONTAINER_METADATA_URI")
+}
+
 func TestCreateMetricsReceiverWithBadUrl(t *testing.T) {
 	os.Setenv(awsecscontainermetrics.EndpointEnvKey, "bad-url-format")
 
@@ -18,12 +18,20 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awsecscontainermetricsreceiver/awsecscontainermetrics"
 )
 
 type fakeRestClient struct {
@@ -152,3 +160,125 @@ func TestCollectDataFromEndpointWithEndpointError(t *testing.T) {
 	err = r.collectDataFromEndpoint(ctx, "")
 	require.Error(t, err)
 }
+
+// newTaskMetadataServer serves the task metadata and stats files like the
+// Amazon ECS Task Metadata Endpoint.
+func newTaskMetadataServer(t *testing.T, metadataFile string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(awsecscontainermetrics.TaskMetadataPath, func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadFile(metadataFile)
+		require.NoError(t, err)
+		w.Write(data)
+	})
+	mux.HandleFunc(awsecscontainermetrics.TaskStatsPath, func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadFile("testdata/task_stats.json")
+		require.NoError(t, err)
+		w.Write(data)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestCollectDataFromTaskMetadataEndpoint(t *testing.T) {
+	tests := []struct {
+		name           string
+		envKey         string
+		metadataFile   string
+		containers     int
+		wantAttributes map[string]string
+		noAttributes   []string
+	}{
+		{
+			name:         "v4",
+			envKey:       awsecscontainermetrics.EndpointEnvKey,
+			metadataFile: "testdata/task_metadata.json",
+			containers:   3,
+			wantAttributes: map[string]string{
+				awsecscontainermetrics.AttributeECSCluster:         "test200",
+				awsecscontainermetrics.AttributeECSClusterARN:      "arn:aws:ecs:us-west-2:803860917211:cluster/test200",
+				awsecscontainermetrics.AttributeECSTaskFamily:      "three-nginx",
+				awsecscontainermetrics.AttributeECSTaskRevision:    "1",
+				awsecscontainermetrics.AttributeECSTaskKnownStatus: "RUNNING",
+				awsecscontainermetrics.AttributeECSLaunchType:      "ec2",
+				conventions.AttributeCloudZone:                     "us-west-2a",
+			},
+		},
+		{
+			name:         "v3",
+			envKey:       awsecscontainermetrics.EndpointV3EnvKey,
+			metadataFile: "testdata/task_metadata_v3.json",
+			containers:   1,
+			wantAttributes: map[string]string{
+				awsecscontainermetrics.AttributeECSCluster:         "test200",
+				awsecscontainermetrics.AttributeECSClusterARN:      "arn:aws:ecs:us-west-2:803860917211:cluster/test200",
+				awsecscontainermetrics.AttributeECSTaskFamily:      "three-nginx",
+				awsecscontainermetrics.AttributeECSTaskRevision:    "1",
+				awsecscontainermetrics.AttributeECSTaskKnownStatus: "RUNNING",
+			},
+			noAttributes: []string{
+				awsecscontainermetrics.AttributeECSLaunchType,
+				conventions.AttributeCloudZone,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTaskMetadataServer(t, tt.metadataFile)
+			defer server.Close()
+
+			os.Unsetenv(awsecscontainermetrics.EndpointEnvKey)
+			os.Unsetenv(awsecscontainermetrics.EndpointV3EnvKey)
+			os.Setenv(tt.envKey, server.URL)
+			defer os.Unsetenv(tt.envKey)
+
+			sink := new(exportertest.SinkMetricsExporter)
+			metricsReceiver, err := createMetricsReceiver(
+				context.Background(),
+				component.ReceiverCreateParams{Logger: zap.NewNop()},
+				createDefaultConfig(),
+				sink,
+			)
+			require.NoError(t, err)
+
+			r := metricsReceiver.(*awsEcsContainerMetricsReceiver)
+			err = r.collectDataFromEndpoint(context.Background(), "")
+			require.NoError(t, err)
+
+			// the metrics of each container, followed by the metrics of the task
+			mds := sink.AllMetrics()
+			require.Len(t, mds, tt.containers+1)
+			for i, md := range mds {
+				require.Equal(t, 1, md.ResourceMetrics().Len())
+				rm := md.ResourceMetrics().At(0)
+				attrs := rm.Resource().Attributes()
+				for k, v := range tt.wantAttributes {
+					value, ok := attrs.Get(k)
+					require.True(t, ok, k)
+					require.Equal(t, v, value.StringVal(), k)
+				}
+				for _, k := range tt.noAttributes {
+					_, ok := attrs.Get(k)
+					require.False(t, ok, k)
+				}
+
+				prefix := awsecscontainermetrics.ContainerPrefix
+				if i == len(mds)-1 {
+					prefix = awsecscontainermetrics.TaskPrefix
+				} else {
+					image, ok := attrs.Get(conventions.AttributeContainerImage)
+					require.True(t, ok)
+					require.Equal(t, "nginx", image.StringVal())
+					tag, ok := attrs.Get(conventions.AttributeContainerTag)
+					require.True(t, ok)
+					require.Equal(t, "latest", tag.StringVal())
+					status, ok := attrs.Get(awsecscontainermetrics.AttributeECSContainerKnownStatus)
+					require.True(t, ok)
+					require.Equal(t, "RUNNING", status.StringVal())
+				}
+				metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+				require.Equal(t, 25, metrics.Len())
+				require.Equal(t, prefix+awsecscontainermetrics.AttributeMemoryUsage, metrics.At(0).Name())
+				require.Equal(t, pdata.MetricDataTypeIntGauge, metrics.At(0).DataType())
+			}
+		})
+	}
+}
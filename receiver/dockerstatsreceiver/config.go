@@ -19,6 +19,8 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
 )
 
 var _ configmodels.Receiver = (*Config)(nil)
@@ -51,8 +53,21 @@ type Config struct {
 	// A list of filters whose matching images are to be excluded.  Supports literals, globs, and regex.
 	ExcludedImages []string `mapstructure:"excluded_images"`
 
-	// Whether to report all CPU metrics.  Default is false
+	// The containers to monitor, selected by name, image name or label.  All containers
+	// are monitored by default.
+	Include *docker.MatchConfig `mapstructure:"include"`
+
+	// The containers not to monitor, selected by name, image name or label.  Exclusions
+	// take precedence over inclusions.
+	Exclude *docker.MatchConfig `mapstructure:"exclude"`
+
+	// Whether to report the CPU usage of each core.  Default is false
 	ProvidePerCoreCPUMetrics bool `mapstructure:"provide_per_core_cpu_metrics"`
+
+	// Whether to report the metrics with the names, units and resource labels they
+	// had before the OpenTelemetry semantic conventions were adopted, for existing
+	// dashboards.  Default is false
+	LegacyMetrics bool `mapstructure:"legacy_metrics"`
 }
 
 func (config Config) Validate() error {
@@ -62,5 +77,8 @@ func (config Config) Validate() error {
 	if config.CollectionInterval == 0 {
 		return errors.New("config.CollectionInterval must be specified")
 	}
+	if _, err := docker.NewContainerFilter(config.Include, config.Exclude); err != nil {
+		return err
+	}
 	return nil
 }
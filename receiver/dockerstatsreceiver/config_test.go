@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -52,8 +54,11 @@ func TestLoadConfig(t *testing.T) {
 	assert.Nil(t, dcfg.ExcludedImages)
 	assert.Nil(t, dcfg.ContainerLabelsToMetricLabels)
 	assert.Nil(t, dcfg.EnvVarsToMetricLabels)
+	assert.Nil(t, dcfg.Include)
+	assert.Nil(t, dcfg.Exclude)
 
 	assert.False(t, dcfg.ProvidePerCoreCPUMetrics)
+	assert.False(t, dcfg.LegacyMetrics)
 
 	ascfg := config.Receivers["docker_stats/allsettings"].(*Config)
 	assert.Equal(t, "docker_stats/allsettings", ascfg.Name())
@@ -66,6 +71,16 @@ func TestLoadConfig(t *testing.T) {
 		"another-*-container",
 	}, ascfg.ExcludedImages)
 
+	assert.Equal(t, &docker.MatchConfig{
+		MatchType: docker.MatchTypeGlob,
+		Names:     []string{"web-*"},
+		Labels:    map[string]string{"com.example.monitored": "true"},
+	}, ascfg.Include)
+	assert.Equal(t, &docker.MatchConfig{
+		MatchType: docker.MatchTypeRegexp,
+		Images:    []string{"^busybox(:.*)?$"},
+	}, ascfg.Exclude)
+
 	assert.Equal(t, map[string]string{
 		"my.container.label":       "my-metric-label",
 		"my.other.container.label": "my-other-metric-label",
@@ -77,4 +92,5 @@ func TestLoadConfig(t *testing.T) {
 	}, ascfg.EnvVarsToMetricLabels)
 
 	assert.True(t, ascfg.ProvidePerCoreCPUMetrics)
+	assert.True(t, ascfg.LegacyMetrics)
 }
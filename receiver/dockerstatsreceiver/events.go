@@ -0,0 +1,144 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerstatsreceiver
+
+import (
+	"strings"
+	"time"
+
+	dtypes "github.com/docker/docker/api/types"
+	devents "github.com/docker/docker/api/types/events"
+	"go.opentelemetry.io/collector/consumer/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
+)
+
+// Container event actions emitted as logs.  Health check results are reported
+// with a "health_status: <status>" action.
+const (
+	eventActionDie          = "die"
+	eventActionKill         = "kill"
+	eventActionOOM          = "oom"
+	eventActionHealthStatus = "health_status"
+)
+
+// Log record attribute keys for container events.
+const (
+	eventKeyAction       = "docker.event.action"
+	eventKeyExitCode     = "docker.event.exit_code"
+	eventKeySignal       = "docker.event.signal"
+	eventKeyHealthStatus = "docker.event.health_status"
+)
+
+// ContainerEventToLogs converts a container event to logs made of a single log record,
+// or returns false when the event isn't one of the container lifecycle events emitted
+// as logs.  The resource has the same attributes as the metrics of the container.
+func ContainerEventToLogs(
+	event devents.Message,
+	container *docker.Container,
+	config *Config,
+) (pdata.Logs, bool) {
+	action, healthStatus := parseEventAction(event.Action)
+	switch action {
+	case eventActionDie, eventActionKill, eventActionOOM, eventActionHealthStatus:
+	default:
+		return pdata.Logs{}, false
+	}
+
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rl := rls.At(0)
+
+	rl.Resource().InitEmpty()
+	resourceAttrs := rl.Resource().Attributes()
+	if config.LegacyMetrics {
+		for k, v := range containerResourceLabels(container, config) {
+			resourceAttrs.InsertString(k, v)
+		}
+	} else {
+		setContainerResourceAttributes(resourceAttrs, container, config)
+	}
+
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(1)
+	lrs := ills.At(0).Logs()
+	lrs.Resize(1)
+	lr := lrs.At(0)
+
+	lr.SetName(action)
+	lr.SetTimestamp(pdata.TimestampUnixNano(uint64(eventTimestamp(event).UnixNano())))
+	severity := eventSeverity(action, event.Actor.Attributes["exitCode"], healthStatus)
+	lr.SetSeverityNumber(severity)
+	lr.SetSeverityText(severityText(severity))
+	lr.Body().SetStringVal(event.Action)
+
+	attrs := lr.Attributes()
+	attrs.InsertString(eventKeyAction, action)
+	if exitCode := event.Actor.Attributes["exitCode"]; exitCode != "" {
+		attrs.InsertString(eventKeyExitCode, exitCode)
+	}
+	if signal := event.Actor.Attributes["signal"]; signal != "" {
+		attrs.InsertString(eventKeySignal, signal)
+	}
+	if healthStatus != "" {
+		attrs.InsertString(eventKeyHealthStatus, healthStatus)
+	}
+
+	return ld, true
+}
+
+// parseEventAction splits the action of the event from the health status that
+// health_status events carry.
+func parseEventAction(action string) (string, string) {
+	parts := strings.SplitN(action, ":", 2)
+	if len(parts) == 2 {
+		return parts[0], strings.TrimSpace(parts[1])
+	}
+	return action, ""
+}
+
+func eventTimestamp(event devents.Message) time.Time {
+	if event.TimeNano != 0 {
+		return time.Unix(0, event.TimeNano)
+	}
+	return time.Unix(event.Time, 0)
+}
+
+// eventSeverity flags the events of containers that were killed by the kernel,
+// crashed or failed their health check.
+func eventSeverity(action, exitCode, healthStatus string) pdata.SeverityNumber {
+	switch {
+	case action == eventActionOOM:
+		return pdata.SeverityNumberERROR
+	case action == eventActionDie && exitCode != "" && exitCode != "0":
+		return pdata.SeverityNumberWARN
+	case action == eventActionHealthStatus && healthStatus == dtypes.Unhealthy:
+		return pdata.SeverityNumberWARN
+	default:
+		return pdata.SeverityNumberINFO
+	}
+}
+
+func severityText(severity pdata.SeverityNumber) string {
+	switch severity {
+	case pdata.SeverityNumberERROR:
+		return "ERROR"
+	case pdata.SeverityNumberWARN:
+		return "WARN"
+	default:
+		return "INFO"
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerstatsreceiver
+
+import (
+	"testing"
+
+	devents "github.com/docker/docker/api/types/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestContainerEventToLogs(t *testing.T) {
+	tests := []struct {
+		name             string
+		action           string
+		actorAttributes  map[string]string
+		expectedName     string
+		expectedSeverity pdata.SeverityNumber
+		expectedAttrs    map[string]string
+	}{
+		{
+			name:             "crashed",
+			action:           "die",
+			actorAttributes:  map[string]string{"exitCode": "1"},
+			expectedName:     "die",
+			expectedSeverity: pdata.SeverityNumberWARN,
+			expectedAttrs:    map[string]string{"docker.event.action": "die", "docker.event.exit_code": "1"},
+		},
+		{
+			name:             "exited",
+			action:           "die",
+			actorAttributes:  map[string]string{"exitCode": "0"},
+			expectedName:     "die",
+			expectedSeverity: pdata.SeverityNumberINFO,
+			expectedAttrs:    map[string]string{"docker.event.action": "die", "docker.event.exit_code": "0"},
+		},
+		{
+			name:             "killed",
+			action:           "kill",
+			actorAttributes:  map[string]string{"signal": "9"},
+			expectedName:     "kill",
+			expectedSeverity: pdata.SeverityNumberINFO,
+			expectedAttrs:    map[string]string{"docker.event.action": "kill", "docker.event.signal": "9"},
+		},
+		{
+			name:             "out of memory",
+			action:           "oom",
+			expectedName:     "oom",
+			expectedSeverity: pdata.SeverityNumberERROR,
+			expectedAttrs:    map[string]string{"docker.event.action": "oom"},
+		},
+		{
+			name:             "unhealthy",
+			action:           "health_status: unhealthy",
+			expectedName:     "health_status",
+			expectedSeverity: pdata.SeverityNumberWARN,
+			expectedAttrs:    map[string]string{"docker.event.action": "health_status", "docker.event.health_status": "unhealthy"},
+		},
+		{
+			name:             "healthy",
+			action:           "health_status: healthy",
+			expectedName:     "health_status",
+			expectedSeverity: pdata.SeverityNumberINFO,
+			expectedAttrs:    map[string]string{"docker.event.action": "health_status", "docker.event.health_status": "healthy"},
+		},
+	}
+
+	container := containerJSON(t)
+	config := &Config{
+		ContainerLabelsToMetricLabels: map[string]string{"my.specified.docker.label": "my.docker.label"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := devents.Message{
+				Type:     devents.ContainerEventType,
+				Action:   tt.action,
+				Actor:    devents.Actor{ID: container.ID, Attributes: tt.actorAttributes},
+				TimeNano: 1600000000000000000,
+			}
+
+			ld, ok := ContainerEventToLogs(event, container, config)
+			require.True(t, ok)
+			require.Equal(t, 1, ld.LogRecordCount())
+
+			rl := ld.ResourceLogs().At(0)
+			assert.Equal(t, map[string]string{
+				"container.hostname":   "abcdef012345",
+				"container.id":         "a2596076ca048f02bcd16a8acd12a7ea2d3bc430d1cde095357239dd3925a4c3",
+				"container.image.name": "myImage",
+				"container.name":       "my-container-name",
+				"container.runtime":    "docker",
+				"my.docker.label":      "my_specified_docker_label_value",
+			}, attributesToMap(rl.Resource().Attributes()))
+
+			lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+			assert.Equal(t, tt.expectedName, lr.Name())
+			assert.Equal(t, tt.expectedSeverity, lr.SeverityNumber())
+			assert.Equal(t, pdata.TimestampUnixNano(1600000000000000000), lr.Timestamp())
+			assert.Equal(t, tt.action, lr.Body().StringVal())
+			assert.Equal(t, tt.expectedAttrs, attributesToMap(lr.Attributes()))
+		})
+	}
+}
+
+func TestContainerEventToLogsLegacyResource(t *testing.T) {
+	container := containerJSON(t)
+	config := &Config{LegacyMetrics: true}
+
+	ld, ok := ContainerEventToLogs(devents.Message{Action: "oom"}, container, config)
+	require.True(t, ok)
+	assert.Equal(t, defaultLabels(), attributesToMap(ld.ResourceLogs().At(0).Resource().Attributes()))
+}
+
+func TestContainerEventToLogsIgnoresOtherEvents(t *testing.T) {
+	for _, action := range []string{"start", "stop", "pause", "unpause", "update"} {
+		_, ok := ContainerEventToLogs(devents.Message{Action: action}, containerJSON(t), &Config{})
+		assert.False(t, ok, action)
+	}
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]string {
+	m := map[string]string{}
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		m[k] = v.StringVal()
+	})
+	return m
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerstatsreceiver
+
+import (
+	"context"
+
+	dtypes "github.com/docker/docker/api/types"
+	devents "github.com/docker/docker/api/types/events"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
+)
+
+// NewEventsReceiver creates the receiver emitting the die, kill, oom and
+// health_status container events as logs.
+func NewEventsReceiver(
+	_ context.Context,
+	logger *zap.Logger,
+	config *Config,
+	nextConsumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	receiver, err := newReceiver(logger, config)
+	if err != nil {
+		return nil, err
+	}
+	receiver.logsConsumer = nextConsumer
+	return receiver, nil
+}
+
+// consumeEvent sends the lifecycle events of the container to the next consumer.
+func (r *Receiver) consumeEvent(event devents.Message, containerJSON *dtypes.ContainerJSON) {
+	container := &docker.Container{
+		ContainerJSON: containerJSON,
+		EnvMap:        docker.ContainerEnvToMap(containerJSON.Config.Env),
+	}
+
+	ld, ok := ContainerEventToLogs(event, container, r.config)
+	if !ok {
+		return
+	}
+
+	if err := r.logsConsumer.ConsumeLogs(r.obsCtx, ld); err != nil {
+		r.logger.Error(
+			"Could not consume docker container event",
+			zap.String("id", event.ID),
+			zap.String("action", event.Action),
+			zap.Error(err),
+		)
+	}
+}
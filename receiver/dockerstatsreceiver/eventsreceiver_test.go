@@ -0,0 +1,131 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerstatsreceiver
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+func TestEventsReceiver(t *testing.T) {
+	container, err := ioutil.ReadFile(path.Join(".", "testdata", "container.json"))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			w.Write([]byte(`{"Type":"container","Action":"start","id":"a2596076ca04","Actor":{"ID":"a2596076ca04"},"timeNano":1}` + "\n"))
+			w.Write([]byte(`{"Type":"container","Action":"oom","id":"a2596076ca04","Actor":{"ID":"a2596076ca04"},"timeNano":2}` + "\n"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case strings.HasSuffix(r.URL.Path, "/containers/a2596076ca04/json"):
+			w.Write(container)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	config := &Config{
+		Endpoint:           srv.URL,
+		CollectionInterval: time.Second,
+		Timeout:            time.Second,
+	}
+	consumer := &exportertest.SinkLogsExporter{}
+	r, err := NewEventsReceiver(context.Background(), zap.NewNop(), config, consumer)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+	defer r.Shutdown(ctx)
+
+	require.Eventually(t, func() bool {
+		return consumer.LogRecordsCount() == 1
+	}, 5*time.Second, 10*time.Millisecond, "event not collected")
+
+	rl := consumer.AllLogs()[0].ResourceLogs().At(0)
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "oom", lr.Name())
+	name, _ := rl.Resource().Attributes().Get("container.name")
+	assert.Equal(t, "my-container-name", name.StringVal())
+}
+
+func TestReceiverMetricsAndEvents(t *testing.T) {
+	container, err := ioutil.ReadFile(path.Join(".", "testdata", "container.json"))
+	require.NoError(t, err)
+	stats, err := ioutil.ReadFile(path.Join(".", "testdata", "stats.json"))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			w.Write([]byte(`{"Type":"container","Action":"oom","id":"a2596076ca04","Actor":{"ID":"a2596076ca04"},"timeNano":1}` + "\n"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case strings.HasSuffix(r.URL.Path, "/containers/json"):
+			w.Write([]byte(`[{"Id":"a2596076ca04"}]`))
+		case strings.HasSuffix(r.URL.Path, "/containers/a2596076ca04/json"):
+			w.Write(container)
+		case strings.HasSuffix(r.URL.Path, "/stats"):
+			w.Write(stats)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	config.Endpoint = srv.URL
+	config.CollectionInterval = 100 * time.Millisecond
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	metricsConsumer := &exportertest.SinkMetricsExporter{}
+	mr, err := factory.CreateMetricsReceiver(context.Background(), params, config, metricsConsumer)
+	require.NoError(t, err)
+	logsConsumer := &exportertest.SinkLogsExporter{}
+	lr, err := factory.CreateLogsReceiver(context.Background(), params, config, logsConsumer)
+	require.NoError(t, err)
+	require.Same(t, mr, lr)
+
+	// A single start polls the stats and watches the events.
+	ctx := context.Background()
+	require.NoError(t, mr.Start(ctx, componenttest.NewNopHost()))
+	defer mr.Shutdown(ctx)
+
+	require.Eventually(t, func() bool {
+		return logsConsumer.LogRecordsCount() == 1 && len(metricsConsumer.AllMetrics()) > 0
+	}, 5*time.Second, 10*time.Millisecond, "stats or event not collected")
+}
+
+func TestNewEventsReceiverErrors(t *testing.T) {
+	r, err := NewEventsReceiver(context.Background(), zap.NewNop(), &Config{}, &exportertest.SinkLogsExporter{})
+	assert.Nil(t, r)
+	require.Error(t, err)
+	assert.Equal(t, "config.Endpoint must be specified", err.Error())
+}
@@ -16,12 +16,14 @@ package dockerstatsreceiver
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
 )
 
 const (
@@ -32,7 +34,8 @@ func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
 }
 
 func createDefaultConfig() configmodels.Receiver {
@@ -48,17 +51,52 @@ func createDefaultConfig() configmodels.Receiver {
 }
 
 func createMetricsReceiver(
-	ctx context.Context,
+	_ context.Context,
 	params component.ReceiverCreateParams,
 	config configmodels.Receiver,
 	consumer consumer.MetricsConsumer,
 ) (component.MetricsReceiver, error) {
-	dockerConfig := config.(*Config)
+	dsr, err := getOrCreateReceiver(params.Logger, config.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	dsr.nextConsumer = consumer
 
-	dsr, err := NewReceiver(ctx, params.Logger, dockerConfig, consumer)
+	return dsr, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateParams,
+	config configmodels.Receiver,
+	consumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	dsr, err := getOrCreateReceiver(params.Logger, config.(*Config))
 	if err != nil {
 		return nil, err
 	}
+	dsr.logsConsumer = consumer
+
+	return dsr, nil
+}
+
+// getOrCreateReceiver returns the receiver of the config, the same instance
+// must be returned for the metrics and logs pipelines of a config.
+func getOrCreateReceiver(logger *zap.Logger, config *Config) (*Receiver, error) {
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+
+	if dsr, ok := receivers[config]; ok {
+		return dsr, nil
+	}
 
+	dsr, err := newReceiver(logger, config)
+	if err != nil {
+		return nil, err
+	}
+	receivers[config] = dsr
 	return dsr, nil
 }
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*Receiver{}
@@ -28,23 +28,17 @@ import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
 )
 
 const (
 	metricPrefix = "container."
 )
 
-// client.ContainerInspect() response container
-// stats and translated environment string map
-// for potential labels
-type DockerContainer struct {
-	*dtypes.ContainerJSON
-	EnvMap map[string]string
-}
-
 func ContainerStatsToMetrics(
 	containerStats *dtypes.StatsJSON,
-	container *DockerContainer,
+	container *docker.Container,
 	config *Config,
 ) (*consumerdata.MetricsData, error) {
 	now, _ := ptypes.TimestampProto(time.Now())
@@ -54,6 +48,7 @@ func ContainerStatsToMetrics(
 	metrics = append(metrics, cpuMetrics(&containerStats.CPUStats, &containerStats.PreCPUStats, now, config.ProvidePerCoreCPUMetrics)...)
 	metrics = append(metrics, memoryMetrics(&containerStats.MemoryStats, now)...)
 	metrics = append(metrics, networkMetrics(&containerStats.Networks, now)...)
+	metrics = append(metrics, containerStateMetrics(container.State, container.RestartCount, now)...)
 
 	if len(metrics) == 0 {
 		return nil, nil
@@ -62,33 +57,49 @@ func ContainerStatsToMetrics(
 	md := &consumerdata.MetricsData{
 		Metrics: metrics,
 		Resource: &resourcepb.Resource{
-			Type: "container",
-			Labels: map[string]string{
-				"container.hostname":                container.Config.Hostname,
-				conventions.AttributeContainerID:    container.ID,
-				conventions.AttributeContainerImage: container.Config.Image,
-				conventions.AttributeContainerName:  strings.TrimPrefix(container.Name, "/"),
-			},
+			Type:   "container",
+			Labels: containerResourceLabels(container, config),
 		},
 	}
 
-	updateConfiguredResourceLabels(md, container, config)
-
 	return md, nil
 }
 
-func updateConfiguredResourceLabels(md *consumerdata.MetricsData, container *DockerContainer, config *Config) {
+// containerResourceLabels returns the resource labels identifying the container,
+// including the ones configured from its environment variables and labels.
+func containerResourceLabels(container *docker.Container, config *Config) map[string]string {
+	labels := map[string]string{
+		"container.hostname":                container.Config.Hostname,
+		conventions.AttributeContainerID:    container.ID,
+		conventions.AttributeContainerImage: container.Config.Image,
+		conventions.AttributeContainerName:  strings.TrimPrefix(container.Name, "/"),
+	}
+
+	for k, v := range configuredResourceLabels(container, config) {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+// configuredResourceLabels returns the resource labels mapped from the environment
+// variables and labels of the container.
+func configuredResourceLabels(container *docker.Container, config *Config) map[string]string {
+	labels := map[string]string{}
+
 	for k, label := range config.EnvVarsToMetricLabels {
 		if v := container.EnvMap[k]; v != "" {
-			md.Resource.Labels[label] = v
+			labels[label] = v
 		}
 	}
 
 	for k, label := range config.ContainerLabelsToMetricLabels {
 		if v := container.Config.Labels[k]; v != "" {
-			md.Resource.Labels[label] = v
+			labels[label] = v
 		}
 	}
+
+	return labels
 }
 
 type blkioStat struct {
@@ -288,6 +299,65 @@ func networkMetrics(
 	return metrics
 }
 
+// containerStates maps the docker container statuses to the values of the
+// container.state metric.
+var containerStates = map[string]int64{
+	"created":    1,
+	"running":    2,
+	"paused":     3,
+	"restarting": 4,
+	"removing":   5,
+	"exited":     6,
+	"dead":       7,
+}
+
+// healthStatuses maps the docker health check statuses to the values of the
+// container.health.status metric.
+var healthStatuses = map[string]int64{
+	dtypes.NoHealthcheck: 0,
+	dtypes.Starting:      1,
+	dtypes.Healthy:       2,
+	dtypes.Unhealthy:     3,
+}
+
+// metrics for the lifecycle of the container, as reported by the inspect api
+func containerStateMetrics(
+	state *dtypes.ContainerState,
+	restartCount int,
+	ts *timestamp.Timestamp,
+) []*metricspb.Metric {
+	if state == nil {
+		return nil
+	}
+
+	var metrics []*metricspb.Metric
+
+	if v, ok := containerStates[state.Status]; ok {
+		metrics = append(metrics, Gauge("state", []int64{v}, ts, "1", nil, nil))
+	}
+
+	if state.Health != nil {
+		if v, ok := healthStatuses[state.Health.Status]; ok {
+			metrics = append(metrics, Gauge("health.status", []int64{v}, ts, "1", nil, nil))
+		}
+	}
+
+	metrics = append(metrics, []*metricspb.Metric{
+		Cumulative("restarts", []int64{int64(restartCount)}, ts, "1", nil, nil),
+		Gauge("exit_code", []int64{int64(state.ExitCode)}, ts, "1", nil, nil),
+	}...)
+
+	if state.Running {
+		startedAt, err := time.Parse(time.RFC3339Nano, state.StartedAt)
+		now, tsErr := ptypes.Timestamp(ts)
+		if err == nil && tsErr == nil && !startedAt.IsZero() {
+			metrics = append(metrics, GaugeF("uptime", []float64{now.Sub(startedAt).Seconds()}, ts, "s", nil, nil))
+		}
+	}
+
+	return metrics
+}
+
 func Cumulative(name string, vals []int64, ts *timestamp.Timestamp, unit string, labelKeys []string, labelValues [][]string) *metricspb.Metric {
 	return metric(name, metricspb.MetricDescriptor_CUMULATIVE_INT64, ts, unit, labelKeys, labelValues, vals, nil)
 }
@@ -0,0 +1,334 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerstatsreceiver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dtypes "github.com/docker/docker/api/types"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/containerimage"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
+)
+
+const (
+	containerRuntime = "docker"
+
+	// Keys of the labels of the container metrics.
+	labelCore        = "core"
+	labelDeviceMajor = "device_major"
+	labelDeviceMinor = "device_minor"
+	labelInterface   = "interface"
+	labelOperation   = "operation"
+)
+
+// ContainerStatsToPdata converts the stats and the state of the container to metrics
+// whose resource is described with the OpenTelemetry semantic conventions.  Unlike
+// ContainerStatsToMetrics, the blkio operations and the cpu cores are label dimensions
+// of their metrics rather than separate metrics, and the units are the ones of the
+// measured quantities.
+func ContainerStatsToPdata(
+	containerStats *dtypes.StatsJSON,
+	container *docker.Container,
+	config *Config,
+) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rms := md.ResourceMetrics()
+	rms.Resize(1)
+	rm := rms.At(0)
+
+	rm.Resource().InitEmpty()
+	setContainerResourceAttributes(rm.Resource().Attributes(), container, config)
+
+	ilms := rm.InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+
+	mb := &metricsBuilder{
+		metrics: ilms.At(0).Metrics(),
+		ts:      pdata.TimestampUnixNano(uint64(time.Now().UnixNano())),
+	}
+	if container.State != nil {
+		if startedAt, err := time.Parse(time.RFC3339Nano, container.State.StartedAt); err == nil && !startedAt.IsZero() {
+			mb.start = pdata.TimestampUnixNano(uint64(startedAt.UnixNano()))
+		}
+	}
+
+	mb.appendBlockioMetrics(&containerStats.BlkioStats)
+	mb.appendCPUMetrics(&containerStats.CPUStats, &containerStats.PreCPUStats, config.ProvidePerCoreCPUMetrics)
+	mb.appendMemoryMetrics(&containerStats.MemoryStats)
+	mb.appendNetworkMetrics(containerStats.Networks)
+	var created pdata.TimestampUnixNano
+	if t, err := time.Parse(time.RFC3339Nano, container.Created); err == nil && !t.IsZero() {
+		created = pdata.TimestampUnixNano(uint64(t.UnixNano()))
+	}
+	mb.appendStateMetrics(container.State, container.RestartCount, created)
+
+	return md
+}
+
+// setContainerResourceAttributes describes the container with the semantic conventions,
+// along with the attributes configured from its environment variables and labels.
+func setContainerResourceAttributes(attrs pdata.AttributeMap, container *docker.Container, config *Config) {
+	imageName, imageTag := containerimage.Parse(container.Config.Image)
+
+	attrs.InsertString(conventions.AttributeContainerID, container.ID)
+	attrs.InsertString(conventions.AttributeContainerName, strings.TrimPrefix(container.Name, "/"))
+	attrs.InsertString(conventions.AttributeContainerImage, imageName)
+	if imageTag != "" {
+		attrs.InsertString(conventions.AttributeContainerTag, imageTag)
+	}
+	attrs.InsertString("container.hostname", container.Config.Hostname)
+	attrs.InsertString("container.runtime", containerRuntime)
+
+	for k, v := range configuredResourceLabels(container, config) {
+		attrs.UpsertString(k, v)
+	}
+}
+
+// metricsBuilder appends the container metrics, sharing the timestamps of the
+// collection to all of them.
+type metricsBuilder struct {
+	metrics pdata.MetricSlice
+	// start is the start time of the cumulative metrics, the time the container
+	// was started.
+	start pdata.TimestampUnixNano
+	ts    pdata.TimestampUnixNano
+}
+
+func (mb *metricsBuilder) newMetric(name, unit string, dataType pdata.MetricDataType) pdata.Metric {
+	metric := pdata.NewMetric()
+	metric.InitEmpty()
+	metric.SetName(metricPrefix + name)
+	metric.SetUnit(unit)
+	metric.SetDataType(dataType)
+	mb.metrics.Append(metric)
+	return metric
+}
+
+// intSum appends a monotonic cumulative sum and returns its data points.
+func (mb *metricsBuilder) intSum(name, unit string) pdata.IntDataPointSlice {
+	sum := mb.newMetric(name, unit, pdata.MetricDataTypeIntSum).IntSum()
+	sum.InitEmpty()
+	sum.SetAggregationTemporality(pdata.AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	return sum.DataPoints()
+}
+
+func (mb *metricsBuilder) intGauge(name, unit string) pdata.IntDataPointSlice {
+	gauge := mb.newMetric(name, unit, pdata.MetricDataTypeIntGauge).IntGauge()
+	gauge.InitEmpty()
+	return gauge.DataPoints()
+}
+
+func (mb *metricsBuilder) doubleGauge(name, unit string) pdata.DoubleDataPointSlice {
+	gauge := mb.newMetric(name, unit, pdata.MetricDataTypeDoubleGauge).DoubleGauge()
+	gauge.InitEmpty()
+	return gauge.DataPoints()
+}
+
+func (mb *metricsBuilder) appendIntDataPoint(dps pdata.IntDataPointSlice, labels map[string]string, value int64) {
+	dp := pdata.NewIntDataPoint()
+	dp.InitEmpty()
+	dp.SetStartTime(mb.start)
+	dp.SetTimestamp(mb.ts)
+	dp.LabelsMap().InitFromMap(labels)
+	dp.SetValue(value)
+	dps.Append(dp)
+}
+
+func (mb *metricsBuilder) appendDoubleDataPoint(dps pdata.DoubleDataPointSlice, labels map[string]string, value float64) {
+	dp := pdata.NewDoubleDataPoint()
+	dp.InitEmpty()
+	dp.SetTimestamp(mb.ts)
+	dp.LabelsMap().InitFromMap(labels)
+	dp.SetValue(value)
+	dps.Append(dp)
+}
+
+// metrics for https://www.kernel.org/doc/Documentation/cgroup-v1/blkio-controller.txt
+func (mb *metricsBuilder) appendBlockioMetrics(blkioStats *dtypes.BlkioStats) {
+	for _, blkiostat := range []blkioStat{
+		{"io_merged_recursive", "{operations}", blkioStats.IoMergedRecursive},
+		{"io_queued_recursive", "{operations}", blkioStats.IoQueuedRecursive},
+		{"io_service_bytes_recursive", "By", blkioStats.IoServiceBytesRecursive},
+		{"io_service_time_recursive", "ns", blkioStats.IoServiceTimeRecursive},
+		{"io_serviced_recursive", "{operations}", blkioStats.IoServicedRecursive},
+		{"io_time_recursive", "ms", blkioStats.IoTimeRecursive},
+		{"io_wait_time_recursive", "ns", blkioStats.IoWaitTimeRecursive},
+		{"sectors_recursive", "{sectors}", blkioStats.SectorsRecursive},
+	} {
+		var entries []dtypes.BlkioStatEntry
+		for _, stat := range blkiostat.entries {
+			if stat.Op != "" {
+				entries = append(entries, stat)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		dps := mb.intSum("blockio."+blkiostat.name, blkiostat.unit)
+		for _, stat := range entries {
+			mb.appendIntDataPoint(dps, map[string]string{
+				labelDeviceMajor: strconv.FormatUint(stat.Major, 10),
+				labelDeviceMinor: strconv.FormatUint(stat.Minor, 10),
+				labelOperation:   strings.ToLower(stat.Op),
+			}, int64(stat.Value))
+		}
+	}
+}
+
+func (mb *metricsBuilder) appendCPUMetrics(
+	cpuStats *dtypes.CPUStats,
+	previousCPUStats *dtypes.CPUStats,
+	providePerCoreMetrics bool,
+) {
+	mb.appendIntDataPoint(mb.intSum("cpu.usage.system", "ns"), nil, int64(cpuStats.SystemUsage))
+
+	// The per core usage is reported as the total usage split by core, which the
+	// kernel doesn't provide with cgroup v2.
+	totalDps := mb.intSum("cpu.usage.total", "ns")
+	if providePerCoreMetrics && len(cpuStats.CPUUsage.PercpuUsage) > 0 {
+		for coreNum, v := range cpuStats.CPUUsage.PercpuUsage {
+			mb.appendIntDataPoint(totalDps, map[string]string{labelCore: fmt.Sprintf("cpu%d", coreNum)}, int64(v))
+		}
+	} else {
+		mb.appendIntDataPoint(totalDps, nil, int64(cpuStats.CPUUsage.TotalUsage))
+	}
+
+	mb.appendIntDataPoint(mb.intSum("cpu.usage.kernelmode", "ns"), nil, int64(cpuStats.CPUUsage.UsageInKernelmode))
+	mb.appendIntDataPoint(mb.intSum("cpu.usage.usermode", "ns"), nil, int64(cpuStats.CPUUsage.UsageInUsermode))
+
+	mb.appendIntDataPoint(mb.intSum("cpu.throttling_data.periods", "{periods}"), nil, int64(cpuStats.ThrottlingData.Periods))
+	mb.appendIntDataPoint(mb.intSum("cpu.throttling_data.throttled_periods", "{periods}"), nil, int64(cpuStats.ThrottlingData.ThrottledPeriods))
+	mb.appendIntDataPoint(mb.intSum("cpu.throttling_data.throttled_time", "ns"), nil, int64(cpuStats.ThrottlingData.ThrottledTime))
+
+	mb.appendDoubleDataPoint(mb.doubleGauge("cpu.percent", "%"), nil, calculateCPUPercent(previousCPUStats, cpuStats))
+}
+
+// memoryStatUnits are the units of the memory stats that aren't amounts of memory,
+// all of them being cumulative.
+var memoryStatUnits = map[string]string{
+	"pgfault":          "{faults}",
+	"pgmajfault":       "{faults}",
+	"pgpgin":           "{pages}",
+	"pgpgout":          "{pages}",
+	"total_pgfault":    "{faults}",
+	"total_pgmajfault": "{faults}",
+	"total_pgpgin":     "{pages}",
+	"total_pgpgout":    "{pages}",
+}
+
+func (mb *metricsBuilder) appendMemoryMetrics(memoryStats *dtypes.MemoryStats) {
+	// The page cache can momentarily exceed the usage, don't let the difference
+	// wrap around.
+	var totalUsage uint64
+	if usage, cache := memoryStats.Usage, memoryStats.Stats["total_cache"]; usage > cache {
+		totalUsage = usage - cache
+	}
+	mb.appendIntDataPoint(mb.intGauge("memory.usage.limit", "By"), nil, int64(memoryStats.Limit))
+	mb.appendIntDataPoint(mb.intGauge("memory.usage.total", "By"), nil, int64(totalUsage))
+
+	var pctUsed float64
+	if memoryStats.Limit != 0 {
+		pctUsed = 100.0 * float64(totalUsage) / float64(memoryStats.Limit)
+	}
+	mb.appendDoubleDataPoint(mb.doubleGauge("memory.percent", "%"), nil, pctUsed)
+	mb.appendIntDataPoint(mb.intGauge("memory.usage.max", "By"), nil, int64(memoryStats.MaxUsage))
+
+	// Sorted iteration for reproducibility, largely for testing
+	sortedNames := make([]string, 0, len(memoryStats.Stats))
+	for statName := range memoryStats.Stats {
+		sortedNames = append(sortedNames, statName)
+	}
+	sort.Strings(sortedNames)
+
+	for _, statName := range sortedNames {
+		v := int64(memoryStats.Stats[statName])
+		if unit, ok := memoryStatUnits[statName]; ok {
+			mb.appendIntDataPoint(mb.intSum("memory."+statName, unit), nil, v)
+		} else {
+			mb.appendIntDataPoint(mb.intGauge("memory."+statName, "By"), nil, v)
+		}
+	}
+}
+
+func (mb *metricsBuilder) appendNetworkMetrics(networks map[string]dtypes.NetworkStats) {
+	if len(networks) == 0 {
+		return
+	}
+
+	// Sorted iteration for reproducibility, largely for testing
+	nics := make([]string, 0, len(networks))
+	for nic := range networks {
+		nics = append(nics, nic)
+	}
+	sort.Strings(nics)
+
+	for _, stat := range []struct {
+		name  string
+		unit  string
+		value func(dtypes.NetworkStats) uint64
+	}{
+		{"rx_bytes", "By", func(s dtypes.NetworkStats) uint64 { return s.RxBytes }},
+		{"tx_bytes", "By", func(s dtypes.NetworkStats) uint64 { return s.TxBytes }},
+		{"rx_dropped", "{packets}", func(s dtypes.NetworkStats) uint64 { return s.RxDropped }},
+		{"rx_errors", "{errors}", func(s dtypes.NetworkStats) uint64 { return s.RxErrors }},
+		{"rx_packets", "{packets}", func(s dtypes.NetworkStats) uint64 { return s.RxPackets }},
+		{"tx_dropped", "{packets}", func(s dtypes.NetworkStats) uint64 { return s.TxDropped }},
+		{"tx_errors", "{errors}", func(s dtypes.NetworkStats) uint64 { return s.TxErrors }},
+		{"tx_packets", "{packets}", func(s dtypes.NetworkStats) uint64 { return s.TxPackets }},
+	} {
+		dps := mb.intSum("network.io.usage."+stat.name, stat.unit)
+		for _, nic := range nics {
+			mb.appendIntDataPoint(dps, map[string]string{labelInterface: nic}, int64(stat.value(networks[nic])))
+		}
+	}
+}
+
+// metrics for the lifecycle of the container, as reported by the inspect api
+func (mb *metricsBuilder) appendStateMetrics(state *dtypes.ContainerState, restartCount int, created pdata.TimestampUnixNano) {
+	if state == nil {
+		return
+	}
+
+	if v, ok := containerStates[state.Status]; ok {
+		mb.appendIntDataPoint(mb.intGauge("state", "1"), nil, v)
+	}
+
+	if state.Health != nil {
+		if v, ok := healthStatuses[state.Health.Status]; ok {
+			mb.appendIntDataPoint(mb.intGauge("health.status", "1"), nil, v)
+		}
+	}
+
+	// Restarts are counted since the container was created, the start time of
+	// the other cumulative metrics being reset on each restart.
+	restarts := mb.intSum("restarts", "{restarts}")
+	mb.appendIntDataPoint(restarts, nil, int64(restartCount))
+	restarts.At(0).SetStartTime(created)
+	mb.appendIntDataPoint(mb.intGauge("exit_code", "1"), nil, int64(state.ExitCode))
+
+	if state.Running && mb.start != 0 {
+		uptime := time.Duration(mb.ts - mb.start)
+		mb.appendDoubleDataPoint(mb.doubleGauge("uptime", "s"), nil, uptime.Seconds())
+	}
+}
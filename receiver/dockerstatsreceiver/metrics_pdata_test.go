@@ -0,0 +1,227 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dockerstatsreceiver
+
+import (
+	"testing"
+	"time"
+
+	dtypes "github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type pdataPoint struct {
+	labels map[string]string
+	value  float64
+}
+
+type pdataMetric struct {
+	dataType pdata.MetricDataType
+	unit     string
+	points   []pdataPoint
+}
+
+// pdataMetrics flattens the metrics of md by name.
+func pdataMetrics(t *testing.T, md pdata.Metrics) map[string]pdataMetric {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	flattened := map[string]pdataMetric{}
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		m := pdataMetric{dataType: metric.DataType(), unit: metric.Unit()}
+
+		intDps := pdata.NewIntDataPointSlice()
+		switch metric.DataType() {
+		case pdata.MetricDataTypeIntSum:
+			assert.True(t, metric.IntSum().IsMonotonic(), metric.Name())
+			assert.Equal(t, pdata.AggregationTemporalityCumulative, metric.IntSum().AggregationTemporality(), metric.Name())
+			intDps = metric.IntSum().DataPoints()
+		case pdata.MetricDataTypeIntGauge:
+			intDps = metric.IntGauge().DataPoints()
+		case pdata.MetricDataTypeDoubleGauge:
+			dps := metric.DoubleGauge().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				m.points = append(m.points, pdataPoint{labelsToMap(dps.At(j).LabelsMap()), dps.At(j).Value()})
+			}
+		default:
+			t.Fatalf("unexpected data type %v of %s", metric.DataType(), metric.Name())
+		}
+		for j := 0; j < intDps.Len(); j++ {
+			m.points = append(m.points, pdataPoint{labelsToMap(intDps.At(j).LabelsMap()), float64(intDps.At(j).Value())})
+		}
+
+		_, duplicate := flattened[metric.Name()]
+		require.False(t, duplicate, metric.Name())
+		flattened[metric.Name()] = m
+	}
+	return flattened
+}
+
+func labelsToMap(labels pdata.StringMap) map[string]string {
+	m := map[string]string{}
+	labels.ForEach(func(k string, v pdata.StringValue) {
+		m[k] = v.Value()
+	})
+	return m
+}
+
+func TestStatsToPdata(t *testing.T) {
+	md := ContainerStatsToPdata(statsJSON(t), containerJSON(t), &Config{
+		EnvVarsToMetricLabels: map[string]string{"MY_ENV_VAR": "my.env.to.metric.label"},
+	})
+
+	assert.Equal(t, map[string]string{
+		"container.hostname":     "abcdef012345",
+		"container.id":           "a2596076ca048f02bcd16a8acd12a7ea2d3bc430d1cde095357239dd3925a4c3",
+		"container.image.name":   "myImage",
+		"container.name":         "my-container-name",
+		"container.runtime":      "docker",
+		"my.env.to.metric.label": "my_env_var_value",
+	}, attributesToMap(md.ResourceMetrics().At(0).Resource().Attributes()))
+
+	metrics := pdataMetrics(t, md)
+	// The blkio and network stats are labeled rather than split in one metric per
+	// operation and interface.
+	assert.Equal(t, 58, len(metrics))
+
+	noLabels := map[string]string{}
+	assert.Equal(t, pdataMetric{
+		dataType: pdata.MetricDataTypeIntSum,
+		unit:     "By",
+		points: []pdataPoint{
+			{map[string]string{"device_major": "202", "device_minor": "0", "operation": "read"}, 56500224},
+			{map[string]string{"device_major": "202", "device_minor": "0", "operation": "write"}, 12103680},
+			{map[string]string{"device_major": "202", "device_minor": "0", "operation": "sync"}, 65314816},
+			{map[string]string{"device_major": "202", "device_minor": "0", "operation": "async"}, 3289088},
+			{map[string]string{"device_major": "202", "device_minor": "0", "operation": "discard"}, 0},
+			{map[string]string{"device_major": "202", "device_minor": "0", "operation": "total"}, 68603904},
+		},
+	}, metrics["container.blockio.io_service_bytes_recursive"])
+	assert.Equal(t, pdataMetric{
+		dataType: pdata.MetricDataTypeIntSum,
+		unit:     "ns",
+		points:   []pdataPoint{{noLabels, 8043152341}},
+	}, metrics["container.cpu.usage.total"])
+	assert.Equal(t, pdataMetric{
+		dataType: pdata.MetricDataTypeDoubleGauge,
+		unit:     "%",
+		points:   []pdataPoint{{noLabels, 0.19316}},
+	}, metrics["container.cpu.percent"])
+	assert.Equal(t, pdataMetric{
+		dataType: pdata.MetricDataTypeIntGauge,
+		unit:     "By",
+		points:   []pdataPoint{{noLabels, 75915264}},
+	}, metrics["container.memory.usage.total"])
+	assert.Equal(t, pdataMetric{
+		dataType: pdata.MetricDataTypeIntSum,
+		unit:     "{faults}",
+		points:   []pdataPoint{{noLabels, 21714}},
+	}, metrics["container.memory.pgfault"])
+	assert.Equal(t, pdataMetric{
+		dataType: pdata.MetricDataTypeIntSum,
+		unit:     "{packets}",
+		points:   []pdataPoint{{map[string]string{"interface": "eth0"}, 16598}},
+	}, metrics["container.network.io.usage.rx_packets"])
+	assert.Equal(t, pdataMetric{
+		dataType: pdata.MetricDataTypeIntGauge,
+		unit:     "1",
+		points:   []pdataPoint{{noLabels, 2}},
+	}, metrics["container.state"])
+	assert.Equal(t, pdata.MetricDataTypeDoubleGauge, metrics["container.uptime"].dataType)
+	assert.Equal(t, pdataMetric{
+		dataType: pdata.MetricDataTypeIntSum,
+		unit:     "{restarts}",
+		points:   []pdataPoint{{noLabels, 0}},
+	}, metrics["container.restarts"])
+	assert.Greater(t, metrics["container.uptime"].points[0].value, 0.0)
+
+	_, ok := metrics["container.cpu.usage.percpu"]
+	assert.False(t, ok)
+}
+
+func TestRestartsStartTime(t *testing.T) {
+	container := containerJSON(t)
+	container.Created = time.Unix(10, 0).UTC().Format(time.RFC3339Nano)
+	container.State.StartedAt = time.Unix(40, 0).UTC().Format(time.RFC3339Nano)
+	md := ContainerStatsToPdata(statsJSON(t), container, &Config{})
+
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if metric.DataType() != pdata.MetricDataTypeIntSum {
+			continue
+		}
+		start := metric.IntSum().DataPoints().At(0).StartTime()
+		if metric.Name() == "container.restarts" {
+			assert.Equal(t, pdata.TimestampUnixNano(10*time.Second), start, metric.Name())
+		} else {
+			assert.Equal(t, pdata.TimestampUnixNano(40*time.Second), start, metric.Name())
+		}
+	}
+}
+
+func TestStatsToPdataPerCoreCPU(t *testing.T) {
+	md := ContainerStatsToPdata(statsJSON(t), containerJSON(t), &Config{ProvidePerCoreCPUMetrics: true})
+
+	metrics := pdataMetrics(t, md)
+	assert.Equal(t, pdataMetric{
+		dataType: pdata.MetricDataTypeIntSum,
+		unit:     "ns",
+		points: []pdataPoint{
+			{map[string]string{"core": "cpu0"}, 8043152341},
+			{map[string]string{"core": "cpu1"}, 0},
+			{map[string]string{"core": "cpu2"}, 0},
+			{map[string]string{"core": "cpu3"}, 0},
+			{map[string]string{"core": "cpu4"}, 0},
+			{map[string]string{"core": "cpu5"}, 0},
+			{map[string]string{"core": "cpu6"}, 0},
+			{map[string]string{"core": "cpu7"}, 0},
+		},
+	}, metrics["container.cpu.usage.total"])
+}
+
+func TestMemoryCacheAboveUsageToPdata(t *testing.T) {
+	stats := &dtypes.StatsJSON{}
+	stats.MemoryStats = dtypes.MemoryStats{
+		Usage: 1024,
+		Limit: 4096,
+		Stats: map[string]uint64{"cache": 2048, "total_cache": 2048},
+	}
+	md := ContainerStatsToPdata(stats, containerJSON(t), &Config{})
+
+	metrics := pdataMetrics(t, md)
+	assert.Equal(t, []pdataPoint{{map[string]string{}, 0}}, metrics["container.memory.usage.total"].points)
+	assert.Equal(t, []pdataPoint{{map[string]string{}, 0.0}}, metrics["container.memory.percent"].points)
+
+	stats.MemoryStats.Usage = 3072
+	md = ContainerStatsToPdata(stats, containerJSON(t), &Config{})
+
+	metrics = pdataMetrics(t, md)
+	assert.Equal(t, []pdataPoint{{map[string]string{}, 1024}}, metrics["container.memory.usage.total"].points)
+	assert.Equal(t, []pdataPoint{{map[string]string{}, 25.0}}, metrics["container.memory.percent"].points)
+}
+
+func TestZeroValueStatsToPdata(t *testing.T) {
+	stats := &dtypes.StatsJSON{}
+	md := ContainerStatsToPdata(stats, containerJSON(t), &Config{ProvidePerCoreCPUMetrics: true})
+
+	metrics := pdataMetrics(t, md)
+	// No blkio, memory stats nor network metrics without the stats.
+	assert.Equal(t, 16, len(metrics))
+	assert.Equal(t, []pdataPoint{{map[string]string{}, 0}}, metrics["container.cpu.usage.total"].points)
+}
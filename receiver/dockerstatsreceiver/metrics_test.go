@@ -19,13 +19,18 @@ import (
 	"io/ioutil"
 	"path"
 	"testing"
+	"time"
 
 	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
 	dtypes "github.com/docker/docker/api/types"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
 )
 
 type Metric struct {
@@ -179,6 +184,21 @@ func defaultMetrics() []Metric {
 	}
 }
 
+// stateMetrics returns the lifecycle metrics of the running container of testdata/container.json.
+func stateMetrics(t *testing.T, ts *timestamp.Timestamp) []Metric {
+	now, err := ptypes.Timestamp(ts)
+	require.NoError(t, err)
+	startedAt, err := time.Parse(time.RFC3339Nano, "2020-01-01T00:00:02.012345678Z")
+	require.NoError(t, err)
+
+	return []Metric{
+		{name: "container.state", mtype: metricspb.MetricDescriptor_GAUGE_INT64, unit: "1", labelKeys: nil, values: []Value{{labelValues: nil, value: 2}}},
+		{name: "container.restarts", mtype: metricspb.MetricDescriptor_CUMULATIVE_INT64, unit: "1", labelKeys: nil, values: []Value{{labelValues: nil, value: 0}}},
+		{name: "container.exit_code", mtype: metricspb.MetricDescriptor_GAUGE_INT64, unit: "1", labelKeys: nil, values: []Value{{labelValues: nil, value: 0}}},
+		{name: "container.uptime", mtype: metricspb.MetricDescriptor_GAUGE_DOUBLE, unit: "s", labelKeys: nil, values: []Value{{labelValues: nil, doubleValue: now.Sub(startedAt).Seconds()}}},
+	}
+}
+
 func mergeMaps(maps ...map[string]string) map[string]string {
 	merged := map[string]string{}
 	for _, m := range maps {
@@ -197,6 +217,7 @@ func assertMetricsDataEqual(
 ) {
 	// Timestamps are generated per ContainerStatsToMetrics call so should be conserved
 	ts := actual.Metrics[0].Timeseries[0].Points[0].Timestamp
+	expected = append(expected, stateMetrics(t, ts)...)
 	expectedMd := metricsData(ts, labels, expected...)
 
 	// Separate for debuggability
@@ -254,7 +275,7 @@ func statsJSON(t *testing.T) *dtypes.StatsJSON {
 	return &stats
 }
 
-func containerJSON(t *testing.T) *DockerContainer {
+func containerJSON(t *testing.T) *docker.Container {
 	containerRaw, err := ioutil.ReadFile(path.Join(".", "testdata", "container.json"))
 	if err != nil {
 		t.Fatal(err)
@@ -265,9 +286,9 @@ func containerJSON(t *testing.T) *DockerContainer {
 	if err != nil {
 		t.Fatal(err)
 	}
-	return &DockerContainer{
+	return &docker.Container{
 		ContainerJSON: &container,
-		EnvMap:        containerEnvToMap(container.Config.Env),
+		EnvMap:        docker.ContainerEnvToMap(container.Config.Env),
 	}
 }
 
@@ -423,3 +444,62 @@ func TestContainerLabelToMetricLabels(t *testing.T) {
 
 	assertMetricsDataEqual(t, defaultMetrics(), expectedLabels, md)
 }
+
+func TestContainerStateMetrics(t *testing.T) {
+	ts, _ := ptypes.TimestampProto(time.Unix(100, 0))
+
+	tests := []struct {
+		name         string
+		state        *dtypes.ContainerState
+		restartCount int
+		expected     []Metric
+	}{
+		{
+			name:     "no state",
+			state:    nil,
+			expected: nil,
+		},
+		{
+			name: "unhealthy",
+			state: &dtypes.ContainerState{
+				Status:    "running",
+				Running:   true,
+				StartedAt: time.Unix(40, 0).UTC().Format(time.RFC3339Nano),
+				Health:    &dtypes.Health{Status: dtypes.Unhealthy},
+			},
+			restartCount: 3,
+			expected: []Metric{
+				{name: "state", mtype: metricspb.MetricDescriptor_GAUGE_INT64, unit: "1", values: []Value{{value: 2}}},
+				{name: "health.status", mtype: metricspb.MetricDescriptor_GAUGE_INT64, unit: "1", values: []Value{{value: 3}}},
+				{name: "restarts", mtype: metricspb.MetricDescriptor_CUMULATIVE_INT64, unit: "1", values: []Value{{value: 3}}},
+				{name: "exit_code", mtype: metricspb.MetricDescriptor_GAUGE_INT64, unit: "1", values: []Value{{value: 0}}},
+				{name: "uptime", mtype: metricspb.MetricDescriptor_GAUGE_DOUBLE, unit: "s", values: []Value{{doubleValue: 60}}},
+			},
+		},
+		{
+			name: "exited",
+			state: &dtypes.ContainerState{
+				Status:    "exited",
+				ExitCode:  137,
+				StartedAt: time.Unix(40, 0).UTC().Format(time.RFC3339Nano),
+			},
+			restartCount: 1,
+			expected: []Metric{
+				{name: "state", mtype: metricspb.MetricDescriptor_GAUGE_INT64, unit: "1", values: []Value{{value: 6}}},
+				{name: "restarts", mtype: metricspb.MetricDescriptor_CUMULATIVE_INT64, unit: "1", values: []Value{{value: 1}}},
+				{name: "exit_code", mtype: metricspb.MetricDescriptor_GAUGE_INT64, unit: "1", values: []Value{{value: 137}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := containerStateMetrics(tt.state, tt.restartCount, ts)
+			// The "container." prefix is added when the metrics are created.
+			for i := range tt.expected {
+				tt.expected[i].name = metricPrefix + tt.expected[i].name
+			}
+			assert.Equal(t, metricsData(ts, nil, tt.expected...).Metrics, metrics)
+		})
+	}
+}
@@ -22,22 +22,32 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
-	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/translator/internaldata"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver/interval"
 )
 
+const (
+	dockerAPIVersion = "v1.22"
+)
+
 var _ component.MetricsReceiver = (*Receiver)(nil)
+var _ component.LogsReceiver = (*Receiver)(nil)
 var _ interval.Runnable = (*Receiver)(nil)
 
+// Receiver polls the container stats when part of a metrics pipeline and
+// watches the container events when part of a logs pipeline, the same
+// instance being shared by the pipelines of a config.
 type Receiver struct {
 	config            *Config
 	logger            *zap.Logger
 	nextConsumer      consumer.MetricsConsumer
-	client            *dockerClient
+	logsConsumer      consumer.LogsConsumer
+	client            *docker.Client
 	runner            *interval.Runner
 	obsCtx            context.Context
 	runnerCtx         context.Context
@@ -46,12 +56,24 @@ type Receiver struct {
 	transport         string
 }
 
+// NewReceiver creates the receiver of the container stats metrics.
 func NewReceiver(
 	_ context.Context,
 	logger *zap.Logger,
 	config *Config,
 	nextConsumer consumer.MetricsConsumer,
 ) (component.MetricsReceiver, error) {
+	receiver, err := newReceiver(logger, config)
+	if err != nil {
+		return nil, err
+	}
+	receiver.nextConsumer = nextConsumer
+	return receiver, nil
+}
+
+// newReceiver creates a receiver without consumers, they are set for each
+// pipeline the receiver is part of.
+func newReceiver(logger *zap.Logger, config *Config) (*Receiver, error) {
 	err := config.Validate()
 	if err != nil {
 		return nil, err
@@ -62,19 +84,28 @@ func NewReceiver(
 		return nil, fmt.Errorf("could not determine receiver transport: %w", err)
 	}
 
-	receiver := Receiver{
-		config:       config,
-		nextConsumer: nextConsumer,
-		logger:       logger,
-		transport:    parsed.Scheme,
-	}
-
-	return &receiver, nil
+	return &Receiver{
+		config:    config,
+		logger:    logger,
+		transport: parsed.Scheme,
+	}, nil
 }
 
 func (r *Receiver) Start(ctx context.Context, host component.Host) error {
 	var err error
-	r.client, err = newDockerClient(r.config, r.logger)
+	dConfig := &docker.Config{
+		Endpoint:         r.config.Endpoint,
+		Timeout:          r.config.Timeout,
+		ExcludedImages:   r.config.ExcludedImages,
+		Include:          r.config.Include,
+		Exclude:          r.config.Exclude,
+		DockerAPIVersion: dockerAPIVersion,
+	}
+	if r.logsConsumer != nil {
+		dConfig.EventHandler = r.consumeEvent
+	}
+
+	r.client, err = docker.NewDockerClient(dConfig, r.logger)
 	if err != nil {
 		return err
 	}
@@ -82,6 +113,16 @@ func (r *Receiver) Start(ctx context.Context, host component.Host) error {
 	r.obsCtx = obsreport.ReceiverContext(ctx, typeStr, r.transport, r.config.Name())
 
 	r.runnerCtx, r.runnerCancel = context.WithCancel(context.Background())
+
+	// The events are watched right away when emitted as logs, otherwise they
+	// are only watched once the containers are listed, to keep them up to date.
+	if r.logsConsumer != nil {
+		go r.client.ContainerEventLoop(r.runnerCtx)
+	}
+	if r.nextConsumer == nil {
+		return nil
+	}
+
 	r.runner = interval.NewRunner(r.config.CollectionInterval, r)
 
 	go func() {
@@ -94,8 +135,12 @@ func (r *Receiver) Start(ctx context.Context, host component.Host) error {
 }
 
 func (r *Receiver) Shutdown(ctx context.Context) error {
-	r.runnerCancel()
-	r.runner.Stop()
+	if r.runnerCancel != nil {
+		r.runnerCancel()
+	}
+	if r.runner != nil {
+		r.runner.Stop()
+	}
 	return nil
 }
 
@@ -105,13 +150,15 @@ func (r *Receiver) Setup() error {
 		return err
 	}
 
-	go r.client.ContainerEventLoop(r.runnerCtx)
+	if r.logsConsumer == nil {
+		go r.client.ContainerEventLoop(r.runnerCtx)
+	}
 	r.successfullySetup = true
 	return nil
 }
 
 type result struct {
-	md  *consumerdata.MetricsData
+	md  pdata.Metrics
 	err error
 }
 
@@ -128,8 +175,8 @@ func (r *Receiver) Run() error {
 	wg := &sync.WaitGroup{}
 	wg.Add(len(containers))
 	for _, container := range containers {
-		go func(dc DockerContainer) {
-			md, err := r.client.FetchContainerStatsAndConvertToMetrics(r.runnerCtx, dc)
+		go func(dc docker.Container) {
+			md, err := r.fetchContainerStatsAndConvertToMetrics(dc)
 			results <- result{md, err}
 			wg.Done()
 		}(container)
@@ -139,17 +186,16 @@ func (r *Receiver) Run() error {
 	close(results)
 
 	numPoints := 0
-	numTimeSeries := 0
+	numMetrics := 0
 	var lastErr error
 	for result := range results {
 		var err error
-		if result.md != nil {
-			nts, np := obsreport.CountMetricPoints(*result.md)
-			numTimeSeries += nts
+		if result.err == nil {
+			nm, np := result.md.MetricAndDataPointCount()
+			numMetrics += nm
 			numPoints += np
 
-			md := internaldata.OCToMetrics(*result.md)
-			err = r.nextConsumer.ConsumeMetrics(r.runnerCtx, md)
+			err = r.nextConsumer.ConsumeMetrics(r.runnerCtx, result.md)
 		} else {
 			err = result.err
 		}
@@ -159,6 +205,33 @@ func (r *Receiver) Run() error {
 		}
 	}
 
-	obsreport.EndMetricsReceiveOp(c, typeStr, numPoints, numTimeSeries, lastErr)
+	// Like the OTLP receiver, the metrics are reported as the legacy time series.
+	obsreport.EndMetricsReceiveOp(c, typeStr, numPoints, numMetrics, lastErr)
 	return nil
 }
+
+// fetchContainerStatsAndConvertToMetrics queries the stats of the container and converts them to metrics.
+func (r *Receiver) fetchContainerStatsAndConvertToMetrics(container docker.Container) (pdata.Metrics, error) {
+	statsJSON, err := r.client.FetchContainerStatsAsJSON(r.runnerCtx, container)
+	if err != nil {
+		return pdata.NewMetrics(), err
+	}
+
+	if !r.config.LegacyMetrics {
+		return ContainerStatsToPdata(statsJSON, &container, r.config), nil
+	}
+
+	md, err := ContainerStatsToMetrics(statsJSON, &container, r.config)
+	if err != nil {
+		r.logger.Error(
+			"Could not convert docker containerStats for container id",
+			zap.String("id", container.ID),
+			zap.Error(err),
+		)
+		return pdata.NewMetrics(), err
+	}
+	if md == nil {
+		return pdata.NewMetrics(), nil
+	}
+	return internaldata.OCToMetrics(*md), nil
+}
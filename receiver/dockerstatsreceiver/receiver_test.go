@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/testbed/testbed"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/docker"
 )
 
 func TestNewReceiver(t *testing.T) {
@@ -56,6 +58,15 @@ func TestNewReceiverErrors(t *testing.T) {
 	assert.Nil(t, r)
 	require.Error(t, err)
 	assert.Equal(t, "config.CollectionInterval must be specified", err.Error())
+
+	r, err = NewReceiver(context.Background(), logger, &Config{
+		Endpoint:           "someEndpoint",
+		CollectionInterval: time.Second,
+		Include:            &docker.MatchConfig{MatchType: "fuzzy", Names: []string{"web"}},
+	}, &testbed.MockMetricConsumer{})
+	assert.Nil(t, r)
+	require.Error(t, err)
+	assert.Equal(t, `invalid include filter: invalid names: unknown match type "fuzzy", must be one of "strict", "regexp" or "glob"`, err.Error())
 }
 
 func TestErrorsInStart(t *testing.T) {
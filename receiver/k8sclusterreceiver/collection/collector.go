@@ -28,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/utils"
 )
 
 // TODO: Consider moving some of these constants to
@@ -46,18 +48,33 @@ const (
 	k8sKeyReplicationControllerUID = "k8s.replicationcontroller.uid"
 	k8sKeyHPAUID                   = "k8s.hpa.uid"
 	k8sKeyResourceQuotaUID         = "k8s.resourcequota.uid"
+	k8sKeyPersistentVolumeUID      = "k8s.persistentvolume.uid"
+	k8sKeyPersistentVolumeClaimUID = "k8s.persistentvolumeclaim.uid"
+	k8sKeyServiceUID               = "k8s.service.uid"
+	k8sKeyEndpointsUID             = "k8s.endpoints.uid"
 
 	// Resource labels keys for Name.
 	k8sKeyNodeName                  = "k8s.node.name"
 	k8sKeyReplicationControllerName = "k8s.replicationcontroller.name"
 	k8sKeyHPAName                   = "k8s.hpa.name"
 	k8sKeyResourceQuotaName         = "k8s.resourcequota.name"
+	k8sKeyPersistentVolumeName      = "k8s.persistentvolume.name"
+	k8sKeyPersistentVolumeClaimName = "k8s.persistentvolumeclaim.name"
+	k8sKeyServiceName               = "k8s.service.name"
+	k8sKeyEndpointsName             = "k8s.endpoints.name"
+
+	// Other resource labels keys.
+	k8sKeyStorageClassName = "k8s.storageclass.name"
+	k8sKeyServiceType      = "k8s.service.type"
 
 	// Kubernetes resource kinds
 	k8sKindCronJob               = "CronJob"
 	k8sKindDaemonSet             = "DaemonSet"
 	k8sKindDeployment            = "Deployment"
+	k8sKindEndpoints             = "Endpoints"
 	k8sKindJob                   = "Job"
+	k8sKindPersistentVolume      = "PersistentVolume"
+	k8sKindPersistentVolumeClaim = "PersistentVolumeClaim"
 	k8sKindReplicationController = "ReplicationController"
 	k8sKindReplicaSet            = "ReplicaSet"
 	k8sKindService               = "Service"
@@ -131,6 +148,15 @@ func (dc *DataCollector) SyncMetrics(obj interface{}) {
 		rm = getMetricsForReplicationController(o)
 	case *corev1.ResourceQuota:
 		rm = getMetricsForResourceQuota(o)
+	case *corev1.PersistentVolume:
+		rm = getMetricsForPersistentVolume(o)
+	case *corev1.PersistentVolumeClaim:
+		rm = getMetricsForPersistentVolumeClaim(o)
+	case *corev1.Service:
+		rm = getMetricsForService(o, dc.metadataStore.endpoints)
+	case *corev1.Endpoints:
+		rm = getMetricsForEndpoints(o)
+		dc.syncServiceForEndpoints(o)
 	case *appsv1.Deployment:
 		rm = getMetricsForDeployment(o)
 	case *appsv1.ReplicaSet:
@@ -166,6 +192,14 @@ func (dc *DataCollector) SyncMetadata(obj interface{}) map[ResourceID]*Kubernete
 		km = getMetadataForNode(o)
 	case *corev1.ReplicationController:
 		km = getMetadataForReplicationController(o)
+	case *corev1.PersistentVolume:
+		km = getMetadataForPersistentVolume(o)
+	case *corev1.PersistentVolumeClaim:
+		km = getMetadataForPersistentVolumeClaim(o)
+	case *corev1.Service:
+		km = getMetadataForService(o)
+	case *corev1.Endpoints:
+		km = getMetadataForEndpoints(o)
 	case *appsv1.Deployment:
 		km = getMetadataForDeployment(o)
 	case *appsv1.ReplicaSet:
@@ -184,3 +218,17 @@ func (dc *DataCollector) SyncMetadata(obj interface{}) map[ResourceID]*Kubernete
 
 	return km
 }
+
+// syncServiceForEndpoints refreshes the metrics of the service backed by the
+// given endpoints, since its endpoint count is derived from them.
+func (dc *DataCollector) syncServiceForEndpoints(ep *corev1.Endpoints) {
+	if dc.metadataStore.services == nil {
+		return
+	}
+	obj, exists, err := dc.metadataStore.services.GetByKey(utils.GetIDForCache(ep.Namespace, ep.Name))
+	if err != nil || !exists {
+		return
+	}
+	svc := obj.(*corev1.Service)
+	dc.UpdateMetricsStore(svc, getMetricsForService(svc, dc.metadataStore.endpoints))
+}
@@ -0,0 +1,92 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"go.opentelemetry.io/collector/translator/conventions"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/utils"
+)
+
+var endpointsReadyAddressesMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.endpoints.ready_addresses",
+	Description: "The number of addresses ready to receive traffic",
+	Unit:        "1",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+var endpointsNotReadyAddressesMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.endpoints.not_ready_addresses",
+	Description: "The number of addresses not ready to receive traffic",
+	Unit:        "1",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+func getMetricsForEndpoints(ep *corev1.Endpoints) []*resourceMetrics {
+	ready, notReady := getEndpointsAddressCounts(ep)
+
+	metrics := []*metricspb.Metric{
+		{
+			MetricDescriptor: endpointsReadyAddressesMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(int64(ready)),
+			},
+		},
+		{
+			MetricDescriptor: endpointsNotReadyAddressesMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(int64(notReady)),
+			},
+		},
+	}
+
+	return []*resourceMetrics{
+		{
+			resource: getResourceForEndpoints(ep),
+			metrics:  metrics,
+		},
+	}
+}
+
+// getEndpointsAddressCounts returns the number of ready and not ready addresses
+// across the subsets of the endpoints.
+func getEndpointsAddressCounts(ep *corev1.Endpoints) (ready int, notReady int) {
+	for _, subset := range ep.Subsets {
+		ready += len(subset.Addresses)
+		notReady += len(subset.NotReadyAddresses)
+	}
+	return ready, notReady
+}
+
+func getResourceForEndpoints(ep *corev1.Endpoints) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Type: k8sType,
+		Labels: map[string]string{
+			k8sKeyEndpointsUID:                string(ep.UID),
+			k8sKeyEndpointsName:               ep.Name,
+			conventions.AttributeK8sNamespace: ep.Namespace,
+			conventions.AttributeK8sCluster:   ep.ClusterName,
+		},
+	}
+}
+
+func getMetadataForEndpoints(ep *corev1.Endpoints) map[ResourceID]*KubernetesMetadata {
+	rm := getGenericMetadata(&ep.ObjectMeta, k8sKindEndpoints)
+	rm.metadata[conventions.AttributeK8sNamespace] = ep.Namespace
+	return map[ResourceID]*KubernetesMetadata{ResourceID(ep.UID): rm}
+}
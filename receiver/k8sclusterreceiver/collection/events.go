@@ -0,0 +1,132 @@
+// Copyright 2020 OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Log record attribute keys for Kubernetes events.
+const (
+	k8sKeyEventName            = "k8s.event.name"
+	k8sKeyEventUID             = "k8s.event.uid"
+	k8sKeyEventType            = "k8s.event.type"
+	k8sKeyEventReason          = "k8s.event.reason"
+	k8sKeyEventCount           = "k8s.event.count"
+	k8sKeyEventSourceComponent = "k8s.event.source.component"
+	k8sKeyEventSourceHost      = "k8s.event.source.host"
+	k8sKeyObjectKind           = "k8s.object.kind"
+	k8sKeyObjectName           = "k8s.object.name"
+	k8sKeyObjectUID            = "k8s.object.uid"
+	k8sKeyObjectFieldPath      = "k8s.object.fieldpath"
+)
+
+// GetLogsForEvent converts a Kubernetes event to logs made of a single log record. The
+// resource describes the object the event is about, with the same keys as its metadata.
+func GetLogsForEvent(event *corev1.Event) pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rl := rls.At(0)
+
+	rl.Resource().InitEmpty()
+	getResourceForEvent(event).CopyTo(rl.Resource().Attributes())
+
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(1)
+	lrs := ills.At(0).Logs()
+	lrs.Resize(1)
+	lr := lrs.At(0)
+
+	lr.SetName(event.Reason)
+	lr.SetTimestamp(pdata.TimestampUnixNano(uint64(GetEventTimestamp(event).UnixNano())))
+	lr.SetSeverityText(event.Type)
+	lr.SetSeverityNumber(getEventSeverity(event))
+	lr.Body().SetStringVal(event.Message)
+
+	attrs := lr.Attributes()
+	attrs.InsertString(k8sKeyEventName, event.Name)
+	attrs.InsertString(k8sKeyEventUID, string(event.UID))
+	attrs.InsertString(k8sKeyEventType, event.Type)
+	attrs.InsertString(k8sKeyEventReason, event.Reason)
+	attrs.InsertInt(k8sKeyEventCount, int64(event.Count))
+	attrs.InsertString(k8sKeyObjectKind, event.InvolvedObject.Kind)
+	attrs.InsertString(k8sKeyObjectName, event.InvolvedObject.Name)
+	attrs.InsertString(k8sKeyObjectUID, string(event.InvolvedObject.UID))
+	if event.InvolvedObject.FieldPath != "" {
+		attrs.InsertString(k8sKeyObjectFieldPath, event.InvolvedObject.FieldPath)
+	}
+	if event.Source.Component != "" {
+		attrs.InsertString(k8sKeyEventSourceComponent, event.Source.Component)
+	}
+	if event.Source.Host != "" {
+		attrs.InsertString(k8sKeyEventSourceHost, event.Source.Host)
+	}
+
+	return ld
+}
+
+// getResourceForEvent returns the resource attributes of the object involved in the event.
+func getResourceForEvent(event *corev1.Event) pdata.AttributeMap {
+	obj := event.InvolvedObject
+	rType := strings.ToLower(obj.Kind)
+
+	attrs := pdata.NewAttributeMap()
+	attrs.InsertString(getResourceIDKey(rType), string(obj.UID))
+	attrs.InsertString(fmt.Sprintf("k8s.%s.name", rType), obj.Name)
+	attrs.InsertString(k8sKeyWorkLoadKind, obj.Kind)
+	attrs.InsertString(k8sKeyWorkLoadName, obj.Name)
+	if obj.Namespace != "" {
+		attrs.InsertString(conventions.AttributeK8sNamespace, obj.Namespace)
+	}
+	if event.ClusterName != "" {
+		attrs.InsertString(conventions.AttributeK8sCluster, event.ClusterName)
+	}
+	return attrs
+}
+
+// GetEventTimestamp returns the last time the event was observed, falling back to
+// the other timestamps of the event when it isn't set.
+func GetEventTimestamp(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// getEventSeverity maps the type of the event to a severity, Kubernetes only
+// defining Normal and Warning events.
+func getEventSeverity(event *corev1.Event) pdata.SeverityNumber {
+	switch event.Type {
+	case corev1.EventTypeNormal:
+		return pdata.SeverityNumberINFO
+	case corev1.EventTypeWarning:
+		return pdata.SeverityNumberWARN
+	default:
+		return pdata.SeverityNumberUNDEFINED
+	}
+}
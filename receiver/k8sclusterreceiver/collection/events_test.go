@@ -0,0 +1,124 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGetLogsForEvent(t *testing.T) {
+	lastTimestamp := time.Date(2020, 10, 6, 12, 0, 0, 0, time.UTC)
+	event := &corev1.Event{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "test-pod-1.163b3b8e9d3a7a1c",
+			Namespace:   "test-namespace",
+			UID:         types.UID("test-event-1-uid"),
+			ClusterName: "test-cluster",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: "test-namespace",
+			Name:      "test-pod-1",
+			UID:       types.UID("test-pod-1-uid"),
+			FieldPath: "spec.containers{container-name}",
+		},
+		Reason:        "BackOff",
+		Message:       "Back-off restarting failed container",
+		Source:        corev1.EventSource{Component: "kubelet", Host: "test-node"},
+		Count:         5,
+		Type:          corev1.EventTypeWarning,
+		LastTimestamp: v1.NewTime(lastTimestamp),
+	}
+
+	ld := GetLogsForEvent(event)
+	require.Equal(t, 1, ld.LogRecordCount())
+
+	rl := ld.ResourceLogs().At(0)
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.pod.uid":        pdata.NewAttributeValueString("test-pod-1-uid"),
+		"k8s.pod.name":       pdata.NewAttributeValueString("test-pod-1"),
+		"k8s.workload.kind":  pdata.NewAttributeValueString("Pod"),
+		"k8s.workload.name":  pdata.NewAttributeValueString("test-pod-1"),
+		"k8s.namespace.name": pdata.NewAttributeValueString("test-namespace"),
+		"k8s.cluster.name":   pdata.NewAttributeValueString("test-cluster"),
+	}, attributesToMap(rl.Resource().Attributes()))
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "BackOff", lr.Name())
+	assert.Equal(t, pdata.TimestampUnixNano(lastTimestamp.UnixNano()), lr.Timestamp())
+	assert.Equal(t, "Warning", lr.SeverityText())
+	assert.Equal(t, pdata.SeverityNumberWARN, lr.SeverityNumber())
+	assert.Equal(t, "Back-off restarting failed container", lr.Body().StringVal())
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.event.name":             pdata.NewAttributeValueString("test-pod-1.163b3b8e9d3a7a1c"),
+		"k8s.event.uid":              pdata.NewAttributeValueString("test-event-1-uid"),
+		"k8s.event.type":             pdata.NewAttributeValueString("Warning"),
+		"k8s.event.reason":           pdata.NewAttributeValueString("BackOff"),
+		"k8s.event.count":            pdata.NewAttributeValueInt(5),
+		"k8s.event.source.component": pdata.NewAttributeValueString("kubelet"),
+		"k8s.event.source.host":      pdata.NewAttributeValueString("test-node"),
+		"k8s.object.kind":            pdata.NewAttributeValueString("Pod"),
+		"k8s.object.name":            pdata.NewAttributeValueString("test-pod-1"),
+		"k8s.object.uid":             pdata.NewAttributeValueString("test-pod-1-uid"),
+		"k8s.object.fieldpath":       pdata.NewAttributeValueString("spec.containers{container-name}"),
+	}, attributesToMap(lr.Attributes()))
+}
+
+func TestGetLogsForEventClusterScoped(t *testing.T) {
+	creationTimestamp := time.Date(2020, 10, 6, 12, 0, 0, 0, time.UTC)
+	event := &corev1.Event{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              "test-node.163b3b8e9d3a7a1c",
+			UID:               types.UID("test-event-2-uid"),
+			CreationTimestamp: v1.NewTime(creationTimestamp),
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind: "Node",
+			Name: "test-node",
+			UID:  types.UID("test-node-uid"),
+		},
+		Reason: "NodeReady",
+		Type:   corev1.EventTypeNormal,
+	}
+
+	ld := GetLogsForEvent(event)
+	rl := ld.ResourceLogs().At(0)
+	assert.Equal(t, map[string]pdata.AttributeValue{
+		"k8s.node.uid":      pdata.NewAttributeValueString("test-node-uid"),
+		"k8s.node.name":     pdata.NewAttributeValueString("test-node"),
+		"k8s.workload.kind": pdata.NewAttributeValueString("Node"),
+		"k8s.workload.name": pdata.NewAttributeValueString("test-node"),
+	}, attributesToMap(rl.Resource().Attributes()))
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampUnixNano(creationTimestamp.UnixNano()), lr.Timestamp())
+	assert.Equal(t, pdata.SeverityNumberINFO, lr.SeverityNumber())
+}
+
+func attributesToMap(attrs pdata.AttributeMap) map[string]pdata.AttributeValue {
+	out := map[string]pdata.AttributeValue{}
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		out[k] = v
+	})
+	return out
+}
@@ -29,9 +29,10 @@ type metadataStore struct {
 	services    cache.Store
 	jobs        cache.Store
 	replicaSets cache.Store
+	endpoints   cache.Store
 }
 
-// setupStore tracks metadata of services, jobs and replicasets.
+// setupStore tracks metadata of services, jobs, replicasets and endpoints.
 func (ms *metadataStore) setupStore(o runtime.Object, store cache.Store) {
 	switch o.(type) {
 	case *corev1.Service:
@@ -40,5 +41,7 @@ func (ms *metadataStore) setupStore(o runtime.Object, store cache.Store) {
 		ms.jobs = store
 	case *appsv1.ReplicaSet:
 		ms.replicaSets = store
+	case *corev1.Endpoints:
+		ms.endpoints = store
 	}
 }
@@ -0,0 +1,125 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"go.opentelemetry.io/collector/translator/conventions"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/utils"
+)
+
+var persistentVolumeClaimPhaseMetric = &metricspb.MetricDescriptor{
+	Name: "k8s.persistentvolumeclaim.phase",
+	Description: "The current phase of the persistent volume claim (1 for pending, 2 for bound, " +
+		"3 for lost and -1 for unknown)",
+	Type: metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+var persistentVolumeClaimRequestedMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.persistentvolumeclaim.requested_storage",
+	Description: "The storage requested by the persistent volume claim",
+	Unit:        "By",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+var persistentVolumeClaimCapacityMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.persistentvolumeclaim.capacity",
+	Description: "The storage capacity of the volume bound to the persistent volume claim",
+	Unit:        "By",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+func getMetricsForPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim) []*resourceMetrics {
+	metrics := []*metricspb.Metric{
+		{
+			MetricDescriptor: persistentVolumeClaimPhaseMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(int64(persistentVolumeClaimPhaseValue(pvc.Status.Phase))),
+			},
+		},
+	}
+
+	if requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: persistentVolumeClaimRequestedMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(requested.Value()),
+			},
+		})
+	}
+
+	// The capacity is only known once the claim is bound.
+	if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: persistentVolumeClaimCapacityMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(capacity.Value()),
+			},
+		})
+	}
+
+	return []*resourceMetrics{
+		{
+			resource: getResourceForPersistentVolumeClaim(pvc),
+			metrics:  metrics,
+		},
+	}
+}
+
+func getResourceForPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Type: k8sType,
+		Labels: map[string]string{
+			k8sKeyPersistentVolumeClaimUID:    string(pvc.UID),
+			k8sKeyPersistentVolumeClaimName:   pvc.Name,
+			k8sKeyStorageClassName:            getPersistentVolumeClaimStorageClass(pvc),
+			conventions.AttributeK8sNamespace: pvc.Namespace,
+			conventions.AttributeK8sCluster:   pvc.ClusterName,
+		},
+	}
+}
+
+func getPersistentVolumeClaimStorageClass(pvc *corev1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName == nil {
+		return ""
+	}
+	return *pvc.Spec.StorageClassName
+}
+
+var persistentVolumeClaimPhaseValues = map[corev1.PersistentVolumeClaimPhase]int32{
+	corev1.ClaimPending: 1,
+	corev1.ClaimBound:   2,
+	corev1.ClaimLost:    3,
+}
+
+func persistentVolumeClaimPhaseValue(phase corev1.PersistentVolumeClaimPhase) int32 {
+	if v, ok := persistentVolumeClaimPhaseValues[phase]; ok {
+		return v
+	}
+	// Unknown phase, for instance when it is blank.
+	return -1
+}
+
+func getMetadataForPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim) map[ResourceID]*KubernetesMetadata {
+	rm := getGenericMetadata(&pvc.ObjectMeta, k8sKindPersistentVolumeClaim)
+	rm.metadata[k8sKeyStorageClassName] = getPersistentVolumeClaimStorageClass(pvc)
+	if pvc.Spec.VolumeName != "" {
+		rm.metadata[k8sKeyPersistentVolumeName] = pvc.Spec.VolumeName
+	}
+	return map[ResourceID]*KubernetesMetadata{ResourceID(pvc.UID): rm}
+}
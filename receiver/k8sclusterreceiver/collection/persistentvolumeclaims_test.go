@@ -0,0 +1,109 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/testutils"
+)
+
+func TestPersistentVolumeClaimMetrics(t *testing.T) {
+	pvc := newPersistentVolumeClaim("1")
+
+	actualResourceMetrics := getMetricsForPersistentVolumeClaim(pvc)
+
+	require.Equal(t, 1, len(actualResourceMetrics))
+
+	require.Equal(t, 3, len(actualResourceMetrics[0].metrics))
+	testutils.AssertResource(t, actualResourceMetrics[0].resource, k8sType,
+		map[string]string{
+			"k8s.persistentvolumeclaim.uid":  "test-persistentvolumeclaim-1-uid",
+			"k8s.persistentvolumeclaim.name": "test-persistentvolumeclaim-1",
+			"k8s.storageclass.name":          "standard",
+			"k8s.namespace.name":             "test-namespace",
+			"k8s.cluster.name":               "test-cluster",
+		},
+	)
+
+	testutils.AssertMetrics(t, actualResourceMetrics[0].metrics[0], "k8s.persistentvolumeclaim.phase",
+		metricspb.MetricDescriptor_GAUGE_INT64, 2)
+
+	testutils.AssertMetrics(t, actualResourceMetrics[0].metrics[1], "k8s.persistentvolumeclaim.requested_storage",
+		metricspb.MetricDescriptor_GAUGE_INT64, 5*1024*1024*1024)
+
+	testutils.AssertMetrics(t, actualResourceMetrics[0].metrics[2], "k8s.persistentvolumeclaim.capacity",
+		metricspb.MetricDescriptor_GAUGE_INT64, 8*1024*1024*1024)
+}
+
+func TestPendingPersistentVolumeClaimMetrics(t *testing.T) {
+	pvc := newPersistentVolumeClaim("1")
+	pvc.Spec.StorageClassName = nil
+	pvc.Status = corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending}
+
+	actualResourceMetrics := getMetricsForPersistentVolumeClaim(pvc)
+
+	require.Equal(t, 2, len(actualResourceMetrics[0].metrics))
+	require.Equal(t, "", actualResourceMetrics[0].resource.Labels["k8s.storageclass.name"])
+	testutils.AssertMetrics(t, actualResourceMetrics[0].metrics[0], "k8s.persistentvolumeclaim.phase",
+		metricspb.MetricDescriptor_GAUGE_INT64, 1)
+}
+
+func TestPersistentVolumeClaimMetadata(t *testing.T) {
+	pvc := newPersistentVolumeClaim("1")
+
+	actualMetadata := getMetadataForPersistentVolumeClaim(pvc)
+
+	require.Equal(t, 1, len(actualMetadata))
+	rm := actualMetadata["test-persistentvolumeclaim-1-uid"]
+	require.Equal(t, "k8s.persistentvolumeclaim.uid", rm.resourceIDKey)
+	require.Equal(t, "PersistentVolumeClaim", rm.metadata["k8s.workload.kind"])
+	require.Equal(t, "standard", rm.metadata["k8s.storageclass.name"])
+	require.Equal(t, "test-persistentvolume-1", rm.metadata["k8s.persistentvolume.name"])
+}
+
+func newPersistentVolumeClaim(id string) *corev1.PersistentVolumeClaim {
+	storageClass := "standard"
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "test-persistentvolumeclaim-" + id,
+			Namespace:   "test-namespace",
+			UID:         types.UID("test-persistentvolumeclaim-" + id + "-uid"),
+			ClusterName: "test-cluster",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("5Gi"),
+				},
+			},
+			StorageClassName: &storageClass,
+			VolumeName:       "test-persistentvolume-" + id,
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.ClaimBound,
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("8Gi"),
+			},
+		},
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"go.opentelemetry.io/collector/translator/conventions"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/utils"
+)
+
+var persistentVolumePhaseMetric = &metricspb.MetricDescriptor{
+	Name: "k8s.persistentvolume.phase",
+	Description: "The current phase of the persistent volume (1 for pending, 2 for available, " +
+		"3 for bound, 4 for released, 5 for failed and -1 for unknown)",
+	Type: metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+var persistentVolumeCapacityMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.persistentvolume.capacity",
+	Description: "The storage capacity of the persistent volume",
+	Unit:        "By",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+func getMetricsForPersistentVolume(pv *corev1.PersistentVolume) []*resourceMetrics {
+	metrics := []*metricspb.Metric{
+		{
+			MetricDescriptor: persistentVolumePhaseMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(int64(persistentVolumePhaseValue(pv.Status.Phase))),
+			},
+		},
+	}
+
+	if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+		metrics = append(metrics, &metricspb.Metric{
+			MetricDescriptor: persistentVolumeCapacityMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(capacity.Value()),
+			},
+		})
+	}
+
+	return []*resourceMetrics{
+		{
+			resource: getResourceForPersistentVolume(pv),
+			metrics:  metrics,
+		},
+	}
+}
+
+func getResourceForPersistentVolume(pv *corev1.PersistentVolume) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Type: k8sType,
+		Labels: map[string]string{
+			k8sKeyPersistentVolumeUID:       string(pv.UID),
+			k8sKeyPersistentVolumeName:      pv.Name,
+			k8sKeyStorageClassName:          pv.Spec.StorageClassName,
+			conventions.AttributeK8sCluster: pv.ClusterName,
+		},
+	}
+}
+
+var persistentVolumePhaseValues = map[corev1.PersistentVolumePhase]int32{
+	corev1.VolumePending:   1,
+	corev1.VolumeAvailable: 2,
+	corev1.VolumeBound:     3,
+	corev1.VolumeReleased:  4,
+	corev1.VolumeFailed:    5,
+}
+
+func persistentVolumePhaseValue(phase corev1.PersistentVolumePhase) int32 {
+	if v, ok := persistentVolumePhaseValues[phase]; ok {
+		return v
+	}
+	// Unknown phase, for instance when it is blank.
+	return -1
+}
+
+func getMetadataForPersistentVolume(pv *corev1.PersistentVolume) map[ResourceID]*KubernetesMetadata {
+	rm := getGenericMetadata(&pv.ObjectMeta, k8sKindPersistentVolume)
+	rm.metadata[k8sKeyStorageClassName] = pv.Spec.StorageClassName
+	if pv.Spec.ClaimRef != nil {
+		rm.metadata[k8sKeyPersistentVolumeClaimName] = pv.Spec.ClaimRef.Name
+		rm.metadata[conventions.AttributeK8sNamespace] = pv.Spec.ClaimRef.Namespace
+	}
+	return map[ResourceID]*KubernetesMetadata{ResourceID(pv.UID): rm}
+}
@@ -0,0 +1,114 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/testutils"
+)
+
+func TestPersistentVolumeMetrics(t *testing.T) {
+	pv := newPersistentVolume("1")
+
+	actualResourceMetrics := getMetricsForPersistentVolume(pv)
+
+	require.Equal(t, 1, len(actualResourceMetrics))
+
+	require.Equal(t, 2, len(actualResourceMetrics[0].metrics))
+	testutils.AssertResource(t, actualResourceMetrics[0].resource, k8sType,
+		map[string]string{
+			"k8s.persistentvolume.uid":  "test-persistentvolume-1-uid",
+			"k8s.persistentvolume.name": "test-persistentvolume-1",
+			"k8s.storageclass.name":     "standard",
+			"k8s.cluster.name":          "test-cluster",
+		},
+	)
+
+	testutils.AssertMetrics(t, actualResourceMetrics[0].metrics[0], "k8s.persistentvolume.phase",
+		metricspb.MetricDescriptor_GAUGE_INT64, 3)
+
+	testutils.AssertMetrics(t, actualResourceMetrics[0].metrics[1], "k8s.persistentvolume.capacity",
+		metricspb.MetricDescriptor_GAUGE_INT64, 10*1024*1024*1024)
+}
+
+func TestPersistentVolumeMetricsUnknownPhase(t *testing.T) {
+	pv := newPersistentVolume("1")
+	pv.Status.Phase = ""
+	pv.Spec.Capacity = nil
+
+	actualResourceMetrics := getMetricsForPersistentVolume(pv)
+
+	require.Equal(t, 1, len(actualResourceMetrics[0].metrics))
+	testutils.AssertMetrics(t, actualResourceMetrics[0].metrics[0], "k8s.persistentvolume.phase",
+		metricspb.MetricDescriptor_GAUGE_INT64, -1)
+}
+
+func TestPersistentVolumeMetadata(t *testing.T) {
+	pv := newPersistentVolume("1")
+
+	actualMetadata := getMetadataForPersistentVolume(pv)
+
+	require.Equal(t, 1, len(actualMetadata))
+	require.Equal(t,
+		KubernetesMetadata{
+			resourceIDKey: "k8s.persistentvolume.uid",
+			resourceID:    "test-persistentvolume-1-uid",
+			metadata: map[string]string{
+				"k8s.workload.kind":                   "PersistentVolume",
+				"k8s.workload.name":                   "test-persistentvolume-1",
+				"k8s.persistentvolumeclaim.name":      "test-persistentvolumeclaim-1",
+				"k8s.namespace.name":                  "test-namespace",
+				"k8s.storageclass.name":               "standard",
+				"persistentvolume.creation_timestamp": "0001-01-01T00:00:00Z",
+				"foo":                                 "bar",
+			},
+		},
+		*actualMetadata["test-persistentvolume-1-uid"],
+	)
+}
+
+func newPersistentVolume(id string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "test-persistentvolume-" + id,
+			UID:         types.UID("test-persistentvolume-" + id + "-uid"),
+			ClusterName: "test-cluster",
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("10Gi"),
+			},
+			StorageClassName: "standard",
+			ClaimRef: &corev1.ObjectReference{
+				Name:      "test-persistentvolumeclaim-" + id,
+				Namespace: "test-namespace",
+			},
+		},
+		Status: corev1.PersistentVolumeStatus{
+			Phase: corev1.VolumeBound,
+		},
+	}
+}
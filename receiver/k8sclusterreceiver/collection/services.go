@@ -0,0 +1,91 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	"go.opentelemetry.io/collector/translator/conventions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/utils"
+)
+
+var servicePortCountMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.service.port_count",
+	Description: "The number of ports exposed by the service",
+	Unit:        "1",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+var serviceEndpointCountMetric = &metricspb.MetricDescriptor{
+	Name:        "k8s.service.endpoint_count",
+	Description: "The number of ready addresses the service routes traffic to",
+	Unit:        "1",
+	Type:        metricspb.MetricDescriptor_GAUGE_INT64,
+}
+
+func getMetricsForService(svc *corev1.Service, endpointsStore cache.Store) []*resourceMetrics {
+	metrics := []*metricspb.Metric{
+		{
+			MetricDescriptor: servicePortCountMetric,
+			Timeseries: []*metricspb.TimeSeries{
+				utils.GetInt64TimeSeries(int64(len(svc.Spec.Ports))),
+			},
+		},
+	}
+
+	// The endpoints of a service have the same name as the service, services
+	// without selector not necessarily having any.
+	if endpointsStore != nil {
+		if obj, exists, err := endpointsStore.GetByKey(utils.GetIDForCache(svc.Namespace, svc.Name)); err == nil && exists {
+			ready, _ := getEndpointsAddressCounts(obj.(*corev1.Endpoints))
+			metrics = append(metrics, &metricspb.Metric{
+				MetricDescriptor: serviceEndpointCountMetric,
+				Timeseries: []*metricspb.TimeSeries{
+					utils.GetInt64TimeSeries(int64(ready)),
+				},
+			})
+		}
+	}
+
+	return []*resourceMetrics{
+		{
+			resource: getResourceForService(svc),
+			metrics:  metrics,
+		},
+	}
+}
+
+func getResourceForService(svc *corev1.Service) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Type: k8sType,
+		Labels: map[string]string{
+			k8sKeyServiceUID:                  string(svc.UID),
+			k8sKeyServiceName:                 svc.Name,
+			k8sKeyServiceType:                 string(svc.Spec.Type),
+			conventions.AttributeK8sNamespace: svc.Namespace,
+			conventions.AttributeK8sCluster:   svc.ClusterName,
+		},
+	}
+}
+
+func getMetadataForService(svc *corev1.Service) map[ResourceID]*KubernetesMetadata {
+	rm := getGenericMetadata(&svc.ObjectMeta, k8sKindService)
+	rm.metadata[k8sKeyServiceType] = string(svc.Spec.Type)
+	rm.metadata[conventions.AttributeK8sNamespace] = svc.Namespace
+	return map[ResourceID]*KubernetesMetadata{ResourceID(svc.UID): rm}
+}
@@ -0,0 +1,98 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collection
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/testutils"
+)
+
+func TestServiceMetrics(t *testing.T) {
+	svc := newService("1")
+	endpointsStore := &testutils.MockStore{
+		Cache: map[string]interface{}{
+			"test-namespace/test-service-1": newEndpoints("1"),
+		},
+	}
+
+	actualResourceMetrics := getMetricsForService(svc, endpointsStore)
+
+	require.Equal(t, 1, len(actualResourceMetrics))
+
+	require.Equal(t, 2, len(actualResourceMetrics[0].metrics))
+	testutils.AssertResource(t, actualResourceMetrics[0].resource, k8sType,
+		map[string]string{
+			"k8s.service.uid":    "test-service-1-uid",
+			"k8s.service.name":   "test-service-1",
+			"k8s.service.type":   "ClusterIP",
+			"k8s.namespace.name": "test-namespace",
+			"k8s.cluster.name":   "test-cluster",
+		},
+	)
+
+	testutils.AssertMetrics(t, actualResourceMetrics[0].metrics[0], "k8s.service.port_count",
+		metricspb.MetricDescriptor_GAUGE_INT64, 2)
+
+	testutils.AssertMetrics(t, actualResourceMetrics[0].metrics[1], "k8s.service.endpoint_count",
+		metricspb.MetricDescriptor_GAUGE_INT64, 3)
+}
+
+func TestServiceMetricsWithoutEndpoints(t *testing.T) {
+	svc := newService("1")
+
+	actualResourceMetrics := getMetricsForService(svc, &testutils.MockStore{})
+	require.Equal(t, 1, len(actualResourceMetrics[0].metrics))
+
+	actualResourceMetrics = getMetricsForService(svc, nil)
+	require.Equal(t, 1, len(actualResourceMetrics[0].metrics))
+}
+
+func TestServiceMetadata(t *testing.T) {
+	svc := newService("1")
+
+	actualMetadata := getMetadataForService(svc)
+
+	require.Equal(t, 1, len(actualMetadata))
+	rm := actualMetadata["test-service-1-uid"]
+	require.Equal(t, "k8s.service.uid", rm.resourceIDKey)
+	require.Equal(t, "Service", rm.metadata["k8s.workload.kind"])
+	require.Equal(t, "ClusterIP", rm.metadata["k8s.service.type"])
+	require.Equal(t, "test-namespace", rm.metadata["k8s.namespace.name"])
+}
+
+func newService(id string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "test-service-" + id,
+			Namespace:   "test-namespace",
+			UID:         types.UID("test-service-" + id + "-uid"),
+			ClusterName: "test-cluster",
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80},
+				{Name: "https", Port: 443},
+			},
+		},
+	}
+}
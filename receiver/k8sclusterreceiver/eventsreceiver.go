@@ -0,0 +1,135 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/collection"
+)
+
+// kubernetesEventsReceiver watches Kubernetes events and emits them as logs on
+// behalf of the kubernetesReceiver.
+type kubernetesEventsReceiver struct {
+	sharedInformerFactory informers.SharedInformerFactory
+
+	config   *Config
+	logger   *zap.Logger
+	consumer consumer.LogsConsumer
+	ctx      context.Context
+	cancel   context.CancelFunc
+	// startTime is when the receiver started, the events last observed
+	// before are not emitted.
+	startTime time.Time
+
+	mu sync.Mutex
+	// resourceVersions is the resource version of the last emitted revision of each event,
+	// used to deduplicate the events delivered again when the informer relists them.
+	resourceVersions map[types.UID]string
+}
+
+func (kr *kubernetesEventsReceiver) Start(ctx context.Context, host component.Host) error {
+	kr.ctx, kr.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, kr.config.Name()))
+	kr.startTime = time.Now()
+
+	kr.logger.Info("Starting shared informer for events.")
+	kr.sharedInformerFactory.Start(kr.ctx.Done())
+	return nil
+}
+
+func (kr *kubernetesEventsReceiver) Shutdown(context.Context) error {
+	kr.cancel()
+	return nil
+}
+
+func (kr *kubernetesEventsReceiver) onAdd(obj interface{}) {
+	if event, ok := obj.(*corev1.Event); ok {
+		kr.consumeEvent(event)
+	}
+}
+
+func (kr *kubernetesEventsReceiver) onUpdate(_, newObj interface{}) {
+	if event, ok := newObj.(*corev1.Event); ok {
+		kr.consumeEvent(event)
+	}
+}
+
+func (kr *kubernetesEventsReceiver) onDelete(obj interface{}) {
+	if o, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = o.Obj
+	}
+	if event, ok := obj.(*corev1.Event); ok {
+		kr.mu.Lock()
+		delete(kr.resourceVersions, event.UID)
+		kr.mu.Unlock()
+	}
+}
+
+// consumeEvent sends the event to the next consumer unless it was last observed
+// before the receiver started or the same revision was already sent.
+func (kr *kubernetesEventsReceiver) consumeEvent(event *corev1.Event) {
+	if collection.GetEventTimestamp(event).Before(kr.startTime) {
+		return
+	}
+
+	kr.mu.Lock()
+	if rv, ok := kr.resourceVersions[event.UID]; ok && rv == event.ResourceVersion {
+		kr.mu.Unlock()
+		return
+	}
+	kr.resourceVersions[event.UID] = event.ResourceVersion
+	kr.mu.Unlock()
+
+	if err := kr.consumer.ConsumeLogs(kr.ctx, collection.GetLogsForEvent(event)); err != nil {
+		kr.logger.Error("failed to consume event",
+			zap.String("event", event.Name),
+			zap.Error(err),
+		)
+	}
+}
+
+// newEventsReceiver creates the part of the Kubernetes cluster receiver emitting events as logs.
+func newEventsReceiver(
+	logger *zap.Logger, config *Config, consumer consumer.LogsConsumer,
+	client kubernetes.Interface) *kubernetesEventsReceiver {
+	kr := &kubernetesEventsReceiver{
+		logger:           logger,
+		config:           config,
+		consumer:         consumer,
+		resourceVersions: map[types.UID]string{},
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0)
+	factory.Core().V1().Events().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    kr.onAdd,
+		UpdateFunc: kr.onUpdate,
+		DeleteFunc: kr.onDelete,
+	})
+	kr.sharedInformerFactory = factory
+
+	return kr
+}
@@ -0,0 +1,116 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sclusterreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestEventsReceiver(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	consumer := &exportertest.SinkLogsExporter{}
+
+	r := newEventsReceiver(zap.NewNop(), &Config{}, consumer, client)
+
+	// Events last observed before the receiver started are not emitted.
+	oldEvent := newEvent("1")
+	oldEvent.LastTimestamp = v1.NewTime(time.Now().Add(-time.Hour))
+	_, err := client.CoreV1().Events(oldEvent.Namespace).Create(context.Background(), oldEvent, v1.CreateOptions{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+
+	event := newEvent("2")
+	event.LastTimestamp = v1.NewTime(time.Now().Add(time.Second))
+	_, err = client.CoreV1().Events(event.Namespace).Create(context.Background(), event, v1.CreateOptions{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return consumer.LogRecordsCount() == 1
+	}, 10*time.Second, 100*time.Millisecond,
+		"event not collected")
+
+	lr := consumer.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, "FailedScheduling", lr.Name())
+	attr, ok := lr.Attributes().Get("k8s.event.name")
+	require.True(t, ok)
+	assert.Equal(t, "test-event-2", attr.StringVal())
+
+	require.NoError(t, r.Shutdown(ctx))
+}
+
+func TestEventsReceiverDeduplicates(t *testing.T) {
+	consumer := &exportertest.SinkLogsExporter{}
+	kr := newEventsReceiver(zap.NewNop(), &Config{}, consumer, fake.NewSimpleClientset())
+	kr.ctx = context.Background()
+
+	event := newEvent("1")
+	kr.onAdd(event)
+	assert.Equal(t, 1, consumer.LogRecordsCount())
+
+	// A relist delivers the same revision of the event again.
+	kr.onUpdate(event, event)
+	assert.Equal(t, 1, consumer.LogRecordsCount())
+
+	// The event occurred again.
+	updated := event.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Count = 2
+	kr.onUpdate(event, updated)
+	assert.Equal(t, 2, consumer.LogRecordsCount())
+
+	// Deleted events are forgotten.
+	kr.onDelete(cache.DeletedFinalStateUnknown{Key: "test-namespace/test-event-1", Obj: updated})
+	assert.Empty(t, kr.resourceVersions)
+
+	// Other objects are ignored.
+	kr.onAdd(&corev1.Pod{})
+	assert.Equal(t, 2, consumer.LogRecordsCount())
+}
+
+func newEvent(id string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: v1.ObjectMeta{
+			Name:            "test-event-" + id,
+			Namespace:       "test-namespace",
+			UID:             types.UID("test-event-" + id + "-uid"),
+			ResourceVersion: "1",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: "test-namespace",
+			Name:      "test-pod-" + id,
+			UID:       types.UID("test-pod-" + id + "-uid"),
+		},
+		Reason:  "FailedScheduling",
+		Message: "0/1 nodes are available: 1 Insufficient cpu.",
+		Count:   1,
+		Type:    corev1.EventTypeWarning,
+	}
+}
@@ -16,12 +16,14 @@ package k8sclusterreceiver
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
@@ -53,19 +55,52 @@ func createDefaultConfig() configmodels.Receiver {
 func createMetricsReceiver(
 	_ context.Context, params component.ReceiverCreateParams, cfg configmodels.Receiver,
 	consumer consumer.MetricsConsumer) (component.MetricsReceiver, error) {
-	rCfg := cfg.(*Config)
+	r, err := getOrCreateReceiver(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.registerMetricsConsumer(consumer)
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context, params component.ReceiverCreateParams, cfg configmodels.Receiver,
+	consumer consumer.LogsConsumer) (component.LogsReceiver, error) {
+	r, err := getOrCreateReceiver(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	r.registerLogsConsumer(consumer)
+	return r, nil
+}
+
+// getOrCreateReceiver returns the receiver of the config, the same instance
+// must be returned for the metrics and logs pipelines of a config.
+func getOrCreateReceiver(logger *zap.Logger, rCfg *Config) (*kubernetesReceiver, error) {
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+
+	if r, ok := receivers[rCfg]; ok {
+		return r, nil
+	}
 
 	k8sClient, err := rCfg.getK8sClient()
 	if err != nil {
 		return nil, err
 	}
-	return newReceiver(params.Logger, rCfg, consumer, k8sClient)
+	r := newReceiver(logger, rCfg, k8sClient)
+	receivers[rCfg] = r
+	return r, nil
 }
 
+var receiverLock sync.Mutex
+var receivers = map[*Config]*kubernetesReceiver{}
+
 // NewFactory creates a factory for k8s_cluster receiver.
 func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
 }
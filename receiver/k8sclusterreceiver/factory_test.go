@@ -21,10 +21,12 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
@@ -83,6 +85,58 @@ func TestFactory(t *testing.T) {
 	require.Error(t, r.Start(context.Background(), nopHostWithExporters{}))
 }
 
+func TestFactoryLogsReceiver(t *testing.T) {
+	f := NewFactory()
+	rCfg := f.CreateDefaultConfig().(*Config)
+
+	// Fails with bad K8s Config.
+	r, err := f.CreateLogsReceiver(
+		context.Background(), component.ReceiverCreateParams{},
+		rCfg, &exportertest.SinkLogsExporter{},
+	)
+	require.Error(t, err)
+	require.Nil(t, r)
+
+	// Override for tests.
+	rCfg.makeClient = func(apiConf k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(), nil
+	}
+	r, err = f.CreateLogsReceiver(
+		context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()},
+		rCfg, &exportertest.SinkLogsExporter{},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	ctx := context.Background()
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, r.Shutdown(ctx))
+}
+
+func TestFactorySharesReceiver(t *testing.T) {
+	f := NewFactory()
+	rCfg := f.CreateDefaultConfig().(*Config)
+	rCfg.makeClient = func(apiConf k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(), nil
+	}
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	mr, err := f.CreateMetricsReceiver(context.Background(), params, rCfg, &exportertest.SinkMetricsExporter{})
+	require.NoError(t, err)
+	lr, err := f.CreateLogsReceiver(context.Background(), params, rCfg, &exportertest.SinkLogsExporter{})
+	require.NoError(t, err)
+
+	// The metrics and logs pipelines of a config share the same receiver.
+	require.Same(t, mr, lr)
+	kr := mr.(*kubernetesReceiver)
+	require.NotNil(t, kr.resourceWatcher)
+	require.NotNil(t, kr.eventsReceiver)
+
+	ctx := context.Background()
+	require.NoError(t, mr.Start(ctx, nopHostWithExporters{}))
+	require.NoError(t, mr.Shutdown(ctx))
+}
+
 // nopHostWithExporters mocks a receiver.ReceiverHost for test purposes.
 type nopHostWithExporters struct {
 }
@@ -35,17 +35,48 @@ const (
 )
 
 var _ component.MetricsReceiver = (*kubernetesReceiver)(nil)
+var _ component.LogsReceiver = (*kubernetesReceiver)(nil)
 
+// kubernetesReceiver collects the cluster metrics when part of a metrics
+// pipeline and the events when part of a logs pipeline, the same instance
+// being shared by the pipelines of a config.
 type kubernetesReceiver struct {
+	// resourceWatcher is set along with the metrics consumer.
 	resourceWatcher *resourceWatcher
+	// eventsReceiver is set along with the logs consumer.
+	eventsReceiver *kubernetesEventsReceiver
 
 	config   *Config
 	logger   *zap.Logger
+	client   kubernetes.Interface
 	consumer consumer.MetricsConsumer
 	cancel   context.CancelFunc
 }
 
+// registerMetricsConsumer enables the collection of the cluster metrics.
+func (kr *kubernetesReceiver) registerMetricsConsumer(consumer consumer.MetricsConsumer) {
+	kr.consumer = consumer
+	kr.resourceWatcher = newResourceWatcher(kr.logger, kr.client, kr.config.NodeConditionTypesToReport, defaultInitialSyncTimeout)
+}
+
+// registerLogsConsumer enables the collection of the events.
+func (kr *kubernetesReceiver) registerLogsConsumer(consumer consumer.LogsConsumer) {
+	kr.eventsReceiver = newEventsReceiver(kr.logger, kr.config, consumer, kr.client)
+}
+
 func (kr *kubernetesReceiver) Start(ctx context.Context, host component.Host) error {
+	if kr.resourceWatcher != nil {
+		if err := kr.startMetrics(ctx, host); err != nil {
+			return err
+		}
+	}
+	if kr.eventsReceiver != nil {
+		return kr.eventsReceiver.Start(ctx, host)
+	}
+	return nil
+}
+
+func (kr *kubernetesReceiver) startMetrics(ctx context.Context, host component.Host) error {
 	var c context.Context
 	c, kr.cancel = context.WithCancel(obsreport.ReceiverContext(ctx, typeStr, transport, kr.config.Name()))
 
@@ -91,8 +122,13 @@ func (kr *kubernetesReceiver) Start(ctx context.Context, host component.Host) er
 	return nil
 }
 
-func (kr *kubernetesReceiver) Shutdown(context.Context) error {
-	kr.cancel()
+func (kr *kubernetesReceiver) Shutdown(ctx context.Context) error {
+	if kr.cancel != nil {
+		kr.cancel()
+	}
+	if kr.eventsReceiver != nil {
+		return kr.eventsReceiver.Shutdown(ctx)
+	}
 	return nil
 }
 
@@ -109,16 +145,12 @@ func (kr *kubernetesReceiver) dispatchMetrics(ctx context.Context) {
 	obsreport.EndMetricsReceiveOp(c, typeStr, numPoints, numTimeseries, err)
 }
 
-// newReceiver creates the Kubernetes cluster receiver with the given configuration.
-func newReceiver(
-	logger *zap.Logger, config *Config, consumer consumer.MetricsConsumer,
-	client kubernetes.Interface) (component.MetricsReceiver, error) {
-	resourceWatcher := newResourceWatcher(logger, client, config.NodeConditionTypesToReport, defaultInitialSyncTimeout)
-
+// newReceiver creates the Kubernetes cluster receiver with the given configuration,
+// the consumers of the pipelines it is part of are registered afterwards.
+func newReceiver(logger *zap.Logger, config *Config, client kubernetes.Interface) *kubernetesReceiver {
 	return &kubernetesReceiver{
-		resourceWatcher: resourceWatcher,
-		logger:          logger,
-		config:          config,
-		consumer:        consumer,
-	}, nil
+		logger: logger,
+		config: config,
+		client: client,
+	}
 }
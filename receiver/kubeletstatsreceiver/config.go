@@ -38,11 +38,19 @@ type Config struct {
 	// ExtraMetadataLabels contains list of extra metadata that should be taken from /pods endpoint
 	// and put as extra labels on metrics resource.
 	// No additional metadata is fetched by default, so there are no extra calls to /pods endpoint.
-	// Supported values include container.id and k8s.volume.type.
+	// Supported values include container.id, k8s.volume.type and k8s.workload.name.
 	ExtraMetadataLabels []kubelet.MetadataLabel `mapstructure:"extra_metadata_labels"`
 
+	// PodLabels is a list of pod label keys whose values, taken from /pods endpoint, are
+	// put as k8s.pod.labels.<key> labels on pod and container metrics resource.
+	PodLabels []string `mapstructure:"pod_labels"`
+
+	// PodAnnotations is a list of pod annotation keys whose values, taken from /pods endpoint,
+	// are put as k8s.pod.annotations.<key> labels on pod and container metrics resource.
+	PodAnnotations []string `mapstructure:"pod_annotations"`
+
 	// MetricGroupsToCollect provides a list of metrics groups to collect metrics from.
-	// "container", "pod", "node" and "volume" are the only valid groups.
+	// "container", "pod", "node", "volume" and "cadvisor" are the only valid groups.
 	MetricGroupsToCollect []kubelet.MetricGroup `mapstructure:"metric_groups"`
 
 	// Configuration of the Kubernetes API client.
@@ -74,6 +82,8 @@ func (cfg *Config) getReceiverOptions() (*receiverOptions, error) {
 		name:                  cfg.Name(),
 		collectionInterval:    cfg.CollectionInterval,
 		extraMetadataLabels:   cfg.ExtraMetadataLabels,
+		podLabels:             cfg.PodLabels,
+		podAnnotations:        cfg.PodAnnotations,
 		metricGroupsToCollect: mgs,
 		k8sAPIClient:          k8sAPIClient,
 	}, nil
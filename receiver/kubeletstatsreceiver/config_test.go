@@ -133,6 +133,30 @@ func TestLoadConfig(t *testing.T) {
 		},
 	}, metadataCfg)
 
+	podMetadataCfg := cfg.Receivers["kubeletstats/pod_metadata"].(*Config)
+	require.Equal(t, &Config{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			TypeVal: "kubeletstats",
+			NameVal: "kubeletstats/pod_metadata",
+		},
+		ClientConfig: kubelet.ClientConfig{
+			APIConfig: k8sconfig.APIConfig{
+				AuthType: "serviceAccount",
+			},
+		},
+		CollectionInterval: duration,
+		ExtraMetadataLabels: []kubelet.MetadataLabel{
+			kubelet.MetadataLabelWorkload,
+		},
+		PodLabels:      []string{"app"},
+		PodAnnotations: []string{"git_sha"},
+		MetricGroupsToCollect: []kubelet.MetricGroup{
+			kubelet.ContainerMetricGroup,
+			kubelet.PodMetricGroup,
+			kubelet.NodeMetricGroup,
+		},
+	}, podMetadataCfg)
+
 	metricGroupsCfg := cfg.Receivers["kubeletstats/metric_groups"].(*Config)
 	require.Equal(t, &Config{
 		ReceiverSettings: configmodels.ReceiverSettings{
@@ -33,6 +33,7 @@ const (
 	PodMetricGroup       = MetricGroup("pod")
 	NodeMetricGroup      = MetricGroup("node")
 	VolumeMetricGroup    = MetricGroup("volume")
+	CadvisorMetricGroup  = MetricGroup("cadvisor")
 )
 
 var ValidMetricGroups = map[MetricGroup]bool{
@@ -40,6 +41,7 @@ var ValidMetricGroups = map[MetricGroup]bool{
 	PodMetricGroup:       true,
 	NodeMetricGroup:      true,
 	VolumeMetricGroup:    true,
+	CadvisorMetricGroup:  true,
 }
 
 type metricDataAccumulator struct {
@@ -0,0 +1,329 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubelet
+
+import (
+	"sort"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	stats "k8s.io/kubernetes/pkg/kubelet/apis/stats/v1alpha1"
+)
+
+// cadvisorMetric describes how a cAdvisor metric family maps to a metric of
+// the receiver.
+type cadvisorMetric struct {
+	// name of the metric, without the container. or k8s.pod. prefix.
+	name string
+	unit string
+	// double metrics keep their value, the others are converted to integers.
+	double     bool
+	cumulative bool
+	// podLevel metrics are reported by cAdvisor for the pod sandbox rather
+	// than for each of its containers, like the network ones.
+	podLevel bool
+	// labels are the static labels of the metric.
+	labels map[string]string
+	// labelsFrom maps the cAdvisor labels copied to the labels of the metric.
+	labelsFrom map[string]string
+}
+
+var (
+	deviceLabelFrom    = map[string]string{"device": "device"}
+	interfaceLabelFrom = map[string]string{"interface": "interface"}
+)
+
+// cadvisorMetrics are the cAdvisor metric families collected. Some of them are
+// also derived from /stats/summary, in which case they are only reported when
+// the corresponding metric group does not already report them.
+var cadvisorMetrics = map[string]cadvisorMetric{
+	"container_cpu_usage_seconds_total":         {name: "cpu.time", unit: "s", double: true, cumulative: true},
+	"container_cpu_cfs_periods_total":           {name: "cpu.cfs.periods", unit: "1", cumulative: true},
+	"container_cpu_cfs_throttled_periods_total": {name: "cpu.cfs.throttled_periods", unit: "1", cumulative: true},
+	"container_cpu_cfs_throttled_seconds_total": {name: "cpu.cfs.throttled_time", unit: "s", double: true, cumulative: true},
+
+	"container_memory_cache":             {name: "memory.cache", unit: "By"},
+	"container_memory_rss":               {name: "memory.rss", unit: "By"},
+	"container_memory_swap":              {name: "memory.swap", unit: "By"},
+	"container_memory_working_set_bytes": {name: "memory.working_set", unit: "By"},
+
+	"container_fs_reads_bytes_total": {
+		name: "disk.io", unit: "By", cumulative: true,
+		labels: map[string]string{directionLabel: "read"}, labelsFrom: deviceLabelFrom,
+	},
+	"container_fs_writes_bytes_total": {
+		name: "disk.io", unit: "By", cumulative: true,
+		labels: map[string]string{directionLabel: "write"}, labelsFrom: deviceLabelFrom,
+	},
+	"container_fs_reads_total": {
+		name: "disk.operations", unit: "1", cumulative: true,
+		labels: map[string]string{directionLabel: "read"}, labelsFrom: deviceLabelFrom,
+	},
+	"container_fs_writes_total": {
+		name: "disk.operations", unit: "1", cumulative: true,
+		labels: map[string]string{directionLabel: "write"}, labelsFrom: deviceLabelFrom,
+	},
+
+	"container_network_receive_bytes_total": {
+		name: "network.io", unit: "By", cumulative: true, podLevel: true,
+		labels: map[string]string{directionLabel: "receive"}, labelsFrom: interfaceLabelFrom,
+	},
+	"container_network_transmit_bytes_total": {
+		name: "network.io", unit: "By", cumulative: true, podLevel: true,
+		labels: map[string]string{directionLabel: "transmit"}, labelsFrom: interfaceLabelFrom,
+	},
+	"container_network_receive_errors_total": {
+		name: "network.errors", unit: "1", cumulative: true, podLevel: true,
+		labels: map[string]string{directionLabel: "receive"}, labelsFrom: interfaceLabelFrom,
+	},
+	"container_network_transmit_errors_total": {
+		name: "network.errors", unit: "1", cumulative: true, podLevel: true,
+		labels: map[string]string{directionLabel: "transmit"}, labelsFrom: interfaceLabelFrom,
+	},
+	"container_network_receive_packets_dropped_total": {
+		name: "network.dropped", unit: "1", cumulative: true, podLevel: true,
+		labels: map[string]string{directionLabel: "receive"}, labelsFrom: interfaceLabelFrom,
+	},
+	"container_network_transmit_packets_dropped_total": {
+		name: "network.dropped", unit: "1", cumulative: true, podLevel: true,
+		labels: map[string]string{directionLabel: "transmit"}, labelsFrom: interfaceLabelFrom,
+	},
+}
+
+// cAdvisor labels identifying the container of a series. Kubernetes versions
+// prior to 1.16 use the pod_name and container_name labels.
+const (
+	cadvisorLabelNamespace        = "namespace"
+	cadvisorLabelPod              = "pod"
+	cadvisorLabelPodLegacy        = "pod_name"
+	cadvisorLabelContainer        = "container"
+	cadvisorLabelContainerLegacy  = "container_name"
+	cadvisorPodSandboxContainerID = "POD"
+)
+
+// cadvisorKey identifies the pod, or the container when set, of a series.
+type cadvisorKey struct {
+	namespace string
+	pod       string
+	container string
+}
+
+// CadvisorMetricsData converts the cAdvisor metric families of the pods and
+// containers of the summary into metrics data. Metrics that are already part
+// of reported, the metrics data derived from the summary, for the same
+// resource are skipped.
+func CadvisorMetricsData(
+	logger *zap.Logger, summary *stats.Summary, families map[string]*dto.MetricFamily,
+	metadata Metadata, typeStr string, reported []consumerdata.MetricsData) []consumerdata.MetricsData {
+	acc := &metricDataAccumulator{
+		metadata: metadata,
+		logger:   logger,
+		time:     time.Now(),
+	}
+
+	series := cadvisorSeries(families)
+	if len(series) == 0 {
+		return nil
+	}
+	reportedNames := reportedMetricNames(reported)
+
+	for _, podStats := range summary.Pods {
+		// Errors were already reported when collecting the summary metrics.
+		podResource, _ := podResource(podStats, metadata)
+		key := cadvisorKey{namespace: podStats.PodRef.Namespace, pod: podStats.PodRef.Name}
+		acc.cadvisorStats(podStats.StartTime.Time, podResource, series[key], reportedNames)
+
+		for _, containerStats := range podStats.Containers {
+			resource, err := containerResource(podResource, containerStats, metadata)
+			if err != nil {
+				continue
+			}
+			key.container = containerStats.Name
+			acc.cadvisorStats(containerStats.StartTime.Time, resource, series[key], reportedNames)
+		}
+	}
+
+	for _, md := range acc.m {
+		md.Resource.Labels["receiver"] = typeStr
+	}
+	return acc.m
+}
+
+func (a *metricDataAccumulator) cadvisorStats(
+	startTime time.Time, r *resourcepb.Resource,
+	metrics []*metricspb.Metric, reportedNames map[string]map[string]bool,
+) {
+	var out []*metricspb.Metric
+	alreadyReported := reportedNames[resourceKey(r.Labels)]
+	for _, metric := range metrics {
+		if !alreadyReported[metric.MetricDescriptor.Name] {
+			out = append(out, metric)
+		}
+	}
+	if len(out) == 0 {
+		return
+	}
+	a.accumulate(timestamppb.New(startTime), r, out)
+}
+
+// cadvisorSeries converts the series of the known metric families, grouping
+// them by pod and container.
+func cadvisorSeries(families map[string]*dto.MetricFamily) map[cadvisorKey][]*metricspb.Metric {
+	// Iterate over sorted names to get a stable order of metrics.
+	names := make([]string, 0, len(families))
+	for name := range families {
+		if _, ok := cadvisorMetrics[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	out := map[cadvisorKey][]*metricspb.Metric{}
+	for _, name := range names {
+		cm := cadvisorMetrics[name]
+		family := families[name]
+		for _, m := range family.Metric {
+			key, ok := getCadvisorKey(m, cm.podLevel)
+			if !ok {
+				continue
+			}
+			metric := cadvisorMetricFromSeries(cm, family.GetType(), m)
+			if metric == nil {
+				continue
+			}
+			out[key] = append(out[key], metric)
+		}
+	}
+	return out
+}
+
+// getCadvisorKey returns the key of the series, false if it does not match
+// the level of the metric, like series of the pod cgroup or of processes not
+// managed by Kubernetes.
+func getCadvisorKey(m *dto.Metric, podLevel bool) (cadvisorKey, bool) {
+	labels := make(map[string]string, len(m.Label))
+	for _, l := range m.Label {
+		labels[l.GetName()] = l.GetValue()
+	}
+
+	key := cadvisorKey{
+		namespace: labels[cadvisorLabelNamespace],
+		pod:       firstNonEmpty(labels[cadvisorLabelPod], labels[cadvisorLabelPodLegacy]),
+		container: firstNonEmpty(labels[cadvisorLabelContainer], labels[cadvisorLabelContainerLegacy]),
+	}
+	if key.pod == "" {
+		return key, false
+	}
+
+	// The pod sandbox, or the pod cgroup depending on the container runtime,
+	// holds the pod level series.
+	sandbox := key.container == "" || key.container == cadvisorPodSandboxContainerID
+	if podLevel {
+		key.container = ""
+		return key, sandbox
+	}
+	return key, !sandbox
+}
+
+func cadvisorMetricFromSeries(cm cadvisorMetric, typ dto.MetricType, m *dto.Metric) *metricspb.Metric {
+	var value float64
+	switch typ {
+	case dto.MetricType_COUNTER:
+		value = m.GetCounter().GetValue()
+	case dto.MetricType_GAUGE:
+		value = m.GetGauge().GetValue()
+	case dto.MetricType_UNTYPED:
+		value = m.GetUntyped().GetValue()
+	default:
+		return nil
+	}
+
+	prefix := containerPrefix
+	if cm.podLevel {
+		prefix = podPrefix
+	}
+
+	var metric *metricspb.Metric
+	intValue := uint64(value)
+	switch {
+	case cm.double && cm.cumulative:
+		metric = cumulativeDouble(prefix+cm.name, cm.unit, &value)
+	case cm.double:
+		metric = doubleGauge(prefix+cm.name, cm.unit, &value)
+	case cm.cumulative:
+		metric = cumulativeInt(prefix+cm.name, cm.unit, &intValue)
+	default:
+		metric = intGauge(prefix+cm.name, cm.unit, &intValue)
+	}
+
+	if len(cm.labels) == 0 && len(cm.labelsFrom) == 0 {
+		return metric
+	}
+	labels := make(map[string]string, len(cm.labels)+len(cm.labelsFrom))
+	for k, v := range cm.labels {
+		labels[k] = v
+	}
+	for _, l := range m.Label {
+		if name, ok := cm.labelsFrom[l.GetName()]; ok {
+			labels[name] = l.GetValue()
+		}
+	}
+	applyLabels(metric, labels)
+	return metric
+}
+
+// reportedMetricNames returns the names of the metrics of each pod and
+// container resource.
+func reportedMetricNames(mds []consumerdata.MetricsData) map[string]map[string]bool {
+	out := map[string]map[string]bool{}
+	for _, md := range mds {
+		key := resourceKey(md.Resource.Labels)
+		if key == "" {
+			continue
+		}
+		if out[key] == nil {
+			out[key] = map[string]bool{}
+		}
+		for _, m := range md.Metrics {
+			out[key][m.MetricDescriptor.Name] = true
+		}
+	}
+	return out
+}
+
+// resourceKey returns the identifier of a pod or container resource, an empty
+// string for other resources.
+func resourceKey(labels map[string]string) string {
+	podUID := labels[conventions.AttributeK8sPodUID]
+	if podUID == "" || labels[labelVolumeName] != "" {
+		return ""
+	}
+	return podUID + "/" + labels[conventions.AttributeK8sContainer]
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubelet
+
+import (
+	"bytes"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// CadvisorProvider wraps a RestClient, returning the parsed metric families
+// of the kubelet /metrics/cadvisor endpoint.
+type CadvisorProvider struct {
+	rc RestClient
+}
+
+func NewCadvisorProvider(rc RestClient) *CadvisorProvider {
+	return &CadvisorProvider{rc: rc}
+}
+
+// MetricFamilies calls the /metrics/cadvisor kubelet endpoint and parses the
+// Prometheus text exposition it returns.
+func (p *CadvisorProvider) MetricFamilies() (map[string]*dto.MetricFamily, error) {
+	body, err := p.rc.Cadvisor()
+	if err != nil {
+		return nil, err
+	}
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(bytes.NewReader(body))
+}
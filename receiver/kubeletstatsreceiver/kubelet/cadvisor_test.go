@@ -0,0 +1,144 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubelet
+
+import (
+	"errors"
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.uber.org/zap"
+)
+
+func TestCadvisorMetricsData(t *testing.T) {
+	rc := &fakeRestClient{}
+	summary, err := NewStatsProvider(rc).StatsSummary()
+	require.NoError(t, err)
+	families, err := NewCadvisorProvider(rc).MetricFamilies()
+	require.NoError(t, err)
+
+	mds := CadvisorMetricsData(zap.NewNop(), summary, families, Metadata{}, "kubeletstats", nil)
+	requireMetricsDataOk(t, mds)
+
+	// The server container and the pod of go-hello-world and the kube-proxy
+	// container reported with the legacy labels.
+	require.Equal(t, 3, len(mds))
+
+	server := findMetricsData(t, mds, "go-hello-world-5456b4b8cd-99vxc", "server")
+	require.Equal(t, "42ad382b-ed0b-446d-9aab-3fdce8b4f9e2", server.Resource.Labels["k8s.pod.uid"])
+	require.Equal(t, "kubeletstats", server.Resource.Labels["receiver"])
+	require.Equal(t, 12, len(server.Metrics))
+
+	diskIO := findMetric(t, server.Metrics, "container.disk.io", "read")
+	require.Equal(t, metricspb.MetricDescriptor_CUMULATIVE_INT64, diskIO.MetricDescriptor.Type)
+	require.Equal(t, int64(8500000), diskIO.Timeseries[0].Points[0].GetInt64Value())
+	require.Equal(t, map[string]string{"direction": "read", "device": "/dev/sda"}, metricLabels(diskIO))
+
+	throttled := findMetric(t, server.Metrics, "container.cpu.cfs.throttled_time", "")
+	require.Equal(t, metricspb.MetricDescriptor_CUMULATIVE_DOUBLE, throttled.MetricDescriptor.Type)
+	require.Equal(t, 1.873, throttled.Timeseries[0].Points[0].GetDoubleValue())
+
+	pod := findMetricsData(t, mds, "go-hello-world-5456b4b8cd-99vxc", "")
+	require.Equal(t, 6, len(pod.Metrics))
+	dropped := findMetric(t, pod.Metrics, "k8s.pod.network.dropped", "receive")
+	require.Equal(t, map[string]string{"direction": "receive", "interface": "eth0"}, metricLabels(dropped))
+
+	kubeProxy := findMetricsData(t, mds, "kube-proxy-v48tf", "kube-proxy")
+	require.Equal(t, 1, len(kubeProxy.Metrics))
+	require.Equal(t, "container.memory.cache", kubeProxy.Metrics[0].MetricDescriptor.Name)
+}
+
+func TestCadvisorMetricsDataDeduplication(t *testing.T) {
+	rc := &fakeRestClient{}
+	summary, err := NewStatsProvider(rc).StatsSummary()
+	require.NoError(t, err)
+	families, err := NewCadvisorProvider(rc).MetricFamilies()
+	require.NoError(t, err)
+
+	reported := MetricsData(zap.NewNop(), summary, Metadata{}, "kubeletstats", map[MetricGroup]bool{
+		ContainerMetricGroup: true,
+		PodMetricGroup:       true,
+	})
+	mds := CadvisorMetricsData(zap.NewNop(), summary, families, Metadata{}, "kubeletstats", reported)
+
+	server := findMetricsData(t, mds, "go-hello-world-5456b4b8cd-99vxc", "server")
+	require.Equal(t, 9, len(server.Metrics))
+	for _, m := range server.Metrics {
+		require.NotContains(t, []string{"container.cpu.time", "container.memory.rss", "container.memory.working_set"},
+			m.MetricDescriptor.Name)
+	}
+
+	pod := findMetricsData(t, mds, "go-hello-world-5456b4b8cd-99vxc", "")
+	require.Equal(t, 2, len(pod.Metrics))
+	for _, m := range pod.Metrics {
+		require.Equal(t, "k8s.pod.network.dropped", m.MetricDescriptor.Name)
+	}
+}
+
+func TestCadvisorMetricsDataNoSeries(t *testing.T) {
+	rc := &fakeRestClient{}
+	summary, err := NewStatsProvider(rc).StatsSummary()
+	require.NoError(t, err)
+
+	require.Empty(t, CadvisorMetricsData(zap.NewNop(), summary, nil, Metadata{}, "kubeletstats", nil))
+}
+
+func TestCadvisorProviderErrors(t *testing.T) {
+	_, err := NewCadvisorProvider(&cadvisorRestClient{err: errors.New("failed")}).MetricFamilies()
+	require.EqualError(t, err, "failed")
+
+	_, err = NewCadvisorProvider(&cadvisorRestClient{body: "container_memory_cache{"}).MetricFamilies()
+	require.Error(t, err)
+}
+
+type cadvisorRestClient struct {
+	fakeRestClient
+	body string
+	err  error
+}
+
+func (c *cadvisorRestClient) Cadvisor() ([]byte, error) {
+	return []byte(c.body), c.err
+}
+
+func findMetricsData(t *testing.T, mds []consumerdata.MetricsData, pod, container string) consumerdata.MetricsData {
+	for _, md := range mds {
+		if md.Resource.Labels["k8s.pod.name"] == pod && md.Resource.Labels["k8s.container.name"] == container {
+			return md
+		}
+	}
+	require.Failf(t, "metrics data not found", "pod %q, container %q", pod, container)
+	return consumerdata.MetricsData{}
+}
+
+func findMetric(t *testing.T, metrics []*metricspb.Metric, name, direction string) *metricspb.Metric {
+	for _, m := range metrics {
+		if m.MetricDescriptor.Name == name && metricLabels(m)["direction"] == direction {
+			return m
+		}
+	}
+	require.Failf(t, "metric not found", "%s, direction %q", name, direction)
+	return nil
+}
+
+func metricLabels(m *metricspb.Metric) map[string]string {
+	out := map[string]string{}
+	for i, k := range m.MetricDescriptor.LabelKeys {
+		out[k.Key] = m.Timeseries[0].LabelValues[i].Value
+	}
+	return out
+}
@@ -19,6 +19,12 @@ const (
 	labelPersistentVolumeClaimName = "k8s.persistentvolumeclaim.name"
 	labelVolumeName                = "k8s.volume.name"
 	labelVolumeType                = "k8s.volume.type"
+	labelWorkloadKind              = "k8s.workload.kind"
+	labelWorkloadName              = "k8s.workload.name"
+
+	// Prefixes of the labels holding pod labels and annotations.
+	labelPodLabelsPrefix      = "k8s.pod.labels."
+	labelPodAnnotationsPrefix = "k8s.pod.annotations."
 
 	// Volume types.
 	labelValuePersistentVolumeClaim = "persistentVolumeClaim"
@@ -29,11 +29,13 @@ type MetadataLabel string
 const (
 	MetadataLabelContainerID MetadataLabel = conventions.AttributeContainerID
 	MetadataLabelVolumeType  MetadataLabel = labelVolumeType
+	MetadataLabelWorkload    MetadataLabel = labelWorkloadName
 )
 
 var supportedLabels = map[MetadataLabel]bool{
 	MetadataLabelContainerID: true,
 	MetadataLabelVolumeType:  true,
+	MetadataLabelWorkload:    true,
 }
 
 // ValidateMetadataLabelsConfig validates that provided list of metadata labels is supported
@@ -56,6 +58,13 @@ type Metadata struct {
 	Labels                  map[MetadataLabel]bool
 	PodsMetadata            *v1.PodList
 	DetailedPVCLabelsSetter func(volCacheID, volumeClaim, namespace string, labels map[string]string) error
+	// PodLabels and PodAnnotations are the keys of the pod labels and annotations
+	// copied onto pod and container resources.
+	PodLabels      []string
+	PodAnnotations []string
+	// OwnerGetter resolves the controller of a ReplicaSet or a Job when
+	// computing the workload of a pod. It may be nil.
+	OwnerGetter OwnerGetter
 }
 
 func NewMetadata(
@@ -102,6 +111,52 @@ func (m *Metadata) setExtraLabels(
 		if err != nil {
 			return err
 		}
+	case MetadataLabelWorkload:
+		err := m.setWorkloadLabels(podUID, extraMetadataFrom, labels)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setPodLabels copies the configured labels and annotations of the pod with the
+// given UID into `labels`. Keys absent from the pod are skipped.
+func (m *Metadata) setPodLabels(labels map[string]string, podUID string) error {
+	if len(m.PodLabels) == 0 && len(m.PodAnnotations) == 0 {
+		return nil
+	}
+
+	if m.PodsMetadata == nil {
+		return errors.New("pods metadata were not fetched")
+	}
+
+	pod := m.getPod(podUID)
+	if pod == nil {
+		return fmt.Errorf("pod %q not found in the fetched metadata", podUID)
+	}
+
+	for _, key := range m.PodLabels {
+		if v, ok := pod.Labels[key]; ok {
+			labels[labelPodLabelsPrefix+key] = v
+		}
+	}
+	for _, key := range m.PodAnnotations {
+		if v, ok := pod.Annotations[key]; ok {
+			labels[labelPodAnnotationsPrefix+key] = v
+		}
+	}
+	return nil
+}
+
+// getPod returns the pod with the given UID from the fetched metadata, nil if
+// there is none.
+func (m *Metadata) getPod(podUID string) *v1.Pod {
+	uid := types.UID(podUID)
+	for i := range m.PodsMetadata.Items {
+		if m.PodsMetadata.Items[i].UID == uid {
+			return &m.PodsMetadata.Items[i]
+		}
 	}
 	return nil
 }
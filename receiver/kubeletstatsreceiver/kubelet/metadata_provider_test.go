@@ -32,6 +32,10 @@ func (f testRestClient) StatsSummary() ([]byte, error) {
 	return []byte{}, nil
 }
 
+func (f testRestClient) Cadvisor() ([]byte, error) {
+	return []byte{}, nil
+}
+
 func (f testRestClient) Pods() ([]byte, error) {
 	if f.fail {
 		return []byte{}, errors.New("failed")
@@ -45,6 +45,11 @@ func TestValidateMetadataLabelsConfig(t *testing.T) {
 			labels:    []MetadataLabel{MetadataLabelVolumeType},
 			wantError: "",
 		},
+		{
+			name:      "workload_valid",
+			labels:    []MetadataLabel{MetadataLabelWorkload},
+			wantError: "",
+		},
 		{
 			name:      "container_id_duplicate",
 			labels:    []MetadataLabel{MetadataLabelContainerID, MetadataLabelContainerID},
@@ -177,6 +182,81 @@ func TestSetExtraLabels(t *testing.T) {
 	}
 }
 
+func TestSetPodLabels(t *testing.T) {
+	podsMetadata := &v1.PodList{
+		Items: []v1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					UID: types.UID("uid-1234"),
+					Labels: map[string]string{
+						"app":     "my-app",
+						"version": "1.0",
+					},
+					Annotations: map[string]string{
+						"git_sha": "58a1e39",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		podsMetadata   *v1.PodList
+		podLabels      []string
+		podAnnotations []string
+		podUID         string
+		wantError      string
+		want           map[string]string
+	}{
+		{
+			name:   "nothing_to_set",
+			podUID: "uid-1234",
+			want:   map[string]string{},
+		},
+		{
+			name:           "set_labels_and_annotations",
+			podsMetadata:   podsMetadata,
+			podLabels:      []string{"app", "missing"},
+			podAnnotations: []string{"git_sha"},
+			podUID:         "uid-1234",
+			want: map[string]string{
+				"k8s.pod.labels.app":          "my-app",
+				"k8s.pod.annotations.git_sha": "58a1e39",
+			},
+		},
+		{
+			name:      "no_metadata",
+			podLabels: []string{"app"},
+			podUID:    "uid-1234",
+			wantError: "pods metadata were not fetched",
+		},
+		{
+			name:         "pod_not_found",
+			podsMetadata: podsMetadata,
+			podLabels:    []string{"app"},
+			podUID:       "uid-5678",
+			wantError:    "pod \"uid-5678\" not found in the fetched metadata",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := NewMetadata(nil, tt.podsMetadata, nil)
+			metadata.PodLabels = tt.podLabels
+			metadata.PodAnnotations = tt.podAnnotations
+
+			labels := map[string]string{}
+			err := metadata.setPodLabels(labels, tt.podUID)
+			if tt.wantError == "" {
+				require.NoError(t, err)
+				assert.EqualValues(t, tt.want, labels)
+			} else {
+				assert.Equal(t, tt.wantError, err.Error())
+			}
+		})
+	}
+}
+
 // Test happy paths for volume type metadata.
 func TestSetExtraLabelsForVolumeTypes(t *testing.T) {
 	tests := []struct {
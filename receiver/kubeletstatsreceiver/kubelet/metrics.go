@@ -36,7 +36,10 @@ func MetricsData(
 	acc.nodeStats(summary.Node)
 	for _, podStats := range summary.Pods {
 		// propagate the pod resource down to the container
-		podResource := podResource(podStats)
+		podResource, err := podResource(podStats, metadata)
+		if err != nil {
+			logger.Warn("failed to set pod metadata", zap.String("pod", podStats.PodRef.Name), zap.Error(err))
+		}
 		acc.podStats(podResource, podStats)
 		for _, containerStats := range podStats.Containers {
 			acc.containerStats(podResource, containerStats)
@@ -19,6 +19,7 @@ import (
 
 	resourcepb "github.com/census-instrumentation/opencensus-proto/gen-go/resource/v1"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/translator/conventions"
 	stats "k8s.io/kubernetes/pkg/kubelet/apis/stats/v1alpha1"
 )
@@ -32,8 +33,10 @@ func nodeResource(s stats.NodeStats) *resourcepb.Resource {
 	}
 }
 
-func podResource(s stats.PodStats) *resourcepb.Resource {
-	return &resourcepb.Resource{
+// podResource returns the resource of the pod. The returned resource is never nil, it
+// only lacks some of the labels coming from metadata when an error is returned.
+func podResource(s stats.PodStats, metadata Metadata) (*resourcepb.Resource, error) {
+	resource := &resourcepb.Resource{
 		Type: "k8s", // k8s/pod
 		Labels: map[string]string{
 			conventions.AttributeK8sPodUID:    s.PodRef.UID,
@@ -41,6 +44,22 @@ func podResource(s stats.PodStats) *resourcepb.Resource {
 			conventions.AttributeK8sNamespace: s.PodRef.Namespace,
 		},
 	}
+
+	// The labels are set independently, a failure doesn't prevent the others
+	// from being set.
+	var errs []error
+	if err := metadata.setPodLabels(resource.Labels, s.PodRef.UID); err != nil {
+		errs = append(errs, errors.WithMessage(err, "failed to set pod labels from metadata"))
+	}
+
+	err := metadata.setExtraLabels(
+		resource.Labels, s.PodRef.UID,
+		MetadataLabelWorkload, s.PodRef.Namespace,
+	)
+	if err != nil {
+		errs = append(errs, errors.WithMessage(err, "failed to set extra labels from metadata"))
+	}
+	return resource, componenterror.CombineErrors(errs)
 }
 
 func containerResource(pod *resourcepb.Resource, s stats.ContainerStats, metadata Metadata) (*resourcepb.Resource, error) {
@@ -0,0 +1,115 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubelet
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/translator/conventions"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OwnerGetter returns the controller of the object of the given kind, name and
+// namespace, nil if it has none.
+type OwnerGetter func(kind, name, namespace string) (*metav1.OwnerReference, error)
+
+const (
+	kindReplicaSet            = "ReplicaSet"
+	kindDeployment            = "Deployment"
+	kindStatefulSet           = "StatefulSet"
+	kindDaemonSet             = "DaemonSet"
+	kindJob                   = "Job"
+	kindCronJob               = "CronJob"
+	kindReplicationController = "ReplicationController"
+)
+
+// workloadNameLabels maps the kinds of workloads to the labels holding their name.
+var workloadNameLabels = map[string]string{
+	kindReplicaSet:            conventions.AttributeK8sReplicaSet,
+	kindDeployment:            conventions.AttributeK8sDeployment,
+	kindStatefulSet:           conventions.AttributeK8sStatefulSet,
+	kindDaemonSet:             conventions.AttributeK8sDaemonSet,
+	kindJob:                   conventions.AttributeK8sJob,
+	kindCronJob:               conventions.AttributeK8sCronJob,
+	kindReplicationController: "k8s.replicationcontroller.name",
+}
+
+// setWorkloadLabels sets the labels describing the workload owning the pod with
+// the given UID: the name of each controller in the ownership chain, e.g.
+// k8s.replicaset.name and k8s.deployment.name, and k8s.workload.kind and
+// k8s.workload.name for the top-most one. Pods not managed by a workload, like
+// static pods, get no label.
+func (m *Metadata) setWorkloadLabels(podUID string, namespace string, labels map[string]string) error {
+	pod := m.getPod(podUID)
+	if pod == nil {
+		return fmt.Errorf("pod %q not found in the fetched metadata", podUID)
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || workloadNameLabels[owner.Kind] == "" {
+		return nil
+	}
+
+	kind, name := owner.Kind, owner.Name
+	labels[workloadNameLabels[kind]] = name
+
+	// ReplicaSets and Jobs are usually managed by a Deployment or a CronJob.
+	// The labels are set even when the lookup of the parent fails, the error
+	// being returned afterwards.
+	var err error
+	if kind == kindReplicaSet || kind == kindJob {
+		var parent *metav1.OwnerReference
+		parent, err = m.getParent(kind, name, namespace)
+		if parent == nil && kind == kindReplicaSet {
+			parent = deploymentFromPodTemplateHash(name, pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey])
+		}
+		if parent != nil && workloadNameLabels[parent.Kind] != "" {
+			kind, name = parent.Kind, parent.Name
+			labels[workloadNameLabels[kind]] = name
+		}
+	}
+
+	labels[labelWorkloadKind] = kind
+	labels[labelWorkloadName] = name
+	return err
+}
+
+func (m *Metadata) getParent(kind, name, namespace string) (*metav1.OwnerReference, error) {
+	if m.OwnerGetter == nil {
+		return nil, nil
+	}
+	owner, err := m.OwnerGetter(kind, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the owner of %s %q: %w", kind, name, err)
+	}
+	return owner, nil
+}
+
+// deploymentFromPodTemplateHash guesses the Deployment managing a ReplicaSet
+// from its name, Deployments naming their ReplicaSets after themselves
+// followed by the pod template hash. Used when the Kubernetes API is not
+// available or the lookup of the owner of the ReplicaSet fails.
+func deploymentFromPodTemplateHash(replicaSetName string, podTemplateHash string) *metav1.OwnerReference {
+	suffix := "-" + podTemplateHash
+	if podTemplateHash == "" || !strings.HasSuffix(replicaSetName, suffix) {
+		return nil
+	}
+	return &metav1.OwnerReference{
+		Kind: kindDeployment,
+		Name: strings.TrimSuffix(replicaSetName, suffix),
+	}
+}
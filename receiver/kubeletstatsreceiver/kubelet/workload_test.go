@@ -0,0 +1,173 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubelet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSetWorkloadLabels(t *testing.T) {
+	parents := map[string]*metav1.OwnerReference{
+		"ReplicaSet/my-app-5456b4b8cd": {Kind: "Deployment", Name: "my-app"},
+		"Job/backup-1603000000":        {Kind: "CronJob", Name: "backup"},
+	}
+	ownerGetter := func(kind, name, namespace string) (*metav1.OwnerReference, error) {
+		require.Equal(t, "ns", namespace)
+		return parents[kind+"/"+name], nil
+	}
+
+	tests := []struct {
+		name        string
+		owner       *metav1.OwnerReference
+		podLabels   map[string]string
+		ownerGetter OwnerGetter
+		want        map[string]string
+	}{
+		{
+			name: "no_owner",
+			want: map[string]string{},
+		},
+		{
+			name:  "not_a_workload",
+			owner: &metav1.OwnerReference{Kind: "Node", Name: "minikube"},
+			want:  map[string]string{},
+		},
+		{
+			name:  "statefulset",
+			owner: &metav1.OwnerReference{Kind: "StatefulSet", Name: "db"},
+			want: map[string]string{
+				"k8s.statefulset.name": "db",
+				"k8s.workload.kind":    "StatefulSet",
+				"k8s.workload.name":    "db",
+			},
+		},
+		{
+			name:        "deployment_from_api",
+			owner:       &metav1.OwnerReference{Kind: "ReplicaSet", Name: "my-app-5456b4b8cd"},
+			ownerGetter: ownerGetter,
+			want: map[string]string{
+				"k8s.replicaset.name": "my-app-5456b4b8cd",
+				"k8s.deployment.name": "my-app",
+				"k8s.workload.kind":   "Deployment",
+				"k8s.workload.name":   "my-app",
+			},
+		},
+		{
+			name:      "deployment_from_pod_template_hash",
+			owner:     &metav1.OwnerReference{Kind: "ReplicaSet", Name: "my-app-5456b4b8cd"},
+			podLabels: map[string]string{"pod-template-hash": "5456b4b8cd"},
+			want: map[string]string{
+				"k8s.replicaset.name": "my-app-5456b4b8cd",
+				"k8s.deployment.name": "my-app",
+				"k8s.workload.kind":   "Deployment",
+				"k8s.workload.name":   "my-app",
+			},
+		},
+		{
+			name:  "bare_replicaset",
+			owner: &metav1.OwnerReference{Kind: "ReplicaSet", Name: "my-rs"},
+			want: map[string]string{
+				"k8s.replicaset.name": "my-rs",
+				"k8s.workload.kind":   "ReplicaSet",
+				"k8s.workload.name":   "my-rs",
+			},
+		},
+		{
+			name:        "cronjob_from_api",
+			owner:       &metav1.OwnerReference{Kind: "Job", Name: "backup-1603000000"},
+			ownerGetter: ownerGetter,
+			want: map[string]string{
+				"k8s.job.name":      "backup-1603000000",
+				"k8s.cronjob.name":  "backup",
+				"k8s.workload.kind": "CronJob",
+				"k8s.workload.name": "backup",
+			},
+		},
+		{
+			name:  "job_without_api",
+			owner: &metav1.OwnerReference{Kind: "Job", Name: "backup-1603000000"},
+			want: map[string]string{
+				"k8s.job.name":      "backup-1603000000",
+				"k8s.workload.kind": "Job",
+				"k8s.workload.name": "backup-1603000000",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:    types.UID("uid-1234"),
+					Labels: tt.podLabels,
+				},
+			}
+			if tt.owner != nil {
+				controller := true
+				tt.owner.Controller = &controller
+				pod.OwnerReferences = []metav1.OwnerReference{*tt.owner}
+			}
+			metadata := NewMetadata([]MetadataLabel{MetadataLabelWorkload}, &v1.PodList{
+				Items: []v1.Pod{pod},
+			}, nil)
+			metadata.OwnerGetter = tt.ownerGetter
+
+			labels := map[string]string{}
+			err := metadata.setExtraLabels(labels, "uid-1234", MetadataLabelWorkload, "ns")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, labels)
+		})
+	}
+}
+
+func TestSetWorkloadLabelsErrors(t *testing.T) {
+	controller := true
+	metadata := NewMetadata([]MetadataLabel{MetadataLabelWorkload}, &v1.PodList{
+		Items: []v1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:    types.UID("uid-1234"),
+					Labels: map[string]string{"pod-template-hash": "5456b4b8cd"},
+					OwnerReferences: []metav1.OwnerReference{
+						{Kind: "ReplicaSet", Name: "my-app-5456b4b8cd", Controller: &controller},
+					},
+				},
+			},
+		},
+	}, nil)
+	metadata.OwnerGetter = func(kind, name, namespace string) (*metav1.OwnerReference, error) {
+		return nil, errors.New("forbidden")
+	}
+
+	// The labels guessed from the pod template hash are set along with the error.
+	labels := map[string]string{}
+	err := metadata.setExtraLabels(labels, "uid-1234", MetadataLabelWorkload, "ns")
+	assert.EqualError(t, err, "failed to get the owner of ReplicaSet \"my-app-5456b4b8cd\": forbidden")
+	assert.Equal(t, map[string]string{
+		"k8s.replicaset.name": "my-app-5456b4b8cd",
+		"k8s.deployment.name": "my-app",
+		"k8s.workload.kind":   "Deployment",
+		"k8s.workload.name":   "my-app",
+	}, labels)
+
+	err = metadata.setExtraLabels(map[string]string{}, "uid-5678", MetadataLabelWorkload, "ns")
+	assert.EqualError(t, err, "pod \"uid-5678\" not found in the fetched metadata")
+}
@@ -15,6 +15,7 @@
 package kubeletstatsreceiver
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -138,3 +139,21 @@ func getMockedObjectsWithNonExistentVolumeName() []runtime.Object {
 		volumeClaim3,
 	}
 }
+
+func getReplicaSet(name, namespace, deploymentName string) *appsv1.ReplicaSet {
+	controller := true
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       types.UID(name),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind:       "Deployment",
+					Name:       deploymentName,
+					Controller: &controller,
+				},
+			},
+		},
+	}
+}
@@ -41,6 +41,8 @@ type receiverOptions struct {
 	name                  string
 	collectionInterval    time.Duration
 	extraMetadataLabels   []kubelet.MetadataLabel
+	podLabels             []string
+	podAnnotations        []string
 	metricGroupsToCollect map[kubelet.MetricGroup]bool
 	k8sAPIClient          kubernetes.Interface
 }
@@ -17,6 +17,7 @@ package kubeletstatsreceiver
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
@@ -33,18 +34,42 @@ import (
 
 var _ interval.Runnable = (*runnable)(nil)
 
+const (
+	// ownerCacheTTL is how long the owner of an object is cached. Owners never
+	// change during the lifetime of an object, the TTL only lets the entries of
+	// deleted objects expire.
+	ownerCacheTTL = time.Hour
+	// ownerNegativeCacheTTL is how long a failed owner lookup is cached, so
+	// that objects that can't be read aren't requested on every run.
+	ownerNegativeCacheTTL = 5 * time.Minute
+	// maxCachedOwners bounds the number of cached owner lookups.
+	maxCachedOwners = 1024
+)
+
+// cachedOwner is the result of an owner lookup.
+type cachedOwner struct {
+	owner     *metav1.OwnerReference
+	err       error
+	expiresAt time.Time
+}
+
 type runnable struct {
 	ctx                   context.Context
 	receiverName          string
 	statsProvider         *kubelet.StatsProvider
+	cadvisorProvider      *kubelet.CadvisorProvider
 	metadataProvider      *kubelet.MetadataProvider
 	consumer              consumer.MetricsConsumer
 	logger                *zap.Logger
 	restClient            kubelet.RestClient
 	extraMetadataLabels   []kubelet.MetadataLabel
+	podLabels             []string
+	podAnnotations        []string
 	metricGroupsToCollect map[kubelet.MetricGroup]bool
 	k8sAPIClient          kubernetes.Interface
 	cachedVolumeLabels    map[string]map[string]string
+	cachedOwners          map[string]cachedOwner
+	now                   func() time.Time
 }
 
 func newRunnable(
@@ -61,9 +86,13 @@ func newRunnable(
 		restClient:            restClient,
 		logger:                logger,
 		extraMetadataLabels:   rOptions.extraMetadataLabels,
+		podLabels:             rOptions.podLabels,
+		podAnnotations:        rOptions.podAnnotations,
 		metricGroupsToCollect: rOptions.metricGroupsToCollect,
 		k8sAPIClient:          rOptions.k8sAPIClient,
 		cachedVolumeLabels:    make(map[string]map[string]string),
+		cachedOwners:          make(map[string]cachedOwner),
+		now:                   time.Now,
 	}
 }
 
@@ -71,6 +100,7 @@ func newRunnable(
 func (r *runnable) Setup() error {
 	r.statsProvider = kubelet.NewStatsProvider(r.restClient)
 	r.metadataProvider = kubelet.NewMetadataProvider(r.restClient)
+	r.cadvisorProvider = kubelet.NewCadvisorProvider(r.restClient)
 	return nil
 }
 
@@ -84,7 +114,7 @@ func (r *runnable) Run() error {
 
 	var podsMetadata *v1.PodList
 	// fetch metadata only when extra metadata labels are needed
-	if len(r.extraMetadataLabels) > 0 {
+	if len(r.extraMetadataLabels) > 0 || len(r.podLabels) > 0 || len(r.podAnnotations) > 0 {
 		podsMetadata, err = r.metadataProvider.Pods()
 		if err != nil {
 			r.logger.Error("call to /pods endpoint failed", zap.Error(err))
@@ -93,7 +123,21 @@ func (r *runnable) Run() error {
 	}
 
 	metadata := kubelet.NewMetadata(r.extraMetadataLabels, podsMetadata, r.detailedPVCLabelsSetter())
+	metadata.PodLabels = r.podLabels
+	metadata.PodAnnotations = r.podAnnotations
+	if r.k8sAPIClient != nil {
+		metadata.OwnerGetter = r.ownerGetter
+	}
 	mds := kubelet.MetricsData(r.logger, summary, metadata, typeStr, r.metricGroupsToCollect)
+	if r.metricGroupsToCollect[kubelet.CadvisorMetricGroup] {
+		// cAdvisor metrics complement the summary ones, so a failure only skips them.
+		families, err := r.cadvisorProvider.MetricFamilies()
+		if err != nil {
+			r.logger.Error("call to /metrics/cadvisor endpoint failed", zap.Error(err))
+		} else {
+			mds = append(mds, kubelet.CadvisorMetricsData(r.logger, summary, families, metadata, typeStr, mds)...)
+		}
+	}
 	metrics := internaldata.OCSliceToMetrics(mds)
 
 	var numTimeSeries, numPoints int
@@ -146,3 +190,61 @@ func (r *runnable) detailedPVCLabelsSetter() func(volCacheID, volumeClaim, names
 		return nil
 	}
 }
+
+// ownerGetter returns the controller of a ReplicaSet or a Job from the Kubernetes API.
+// The results, failures included, are cached for a while.
+func (r *runnable) ownerGetter(kind, name, namespace string) (*metav1.OwnerReference, error) {
+	cacheID := fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+	now := r.now()
+	if cached, ok := r.cachedOwners[cacheID]; ok && now.Before(cached.expiresAt) {
+		return cached.owner, cached.err
+	}
+
+	owner, err := r.getOwner(kind, name, namespace)
+	ttl := ownerCacheTTL
+	if err != nil {
+		ttl = ownerNegativeCacheTTL
+	}
+	r.cacheOwner(cacheID, cachedOwner{owner: owner, err: err, expiresAt: now.Add(ttl)})
+	return owner, err
+}
+
+// cacheOwner caches the result of an owner lookup. The expired entries are
+// removed when the cache is full, then arbitrary ones if it is still full.
+func (r *runnable) cacheOwner(cacheID string, entry cachedOwner) {
+	if _, ok := r.cachedOwners[cacheID]; !ok && len(r.cachedOwners) >= maxCachedOwners {
+		now := r.now()
+		for id, cached := range r.cachedOwners {
+			if !now.Before(cached.expiresAt) {
+				delete(r.cachedOwners, id)
+			}
+		}
+		for id := range r.cachedOwners {
+			if len(r.cachedOwners) < maxCachedOwners {
+				break
+			}
+			delete(r.cachedOwners, id)
+		}
+	}
+	r.cachedOwners[cacheID] = entry
+}
+
+func (r *runnable) getOwner(kind, name, namespace string) (*metav1.OwnerReference, error) {
+	ctx := context.Background()
+	switch kind {
+	case "ReplicaSet":
+		rs, err := r.k8sAPIClient.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return metav1.GetControllerOf(rs), nil
+	case "Job":
+		job, err := r.k8sAPIClient.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return metav1.GetControllerOf(job), nil
+	default:
+		return nil, fmt.Errorf("unsupported owner lookup for kind %q", kind)
+	}
+}
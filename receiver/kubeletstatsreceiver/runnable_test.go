@@ -17,15 +17,20 @@ package kubeletstatsreceiver
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/translator/internaldata"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -46,6 +51,11 @@ const (
 	podMetrics       = 15
 	containerMetrics = 11
 	volumeMetrics    = 5
+
+	// Number of metrics in testdata/cadvisor.txt matching the pods of
+	// testdata/stats-summary.json, and of those not derived from the summary.
+	cadvisorMetrics        = 19
+	cadvisorSummaryMetrics = 7
 )
 
 var allMetricGroups = map[kubelet.MetricGroup]bool{
@@ -139,6 +149,80 @@ func TestRunnableWithMetadata(t *testing.T) {
 	}
 }
 
+func TestRunnableWithPodMetadata(t *testing.T) {
+	tests := []struct {
+		name           string
+		k8sAPIClient   kubernetes.Interface
+		wantDeployment string
+	}{
+		{
+			name:           "without k8s api",
+			wantDeployment: "go-hello-world",
+		},
+		{
+			name: "with k8s api",
+			k8sAPIClient: fake.NewSimpleClientset(
+				getReplicaSet("go-hello-world-5456b4b8cd", "default", "hello-world"),
+				getReplicaSet("coredns-66bff467f8", "kube-system", "coredns"),
+			),
+			wantDeployment: "hello-world",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := &exportertest.SinkMetricsExporter{}
+			options := &receiverOptions{
+				extraMetadataLabels: []kubelet.MetadataLabel{kubelet.MetadataLabelWorkload},
+				podLabels:           []string{"app"},
+				podAnnotations:      []string{"git_sha"},
+				metricGroupsToCollect: map[kubelet.MetricGroup]bool{
+					kubelet.ContainerMetricGroup: true,
+					kubelet.PodMetricGroup:       true,
+				},
+				k8sAPIClient: tt.k8sAPIClient,
+			}
+			r := newRunnable(
+				context.Background(),
+				consumer,
+				&fakeRestClient{},
+				zap.NewNop(),
+				options,
+			)
+			err := r.Setup()
+			require.NoError(t, err)
+			err = r.Run()
+			require.NoError(t, err)
+			require.Equal(t, numContainers*containerMetrics+numPods*podMetrics, consumer.MetricsCount())
+
+			var found int
+			for _, metrics := range consumer.AllMetrics() {
+				for _, md := range internaldata.MetricsToOC(metrics) {
+					labels := md.Resource.Labels
+					switch labels["k8s.pod.name"] {
+					case "go-hello-world-5456b4b8cd-99vxc":
+						found++
+						require.Equal(t, "go-hello-world", labels["k8s.pod.labels.app"])
+						require.Equal(t, "58a1e39", labels["k8s.pod.annotations.git_sha"])
+						require.Equal(t, "go-hello-world-5456b4b8cd", labels["k8s.replicaset.name"])
+						require.Equal(t, tt.wantDeployment, labels["k8s.deployment.name"])
+						require.Equal(t, "Deployment", labels["k8s.workload.kind"])
+						require.Equal(t, tt.wantDeployment, labels["k8s.workload.name"])
+					case "kube-proxy-v48tf":
+						require.Equal(t, "kube-proxy", labels["k8s.daemonset.name"])
+						require.Equal(t, "DaemonSet", labels["k8s.workload.kind"])
+					case "etcd-minikube":
+						_, ok := labels["k8s.workload.kind"]
+						require.False(t, ok)
+					}
+				}
+			}
+			// The pod and its container.
+			require.Equal(t, 2, found)
+		})
+	}
+}
+
 func TestRunnableWithMetricGroups(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -186,6 +270,22 @@ func TestRunnableWithMetricGroups(t *testing.T) {
 			},
 			dataLen: numNodes*nodeMetrics + numPods*podMetrics,
 		},
+		{
+			name: "only cadvisor group",
+			metricGroups: map[kubelet.MetricGroup]bool{
+				kubelet.CadvisorMetricGroup: true,
+			},
+			dataLen: cadvisorMetrics,
+		},
+		{
+			name: "container, pod and cadvisor groups",
+			metricGroups: map[kubelet.MetricGroup]bool{
+				kubelet.ContainerMetricGroup: true,
+				kubelet.PodMetricGroup:       true,
+				kubelet.CadvisorMetricGroup:  true,
+			},
+			dataLen: numContainers*containerMetrics + numPods*podMetrics + cadvisorMetrics - cadvisorSummaryMetrics,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -406,6 +506,7 @@ func TestClientErrors(t *testing.T) {
 		name                  string
 		statsSummaryFail      bool
 		podsFail              bool
+		cadvisorFail          bool
 		extraMetadataLabels   []kubelet.MetadataLabel
 		metricGroupsToCollect map[kubelet.MetricGroup]bool
 		numLogs               int
@@ -442,6 +543,14 @@ func TestClientErrors(t *testing.T) {
 			metricGroupsToCollect: allMetricGroups,
 			numLogs:               1,
 		},
+		{
+			name:         "cadvisor_endpoint_error",
+			cadvisorFail: true,
+			metricGroupsToCollect: map[kubelet.MetricGroup]bool{
+				kubelet.CadvisorMetricGroup: true,
+			},
+			numLogs: 1,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -456,6 +565,7 @@ func TestClientErrors(t *testing.T) {
 				&fakeRestClient{
 					statsSummaryFail: test.statsSummaryFail,
 					podsFail:         test.podsFail,
+					cadvisorFail:     test.cadvisorFail,
 				},
 				zap.New(core),
 				options,
@@ -510,6 +620,7 @@ var _ kubelet.RestClient = (*fakeRestClient)(nil)
 type fakeRestClient struct {
 	statsSummaryFail bool
 	podsFail         bool
+	cadvisorFail     bool
 }
 
 func (f *fakeRestClient) StatsSummary() ([]byte, error) {
@@ -525,3 +636,54 @@ func (f *fakeRestClient) Pods() ([]byte, error) {
 	}
 	return ioutil.ReadFile("testdata/pods.json")
 }
+
+func (f *fakeRestClient) Cadvisor() ([]byte, error) {
+	if f.cadvisorFail {
+		return nil, errors.New("")
+	}
+	return ioutil.ReadFile("testdata/cadvisor.txt")
+}
+
+func TestOwnerGetterCache(t *testing.T) {
+	controller := true
+	client := fake.NewSimpleClientset(&appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app-5456b4b8cd",
+			Namespace: "ns",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Deployment", Name: "my-app", Controller: &controller},
+			},
+		},
+	})
+	now := time.Now()
+	r := newRunnable(context.Background(), nil, nil, zap.NewNop(), &receiverOptions{k8sAPIClient: client})
+	r.now = func() time.Time { return now }
+
+	owner, err := r.ownerGetter("ReplicaSet", "my-app-5456b4b8cd", "ns")
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", owner.Name)
+	_, err = r.ownerGetter("ReplicaSet", "my-app-5456b4b8cd", "ns")
+	require.NoError(t, err)
+	assert.Len(t, client.Actions(), 1)
+
+	// Failed lookups are cached too, for a shorter time.
+	_, err = r.ownerGetter("ReplicaSet", "missing", "ns")
+	require.Error(t, err)
+	_, err = r.ownerGetter("ReplicaSet", "missing", "ns")
+	require.Error(t, err)
+	assert.Len(t, client.Actions(), 2)
+
+	now = now.Add(ownerNegativeCacheTTL)
+	_, err = r.ownerGetter("ReplicaSet", "missing", "ns")
+	require.Error(t, err)
+	_, err = r.ownerGetter("ReplicaSet", "my-app-5456b4b8cd", "ns")
+	require.NoError(t, err)
+	assert.Len(t, client.Actions(), 3)
+
+	// The cache is bounded.
+	for i := 0; i < maxCachedOwners+10; i++ {
+		_, err = r.ownerGetter("ReplicaSet", fmt.Sprintf("missing-%d", i), "ns")
+		require.Error(t, err)
+	}
+	assert.Len(t, r.cachedOwners, maxCachedOwners)
+}
@@ -53,7 +53,11 @@ type receiverTemplate struct {
 	// Rule is the discovery rule that when matched will create a receiver instance
 	// based on receiverTemplate.
 	Rule string `mapstructure:"rule"`
-	rule rule
+	// ResourceAttributes overrides the resource attributes added to the telemetry of
+	// the receiver instances, which default to attributes derived from the endpoint.
+	// Values can be expanded like the receiver config.
+	ResourceAttributes userConfigMap `mapstructure:"resource_attributes"`
+	rule               rule
 }
 
 // newReceiverTemplate creates a receiverTemplate instance from the full name of a subreceiver
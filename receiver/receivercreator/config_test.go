@@ -85,5 +85,9 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, userConfigMap{
 		endpointConfigKey: "localhost:12345",
 	}, r1.receiverTemplates["examplereceiver/1"].config)
+	assert.Equal(t, userConfigMap{
+		"service.name":       "`pod.labels[\"app\"]`",
+		"k8s.namespace.name": "",
+	}, r1.receiverTemplates["examplereceiver/1"].ResourceAttributes)
 	assert.Equal(t, []configmodels.Type{"mock_observer"}, r1.WatchObservers)
 }
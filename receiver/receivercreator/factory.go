@@ -17,12 +17,14 @@ package receivercreator
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
 )
 
 // This file implements factory for receiver_creator. A receiver_creator can create other receivers at runtime.
@@ -37,7 +39,9 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		receiverhelper.WithCustomUnmarshaler(customUnmarshaler),
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithTraces(createTraceReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
 }
 
 func createDefaultConfig() configmodels.Receiver {
@@ -56,9 +60,59 @@ func createMetricsReceiver(
 	cfg configmodels.Receiver,
 	consumer consumer.MetricsConsumer,
 ) (component.MetricsReceiver, error) {
-	return newReceiverCreator(params.Logger, consumer, cfg.(*Config))
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+	r := getOrCreateReceiverCreator(params.Logger, cfg.(*Config))
+	r.registerMetricsConsumer(consumer)
+	return r, nil
 }
 
+func createTraceReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.TraceConsumer,
+) (component.TraceReceiver, error) {
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+	r := getOrCreateReceiverCreator(params.Logger, cfg.(*Config))
+	r.registerTracesConsumer(consumer)
+	return r, nil
+}
+
+func createLogsReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	if consumer == nil {
+		return nil, errNilNextConsumer
+	}
+	r := getOrCreateReceiverCreator(params.Logger, cfg.(*Config))
+	r.registerLogsConsumer(consumer)
+	return r, nil
+}
+
+// getOrCreateReceiverCreator returns the receiver_creator of the config, the
+// same instance being used for all the data types so that the receivers it
+// starts at runtime are only started once.
+func getOrCreateReceiverCreator(logger *zap.Logger, cfg *Config) *receiverCreator {
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+	r := receivers[cfg]
+	if r == nil {
+		r = newReceiverCreator(logger, cfg)
+		receivers[cfg] = r
+	}
+	return r
+}
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*receiverCreator{}
+
 func customUnmarshaler(sourceViperSection *viper.Viper, intoCfg interface{}) error {
 	if sourceViperSection == nil {
 		// Nothing to do if there is no config given.
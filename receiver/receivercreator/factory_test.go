@@ -20,7 +20,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config/configerror"
 	"go.uber.org/zap"
 )
 
@@ -37,8 +36,23 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 
-	mReceiver, err := factory.CreateTraceReceiver(context.Background(), params, cfg, nil)
-	assert.Error(t, err)
-	assert.Equal(t, err, configerror.ErrDataTypeIsNotSupported)
+	trReceiver, err := factory.CreateTraceReceiver(context.Background(), params, cfg, &mockTraceConsumer{})
+	assert.NoError(t, err, "receiver creation failed")
+	assert.NotNil(t, trReceiver, "receiver creation failed")
+
+	lReceiver, err := factory.CreateLogsReceiver(context.Background(), params, cfg, &mockLogsConsumer{})
+	assert.NoError(t, err, "receiver creation failed")
+	assert.NotNil(t, lReceiver, "receiver creation failed")
+
+	// The same receiver is shared by all the data types.
+	assert.Same(t, tReceiver, trReceiver)
+	assert.Same(t, tReceiver, lReceiver)
+	rc := tReceiver.(*receiverCreator)
+	assert.NotNil(t, rc.nextMetricsConsumer)
+	assert.NotNil(t, rc.nextTracesConsumer)
+	assert.NotNil(t, rc.nextLogsConsumer)
+
+	mReceiver, err := factory.CreateMetricsReceiver(context.Background(), params, cfg, nil)
+	assert.Equal(t, errNilNextConsumer, err)
 	assert.Nil(t, mReceiver)
 }
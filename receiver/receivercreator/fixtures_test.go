@@ -46,6 +46,94 @@ var portEndpoint = observer.Endpoint{
 	},
 }
 
+var containerEndpoint = observer.Endpoint{
+	ID:     "container-1",
+	Target: "172.17.0.2:6379",
+	Details: observer.Container{
+		Name:        "redis-cache",
+		Image:       "redis",
+		Tag:         "6.0",
+		ContainerID: "c6f8b58a2c71",
+		Port:        6379,
+		Transport:   observer.ProtocolTCP,
+		Host:        "172.17.0.2",
+		Network:     "bridge",
+		Labels: map[string]string{
+			"app": "cache",
+		},
+	},
+}
+
+var serviceEndpoint = observer.Endpoint{
+	ID:     "service-1",
+	Target: "10.96.0.20:9090",
+	Details: observer.Service{
+		Name:      "service-1",
+		Namespace: "default",
+		UID:       "service-1-UID",
+		Annotations: map[string]string{
+			"prometheus.io/scrape": "true",
+		},
+		ServiceType: "ClusterIP",
+		ClusterIP:   "10.96.0.20",
+		PortName:    "metrics",
+		Port:        9090,
+		Transport:   observer.ProtocolTCP,
+	},
+}
+
+var nodeEndpoint = observer.Endpoint{
+	ID:     "node-1",
+	Target: "192.168.1.10:10250",
+	Details: observer.Node{
+		Name: "node-1",
+		UID:  "node-1-UID",
+		Labels: map[string]string{
+			"kubernetes.io/os": "linux",
+		},
+		Hostname:            "node-1.local",
+		InternalIP:          "192.168.1.10",
+		KubeletEndpointPort: 10250,
+	},
+}
+
+var ingressEndpoint = observer.Endpoint{
+	ID:     "ingress-1",
+	Target: "example.com",
+	Details: observer.Ingress{
+		Name:        "ingress-1",
+		Namespace:   "default",
+		UID:         "ingress-1-UID",
+		Host:        "example.com",
+		Path:        "/api",
+		Scheme:      "https",
+		ServiceName: "service-1",
+		ServicePort: "80",
+	},
+}
+
+var ecsTaskEndpoint = observer.Endpoint{
+	ID:     "arn:aws:ecs:us-west-2:123456789012:task/cluster/task-1/exporter/9100",
+	Target: "10.0.1.12:32768",
+	Details: observer.ECSTask{
+		TaskARN:           "arn:aws:ecs:us-west-2:123456789012:task/cluster/task-1",
+		TaskDefinitionARN: "arn:aws:ecs:us-west-2:123456789012:task-definition/exporter:3",
+		Family:            "exporter",
+		Revision:          3,
+		LaunchType:        "EC2",
+		ContainerName:     "exporter",
+		Image:             "prom/node-exporter",
+		Tag:               "v1.0.1",
+		Labels: map[string]string{
+			"prometheus.io/scrape": "true",
+		},
+		Port:          32768,
+		ContainerPort: 9100,
+		Transport:     observer.ProtocolTCP,
+		Host:          "10.0.1.12",
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
@@ -19,6 +19,7 @@ import (
 	"sync"
 
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configerror"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
@@ -106,14 +107,25 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 				continue
 			}
 
+			resourceAttributes, err := resolveResourceAttributes(e, env, template.ResourceAttributes)
+			if err != nil {
+				obs.logger.Error("unable to resolve resource attributes", zap.String("receiver", template.fullName), zap.Error(err))
+				continue
+			}
+
 			rcvr, err := obs.runner.start(receiverConfig{
 				fullName: template.fullName,
 				typeStr:  template.typeStr,
 				config:   resolvedConfig,
-			}, resolvedDiscoveredConfig)
+			}, resolvedDiscoveredConfig, resourceAttributes)
 
+			if err == configerror.ErrDataTypeIsNotSupported {
+				// The receiver_creator is only part of pipelines of data types the receiver doesn't support.
+				obs.logger.Debug("receiver does not support the data types of the pipelines", zap.String("receiver", template.fullName))
+				continue
+			}
 			if err != nil {
-				obs.logger.Error("failed to start receiver", zap.String("receiver", template.fullName))
+				obs.logger.Error("failed to start receiver", zap.String("receiver", template.fullName), zap.Error(err))
 				continue
 			}
 
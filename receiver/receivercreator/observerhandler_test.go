@@ -21,7 +21,9 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
@@ -31,8 +33,8 @@ type mockRunner struct {
 	mock.Mock
 }
 
-func (run *mockRunner) start(receiver receiverConfig, discoveredConfig userConfigMap) (component.Receiver, error) {
-	args := run.Called(receiver, discoveredConfig)
+func (run *mockRunner) start(receiver receiverConfig, discoveredConfig userConfigMap, resourceAttributes userConfigMap) (component.Receiver, error) {
+	args := run.Called(receiver, discoveredConfig, resourceAttributes)
 	return args.Get(0).(component.Receiver), args.Error(1)
 }
 
@@ -43,19 +45,24 @@ func (run *mockRunner) shutdown(rcvr component.Receiver) error {
 
 var _ runner = (*mockRunner)(nil)
 
+var portResourceAttributes = userConfigMap{
+	conventions.AttributeK8sPod:      "pod-1",
+	conventions.AttributeNetHostPort: uint16(1234),
+}
+
 func TestOnAdd(t *testing.T) {
 	runner := &mockRunner{}
 	rcvrCfg := receiverConfig{typeStr: configmodels.Type("name"), config: userConfigMap{"foo": "bar"}, fullName: "name/1"}
 	handler := &observerHandler{
 		logger: zap.NewNop(),
 		receiverTemplates: map[string]receiverTemplate{
-			"name/1": {rcvrCfg, "", newRuleOrPanic(`type.port`)},
+			"name/1": {rcvrCfg, "", nil, newRuleOrPanic(`type.port`)},
 		},
 		receiversByEndpointID: receiverMap{},
 		runner:                runner,
 	}
 
-	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}).Return(&componenttest.ExampleReceiverProducer{}, nil)
+	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}, portResourceAttributes).Return(&componenttest.ExampleReceiverProducer{}, nil)
 
 	handler.OnAdd([]observer.Endpoint{
 		portEndpoint,
@@ -66,6 +73,27 @@ func TestOnAdd(t *testing.T) {
 	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
 }
 
+func TestOnAddUnsupportedDataType(t *testing.T) {
+	runner := &mockRunner{}
+	rcvrCfg := receiverConfig{typeStr: configmodels.Type("name"), config: userConfigMap{"foo": "bar"}, fullName: "name/1"}
+	handler := &observerHandler{
+		logger: zap.NewNop(),
+		receiverTemplates: map[string]receiverTemplate{
+			"name/1": {rcvrCfg, "", nil, newRuleOrPanic(`type.port`)},
+		},
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+	}
+
+	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}, portResourceAttributes).Return(
+		(*componenttest.ExampleReceiverProducer)(nil), configerror.ErrDataTypeIsNotSupported)
+
+	handler.OnAdd([]observer.Endpoint{portEndpoint})
+
+	runner.AssertExpectations(t)
+	assert.Equal(t, 0, handler.receiversByEndpointID.Size())
+}
+
 func TestOnRemove(t *testing.T) {
 	runner := &mockRunner{}
 	rcvr := &componenttest.ExampleReceiverProducer{}
@@ -93,7 +121,7 @@ func TestOnChange(t *testing.T) {
 	handler := &observerHandler{
 		logger: zap.NewNop(),
 		receiverTemplates: map[string]receiverTemplate{
-			"name/1": {rcvrCfg, "", newRuleOrPanic(`type.port`)},
+			"name/1": {rcvrCfg, "", nil, newRuleOrPanic(`type.port`)},
 		},
 		receiversByEndpointID: receiverMap{},
 		runner:                runner,
@@ -102,7 +130,7 @@ func TestOnChange(t *testing.T) {
 	handler.receiversByEndpointID.Put("port-1", oldRcvr)
 
 	runner.On("shutdown", oldRcvr).Return(nil)
-	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}).Return(newRcvr, nil)
+	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}, portResourceAttributes).Return(newRcvr, nil)
 
 	handler.OnChange([]observer.Endpoint{portEndpoint})
 
@@ -129,10 +157,40 @@ func TestDynamicConfig(t *testing.T) {
 		fullName: "name/1",
 		typeStr:  "name",
 		config:   userConfigMap{endpointConfigKey: "localhost:6379"},
-	}, userConfigMap{}).Return(&componenttest.ExampleReceiverProducer{}, nil)
+	}, userConfigMap{}, userConfigMap{conventions.AttributeK8sPod: "pod-1"}).Return(&componenttest.ExampleReceiverProducer{}, nil)
 	handler.OnAdd([]observer.Endpoint{
 		podEndpoint,
 	})
 
 	runner.AssertExpectations(t)
 }
+
+func TestResourceAttributesOverride(t *testing.T) {
+	runner := &mockRunner{}
+	rcvrCfg := receiverConfig{typeStr: configmodels.Type("name"), config: userConfigMap{}, fullName: "name/1"}
+	handler := &observerHandler{
+		logger:                zap.NewNop(),
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+		receiverTemplates: map[string]receiverTemplate{
+			"name/1": {
+				receiverConfig: rcvrCfg,
+				Rule:           "type.port",
+				rule:           newRuleOrPanic("type.port"),
+				ResourceAttributes: userConfigMap{
+					"service.name":                   "`pod.labels[\"app\"]`",
+					conventions.AttributeNetHostPort: "",
+				},
+			},
+		},
+	}
+	runner.On("start", rcvrCfg, userConfigMap{endpointConfigKey: "localhost:1234"}, userConfigMap{
+		conventions.AttributeK8sPod: "pod-1",
+		"service.name":              "redis",
+	}).Return(&componenttest.ExampleReceiverProducer{}, nil)
+	handler.OnAdd([]observer.Endpoint{
+		portEndpoint,
+	})
+
+	runner.AssertExpectations(t)
+}
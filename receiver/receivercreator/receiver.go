@@ -31,28 +31,45 @@ var (
 	errNilNextConsumer = errors.New("nil nextConsumer")
 )
 
-var _ component.MetricsReceiver = (*receiverCreator)(nil)
+var (
+	_ component.MetricsReceiver = (*receiverCreator)(nil)
+	_ component.TraceReceiver   = (*receiverCreator)(nil)
+	_ component.LogsReceiver    = (*receiverCreator)(nil)
+)
 
-// receiverCreator implements consumer.MetricsConsumer.
+// receiverCreator starts receivers at runtime. A single instance is shared by
+// the pipelines the receiver_creator is part of, the next consumer of each of
+// their data types being registered on it.
 type receiverCreator struct {
-	nextConsumer    consumer.MetricsConsumer
-	logger          *zap.Logger
-	cfg             *Config
-	observerHandler observerHandler
+	nextMetricsConsumer consumer.MetricsConsumer
+	nextTracesConsumer  consumer.TraceConsumer
+	nextLogsConsumer    consumer.LogsConsumer
+	logger              *zap.Logger
+	cfg                 *Config
+	observerHandler     observerHandler
 }
 
-// newReceiverCreator creates the receiver_creator with the given parameters.
-func newReceiverCreator(logger *zap.Logger, nextConsumer consumer.MetricsConsumer, cfg *Config) (component.MetricsReceiver, error) {
-	if nextConsumer == nil {
-		return nil, errNilNextConsumer
+// newReceiverCreator creates a receiver_creator without any next consumer.
+func newReceiverCreator(logger *zap.Logger, cfg *Config) *receiverCreator {
+	return &receiverCreator{
+		logger: logger,
+		cfg:    cfg,
 	}
+}
 
-	r := &receiverCreator{
-		logger:       logger,
-		nextConsumer: nextConsumer,
-		cfg:          cfg,
-	}
-	return r, nil
+// registerMetricsConsumer sets the next consumer of the metrics pipeline.
+func (rc *receiverCreator) registerMetricsConsumer(mc consumer.MetricsConsumer) {
+	rc.nextMetricsConsumer = mc
+}
+
+// registerTracesConsumer sets the next consumer of the traces pipeline.
+func (rc *receiverCreator) registerTracesConsumer(tc consumer.TraceConsumer) {
+	rc.nextTracesConsumer = tc
+}
+
+// registerLogsConsumer sets the next consumer of the logs pipeline.
+func (rc *receiverCreator) registerLogsConsumer(lc consumer.LogsConsumer) {
+	rc.nextLogsConsumer = lc
 }
 
 // loggingHost provides a safer version of host that logs errors instead of exiting the process.
@@ -75,9 +92,11 @@ func (rc *receiverCreator) Start(ctx context.Context, host component.Host) error
 		receiverTemplates:     rc.cfg.receiverTemplates,
 		receiversByEndpointID: receiverMap{},
 		runner: &receiverRunner{
-			logger:       rc.logger,
-			nextConsumer: rc.nextConsumer,
-			idNamespace:  rc.cfg.Name(),
+			logger:              rc.logger,
+			nextMetricsConsumer: rc.nextMetricsConsumer,
+			nextTracesConsumer:  rc.nextTracesConsumer,
+			nextLogsConsumer:    rc.nextLogsConsumer,
+			idNamespace:         rc.cfg.Name(),
 			// TODO: not really sure what context should be used here for starting subreceivers
 			// as don't think it makes sense to use Start context as the lifetimes are different.
 			ctx:  context.Background(),
@@ -33,6 +33,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
 	"go.opentelemetry.io/collector/translator/internaldata"
 	"go.uber.org/zap"
 	zapObserver "go.uber.org/zap/zaptest/observer"
@@ -60,6 +61,32 @@ func (p *mockMetricsConsumer) ConsumeMetrics(ctx context.Context, md pdata.Metri
 	return nil
 }
 
+type mockTraceConsumer struct {
+	Traces     []pdata.Traces
+	TotalSpans int
+}
+
+var _ consumer.TraceConsumer = &mockTraceConsumer{}
+
+func (p *mockTraceConsumer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	p.Traces = append(p.Traces, td)
+	p.TotalSpans += td.SpanCount()
+	return nil
+}
+
+type mockLogsConsumer struct {
+	Logs      []pdata.Logs
+	TotalLogs int
+}
+
+var _ consumer.LogsConsumer = &mockLogsConsumer{}
+
+func (p *mockLogsConsumer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	p.Logs = append(p.Logs, ld)
+	p.TotalLogs += ld.LogRecordCount()
+	return nil
+}
+
 type mockObserver struct {
 }
 
@@ -143,13 +170,60 @@ func TestMockedEndToEnd(t *testing.T) {
 	}
 
 	// TODO: Will have to rework once receivers are started asynchronously to Start().
-	assert.Len(t, mockConsumer.Metrics, 1)
+	require.Len(t, mockConsumer.Metrics, 1)
+
+	// Test that the resource attributes of the endpoint were added without overwriting existing ones.
+	attrs := mockConsumer.Metrics[0].ResourceMetrics().At(0).Resource().Attributes()
+	podName, ok := attrs.Get(conventions.AttributeK8sPod)
+	require.True(t, ok)
+	assert.Equal(t, "pod-1", podName.StringVal())
+	serviceName, ok := attrs.Get(conventions.AttributeServiceName)
+	require.True(t, ok)
+	assert.Equal(t, "dynamictest", serviceName.StringVal())
 
 	shutdown()
 
 	assert.True(t, dyn.observerHandler.receiversByEndpointID.Values()[0].(*componenttest.ExampleReceiverProducer).Stopped)
 }
 
+func TestMockedEndToEndTraces(t *testing.T) {
+	host, cfg := exampleCreatorFactory(t)
+	host.extensions = map[configmodels.Extension]component.ServiceExtension{
+		&configmodels.ExtensionSettings{
+			TypeVal: "mock_observer",
+			NameVal: "mock_observer",
+		}: &mockObserver{},
+	}
+	dynCfg := cfg.Receivers["receiver_creator/1"]
+	factory := NewFactory()
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	mockConsumer := &mockTraceConsumer{}
+	rcvr, err := factory.CreateTraceReceiver(context.Background(), params, dynCfg, mockConsumer)
+	require.NoError(t, err)
+	dyn := rcvr.(*receiverCreator)
+	require.NoError(t, rcvr.Start(context.Background(), host))
+	defer func() {
+		assert.NoError(t, rcvr.Shutdown(context.Background()))
+	}()
+
+	require.Eventuallyf(t, func() bool {
+		return dyn.observerHandler.receiversByEndpointID.Size() == 1
+	}, 1*time.Second, 100*time.Millisecond, "expected 1 receiver but got %v", dyn.observerHandler.receiversByEndpointID)
+
+	// Test that we can send spans.
+	for _, receiver := range dyn.observerHandler.receiversByEndpointID.Values() {
+		example := receiver.(*componenttest.ExampleReceiverProducer)
+		assert.Nil(t, example.MetricsConsumer)
+		td := pdata.NewTraces()
+		td.ResourceSpans().Resize(1)
+		td.ResourceSpans().At(0).InstrumentationLibrarySpans().Resize(1)
+		td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().Resize(1)
+		assert.NoError(t, example.TraceConsumer.ConsumeTraces(context.Background(), td))
+	}
+
+	assert.Equal(t, 1, mockConsumer.TotalSpans)
+}
+
 func TestLoggingHost(t *testing.T) {
 	core, obs := zapObserver.New(zap.ErrorLevel)
 	host := &loggingHost{
@@ -0,0 +1,205 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// Resource attributes of the k8s objects without semantic conventions.
+const (
+	k8sNodeName    = "k8s.node.name"
+	k8sServiceName = "k8s.service.name"
+	k8sIngressName = "k8s.ingress.name"
+)
+
+// Resource attributes of the ECS tasks without semantic conventions.
+const (
+	awsECSTaskARN    = "aws.ecs.task.arn"
+	awsECSTaskFamily = "aws.ecs.task.family"
+)
+
+// defaultResourceAttributes returns the resource attributes added to the telemetry of the
+// receivers started for the endpoint. The values are expanded like the receiver config.
+func defaultResourceAttributes(e observer.Endpoint) userConfigMap {
+	switch e.Details.(type) {
+	case observer.Pod:
+		return userConfigMap{
+			conventions.AttributeK8sPod:       "`name`",
+			conventions.AttributeK8sPodUID:    "`uid`",
+			conventions.AttributeK8sNamespace: "`namespace`",
+		}
+	case observer.Port:
+		return userConfigMap{
+			conventions.AttributeK8sPod:       "`pod.name`",
+			conventions.AttributeK8sPodUID:    "`pod.uid`",
+			conventions.AttributeK8sNamespace: "`pod.namespace`",
+			conventions.AttributeNetHostPort:  "`port`",
+		}
+	case observer.HostPort:
+		return userConfigMap{
+			conventions.AttributeNetHostPort: "`port`",
+		}
+	case observer.Container:
+		return userConfigMap{
+			conventions.AttributeContainerName:  "`name`",
+			conventions.AttributeContainerID:    "`container_id`",
+			conventions.AttributeContainerImage: "`image`",
+			conventions.AttributeContainerTag:   "`tag`",
+			conventions.AttributeNetHostPort:    "`port`",
+		}
+	case observer.Service:
+		return userConfigMap{
+			conventions.AttributeK8sNamespace: "`namespace`",
+			k8sServiceName:                    "`name`",
+			conventions.AttributeNetHostPort:  "`port`",
+		}
+	case observer.Node:
+		return userConfigMap{
+			k8sNodeName: "`name`",
+		}
+	case observer.Ingress:
+		return userConfigMap{
+			conventions.AttributeK8sNamespace: "`namespace`",
+			k8sIngressName:                    "`name`",
+		}
+	case observer.ECSTask:
+		return userConfigMap{
+			awsECSTaskARN:                       "`task_arn`",
+			awsECSTaskFamily:                    "`family`",
+			conventions.AttributeContainerName:  "`container_name`",
+			conventions.AttributeContainerImage: "`image`",
+			conventions.AttributeContainerTag:   "`tag`",
+			conventions.AttributeNetHostPort:    "`port`",
+		}
+	default:
+		return userConfigMap{}
+	}
+}
+
+// resolveResourceAttributes expands the default resource attributes of the endpoint merged
+// with the ones of the receiver template, which take precedence. Attributes expanding to an
+// empty string are left out so that defaults can be disabled.
+func resolveResourceAttributes(e observer.Endpoint, env observer.EndpointEnv, templateAttributes userConfigMap) (userConfigMap, error) {
+	attributes := defaultResourceAttributes(e)
+	for k, v := range templateAttributes {
+		attributes[k] = v
+	}
+
+	resolved, err := expandMap(attributes, env)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range resolved {
+		if v == "" {
+			delete(resolved, k)
+		}
+	}
+	return resolved, nil
+}
+
+// toAttributeMap converts the resolved resource attributes to an AttributeMap.
+func toAttributeMap(attributes userConfigMap) pdata.AttributeMap {
+	am := pdata.NewAttributeMap()
+	for k, v := range attributes {
+		switch val := v.(type) {
+		case string:
+			am.InsertString(k, val)
+		case bool:
+			am.InsertBool(k, val)
+		case int:
+			am.InsertInt(k, int64(val))
+		case int64:
+			am.InsertInt(k, val)
+		case uint16:
+			am.InsertInt(k, int64(val))
+		case float64:
+			am.InsertDouble(k, val)
+		default:
+			am.InsertString(k, fmt.Sprintf("%v", val))
+		}
+	}
+	return am
+}
+
+// resourceEnhancer adds resource attributes to the data of a receiver started at
+// runtime before passing it to the next consumer.
+type resourceEnhancer struct {
+	attributes          pdata.AttributeMap
+	nextMetricsConsumer consumer.MetricsConsumer
+	nextTracesConsumer  consumer.TraceConsumer
+	nextLogsConsumer    consumer.LogsConsumer
+}
+
+var (
+	_ consumer.MetricsConsumer = (*resourceEnhancer)(nil)
+	_ consumer.TraceConsumer   = (*resourceEnhancer)(nil)
+	_ consumer.LogsConsumer    = (*resourceEnhancer)(nil)
+)
+
+// enhance inserts the attributes in the resource, existing attributes are kept.
+func (r *resourceEnhancer) enhance(resource pdata.Resource) {
+	if resource.IsNil() {
+		resource.InitEmpty()
+	}
+	attrs := resource.Attributes()
+	r.attributes.ForEach(func(k string, v pdata.AttributeValue) {
+		attrs.Insert(k, v)
+	})
+}
+
+func (r *resourceEnhancer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
+			continue
+		}
+		r.enhance(rm.Resource())
+	}
+	return r.nextMetricsConsumer.ConsumeMetrics(ctx, md)
+}
+
+func (r *resourceEnhancer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		r.enhance(rs.Resource())
+	}
+	return r.nextTracesConsumer.ConsumeTraces(ctx, td)
+}
+
+func (r *resourceEnhancer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		r.enhance(rl.Resource())
+	}
+	return r.nextLogsConsumer.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,209 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestResolveResourceAttributes(t *testing.T) {
+	k8sPod := observer.Pod{
+		Name:      "pod-1",
+		Namespace: "default",
+		UID:       "pod-1-UID",
+		Labels:    map[string]string{"app": "redis"},
+	}
+	tests := []struct {
+		name               string
+		endpoint           observer.Endpoint
+		templateAttributes userConfigMap
+		want               userConfigMap
+	}{
+		{
+			name:     "pod",
+			endpoint: observer.Endpoint{ID: "pod-1", Target: "localhost", Details: k8sPod},
+			want: userConfigMap{
+				conventions.AttributeK8sPod:       "pod-1",
+				conventions.AttributeK8sPodUID:    "pod-1-UID",
+				conventions.AttributeK8sNamespace: "default",
+			},
+		},
+		{
+			name: "port",
+			endpoint: observer.Endpoint{
+				ID:      "port-1",
+				Target:  "localhost:6379",
+				Details: observer.Port{Name: "redis", Pod: k8sPod, Port: 6379},
+			},
+			want: userConfigMap{
+				conventions.AttributeK8sPod:       "pod-1",
+				conventions.AttributeK8sPodUID:    "pod-1-UID",
+				conventions.AttributeK8sNamespace: "default",
+				conventions.AttributeNetHostPort:  uint16(6379),
+			},
+		},
+		{
+			name:     "container",
+			endpoint: containerEndpoint,
+			want: userConfigMap{
+				conventions.AttributeContainerName:  "redis-cache",
+				conventions.AttributeContainerID:    "c6f8b58a2c71",
+				conventions.AttributeContainerImage: "redis",
+				conventions.AttributeContainerTag:   "6.0",
+				conventions.AttributeNetHostPort:    uint16(6379),
+			},
+		},
+		{
+			name: "host port",
+			endpoint: observer.Endpoint{
+				ID:      "host-port-1",
+				Target:  "localhost:6379",
+				Details: observer.HostPort{Name: "redis-server", Port: 6379},
+			},
+			want: userConfigMap{
+				conventions.AttributeNetHostPort: uint16(6379),
+			},
+		},
+		{
+			name:     "service",
+			endpoint: serviceEndpoint,
+			want: userConfigMap{
+				conventions.AttributeK8sNamespace: "default",
+				k8sServiceName:                    "service-1",
+				conventions.AttributeNetHostPort:  uint16(9090),
+			},
+		},
+		{
+			name:     "node",
+			endpoint: nodeEndpoint,
+			want: userConfigMap{
+				k8sNodeName: "node-1",
+			},
+		},
+		{
+			name:     "ingress",
+			endpoint: ingressEndpoint,
+			want: userConfigMap{
+				conventions.AttributeK8sNamespace: "default",
+				k8sIngressName:                    "ingress-1",
+			},
+		},
+		{
+			name:     "ecs task",
+			endpoint: ecsTaskEndpoint,
+			want: userConfigMap{
+				awsECSTaskARN:                       "arn:aws:ecs:us-west-2:123456789012:task/cluster/task-1",
+				awsECSTaskFamily:                    "exporter",
+				conventions.AttributeContainerName:  "exporter",
+				conventions.AttributeContainerImage: "prom/node-exporter",
+				conventions.AttributeContainerTag:   "v1.0.1",
+				conventions.AttributeNetHostPort:    uint16(32768),
+			},
+		},
+		{
+			name: "overridden",
+			endpoint: observer.Endpoint{
+				ID:      "port-1",
+				Target:  "localhost:6379",
+				Details: observer.Port{Name: "redis", Pod: k8sPod, Port: 6379},
+			},
+			templateAttributes: userConfigMap{
+				conventions.AttributeServiceName:  "`pod.labels[\"app\"]`",
+				conventions.AttributeK8sNamespace: "static",
+				conventions.AttributeNetHostPort:  "",
+			},
+			want: userConfigMap{
+				conventions.AttributeK8sPod:       "pod-1",
+				conventions.AttributeK8sPodUID:    "pod-1-UID",
+				conventions.AttributeK8sNamespace: "static",
+				conventions.AttributeServiceName:  "redis",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, err := observer.EndpointToEnv(tt.endpoint)
+			require.NoError(t, err)
+			got, err := resolveResourceAttributes(tt.endpoint, env, tt.templateAttributes)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResourceEnhancer(t *testing.T) {
+	attributes := userConfigMap{
+		conventions.AttributeK8sPod:      "pod-1",
+		conventions.AttributeNetHostPort: uint16(6379),
+	}
+
+	assertResource := func(t *testing.T, resource pdata.Resource) {
+		require.False(t, resource.IsNil())
+		attrs := resource.Attributes()
+		podName, ok := attrs.Get(conventions.AttributeK8sPod)
+		require.True(t, ok)
+		// Existing attributes are not overwritten.
+		assert.Equal(t, "existing", podName.StringVal())
+		port, ok := attrs.Get(conventions.AttributeNetHostPort)
+		require.True(t, ok)
+		assert.Equal(t, int64(6379), port.IntVal())
+	}
+
+	t.Run("metrics", func(t *testing.T) {
+		next := &mockMetricsConsumer{}
+		enhancer := &resourceEnhancer{attributes: toAttributeMap(attributes), nextMetricsConsumer: next}
+		md := pdata.NewMetrics()
+		md.ResourceMetrics().Resize(2)
+		md.ResourceMetrics().At(0).Resource().InitEmpty()
+		md.ResourceMetrics().At(0).Resource().Attributes().InsertString(conventions.AttributeK8sPod, "existing")
+		require.NoError(t, enhancer.ConsumeMetrics(context.Background(), md))
+		require.Len(t, next.Metrics, 1)
+		assertResource(t, next.Metrics[0].ResourceMetrics().At(0).Resource())
+		_, ok := next.Metrics[0].ResourceMetrics().At(1).Resource().Attributes().Get(conventions.AttributeK8sPod)
+		assert.True(t, ok)
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		next := &mockTraceConsumer{}
+		enhancer := &resourceEnhancer{attributes: toAttributeMap(attributes), nextTracesConsumer: next}
+		td := pdata.NewTraces()
+		td.ResourceSpans().Resize(1)
+		td.ResourceSpans().At(0).Resource().InitEmpty()
+		td.ResourceSpans().At(0).Resource().Attributes().InsertString(conventions.AttributeK8sPod, "existing")
+		require.NoError(t, enhancer.ConsumeTraces(context.Background(), td))
+		require.Len(t, next.Traces, 1)
+		assertResource(t, next.Traces[0].ResourceSpans().At(0).Resource())
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		next := &mockLogsConsumer{}
+		enhancer := &resourceEnhancer{attributes: toAttributeMap(attributes), nextLogsConsumer: next}
+		ld := pdata.NewLogs()
+		ld.ResourceLogs().Resize(1)
+		ld.ResourceLogs().At(0).Resource().InitEmpty()
+		ld.ResourceLogs().At(0).Resource().Attributes().InsertString(conventions.AttributeK8sPod, "existing")
+		require.NoError(t, enhancer.ConsumeLogs(context.Background(), ld))
+		require.Len(t, next.Logs, 1)
+		assertResource(t, next.Logs[0].ResourceLogs().At(0).Resource())
+	})
+}
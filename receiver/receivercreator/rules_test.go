@@ -47,6 +47,12 @@ func Test_ruleEval(t *testing.T) {
 		{"basic port", args{`type.port && name == "http" && pod.labels["app"] == "redis"`, portEndpoint}, true, false},
 		{"basic pod", args{`type.pod && labels["region"] == "west-1"`, podEndpoint}, true, false},
 		{"annotations", args{`type.pod && annotations["scrape"] == "true"`, podEndpoint}, true, false},
+		{"basic container", args{`type.container && image matches "redis" && labels["app"] == "cache"`, containerEndpoint}, true, false},
+		{"container type mismatch", args{`type.port && port == 6379`, containerEndpoint}, false, false},
+		{"basic service", args{`type.service && annotations["prometheus.io/scrape"] == "true" && port_name == "metrics"`, serviceEndpoint}, true, false},
+		{"basic node", args{`type.node && labels["kubernetes.io/os"] == "linux"`, nodeEndpoint}, true, false},
+		{"basic ingress", args{`type.ingress && scheme == "https"`, ingressEndpoint}, true, false},
+		{"basic ecs task", args{`type.ecs_task && labels["prometheus.io/scrape"] == "true" && container_port == 9100`, ecsTaskEndpoint}, true, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -82,6 +88,11 @@ func Test_newRule(t *testing.T) {
 		{"does not start with type", args{"port == 1234"}, true},
 		{"invalid syntax", args{"port =="}, true},
 		{"valid", args{`type.port && port_name == "http"`}, false},
+		{"valid container", args{`type.container && image == "redis"`}, false},
+		{"valid service", args{`type.service && port == 9090`}, false},
+		{"valid node", args{`type.node`}, false},
+		{"valid ingress", args{`type.ingress && host == "example.com"`}, false},
+		{"valid ecs task", args{`type.ecs_task && family == "exporter"`}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
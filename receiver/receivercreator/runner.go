@@ -19,7 +19,9 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/zap"
@@ -27,25 +29,31 @@ import (
 
 // runner starts and stops receiver instances.
 type runner interface {
-	// start a receiver instance from its static config and discovered config.
-	start(receiver receiverConfig, discoveredConfig userConfigMap) (component.Receiver, error)
+	// start a receiver instance from its static config and discovered config, its telemetry
+	// being enriched with the given resource attributes.
+	start(receiver receiverConfig, discoveredConfig userConfigMap, resourceAttributes userConfigMap) (component.Receiver, error)
 	// shutdown a receiver.
 	shutdown(rcvr component.Receiver) error
 }
 
 // receiverRunner handles starting/stopping of a concrete subreceiver instance.
+// The next consumers of the data types of the pipelines the receiver_creator
+// instance is part of are set, the others are nil.
 type receiverRunner struct {
-	logger       *zap.Logger
-	nextConsumer consumer.MetricsConsumer
-	idNamespace  string
-	ctx          context.Context
-	host         component.Host
+	logger              *zap.Logger
+	nextMetricsConsumer consumer.MetricsConsumer
+	nextTracesConsumer  consumer.TraceConsumer
+	nextLogsConsumer    consumer.LogsConsumer
+	idNamespace         string
+	ctx                 context.Context
+	host                component.Host
 }
 
 var _ runner = (*receiverRunner)(nil)
 
-// start a receiver instance from its static config and discovered config.
-func (run *receiverRunner) start(receiver receiverConfig, discoveredConfig userConfigMap) (component.Receiver, error) {
+// start a receiver instance from its static config and discovered config, its telemetry
+// being enriched with the given resource attributes.
+func (run *receiverRunner) start(receiver receiverConfig, discoveredConfig userConfigMap, resourceAttributes userConfigMap) (component.Receiver, error) {
 	factory := run.host.GetFactory(component.KindReceiver, receiver.typeStr)
 
 	if factory == nil {
@@ -58,8 +66,10 @@ func (run *receiverRunner) start(receiver receiverConfig, discoveredConfig userC
 	if err != nil {
 		return nil, err
 	}
-	recvr, err := run.createRuntimeReceiver(receiverFactory, cfg)
+	recvr, err := run.createRuntimeReceiver(receiverFactory, cfg, resourceAttributes)
 	if err != nil {
+		// The error is returned as is so that receivers not supporting
+		// the data type of the pipeline can be told apart.
 		return nil, err
 	}
 
@@ -104,8 +114,97 @@ func (run *receiverRunner) loadRuntimeReceiverConfig(
 	return receiverConfig, nil
 }
 
-// createRuntimeReceiver creates a receiver that is discovered at runtime.
-func (run *receiverRunner) createRuntimeReceiver(factory component.ReceiverFactory, cfg configmodels.Receiver) (component.MetricsReceiver, error) {
+// createRuntimeReceiver creates a receiver that is discovered at runtime, for
+// each data type of the pipelines it supports. configerror.ErrDataTypeIsNotSupported
+// is returned when the receiver supports none of them.
+//
+// Factories supporting several data types return the same receiver for all of
+// them, which is then only started once. A receiverGroup is returned for the
+// factories creating distinct receivers instead.
+func (run *receiverRunner) createRuntimeReceiver(
+	factory component.ReceiverFactory,
+	cfg configmodels.Receiver,
+	resourceAttributes userConfigMap,
+) (component.Receiver, error) {
 	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
-	return factory.CreateMetricsReceiver(context.Background(), params, cfg, run.nextConsumer)
+	enhancer := &resourceEnhancer{
+		attributes:          toAttributeMap(resourceAttributes),
+		nextMetricsConsumer: run.nextMetricsConsumer,
+		nextTracesConsumer:  run.nextTracesConsumer,
+		nextLogsConsumer:    run.nextLogsConsumer,
+	}
+
+	var receivers receiverGroup
+	add := func(recvr component.Receiver, err error) error {
+		if err == configerror.ErrDataTypeIsNotSupported {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, r := range receivers {
+			if r == recvr {
+				return nil
+			}
+		}
+		receivers = append(receivers, recvr)
+		return nil
+	}
+
+	if run.nextMetricsConsumer == nil && run.nextTracesConsumer == nil && run.nextLogsConsumer == nil {
+		return nil, errNilNextConsumer
+	}
+	if run.nextMetricsConsumer != nil {
+		if err := add(factory.CreateMetricsReceiver(context.Background(), params, cfg, enhancer)); err != nil {
+			return nil, err
+		}
+	}
+	if run.nextTracesConsumer != nil {
+		if err := add(factory.CreateTraceReceiver(context.Background(), params, cfg, enhancer)); err != nil {
+			return nil, err
+		}
+	}
+	if run.nextLogsConsumer != nil {
+		if err := add(factory.CreateLogsReceiver(context.Background(), params, cfg, enhancer)); err != nil {
+			return nil, err
+		}
+	}
+
+	switch len(receivers) {
+	case 0:
+		return nil, configerror.ErrDataTypeIsNotSupported
+	case 1:
+		return receivers[0], nil
+	default:
+		return receivers, nil
+	}
+}
+
+// receiverGroup is a receiver made of the distinct receivers created for each
+// data type by a factory.
+type receiverGroup []component.Receiver
+
+var _ component.Receiver = (receiverGroup)(nil)
+
+// Start starts all the receivers, the ones already started being shut down
+// when one of them fails.
+func (g receiverGroup) Start(ctx context.Context, host component.Host) error {
+	for i, r := range g {
+		if err := r.Start(ctx, host); err != nil {
+			_ = g[:i].Shutdown(ctx)
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown shuts down all the receivers.
+func (g receiverGroup) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, r := range g {
+		if err := r.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
 }
@@ -15,16 +15,22 @@
 package receivercreator
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configerror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.uber.org/zap"
 )
 
 func Test_loadAndCreateRuntimeReceiver(t *testing.T) {
-	run := &receiverRunner{logger: zap.NewNop(), nextConsumer: &mockMetricsConsumer{}, idNamespace: "receiver_creator/1"}
+	run := &receiverRunner{logger: zap.NewNop(), nextMetricsConsumer: &mockMetricsConsumer{}, idNamespace: "receiver_creator/1"}
 	exampleFactory := &componenttest.ExampleReceiverFactory{}
 	template, err := newReceiverTemplate("examplereceiver/1", nil)
 	require.NoError(t, err)
@@ -41,10 +47,97 @@ func Test_loadAndCreateRuntimeReceiver(t *testing.T) {
 
 	// Test that metric receiver can be created from loaded config.
 	t.Run("test create receiver from loaded config", func(t *testing.T) {
-		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig)
+		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig, userConfigMap{})
 		require.NoError(t, err)
 		assert.NotNil(t, recvr)
 		exampleReceiver := recvr.(*componenttest.ExampleReceiverProducer)
-		assert.Equal(t, run.nextConsumer, exampleReceiver.MetricsConsumer)
+		assert.Equal(t, run.nextMetricsConsumer, exampleReceiver.MetricsConsumer.(*resourceEnhancer).nextMetricsConsumer)
+	})
+
+	t.Run("test create trace receiver from loaded config", func(t *testing.T) {
+		traceRun := &receiverRunner{logger: zap.NewNop(), nextTracesConsumer: &mockTraceConsumer{}, idNamespace: "receiver_creator/1"}
+		recvr, err := traceRun.createRuntimeReceiver(exampleFactory, loadedConfig, userConfigMap{})
+		require.NoError(t, err)
+		exampleReceiver := recvr.(*componenttest.ExampleReceiverProducer)
+		assert.Equal(t, traceRun.nextTracesConsumer, exampleReceiver.TraceConsumer.(*resourceEnhancer).nextTracesConsumer)
+	})
+
+	t.Run("test create logs receiver from loaded config", func(t *testing.T) {
+		logsRun := &receiverRunner{logger: zap.NewNop(), nextLogsConsumer: &mockLogsConsumer{}, idNamespace: "receiver_creator/1"}
+		recvr, err := logsRun.createRuntimeReceiver(exampleFactory, loadedConfig, userConfigMap{})
+		require.NoError(t, err)
+		exampleReceiver := recvr.(*componenttest.ExampleReceiverProducer)
+		assert.Equal(t, logsRun.nextLogsConsumer, exampleReceiver.LogConsumer.(*resourceEnhancer).nextLogsConsumer)
+	})
+}
+
+func TestCreateRuntimeReceiverUnsupportedDataType(t *testing.T) {
+	run := &receiverRunner{logger: zap.NewNop(), nextLogsConsumer: &mockLogsConsumer{}, idNamespace: "receiver_creator/1"}
+	// The receiver_creator factory itself only supports what it is given to consume, use
+	// a metrics only factory instead.
+	metricsOnlyFactory := receiverhelper.NewFactory(
+		"metricsonly",
+		func() configmodels.Receiver {
+			return &configmodels.ReceiverSettings{TypeVal: "metricsonly", NameVal: "metricsonly"}
+		},
+		receiverhelper.WithMetrics(func(
+			context.Context, component.ReceiverCreateParams, configmodels.Receiver, consumer.MetricsConsumer,
+		) (component.MetricsReceiver, error) {
+			return &componenttest.ExampleReceiverProducer{}, nil
+		}))
+
+	recvr, err := run.createRuntimeReceiver(metricsOnlyFactory, metricsOnlyFactory.CreateDefaultConfig(), userConfigMap{})
+	assert.Equal(t, configerror.ErrDataTypeIsNotSupported, err)
+	assert.Nil(t, recvr)
+}
+
+func TestCreateRuntimeReceiverForAllDataTypes(t *testing.T) {
+	run := &receiverRunner{
+		logger:              zap.NewNop(),
+		nextMetricsConsumer: &mockMetricsConsumer{},
+		nextTracesConsumer:  &mockTraceConsumer{},
+		nextLogsConsumer:    &mockLogsConsumer{},
+		idNamespace:         "receiver_creator/1",
+	}
+
+	t.Run("shared receiver", func(t *testing.T) {
+		exampleFactory := &componenttest.ExampleReceiverFactory{}
+		recvr, err := run.createRuntimeReceiver(exampleFactory, exampleFactory.CreateDefaultConfig(), userConfigMap{})
+		require.NoError(t, err)
+		exampleReceiver := recvr.(*componenttest.ExampleReceiverProducer)
+		assert.Equal(t, run.nextMetricsConsumer, exampleReceiver.MetricsConsumer.(*resourceEnhancer).nextMetricsConsumer)
+		assert.Equal(t, run.nextTracesConsumer, exampleReceiver.TraceConsumer.(*resourceEnhancer).nextTracesConsumer)
+		assert.Equal(t, run.nextLogsConsumer, exampleReceiver.LogConsumer.(*resourceEnhancer).nextLogsConsumer)
+	})
+
+	t.Run("distinct receivers", func(t *testing.T) {
+		metricsReceiver := &componenttest.ExampleReceiverProducer{}
+		tracesReceiver := &componenttest.ExampleReceiverProducer{}
+		factory := receiverhelper.NewFactory(
+			"distinct",
+			func() configmodels.Receiver {
+				return &configmodels.ReceiverSettings{TypeVal: "distinct", NameVal: "distinct"}
+			},
+			receiverhelper.WithMetrics(func(
+				context.Context, component.ReceiverCreateParams, configmodels.Receiver, consumer.MetricsConsumer,
+			) (component.MetricsReceiver, error) {
+				return metricsReceiver, nil
+			}),
+			receiverhelper.WithTraces(func(
+				context.Context, component.ReceiverCreateParams, configmodels.Receiver, consumer.TraceConsumer,
+			) (component.TraceReceiver, error) {
+				return tracesReceiver, nil
+			}))
+
+		recvr, err := run.createRuntimeReceiver(factory, factory.CreateDefaultConfig(), userConfigMap{})
+		require.NoError(t, err)
+		assert.Equal(t, receiverGroup{metricsReceiver, tracesReceiver}, recvr)
+
+		require.NoError(t, recvr.Start(context.Background(), componenttest.NewNopHost()))
+		assert.True(t, metricsReceiver.Started)
+		assert.True(t, tracesReceiver.Started)
+		require.NoError(t, recvr.Shutdown(context.Background()))
+		assert.True(t, metricsReceiver.Stopped)
+		assert.True(t, tracesReceiver.Stopped)
 	})
 }
@@ -15,6 +15,10 @@
 package redisreceiver
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/go-redis/redis/v7"
 )
 
@@ -22,11 +26,27 @@ import (
 type client interface {
 	// retrieves a string of key/value pairs of redis metadata
 	retrieveInfo() (string, error)
+	// retrieves the INFO commandstats section, not part of the default sections
+	retrieveCommandStats() (string, error)
+	// retrieves a string of key/value pairs of CLUSTER INFO
+	retrieveClusterInfo() (string, error)
+	// retrieves at most count of the latest SLOWLOG entries
+	retrieveSlowLog(count int) ([]slowLogEntry, error)
 	// line delimiter
 	// redis lines are delimited by \r\n, files (for testing) by \n
 	delimiter() string
 }
 
+// An entry of the Redis SLOWLOG.
+type slowLogEntry struct {
+	id         int64
+	time       time.Time
+	duration   time.Duration
+	args       []string
+	clientAddr string
+	clientName string
+}
+
 // Wraps a real Redis client, implements `client` interface.
 type redisClient struct {
 	client *redis.Client
@@ -50,3 +70,73 @@ func (c *redisClient) delimiter() string {
 func (c *redisClient) retrieveInfo() (string, error) {
 	return c.client.Info().Result()
 }
+
+func (c *redisClient) retrieveCommandStats() (string, error) {
+	return c.client.Info("commandstats").Result()
+}
+
+func (c *redisClient) retrieveClusterInfo() (string, error) {
+	return c.client.ClusterInfo().Result()
+}
+
+// Retrieve the SLOWLOG with the generic command, go-redis not supporting it.
+func (c *redisClient) retrieveSlowLog(count int) ([]slowLogEntry, error) {
+	res, err := c.client.Do("slowlog", "get", count).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseSlowLog(res)
+}
+
+// Parses the reply of SLOWLOG GET: an array of entries, each of them being an
+// array of the id, the unix timestamp, the duration in microseconds and the
+// arguments of the command, followed since Redis 4.0 by the client address
+// and name.
+func parseSlowLog(reply interface{}) ([]slowLogEntry, error) {
+	items, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected slowlog reply %T", reply)
+	}
+
+	entries := make([]slowLogEntry, 0, len(items))
+	for _, item := range items {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) < 4 {
+			return nil, fmt.Errorf("unexpected slowlog entry %v", item)
+		}
+
+		id, ok1 := fields[0].(int64)
+		ts, ok2 := fields[1].(int64)
+		usec, ok3 := fields[2].(int64)
+		rawArgs, ok4 := fields[3].([]interface{})
+		if !ok1 || !ok2 || !ok3 || !ok4 {
+			return nil, fmt.Errorf("unexpected slowlog entry %v", item)
+		}
+
+		args := make([]string, len(rawArgs))
+		for i, arg := range rawArgs {
+			args[i] = fmt.Sprint(arg)
+		}
+
+		entry := slowLogEntry{
+			id:       id,
+			time:     time.Unix(ts, 0),
+			duration: time.Duration(usec) * time.Microsecond,
+			args:     args,
+		}
+		if len(fields) >= 6 {
+			entry.clientAddr, _ = fields[4].(string)
+			entry.clientName, _ = fields[5].(string)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// command returns the lower cased name of the command of the entry.
+func (e *slowLogEntry) command() string {
+	if len(e.args) == 0 {
+		return ""
+	}
+	return strings.ToLower(e.args[0])
+}
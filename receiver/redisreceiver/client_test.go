@@ -19,7 +19,9 @@ import (
 	"path"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,6 +41,37 @@ func (fakeClient) retrieveInfo() (string, error) {
 	return readFile("info")
 }
 
+func (fakeClient) retrieveCommandStats() (string, error) {
+	return readFile("commandstats")
+}
+
+func (fakeClient) retrieveClusterInfo() (string, error) {
+	return readFile("clusterinfo")
+}
+
+func (fakeClient) retrieveSlowLog(count int) ([]slowLogEntry, error) {
+	entries := []slowLogEntry{
+		{
+			id:         12,
+			time:       time.Unix(1600000010, 0),
+			duration:   15 * time.Millisecond,
+			args:       []string{"KEYS", "*"},
+			clientAddr: "127.0.0.1:58712",
+			clientName: "worker",
+		},
+		{
+			id:       11,
+			time:     time.Unix(1600000000, 0),
+			duration: 12 * time.Millisecond,
+			args:     []string{"HGETALL", "sessions"},
+		},
+	}
+	if count < len(entries) {
+		entries = entries[:count]
+	}
+	return entries, nil
+}
+
 func readFile(fname string) (string, error) {
 	file, err := ioutil.ReadFile(path.Join("testdata", fname+".txt"))
 	if err != nil {
@@ -53,3 +86,28 @@ func TestRetrieveInfo(t *testing.T) {
 	require.Nil(t, err)
 	require.True(t, strings.HasPrefix(res, "# Server"))
 }
+
+func TestParseSlowLog(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{int64(12), int64(1600000010), int64(15000), []interface{}{"KEYS", "*"}, "127.0.0.1:58712", "worker"},
+		// Redis versions prior to 4.0 don't report the client.
+		[]interface{}{int64(11), int64(1600000000), int64(12000), []interface{}{"HGETALL", "sessions"}},
+	}
+	entries, err := parseSlowLog(reply)
+	require.NoError(t, err)
+
+	expected, _ := fakeClient{}.retrieveSlowLog(10)
+	assert.Equal(t, expected, entries)
+	assert.Equal(t, "keys", entries[0].command())
+}
+
+func TestParseSlowLogErrors(t *testing.T) {
+	_, err := parseSlowLog("OK")
+	assert.EqualError(t, err, "unexpected slowlog reply string")
+
+	_, err = parseSlowLog([]interface{}{[]interface{}{int64(1), "now"}})
+	assert.EqualError(t, err, "unexpected slowlog entry [1 now]")
+
+	_, err = parseSlowLog([]interface{}{[]interface{}{int64(1), "now", int64(10), []interface{}{"GET"}}})
+	assert.EqualError(t, err, "unexpected slowlog entry [1 now 10 [GET]]")
+}
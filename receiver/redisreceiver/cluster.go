@@ -0,0 +1,86 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+)
+
+// The metrics extracted from CLUSTER INFO, except the cluster state.
+func getClusterRedisMetrics() []*redisMetric {
+	return []*redisMetric{
+		{
+			key:    "cluster_slots_assigned",
+			name:   "redis/cluster/slots",
+			labels: map[string]string{"state": "assigned"},
+			mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+			desc:   "Number of slots associated to some node of the cluster",
+		},
+		{
+			key:    "cluster_slots_ok",
+			name:   "redis/cluster/slots",
+			labels: map[string]string{"state": "ok"},
+			mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+			desc:   "Number of slots mapped to nodes that are not failing",
+		},
+		{
+			key:    "cluster_slots_pfail",
+			name:   "redis/cluster/slots",
+			labels: map[string]string{"state": "pfail"},
+			mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+			desc:   "Number of slots mapped to nodes that are possibly failing",
+		},
+		{
+			key:    "cluster_slots_fail",
+			name:   "redis/cluster/slots",
+			labels: map[string]string{"state": "fail"},
+			mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+			desc:   "Number of slots mapped to failing nodes",
+		},
+		{
+			key:    "cluster_known_nodes",
+			name:   "redis/cluster/known_nodes",
+			mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+			desc:   "Number of nodes in the cluster, including the nodes in handshake state",
+		},
+		{
+			key:    "cluster_size",
+			name:   "redis/cluster/size",
+			mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+			desc:   "Number of master nodes serving at least one slot",
+		},
+	}
+}
+
+// Builds proto metrics from CLUSTER INFO: the state of the cluster, 1 if ok and
+// 0 otherwise, and the slot and node counts. Returns proto metrics and parsing
+// errors, to be treated as warnings, if there were any.
+func (i info) buildClusterProtoMetrics(t *timeBundle) (
+	protoMetrics []*metricspb.Metric,
+	warnings []error,
+) {
+	state := int64(0)
+	if i["cluster_state"] == "ok" {
+		state = 1
+	}
+	protoMetrics = append(protoMetrics, newProtoMetric(&redisMetric{
+		name:   "redis/cluster/state",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Whether the cluster state is ok",
+	}, int64Point(state), t))
+
+	metrics, warnings := i.buildFixedProtoMetrics(getClusterRedisMetrics(), t)
+	return append(protoMetrics, metrics...), warnings
+}
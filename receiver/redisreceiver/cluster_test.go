@@ -0,0 +1,54 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildClusterProtoMetrics(t *testing.T) {
+	svc := newRedisSvc(newFakeClient())
+	clusterInfo, err := svc.clusterInfo()
+	require.Nil(t, err)
+
+	metrics, warnings := clusterInfo.buildClusterProtoMetrics(newTimeBundle(time.Now(), 100))
+	require.Nil(t, warnings)
+	require.Equal(t, len(getClusterRedisMetrics())+1, len(metrics))
+
+	require.Equal(t, "redis/cluster/state", metrics[0].MetricDescriptor.Name)
+	require.Equal(t, int64(1), metrics[0].Timeseries[0].Points[0].GetInt64Value())
+
+	slots := map[string]int64{}
+	for _, m := range metrics[1:5] {
+		require.Equal(t, "redis/cluster/slots", m.MetricDescriptor.Name)
+		slots[m.Timeseries[0].LabelValues[0].Value] = m.Timeseries[0].Points[0].GetInt64Value()
+	}
+	require.Equal(t, map[string]int64{"assigned": 16384, "ok": 16380, "pfail": 3, "fail": 1}, slots)
+
+	require.Equal(t, "redis/cluster/known_nodes", metrics[5].MetricDescriptor.Name)
+	require.Equal(t, int64(6), metrics[5].Timeseries[0].Points[0].GetInt64Value())
+	require.Equal(t, "redis/cluster/size", metrics[6].MetricDescriptor.Name)
+	require.Equal(t, int64(3), metrics[6].Timeseries[0].Points[0].GetInt64Value())
+}
+
+func TestBuildFailedClusterProtoMetrics(t *testing.T) {
+	metrics, warnings := info{"cluster_state": "fail"}.buildClusterProtoMetrics(newTimeBundle(time.Now(), 100))
+	require.Equal(t, len(getClusterRedisMetrics()), len(warnings))
+	require.Equal(t, 1, len(metrics))
+	require.Equal(t, int64(0), metrics[0].Timeseries[0].Points[0].GetInt64Value())
+}
@@ -0,0 +1,116 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+)
+
+const commandStatsPrefix = "cmdstat_"
+
+// Holds fields returned by the Commandstats section of the INFO command: e.g.
+// "cmdstat_get:calls=1,usec=2,usec_per_call=2.00"
+type commandStats struct {
+	cmd   string
+	calls int64
+	usec  int64
+}
+
+// Turns a command stats value (the part after the colon e.g.
+// "calls=1,usec=2,usec_per_call=2.00") into a commandStats struct. Fields
+// other than calls and usec are ignored.
+func parseCommandStatsString(cmd string, str string) (*commandStats, error) {
+	stats := commandStats{cmd: cmd}
+	for _, pairStr := range strings.Split(str, ",") {
+		pair := strings.Split(pairStr, "=")
+		if len(pair) != 2 {
+			return nil, fmt.Errorf(
+				"unexpected command stats pair '%s'",
+				pairStr,
+			)
+		}
+		var field *int64
+		switch pair[0] {
+		case "calls":
+			field = &stats.calls
+		case "usec":
+			field = &stats.usec
+		}
+		if field != nil {
+			val, err := strconv.ParseInt(pair[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			*field = val
+		}
+	}
+	return &stats, nil
+}
+
+// Builds proto metrics from the 'cmdstat_' lines of Redis INFO commandstats.
+// Returns proto metrics and parsing errors, to be treated as warnings, if there
+// were any.
+func (i info) buildCommandStatsProtoMetrics(t *timeBundle) (
+	protoMetrics []*metricspb.Metric,
+	warnings []error,
+) {
+	// Sorted iteration for reproducibility
+	var keys []string
+	for key := range i {
+		if strings.HasPrefix(key, commandStatsPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		stats, parsingError := parseCommandStatsString(strings.TrimPrefix(key, commandStatsPrefix), i[key])
+		if parsingError != nil {
+			warnings = append(warnings, parsingError)
+			continue
+		}
+		protoMetrics = append(protoMetrics, buildCommandStatsMetrics(stats, t)...)
+	}
+	return protoMetrics, warnings
+}
+
+func buildCommandStatsMetrics(s *commandStats, t *timeBundle) []*metricspb.Metric {
+	calls := &redisMetric{
+		name:   "redis/commands/calls",
+		labels: map[string]string{"cmd": s.cmd},
+		mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc:   "Number of calls of the command since server start",
+	}
+	usec := &redisMetric{
+		name:   "redis/commands/usec",
+		units:  "us",
+		labels: map[string]string{"cmd": s.cmd},
+		mdType: metricspb.MetricDescriptor_CUMULATIVE_INT64,
+		desc:   "Total CPU time consumed by the calls of the command since server start",
+	}
+	return []*metricspb.Metric{
+		newProtoMetric(calls, int64Point(s.calls), t),
+		newProtoMetric(usec, int64Point(s.usec), t),
+	}
+}
+
+func int64Point(v int64) *metricspb.Point {
+	return &metricspb.Point{Value: &metricspb.Point_Int64Value{Int64Value: v}}
+}
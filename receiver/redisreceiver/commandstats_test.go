@@ -0,0 +1,66 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommandStatsString(t *testing.T) {
+	stats, err := parseCommandStatsString("set", "calls=300,usec=1500,usec_per_call=5.00,rejected_calls=0,failed_calls=2")
+	require.Nil(t, err)
+	require.Equal(t, &commandStats{cmd: "set", calls: 300, usec: 1500}, stats)
+}
+
+func TestParseMalformedCommandStats(t *testing.T) {
+	tests := []struct{ name, stats string }{
+		{"missing value", "calls=1,usec="},
+		{"missing equals", "calls=1,usec"},
+		{"not a number", "calls=x,usec=2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseCommandStatsString("get", test.stats)
+			require.NotNil(t, err)
+		})
+	}
+}
+
+func TestBuildCommandStatsProtoMetrics(t *testing.T) {
+	svc := newRedisSvc(newFakeClient())
+	stats, err := svc.commandStats()
+	require.Nil(t, err)
+
+	metrics, warnings := stats.buildCommandStatsProtoMetrics(newTimeBundle(time.Now(), 100))
+	require.Nil(t, warnings)
+	require.Equal(t, 6, len(metrics))
+
+	// sorted by command
+	require.Equal(t, "redis/commands/calls", metrics[0].MetricDescriptor.Name)
+	require.Equal(t, "cmd", metrics[0].MetricDescriptor.LabelKeys[0].Key)
+	require.Equal(t, "get", metrics[0].Timeseries[0].LabelValues[0].Value)
+	require.Equal(t, int64(1200), metrics[0].Timeseries[0].Points[0].GetInt64Value())
+	require.NotNil(t, metrics[0].Timeseries[0].StartTimestamp)
+
+	require.Equal(t, "redis/commands/usec", metrics[1].MetricDescriptor.Name)
+	require.Equal(t, "us", metrics[1].MetricDescriptor.Unit)
+	require.Equal(t, int64(3600), metrics[1].Timeseries[0].Points[0].GetInt64Value())
+
+	require.Equal(t, "info", metrics[2].Timeseries[0].LabelValues[0].Value)
+	require.Equal(t, "set", metrics[4].Timeseries[0].LabelValues[0].Value)
+}
@@ -15,25 +15,60 @@
 package redisreceiver
 
 import (
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 type config struct {
 	configmodels.ReceiverSettings `mapstructure:",squash"`
+	// The Redis server to collect from, in addition to the Endpoints.
+	EndpointConfig `mapstructure:",squash"`
+	// Additional Redis servers to collect from, each with its own service name
+	// and credentials.
+	Endpoints []EndpointConfig `mapstructure:"endpoints"`
+	// The duration between Redis metric fetches.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// The maximum number of SLOWLOG entries retrieved on each collection by the
+	// logs receiver.
+	SlowLogMaxEntries int `mapstructure:"slowlog_max_entries"`
+}
+
+// EndpointConfig defines a Redis server to collect from.
+type EndpointConfig struct {
 	// TODO: Use one of the configs from core.
 	// The target endpoint.
 	Endpoint string `mapstructure:"endpoint"`
-	// The duration between Redis metric fetches.
-	CollectionInterval time.Duration `mapstructure:"collection_interval"`
 	// The logical name of the Redis server. This value will be added as a
 	// "service.name" Resource label.
 	ServiceName string `mapstructure:"service_name"`
 
 	// TODO allow users to add additional resource key value pairs?
 
+	// Optional username, to authenticate with a Redis 6 ACL user rather than
+	// the default user.
+	Username string `mapstructure:"username"`
 	// Optional password. Must match the password specified in the
-	// requirepass server configuration option.
+	// requirepass server configuration option, or the password of Username.
 	Password string `mapstructure:"password"`
+	// Optional TLS settings. The connection isn't encrypted if nil.
+	TLS *configtls.TLSClientSetting `mapstructure:"tls"`
+}
+
+// endpoints returns all of the configured Redis servers.
+func (cfg *config) endpoints() []EndpointConfig {
+	var endpoints []EndpointConfig
+	if cfg.Endpoint != "" {
+		endpoints = append(endpoints, cfg.EndpointConfig)
+	}
+	return append(endpoints, cfg.Endpoints...)
+}
+
+func (cfg *config) validate() error {
+	if cfg.SlowLogMaxEntries <= 0 {
+		return fmt.Errorf("slowlog_max_entries must be positive, got %d", cfg.SlowLogMaxEntries)
+	}
+	return nil
 }
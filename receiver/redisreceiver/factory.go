@@ -16,12 +16,14 @@ package redisreceiver
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
 )
 
 const (
@@ -33,7 +35,8 @@ func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
 }
 
 func createDefaultConfig() configmodels.Receiver {
@@ -43,6 +46,7 @@ func createDefaultConfig() configmodels.Receiver {
 			NameVal: typeStr,
 		},
 		CollectionInterval: 10 * time.Second,
+		SlowLogMaxEntries:  128,
 	}
 }
 
@@ -52,7 +56,45 @@ func createMetricsReceiver(
 	cfg configmodels.Receiver,
 	consumer consumer.MetricsConsumer,
 ) (component.MetricsReceiver, error) {
-	oCfg := cfg.(*config)
+	r, err := getOrCreateReceiver(params.Logger, cfg.(*config))
+	if err != nil {
+		return nil, err
+	}
+	r.metricsConsumer = consumer
+	return r, nil
+}
 
-	return newRedisReceiver(params.Logger, oCfg, consumer), nil
+func createLogsReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateParams,
+	cfg configmodels.Receiver,
+	consumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	r, err := getOrCreateReceiver(params.Logger, cfg.(*config))
+	if err != nil {
+		return nil, err
+	}
+	r.logsConsumer = consumer
+	return r, nil
 }
+
+// Returns the receiver of the config, the same instance must be returned for
+// the metrics and logs pipelines of a config.
+func getOrCreateReceiver(logger *zap.Logger, oCfg *config) (*redisReceiver, error) {
+	receiverLock.Lock()
+	defer receiverLock.Unlock()
+
+	if r, ok := receivers[oCfg]; ok {
+		return r, nil
+	}
+
+	if err := oCfg.validate(); err != nil {
+		return nil, err
+	}
+	r := newRedisReceiver(logger, oCfg)
+	receivers[oCfg] = r
+	return r, nil
+}
+
+var receiverLock sync.Mutex
+var receivers = map[*config]*redisReceiver{}
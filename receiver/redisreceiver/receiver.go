@@ -16,6 +16,7 @@ package redisreceiver
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-redis/redis/v7"
 	"go.opentelemetry.io/collector/component"
@@ -25,44 +26,90 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver/interval"
 )
 
+// redisReceiver collects the metrics of the configured Redis servers when part
+// of a metrics pipeline and their SLOWLOG entries when part of a logs
+// pipeline, the same instance being shared by the pipelines of a config.
 type redisReceiver struct {
-	logger         *zap.Logger
-	config         *config
-	consumer       consumer.MetricsConsumer
-	intervalRunner *interval.Runner
+	logger          *zap.Logger
+	config          *config
+	metricsConsumer consumer.MetricsConsumer
+	logsConsumer    consumer.LogsConsumer
+	// newRunnables creates the runnables collecting a Redis server.
+	newRunnables func(ctx context.Context, client client, endpoint EndpointConfig) []interval.Runnable
+	// One runner per runnable of each Redis server, so that a slow or
+	// unreachable server doesn't delay the collection of the others.
+	intervalRunners []*interval.Runner
 }
 
-func newRedisReceiver(
-	logger *zap.Logger,
-	config *config,
-	consumer consumer.MetricsConsumer,
-) *redisReceiver {
-	return &redisReceiver{
-		logger:   logger,
-		config:   config,
-		consumer: consumer,
+// Creates a receiver of the configured Redis servers, the consumers of the
+// pipelines it is part of are registered afterwards.
+func newRedisReceiver(logger *zap.Logger, config *config) *redisReceiver {
+	r := &redisReceiver{
+		logger: logger,
+		config: config,
 	}
+	r.newRunnables = r.endpointRunnables
+	return r
 }
 
-// Set up and kick off the interval runner.
-func (r *redisReceiver) Start(ctx context.Context, host component.Host) error {
-	c := newRedisClient(&redis.Options{
-		Addr:     r.config.Endpoint,
-		Password: r.config.Password,
-	})
-	redisRunnable := newRedisRunnable(ctx, c, r.config.ServiceName, r.consumer, r.logger)
-	r.intervalRunner = interval.NewRunner(r.config.CollectionInterval, redisRunnable)
+// Creates the runnables collecting the metrics and the SLOWLOG entries of a
+// Redis server, depending on the consumers registered.
+func (r *redisReceiver) endpointRunnables(ctx context.Context, client client, endpoint EndpointConfig) []interval.Runnable {
+	var runnables []interval.Runnable
+	if r.metricsConsumer != nil {
+		runnables = append(runnables, newRedisRunnable(ctx, client, endpoint.ServiceName, r.metricsConsumer, r.logger))
+	}
+	if r.logsConsumer != nil {
+		runnables = append(runnables,
+			newSlowLogRunnable(ctx, client, endpoint.ServiceName, r.config.SlowLogMaxEntries, r.logsConsumer, r.logger))
+	}
+	return runnables
+}
 
-	go func() {
-		if err := r.intervalRunner.Start(); err != nil {
-			host.ReportFatalError(err)
+// Set up and kick off the interval runners of each Redis server.
+func (r *redisReceiver) Start(ctx context.Context, host component.Host) error {
+	var runnables []interval.Runnable
+	for _, endpoint := range r.config.endpoints() {
+		options, err := newRedisOptions(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid configuration of redis endpoint %q: %w", endpoint.Endpoint, err)
 		}
-	}()
+		runnables = append(runnables, r.newRunnables(ctx, newRedisClient(options), endpoint)...)
+	}
+
+	for _, runnable := range runnables {
+		runner := interval.NewRunner(r.config.CollectionInterval, runnable)
+		r.intervalRunners = append(r.intervalRunners, runner)
+		go func() {
+			if err := runner.Start(); err != nil {
+				host.ReportFatalError(err)
+			}
+		}()
+	}
 
 	return nil
 }
 
 func (r *redisReceiver) Shutdown(ctx context.Context) error {
-	r.intervalRunner.Stop()
+	for _, runner := range r.intervalRunners {
+		runner.Stop()
+	}
 	return nil
 }
+
+// Builds the options of the client of a Redis server, using TLS if configured.
+func newRedisOptions(endpoint EndpointConfig) (*redis.Options, error) {
+	options := &redis.Options{
+		Addr:     endpoint.Endpoint,
+		Username: endpoint.Username,
+		Password: endpoint.Password,
+	}
+	if endpoint.TLS != nil {
+		tlsConfig, err := endpoint.TLS.LoadTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		options.TLSConfig = tlsConfig
+	}
+	return options, nil
+}
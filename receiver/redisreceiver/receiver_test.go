@@ -0,0 +1,147 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver/interval"
+)
+
+func TestNewRedisOptions(t *testing.T) {
+	options, err := newRedisOptions(EndpointConfig{
+		Endpoint: "localhost:6379",
+		Username: "otel",
+		Password: "secret",
+	})
+	require.Nil(t, err)
+	require.Equal(t, "localhost:6379", options.Addr)
+	require.Equal(t, "otel", options.Username)
+	require.Equal(t, "secret", options.Password)
+	require.Nil(t, options.TLSConfig)
+
+	options, err = newRedisOptions(EndpointConfig{
+		Endpoint: "localhost:6379",
+		TLS: &configtls.TLSClientSetting{
+			ServerName: "redis.local",
+		},
+	})
+	require.Nil(t, err)
+	require.NotNil(t, options.TLSConfig)
+	require.Equal(t, "redis.local", options.TLSConfig.ServerName)
+
+	_, err = newRedisOptions(EndpointConfig{
+		Endpoint: "localhost:6379",
+		TLS: &configtls.TLSClientSetting{
+			TLSSetting: configtls.TLSSetting{CAFile: "testdata/missing.pem"},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestInvalidSlowLogMaxEntries(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*config)
+	cfg.Endpoint = "localhost:6379"
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	for _, maxEntries := range []int{0, -1} {
+		cfg.SlowLogMaxEntries = maxEntries
+		_, err := f.CreateLogsReceiver(context.Background(), params, cfg, &exportertest.SinkLogsExporter{})
+		require.EqualError(t, err, fmt.Sprintf("slowlog_max_entries must be positive, got %d", maxEntries))
+		_, err = f.CreateMetricsReceiver(context.Background(), params, cfg, &exportertest.SinkMetricsExporter{})
+		require.Error(t, err)
+	}
+}
+
+func TestRunnerPerEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*config)
+	cfg.Endpoint = "localhost:6379"
+	cfg.Endpoints = []EndpointConfig{{Endpoint: "localhost:6380"}}
+	cfg.CollectionInterval = time.Millisecond
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	ran := make(chan struct{}, 1)
+	r := newRedisReceiver(zap.NewNop(), cfg)
+	r.newRunnables = func(ctx context.Context, client client, endpoint EndpointConfig) []interval.Runnable {
+		if endpoint.Endpoint == "localhost:6379" {
+			return []interval.Runnable{&fakeRunnable{run: func() { <-unblock }}}
+		}
+		return []interval.Runnable{&fakeRunnable{run: func() {
+			select {
+			case ran <- struct{}{}:
+			default:
+			}
+		}}}
+	}
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.Len(t, r.intervalRunners, 2)
+
+	// The second server is collected although the first one never responds.
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second endpoint was never collected")
+	}
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestMetricsAndLogsShareReceiver(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*config)
+	cfg.Endpoint = "localhost:6379"
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	mr, err := f.CreateMetricsReceiver(context.Background(), params, cfg, &exportertest.SinkMetricsExporter{})
+	require.NoError(t, err)
+	lr, err := f.CreateLogsReceiver(context.Background(), params, cfg, &exportertest.SinkLogsExporter{})
+	require.NoError(t, err)
+	require.Same(t, mr, lr)
+
+	// A single start runs both the metrics and the SLOWLOG collection.
+	r := mr.(*redisReceiver)
+	runnables := r.endpointRunnables(context.Background(), newFakeClient(), cfg.endpoints()[0])
+	require.Len(t, runnables, 2)
+	require.IsType(t, &redisRunnable{}, runnables[0])
+	require.IsType(t, &slowLogRunnable{}, runnables[1])
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.Len(t, r.intervalRunners, 2)
+	require.NoError(t, r.Shutdown(context.Background()))
+}
+
+type fakeRunnable struct {
+	run func()
+}
+
+func (*fakeRunnable) Setup() error {
+	return nil
+}
+
+func (f *fakeRunnable) Run() error {
+	f.run()
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"time"
 
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/translator/internaldata"
@@ -108,6 +109,21 @@ func (r *redisRunnable) Run() error {
 		)
 	}
 
+	replicationMetrics, warnings := inf.buildReplicationProtoMetrics(r.timeBundle)
+	metrics = append(metrics, replicationMetrics...)
+	if warnings != nil {
+		r.logger.Warn(
+			"errors parsing replication string",
+			zap.Errors("parsing errors", warnings),
+		)
+	}
+
+	metrics = append(metrics, r.commandStatsMetrics()...)
+
+	if inf["cluster_enabled"] == "1" {
+		metrics = append(metrics, r.clusterMetrics()...)
+	}
+
 	md := newMetricsData(metrics, r.serviceName)
 
 	err = r.metricsConsumer.ConsumeMetrics(r.ctx, internaldata.OCToMetrics(md))
@@ -116,3 +132,41 @@ func (r *redisRunnable) Run() error {
 
 	return nil
 }
+
+// Queries INFO commandstats and builds its metrics. The other metrics are still
+// reported if it fails, e.g. when the ACL user isn't allowed to run it.
+func (r *redisRunnable) commandStatsMetrics() []*metricspb.Metric {
+	stats, err := r.redisSvc.commandStats()
+	if err != nil {
+		r.logger.Warn("failed to retrieve redis command stats", zap.Error(err))
+		return nil
+	}
+
+	metrics, warnings := stats.buildCommandStatsProtoMetrics(r.timeBundle)
+	if warnings != nil {
+		r.logger.Warn(
+			"errors parsing command stats string",
+			zap.Errors("parsing errors", warnings),
+		)
+	}
+	return metrics
+}
+
+// Queries CLUSTER INFO and builds its metrics. The other metrics are still
+// reported if it fails.
+func (r *redisRunnable) clusterMetrics() []*metricspb.Metric {
+	clusterInfo, err := r.redisSvc.clusterInfo()
+	if err != nil {
+		r.logger.Warn("failed to retrieve redis cluster info", zap.Error(err))
+		return nil
+	}
+
+	metrics, warnings := clusterInfo.buildClusterProtoMetrics(r.timeBundle)
+	if warnings != nil {
+		r.logger.Warn(
+			"errors parsing cluster info string",
+			zap.Errors("parsing errors", warnings),
+		)
+	}
+	return metrics
+}
@@ -31,6 +31,9 @@ func TestRedisRunnable(t *testing.T) {
 	require.Nil(t, err)
 	err = runner.Run()
 	require.Nil(t, err)
-	// + 6 because there are two keyspace entries each of which has three metrics
-	require.Equal(t, len(getDefaultRedisMetrics())+6, consumer.MetricsCount())
+	// + 6 because there are two keyspace entries each of which has three metrics,
+	// + 4 because there are two replicas each of which has two metrics,
+	// + 6 because there are three commands each of which has two metrics.
+	// Cluster metrics aren't collected because cluster support is disabled.
+	require.Equal(t, len(getDefaultRedisMetrics())+6+4+6, consumer.MetricsCount())
 }
@@ -39,16 +39,47 @@ func (p *redisSvc) info() (info, error) {
 	if err != nil {
 		return nil, err
 	}
+	return p.parseInfo(str), nil
+}
+
+// Calls the Redis INFO commandstats command on the client and returns an `info`
+// map of the stats of each command.
+func (p *redisSvc) commandStats() (info, error) {
+	str, err := p.client.retrieveCommandStats()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseInfo(str), nil
+}
+
+// Calls the Redis CLUSTER INFO command on the client and returns an `info` map.
+func (p *redisSvc) clusterInfo() (info, error) {
+	str, err := p.client.retrieveClusterInfo()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseInfo(str), nil
+}
+
+// Calls the Redis SLOWLOG GET command on the client and returns at most count
+// of the latest entries.
+func (p *redisSvc) slowLog(count int) ([]slowLogEntry, error) {
+	return p.client.retrieveSlowLog(count)
+}
+
+// Parses the key value pairs of the lines of the INFO and CLUSTER INFO commands.
+func (p *redisSvc) parseInfo(str string) info {
 	lines := strings.Split(str, p.delimiter)
 	attrs := make(map[string]string)
 	for _, line := range lines {
 		if len(line) == 0 || strings.HasPrefix(line, "#") {
 			continue
 		}
-		pair := strings.Split(line, ":")
+		// Values may contain colons, e.g. the IPv6 address of a replica.
+		pair := strings.SplitN(line, ":", 2)
 		if len(pair) == 2 { // defensive, should always == 2
 			attrs[pair[0]] = pair[1]
 		}
 	}
-	return attrs, nil
+	return attrs
 }
@@ -28,6 +28,24 @@ func TestParser(t *testing.T) {
 	s := newFakeAPIParser()
 	info, err := s.info()
 	require.Nil(t, err)
-	require.Equal(t, 123, len(info))
+	require.Equal(t, 125, len(info))
 	require.Equal(t, "1.24", info["allocator_frag_ratio"]) // spot check
+	// values containing colons are kept whole
+	require.Equal(t, "ip=fd00::13,port=6380,state=online,offset=2980,lag=1", info["slave1"])
+}
+
+func TestParseCommandStats(t *testing.T) {
+	s := newFakeAPIParser()
+	stats, err := s.commandStats()
+	require.Nil(t, err)
+	require.Equal(t, 3, len(stats))
+	require.Equal(t, "calls=1200,usec=3600,usec_per_call=3.00", stats["cmdstat_get"])
+}
+
+func TestParseClusterInfo(t *testing.T) {
+	s := newFakeAPIParser()
+	clusterInfo, err := s.clusterInfo()
+	require.Nil(t, err)
+	require.Equal(t, 11, len(clusterInfo))
+	require.Equal(t, "ok", clusterInfo["cluster_state"])
 }
@@ -0,0 +1,155 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+)
+
+// Holds fields returned for each replica by the Replication section of the
+// INFO command of a master: e.g. "slave0:ip=10.0.0.1,port=6379,state=online,offset=1,lag=0"
+type replica struct {
+	addr   string
+	offset int64
+	lag    int64
+}
+
+// Turns a replica value (the part after the colon e.g.
+// "ip=10.0.0.1,port=6379,state=online,offset=1,lag=0") into a replica struct.
+func parseReplicaString(str string) (*replica, error) {
+	var ip, port string
+	r := replica{}
+	for _, pairStr := range strings.Split(str, ",") {
+		pair := strings.Split(pairStr, "=")
+		if len(pair) != 2 {
+			return nil, fmt.Errorf(
+				"unexpected replica pair '%s'",
+				pairStr,
+			)
+		}
+		var field *int64
+		switch pair[0] {
+		case "ip":
+			ip = pair[1]
+		case "port":
+			port = pair[1]
+		case "offset":
+			field = &r.offset
+		case "lag":
+			field = &r.lag
+		}
+		if field != nil {
+			val, err := strconv.ParseInt(pair[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			*field = val
+		}
+	}
+	r.addr = net.JoinHostPort(ip, port)
+	return &r, nil
+}
+
+// Builds proto metrics from the Replication section of Redis INFO: the offset
+// and lag of each replica of a master, labeled with the replica address, and
+// the state of the link to the master of a replica. Returns proto metrics and
+// parsing errors, to be treated as warnings, if there were any.
+func (i info) buildReplicationProtoMetrics(t *timeBundle) (
+	protoMetrics []*metricspb.Metric,
+	warnings []error,
+) {
+	for n := 0; ; n++ {
+		str, ok := i["slave"+strconv.Itoa(n)]
+		if !ok {
+			break
+		}
+		r, parsingError := parseReplicaString(str)
+		if parsingError != nil {
+			warnings = append(warnings, parsingError)
+			continue
+		}
+		protoMetrics = append(protoMetrics, buildReplicaMetrics(r, t)...)
+	}
+
+	if i["role"] != "slave" {
+		return protoMetrics, warnings
+	}
+
+	linkUp := int64(0)
+	if i["master_link_status"] == "up" {
+		linkUp = 1
+	}
+	protoMetrics = append(protoMetrics, newProtoMetric(&redisMetric{
+		name:   "redis/replication/master_link_up",
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Whether the link to the master is up",
+	}, int64Point(linkUp), t))
+
+	for _, m := range []*redisMetric{
+		{
+			key:    "master_last_io_seconds_ago",
+			name:   "redis/replication/master_last_io",
+			units:  "s",
+			mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+			desc:   "Number of seconds since the last interaction with the master",
+		},
+		{
+			key:    "slave_repl_offset",
+			name:   "redis/replication/offset",
+			units:  "By",
+			mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+			desc:   "The replication offset of the replica",
+		},
+	} {
+		strVal, ok := i[m.key]
+		if !ok {
+			continue
+		}
+		protoMetric, parsingError := m.parseMetric(strVal, t)
+		if parsingError != nil {
+			warnings = append(warnings, parsingError)
+			continue
+		}
+		protoMetrics = append(protoMetrics, protoMetric)
+	}
+
+	return protoMetrics, warnings
+}
+
+func buildReplicaMetrics(r *replica, t *timeBundle) []*metricspb.Metric {
+	offset := &redisMetric{
+		name:   "redis/replication/replica_offset",
+		units:  "By",
+		labels: map[string]string{"replica": r.addr},
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "The replication offset acknowledged by the replica",
+	}
+	lag := &redisMetric{
+		name:   "redis/replication/replica_lag",
+		units:  "s",
+		labels: map[string]string{"replica": r.addr},
+		mdType: metricspb.MetricDescriptor_GAUGE_INT64,
+		desc:   "Number of seconds since the last acknowledgement of the replica",
+	}
+	return []*metricspb.Metric{
+		newProtoMetric(offset, int64Point(r.offset), t),
+		newProtoMetric(lag, int64Point(r.lag), t),
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"testing"
+	"time"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReplicaString(t *testing.T) {
+	r, err := parseReplicaString("ip=fd00::13,port=6380,state=online,offset=2980,lag=1")
+	require.Nil(t, err)
+	require.Equal(t, &replica{addr: "[fd00::13]:6380", offset: 2980, lag: 1}, r)
+}
+
+func TestParseMalformedReplica(t *testing.T) {
+	tests := []struct{ name, replica string }{
+		{"missing value", "ip=10.0.0.1,port=6379,offset="},
+		{"missing equals", "ip=10.0.0.1,port=6379,offset"},
+		{"not a number", "ip=10.0.0.1,port=6379,lag=x"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseReplicaString(test.replica)
+			require.NotNil(t, err)
+		})
+	}
+}
+
+func TestBuildMasterReplicationProtoMetrics(t *testing.T) {
+	svc := newRedisSvc(newFakeClient())
+	inf, err := svc.info()
+	require.Nil(t, err)
+
+	metrics, warnings := inf.buildReplicationProtoMetrics(newTimeBundle(time.Now(), 100))
+	require.Nil(t, warnings)
+	require.Equal(t, 4, len(metrics))
+
+	expected := []struct {
+		name    string
+		replica string
+		value   int64
+	}{
+		{"redis/replication/replica_offset", "10.0.0.12:6379", 3024},
+		{"redis/replication/replica_lag", "10.0.0.12:6379", 0},
+		{"redis/replication/replica_offset", "[fd00::13]:6380", 2980},
+		{"redis/replication/replica_lag", "[fd00::13]:6380", 1},
+	}
+	for i, e := range expected {
+		require.Equal(t, e.name, metrics[i].MetricDescriptor.Name)
+		require.Equal(t, "replica", metrics[i].MetricDescriptor.LabelKeys[0].Key)
+		require.Equal(t, e.replica, metrics[i].Timeseries[0].LabelValues[0].Value)
+		require.Equal(t, e.value, metrics[i].Timeseries[0].Points[0].GetInt64Value())
+	}
+}
+
+func TestBuildReplicaReplicationProtoMetrics(t *testing.T) {
+	inf := info{
+		"role":                       "slave",
+		"master_link_status":         "down",
+		"master_last_io_seconds_ago": "12",
+		"slave_repl_offset":          "2980",
+	}
+
+	metrics, warnings := inf.buildReplicationProtoMetrics(newTimeBundle(time.Now(), 100))
+	require.Nil(t, warnings)
+	require.Equal(t, 3, len(metrics))
+
+	require.Equal(t, "redis/replication/master_link_up", metrics[0].MetricDescriptor.Name)
+	require.Equal(t, int64(0), metrics[0].Timeseries[0].Points[0].GetInt64Value())
+	require.Equal(t, "redis/replication/master_last_io", metrics[1].MetricDescriptor.Name)
+	require.Equal(t, int64(12), metrics[1].Timeseries[0].Points[0].GetInt64Value())
+	require.Equal(t, "redis/replication/offset", metrics[2].MetricDescriptor.Name)
+	require.Equal(t, metricspb.MetricDescriptor_GAUGE_INT64, metrics[2].MetricDescriptor.Type)
+	require.Equal(t, int64(2980), metrics[2].Timeseries[0].Points[0].GetInt64Value())
+}
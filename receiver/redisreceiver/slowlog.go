@@ -0,0 +1,162 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver/interval"
+)
+
+// Log record attribute keys for SLOWLOG entries.
+const (
+	slowLogKeyID         = "redis.slowlog.id"
+	slowLogKeyDuration   = "redis.slowlog.duration_us"
+	slowLogKeyCommand    = "redis.command"
+	slowLogKeyClientAddr = "redis.client.addr"
+	slowLogKeyClientName = "redis.client.name"
+)
+
+var _ interval.Runnable = (*slowLogRunnable)(nil)
+
+// Runs intermittently, fetching the SLOWLOG entries added since the previous
+// run and feeding them as logs to a logsConsumer.
+type slowLogRunnable struct {
+	ctx          context.Context
+	logsConsumer consumer.LogsConsumer
+	redisSvc     *redisSvc
+	logger       *zap.Logger
+	serviceName  string
+	maxEntries   int
+	// lastID is the id of the latest entry that was sent, -1 if there is none.
+	lastID int64
+	// initialized tells whether the entries logged before the first run were skipped.
+	initialized bool
+}
+
+func newSlowLogRunnable(
+	ctx context.Context,
+	client client,
+	serviceName string,
+	maxEntries int,
+	logsConsumer consumer.LogsConsumer,
+	logger *zap.Logger,
+) *slowLogRunnable {
+	return &slowLogRunnable{
+		ctx:          ctx,
+		serviceName:  serviceName,
+		maxEntries:   maxEntries,
+		redisSvc:     newRedisSvc(client),
+		logsConsumer: logsConsumer,
+		logger:       logger,
+		lastID:       -1,
+	}
+}
+
+func (r *slowLogRunnable) Setup() error {
+	return nil
+}
+
+// Run queries the latest SLOWLOG entries and sends the ones that weren't sent
+// yet. The entries logged before the first run are skipped, as they may have
+// been sent by a previous instance of the receiver.
+func (r *slowLogRunnable) Run() error {
+	entries, err := r.redisSvc.slowLog(r.maxEntries)
+	if err != nil {
+		r.logger.Warn("failed to retrieve redis slowlog", zap.Error(err))
+		return nil
+	}
+
+	newEntries := r.newEntries(entries)
+	if !r.initialized {
+		r.initialized = true
+		return nil
+	}
+	if len(newEntries) == 0 {
+		return nil
+	}
+
+	if err := r.logsConsumer.ConsumeLogs(r.ctx, slowLogToLogs(newEntries, r.serviceName)); err != nil {
+		r.logger.Error("failed to consume redis slowlog entries", zap.Error(err))
+	}
+	return nil
+}
+
+// newEntries returns the entries more recent than the last sent one, and
+// records the latest of them as sent. Entries are ordered from the latest to
+// the oldest, and ids start again from 0 when the server restarts.
+func (r *slowLogRunnable) newEntries(entries []slowLogEntry) []slowLogEntry {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	latestID := entries[0].id
+	if latestID < r.lastID {
+		// assume a server restart or a SLOWLOG RESET
+		r.lastID = -1
+	}
+
+	var newEntries []slowLogEntry
+	for _, entry := range entries {
+		if entry.id <= r.lastID {
+			break
+		}
+		newEntries = append(newEntries, entry)
+	}
+	r.lastID = latestID
+	return newEntries
+}
+
+// Converts SLOWLOG entries to logs made of one log record per entry, named
+// after the command.
+func slowLogToLogs(entries []slowLogEntry, serviceName string) pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rl := rls.At(0)
+
+	rl.Resource().InitEmpty()
+	rl.Resource().Attributes().InsertString("service.name", serviceName)
+
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(1)
+	lrs := ills.At(0).Logs()
+	lrs.Resize(len(entries))
+
+	for i, entry := range entries {
+		lr := lrs.At(i)
+		lr.SetName(entry.command())
+		lr.SetTimestamp(pdata.TimestampUnixNano(uint64(entry.time.UnixNano())))
+		lr.Body().SetStringVal(strings.Join(entry.args, " "))
+
+		attrs := lr.Attributes()
+		attrs.InsertInt(slowLogKeyID, entry.id)
+		attrs.InsertInt(slowLogKeyDuration, entry.duration.Microseconds())
+		attrs.InsertString(slowLogKeyCommand, entry.command())
+		if entry.clientAddr != "" {
+			attrs.InsertString(slowLogKeyClientAddr, entry.clientAddr)
+		}
+		if entry.clientName != "" {
+			attrs.InsertString(slowLogKeyClientName, entry.clientName)
+		}
+	}
+
+	return ld
+}
@@ -0,0 +1,127 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+)
+
+// A fake client whose SLOWLOG can be changed between runs.
+type slowLogClient struct {
+	fakeClient
+	entries []slowLogEntry
+}
+
+func (c *slowLogClient) retrieveSlowLog(count int) ([]slowLogEntry, error) {
+	if count < len(c.entries) {
+		return c.entries[:count], nil
+	}
+	return c.entries, nil
+}
+
+func newSlowLogEntry(id int64) slowLogEntry {
+	return slowLogEntry{
+		id:       id,
+		time:     time.Unix(1600000000+id, 0),
+		duration: time.Duration(id) * time.Millisecond,
+		args:     []string{"GET", "key"},
+	}
+}
+
+func TestSlowLogRunnable(t *testing.T) {
+	consumer := &exportertest.SinkLogsExporter{}
+	client := &slowLogClient{entries: []slowLogEntry{newSlowLogEntry(1), newSlowLogEntry(0)}}
+	runner := newSlowLogRunnable(context.Background(), client, "my-redis", 10, consumer, zap.NewNop())
+	require.Nil(t, runner.Setup())
+
+	// the entries logged before the first run are skipped
+	require.Nil(t, runner.Run())
+	require.Equal(t, 0, consumer.LogRecordsCount())
+
+	client.entries = append([]slowLogEntry{newSlowLogEntry(3), newSlowLogEntry(2)}, client.entries...)
+	require.Nil(t, runner.Run())
+	require.Equal(t, 2, consumer.LogRecordsCount())
+
+	// nothing new
+	require.Nil(t, runner.Run())
+	require.Equal(t, 2, consumer.LogRecordsCount())
+
+	// the ids start again after a restart
+	client.entries = []slowLogEntry{newSlowLogEntry(0)}
+	require.Nil(t, runner.Run())
+	require.Equal(t, 3, consumer.LogRecordsCount())
+
+	logs := consumer.AllLogs()
+	require.Equal(t, 2, len(logs))
+	lrs := logs[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	id, _ := lrs.At(0).Attributes().Get(slowLogKeyID)
+	require.Equal(t, int64(3), id.IntVal())
+	id, _ = lrs.At(1).Attributes().Get(slowLogKeyID)
+	require.Equal(t, int64(2), id.IntVal())
+}
+
+func TestSlowLogRunnableEmptySlowLog(t *testing.T) {
+	consumer := &exportertest.SinkLogsExporter{}
+	client := &slowLogClient{}
+	runner := newSlowLogRunnable(context.Background(), client, "my-redis", 10, consumer, zap.NewNop())
+
+	require.Nil(t, runner.Run())
+	client.entries = []slowLogEntry{newSlowLogEntry(0)}
+	require.Nil(t, runner.Run())
+	require.Equal(t, 1, consumer.LogRecordsCount())
+}
+
+func TestSlowLogToLogs(t *testing.T) {
+	entries, _ := fakeClient{}.retrieveSlowLog(10)
+	ld := slowLogToLogs(entries, "my-redis")
+	require.Equal(t, 2, ld.LogRecordCount())
+
+	rl := ld.ResourceLogs().At(0)
+	serviceName, _ := rl.Resource().Attributes().Get("service.name")
+	require.Equal(t, "my-redis", serviceName.StringVal())
+
+	lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	require.Equal(t, "keys", lr.Name())
+	require.Equal(t, pdata.TimestampUnixNano(1600000010*time.Second), lr.Timestamp())
+	require.Equal(t, "KEYS *", lr.Body().StringVal())
+
+	attrs := map[string]interface{}{}
+	lr.Attributes().ForEach(func(k string, v pdata.AttributeValue) {
+		switch v.Type() {
+		case pdata.AttributeValueINT:
+			attrs[k] = v.IntVal()
+		default:
+			attrs[k] = v.StringVal()
+		}
+	})
+	require.Equal(t, map[string]interface{}{
+		"redis.slowlog.id":          int64(12),
+		"redis.slowlog.duration_us": int64(15000),
+		"redis.command":             "keys",
+		"redis.client.addr":         "127.0.0.1:58712",
+		"redis.client.name":         "worker",
+	}, attrs)
+
+	// the client is not reported by Redis versions prior to 4.0
+	lr = rl.InstrumentationLibraryLogs().At(0).Logs().At(1)
+	require.Equal(t, 3, lr.Attributes().Len())
+}
@@ -27,4 +27,8 @@ type Config struct {
 	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	splunk.AccessTokenPassthroughConfig `mapstructure:",squash"`
+
+	// MaxRawLineSize is the maximum size in bytes of a line sent to the raw
+	// endpoint, requests with longer lines are rejected.
+	MaxRawLineSize int `mapstructure:"max_raw_line_size"`
 }
@@ -60,6 +60,7 @@ func TestLoadConfig(t *testing.T) {
 			AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
 				AccessTokenPassthrough: true,
 			},
+			MaxRawLineSize: 65536,
 		})
 
 	r2 := cfg.Receivers["splunk_hec/tls"].(*Config)
@@ -81,5 +82,6 @@ func TestLoadConfig(t *testing.T) {
 			AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{
 				AccessTokenPassthrough: false,
 			},
+			MaxRawLineSize: defaultMaxRawLineSize,
 		})
 }
@@ -19,9 +19,9 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
@@ -38,14 +38,18 @@ const (
 
 	// Default endpoints to bind to.
 	defaultEndpoint = ":8088"
+
+	// Default maximum size of the lines sent to the raw endpoint.
+	defaultMaxRawLineSize = 1024 * 1024
 )
 
-// NewFactory creates a factory for SignalFx receiver.
+// NewFactory creates a factory for Splunk HEC receiver.
 func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver))
 }
 
 // CreateDefaultConfig creates the default configuration for Splunk HEC receiver.
@@ -59,6 +63,7 @@ func createDefaultConfig() configmodels.Receiver {
 			Endpoint: defaultEndpoint,
 		},
 		AccessTokenPassthroughConfig: splunk.AccessTokenPassthroughConfig{},
+		MaxRawLineSize:               defaultMaxRawLineSize,
 	}
 }
 
@@ -81,28 +86,70 @@ func extractPortFromEndpoint(endpoint string) (int, error) {
 
 // verify that the configured port is not 0
 func (rCfg *Config) validate() error {
+	if rCfg.Endpoint == "" {
+		return errEmptyEndpoint
+	}
+	if rCfg.MaxRawLineSize <= 0 {
+		return fmt.Errorf("max_raw_line_size must be positive: %d", rCfg.MaxRawLineSize)
+	}
+
 	_, err := extractPortFromEndpoint(rCfg.Endpoint)
 	return err
 }
 
-// CreateTraceReceiver creates a trace receiver based on provided config.
-func createTraceReceiver(
-	ctx context.Context,
+// createMetricsReceiver creates a metrics receiver based on provided config.
+func createMetricsReceiver(
+	_ context.Context,
 	params component.ReceiverCreateParams,
 	cfg configmodels.Receiver,
-	consumer consumer.TraceConsumer,
-) (component.TraceReceiver, error) {
+	consumer consumer.MetricsConsumer,
+) (component.MetricsReceiver, error) {
+	rCfg := cfg.(*Config)
+
+	err := rCfg.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	receiverLock.Lock()
+	r := receivers[rCfg]
+	if r == nil {
+		r = newReceiver(params.Logger, *rCfg)
+		receivers[rCfg] = r
+	}
+	receiverLock.Unlock()
+
+	r.RegisterMetricsConsumer(consumer)
 
-	return nil, configerror.ErrDataTypeIsNotSupported
+	return r, nil
 }
 
-// CreateMetricsReceiver creates a metrics receiver based on provided config.
-func createMetricsReceiver(
+// createLogsReceiver creates a logs receiver based on provided config.
+func createLogsReceiver(
 	_ context.Context,
 	params component.ReceiverCreateParams,
 	cfg configmodels.Receiver,
-	consumer consumer.MetricsConsumer,
-) (component.MetricsReceiver, error) {
+	consumer consumer.LogsConsumer,
+) (component.LogsReceiver, error) {
+	rCfg := cfg.(*Config)
+
+	err := rCfg.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	receiverLock.Lock()
+	r := receivers[rCfg]
+	if r == nil {
+		r = newReceiver(params.Logger, *rCfg)
+		receivers[rCfg] = r
+	}
+	receiverLock.Unlock()
+
+	r.RegisterLogsConsumer(consumer)
 
-	return nil, configerror.ErrDataTypeIsNotSupported
+	return r, nil
 }
+
+var receiverLock sync.Mutex
+var receivers = map[*Config]*splunkReceiver{}
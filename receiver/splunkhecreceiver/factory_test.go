@@ -37,17 +37,36 @@ func TestCreateReceiver(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	cfg.Endpoint = "localhost:1" // Endpoint is required, not going to be used here.
 
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
 	mockMetricsConsumer := exportertest.NewNopMetricsExporter()
-	mReceiver, err := createMetricsReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, mockMetricsConsumer)
-	assert.Equal(t, err, configerror.ErrDataTypeIsNotSupported)
-	assert.Nil(t, mReceiver)
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, mockMetricsConsumer)
+	assert.Nil(t, err, "receiver creation failed")
+	assert.NotNil(t, mReceiver, "receiver creation failed")
+
+	mockLogsConsumer := exportertest.NewNopLogsExporter()
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, mockLogsConsumer)
+	assert.Nil(t, err, "receiver creation failed")
+	assert.NotNil(t, lReceiver, "receiver creation failed")
+
+	// Metrics and logs share the same receiver instance.
+	assert.Same(t, mReceiver, lReceiver)
 
 	mockTracesConsumer := exportertest.NewNopTraceExporter()
-	tReceiver, err := createTraceReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, mockTracesConsumer)
+	tReceiver, err := NewFactory().CreateTraceReceiver(context.Background(), params, cfg, mockTracesConsumer)
 	assert.Equal(t, err, configerror.ErrDataTypeIsNotSupported)
 	assert.Nil(t, tReceiver)
 }
 
+func TestCreateReceiverEmptyEndpoint(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ""
+
+	mReceiver, err := createMetricsReceiver(context.Background(), component.ReceiverCreateParams{Logger: zap.NewNop()}, cfg, exportertest.NewNopMetricsExporter())
+	assert.Equal(t, errEmptyEndpoint, err)
+	assert.Nil(t, mReceiver)
+}
+
 func TestFactoryType(t *testing.T) {
 	assert.Equal(t, configmodels.Type("splunk_hec"), NewFactory().Type())
 }
@@ -87,3 +106,11 @@ func TestValidateBadEndpoint(t *testing.T) {
 	err := config.validate()
 	assert.EqualError(t, err, "endpoint port is not a number: strconv.ParseInt: parsing \"abr\": invalid syntax")
 }
+
+func TestValidateBadMaxRawLineSize(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.MaxRawLineSize = 0
+
+	err := config.validate()
+	assert.EqualError(t, err, "max_raw_line_size must be positive: 0")
+}
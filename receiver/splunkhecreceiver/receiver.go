@@ -0,0 +1,484 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opencensus.io/trace"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
+)
+
+const (
+	defaultServerTimeout = 20 * time.Second
+
+	// Name of the span of the logs receive operations, there is no obsreport
+	// support for logs yet.
+	logsReceivedSpanSuffix = "/LogsReceived"
+	acceptedLogRecordsKey  = "accepted_log_records"
+	refusedLogRecordsKey   = "refused_log_records"
+
+	// HEC endpoints, see https://docs.splunk.com/Documentation/Splunk/8.0.5/RESTREF/RESTinput.
+	hecPath      = "/services/collector"
+	hecEventPath = "/services/collector/event"
+	hecRawPath   = "/services/collector/raw"
+
+	// Query parameters accepted by the raw endpoint.
+	queryHost       = "host"
+	querySource     = "source"
+	querySourcetype = "sourcetype"
+	queryIndex      = "index"
+
+	// Centralizing some HTTP and related string constants.
+	gzipEncoding              = "gzip"
+	httpContentEncodingHeader = "Content-Encoding"
+	httpAuthorizationHeader   = "Authorization"
+	httpContentTypeHeader     = "Content-Type"
+	httpJSONTypeHeader        = "application/json"
+)
+
+var (
+	errNilNextConsumer = errors.New("nil nextConsumer")
+	errEmptyEndpoint   = errors.New("empty endpoint")
+	errInvalidMethod   = errors.New("invalid http method")
+	errGzipReader      = errors.New("could not read gzip body")
+	errNoLogsConsumer  = errors.New("no logs pipeline is configured")
+	errNoMetricsConsum = errors.New("no metrics pipeline is configured")
+
+	// HEC compatible responses, see
+	// https://docs.splunk.com/Documentation/Splunk/8.0.5/Data/TroubleshootHTTPEventCollector#Possible_error_codes
+	okRespBody                = initJSONResponse(0, "Success")
+	noDataRespBody            = initJSONResponse(5, "No data")
+	invalidFormatRespBody     = initJSONResponse(6, "Invalid data format")
+	errInternalServerRespBody = initJSONResponse(8, "Internal server error")
+	partialSuccessRespBody    = initJSONResponse(8, "Internal server error, the events were partially accepted")
+	eventRequiredRespBody     = initJSONResponse(12, "Event field is required")
+	eventBlankRespBody        = initJSONResponse(13, "Event field cannot be blank")
+)
+
+// splunkReceiver implements the component.MetricsReceiver and
+// component.LogsReceiver for the Splunk HEC protocol.
+type splunkReceiver struct {
+	sync.Mutex
+	logger          *zap.Logger
+	config          *Config
+	metricsConsumer consumer.MetricsConsumer
+	logsConsumer    consumer.LogsConsumer
+	server          *http.Server
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+var _ component.MetricsReceiver = (*splunkReceiver)(nil)
+var _ component.LogsReceiver = (*splunkReceiver)(nil)
+
+// newReceiver creates the Splunk HEC receiver with the given configuration.
+func newReceiver(
+	logger *zap.Logger,
+	config Config,
+) *splunkReceiver {
+	r := &splunkReceiver{
+		logger: logger,
+		config: &config,
+	}
+
+	return r
+}
+
+func (r *splunkReceiver) RegisterMetricsConsumer(mc consumer.MetricsConsumer) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.metricsConsumer = mc
+}
+
+func (r *splunkReceiver) RegisterLogsConsumer(lc consumer.LogsConsumer) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.logsConsumer = lc
+}
+
+// Start tells the receiver to start its processing.
+// By convention the consumer of the received data is set when the receiver
+// instance is created.
+func (r *splunkReceiver) Start(_ context.Context, host component.Host) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.metricsConsumer == nil && r.logsConsumer == nil {
+		return errNilNextConsumer
+	}
+
+	err := componenterror.ErrAlreadyStarted
+	r.startOnce.Do(func() {
+		err = nil
+
+		var ln net.Listener
+		// set up the listener
+		ln, err = r.config.HTTPServerSettings.ToListener()
+		if err != nil {
+			err = fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+			return
+		}
+
+		mx := mux.NewRouter()
+		mx.HandleFunc(hecPath, r.handleReq)
+		mx.HandleFunc(hecEventPath, r.handleReq)
+		mx.HandleFunc(hecRawPath, r.handleRawReq)
+
+		r.server = r.config.HTTPServerSettings.ToServer(mx)
+
+		// TODO: Evaluate what properties should be configurable, for now
+		//		set some hard-coded values.
+		r.server.ReadHeaderTimeout = defaultServerTimeout
+		r.server.WriteTimeout = defaultServerTimeout
+
+		go func() {
+			if errHTTP := r.server.Serve(ln); errHTTP != http.ErrServerClosed {
+				host.ReportFatalError(errHTTP)
+			}
+		}()
+	})
+
+	return err
+}
+
+// Shutdown tells the receiver that should stop reception,
+// giving it a chance to perform any necessary clean-up.
+func (r *splunkReceiver) Shutdown(context.Context) error {
+	r.Lock()
+	defer r.Unlock()
+
+	err := componenterror.ErrAlreadyStopped
+	r.stopOnce.Do(func() {
+		err = nil
+		if r.server != nil {
+			err = r.server.Close()
+		}
+	})
+	return err
+}
+
+// bodyReader validates the request method and encoding and returns a reader
+// over the (possibly decompressed) request body.
+func (r *splunkReceiver) bodyReader(ctx context.Context, resp http.ResponseWriter, req *http.Request) (io.Reader, bool) {
+	if req.Method != http.MethodPost {
+		r.failRequest(ctx, resp, http.StatusBadRequest, invalidFormatRespBody, errInvalidMethod)
+		return nil, false
+	}
+
+	encoding := req.Header.Get(httpContentEncodingHeader)
+	if encoding != "" && encoding != gzipEncoding {
+		r.failRequest(ctx, resp, http.StatusUnsupportedMediaType, invalidFormatRespBody, nil)
+		return nil, false
+	}
+
+	var bodyReader io.Reader = req.Body
+	if encoding == gzipEncoding {
+		gz, err := gzip.NewReader(bodyReader)
+		if err != nil {
+			r.failRequest(ctx, resp, http.StatusBadRequest, invalidFormatRespBody, fmt.Errorf("%v: %w", errGzipReader, err))
+			return nil, false
+		}
+		bodyReader = gz
+	}
+	return bodyReader, true
+}
+
+// handleReq serves the /services/collector and /services/collector/event
+// endpoints. The body is a stream of concatenated HEC JSON events, metric
+// events are routed to the metrics pipeline and all others to the logs
+// pipeline.
+func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request) {
+	ctx := r.receiverContext(req)
+
+	bodyReader, ok := r.bodyReader(ctx, resp, req)
+	if !ok {
+		return
+	}
+
+	var events []*splunk.Event
+	var metricEvents []*splunk.Event
+	dec := json.NewDecoder(bodyReader)
+	dec.UseNumber()
+	for dec.More() {
+		var ev splunk.Event
+		if err := dec.Decode(&ev); err != nil {
+			r.failRequest(ctx, resp, http.StatusBadRequest, invalidFormatRespBody, err)
+			return
+		}
+		if ev.Event == nil {
+			r.failRequest(ctx, resp, http.StatusBadRequest, eventRequiredRespBody, nil)
+			return
+		}
+		if s, isString := ev.Event.(string); isString && s == "" {
+			r.failRequest(ctx, resp, http.StatusBadRequest, eventBlankRespBody, nil)
+			return
+		}
+		if ev.IsMetric() {
+			metricEvents = append(metricEvents, &ev)
+		} else {
+			events = append(events, &ev)
+		}
+	}
+
+	if len(events) == 0 && len(metricEvents) == 0 {
+		r.failRequest(ctx, resp, http.StatusBadRequest, noDataRespBody, nil)
+		return
+	}
+	if len(events) > 0 && r.logsConsumer == nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, invalidFormatRespBody, errNoLogsConsumer)
+		return
+	}
+	if len(metricEvents) > 0 && r.metricsConsumer == nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, invalidFormatRespBody, errNoMetricsConsum)
+		return
+	}
+
+	accessToken := r.accessToken(req)
+
+	if len(events) > 0 {
+		if err := r.consumeLogs(ctx, events, accessToken); err != nil {
+			r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerRespBody, err)
+			return
+		}
+	}
+	if len(metricEvents) > 0 {
+		if err := r.consumeMetrics(ctx, metricEvents, accessToken); err != nil {
+			if len(events) > 0 {
+				// The logs were already accepted, a retry by the client
+				// would duplicate them so a non retryable status is used.
+				r.logger.Error(
+					"Splunk HEC receiver failed consuming the metric events of a partially accepted request",
+					zap.Error(err),
+					zap.String("receiver", r.config.Name()))
+				r.failRequest(ctx, resp, http.StatusBadRequest, partialSuccessRespBody, err)
+				return
+			}
+			r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerRespBody, err)
+			return
+		}
+	}
+
+	r.writeSuccessResponse(resp)
+}
+
+// handleRawReq serves the /services/collector/raw endpoint. Each line of the
+// body becomes a log record, the event metadata is taken from the query
+// parameters.
+func (r *splunkReceiver) handleRawReq(resp http.ResponseWriter, req *http.Request) {
+	ctx := r.receiverContext(req)
+
+	bodyReader, ok := r.bodyReader(ctx, resp, req)
+	if !ok {
+		return
+	}
+
+	if r.logsConsumer == nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, invalidFormatRespBody, errNoLogsConsumer)
+		return
+	}
+
+	query := req.URL.Query()
+	var events []*splunk.Event
+	sc := bufio.NewScanner(bodyReader)
+	// The scanner allows tokens as large as the capacity of the initial
+	// buffer, so it must not exceed the maximum.
+	initialSize := bufio.MaxScanTokenSize
+	if r.config.MaxRawLineSize < initialSize {
+		initialSize = r.config.MaxRawLineSize
+	}
+	sc.Buffer(make([]byte, 0, initialSize), r.config.MaxRawLineSize)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		events = append(events, &splunk.Event{
+			Host:       query.Get(queryHost),
+			Source:     query.Get(querySource),
+			SourceType: query.Get(querySourcetype),
+			Index:      query.Get(queryIndex),
+			Event:      line,
+		})
+	}
+	if err := sc.Err(); err != nil {
+		r.failRequest(ctx, resp, http.StatusBadRequest, invalidFormatRespBody, err)
+		return
+	}
+
+	if len(events) == 0 {
+		r.failRequest(ctx, resp, http.StatusBadRequest, noDataRespBody, nil)
+		return
+	}
+
+	if err := r.consumeLogs(ctx, events, r.accessToken(req)); err != nil {
+		r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerRespBody, err)
+		return
+	}
+
+	r.writeSuccessResponse(resp)
+}
+
+// consumeLogs sends the log events to the logs pipeline. There is no logs
+// specific obsreport operation yet, so the operation is only traced.
+func (r *splunkReceiver) consumeLogs(ctx context.Context, events []*splunk.Event, accessToken string) error {
+	ctx, span := trace.StartSpan(ctx, "receiver/"+r.config.Name()+logsReceivedSpanSuffix)
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute(obsreport.TransportKey, r.transport()))
+
+	ld := splunkHecToLogData(r.logger, events, accessToken)
+	err := r.logsConsumer.ConsumeLogs(ctx, ld)
+
+	numAccepted, numRefused := len(events), 0
+	if err != nil {
+		numAccepted, numRefused = 0, len(events)
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+	span.AddAttributes(
+		trace.Int64Attribute(acceptedLogRecordsKey, int64(numAccepted)),
+		trace.Int64Attribute(refusedLogRecordsKey, int64(numRefused)))
+	return err
+}
+
+// consumeMetrics sends the metric events to the metrics pipeline within a
+// metrics receive operation.
+func (r *splunkReceiver) consumeMetrics(ctx context.Context, events []*splunk.Event, accessToken string) error {
+	ctx = obsreport.StartMetricsReceiveOp(ctx, r.config.Name(), r.transport())
+
+	md, numDropped := splunkHecToMetricsData(r.logger, events, accessToken)
+	numTimeSeries, numPoints := md.MetricAndDataPointCount()
+	err := r.metricsConsumer.ConsumeMetrics(ctx, md)
+
+	obsreport.EndMetricsReceiveOp(ctx, typeStr, numPoints+numDropped, numTimeSeries+numDropped, err)
+	return err
+}
+
+// accessToken returns the HEC token of the request if access token
+// passthrough is enabled, otherwise an empty string.
+func (r *splunkReceiver) accessToken(req *http.Request) string {
+	if !r.config.AccessTokenPassthrough {
+		return ""
+	}
+	auth := req.Header.Get(httpAuthorizationHeader)
+	if !strings.HasPrefix(auth, splunk.HECTokenHeader+" ") {
+		return ""
+	}
+	return strings.TrimSpace(auth[len(splunk.HECTokenHeader)+1:])
+}
+
+func (r *splunkReceiver) transport() string {
+	if r.config.TLSSetting != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// receiverContext returns the context of an incoming request holding the
+// keys used by the obsreport operations.
+func (r *splunkReceiver) receiverContext(req *http.Request) context.Context {
+	return obsreport.ReceiverContext(req.Context(), r.config.Name(), r.transport(), r.config.Name())
+}
+
+func (r *splunkReceiver) writeSuccessResponse(resp http.ResponseWriter) {
+	resp.Header().Set(httpContentTypeHeader, httpJSONTypeHeader)
+	resp.WriteHeader(http.StatusOK)
+	if _, writeErr := resp.Write(okRespBody); writeErr != nil {
+		r.logger.Warn(
+			"Error writing HTTP response message",
+			zap.Error(writeErr),
+			zap.String("receiver", r.config.Name()))
+	}
+}
+
+func (r *splunkReceiver) failRequest(
+	ctx context.Context,
+	resp http.ResponseWriter,
+	httpStatusCode int,
+	jsonResponse []byte,
+	err error,
+) {
+	resp.Header().Set(httpContentTypeHeader, httpJSONTypeHeader)
+	resp.WriteHeader(httpStatusCode)
+	if len(jsonResponse) > 0 {
+		_, writeErr := resp.Write(jsonResponse)
+		if writeErr != nil {
+			r.logger.Warn(
+				"Error writing HTTP response message",
+				zap.Error(writeErr),
+				zap.String("receiver", r.config.Name()))
+		}
+	}
+
+	msg := string(jsonResponse)
+
+	// The span of the request, if any, is owned by the caller so it is only
+	// annotated here.
+	reqSpan := trace.FromContext(ctx)
+	reqSpan.AddAttributes(
+		trace.Int64Attribute(conventions.AttributeHTTPStatusCode, int64(httpStatusCode)),
+		trace.StringAttribute(conventions.AttributeHTTPStatusText, msg))
+	traceStatus := trace.Status{
+		Code: trace.StatusCodeInvalidArgument,
+	}
+	if httpStatusCode == http.StatusInternalServerError {
+		traceStatus.Code = trace.StatusCodeInternal
+	}
+	if err != nil {
+		traceStatus.Message = err.Error()
+	}
+	reqSpan.SetStatus(traceStatus)
+
+	r.logger.Debug(
+		"Splunk HEC receiver request failed",
+		zap.Int("http_status_code", httpStatusCode),
+		zap.String("msg", msg),
+		zap.Error(err), // It handles nil error
+		zap.String("receiver", r.config.Name()))
+}
+
+// initJSONResponse builds a HEC compatible response body.
+func initJSONResponse(code int, text string) []byte {
+	respBody, err := json.Marshal(map[string]interface{}{
+		"text": text,
+		"code": code,
+	})
+	if err != nil {
+		// This is to be used in initialization so panic here is fine.
+		panic(err)
+	}
+	return respBody
+}
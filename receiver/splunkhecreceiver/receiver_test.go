@@ -0,0 +1,445 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/testutil"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
+)
+
+func Test_splunkhecreceiver_New(t *testing.T) {
+	defaultConfig := createDefaultConfig().(*Config)
+	tests := []struct {
+		name         string
+		config       Config
+		metrics      bool
+		logs         bool
+		wantStartErr error
+	}{
+		{
+			name:         "nil_nextConsumer",
+			config:       *defaultConfig,
+			wantStartErr: errNilNextConsumer,
+		},
+		{
+			name:    "metrics_only",
+			config:  Config{HTTPServerSettings: defaultConfig.HTTPServerSettings},
+			metrics: true,
+		},
+		{
+			name:   "logs_only",
+			config: Config{HTTPServerSettings: defaultConfig.HTTPServerSettings},
+			logs:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Endpoint = fmt.Sprintf("localhost:%d", testutil.GetAvailablePort(t))
+			got := newReceiver(zap.NewNop(), tt.config)
+			if tt.metrics {
+				got.RegisterMetricsConsumer(exportertest.NewNopMetricsExporter())
+			}
+			if tt.logs {
+				got.RegisterLogsConsumer(exportertest.NewNopLogsExporter())
+			}
+			err := got.Start(context.Background(), componenttest.NewNopHost())
+			assert.Equal(t, tt.wantStartErr, err)
+			assert.NoError(t, got.Shutdown(context.Background()))
+		})
+	}
+}
+
+func Test_splunkhecreceiver_EndToEnd(t *testing.T) {
+	port := testutil.GetAvailablePort(t)
+	addr := fmt.Sprintf("localhost:%d", port)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = addr
+	cfg.AccessTokenPassthrough = true
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	logsSink := new(exportertest.SinkLogsExporter)
+	r := newReceiver(zap.NewNop(), *cfg)
+	r.RegisterMetricsConsumer(metricsSink)
+	r.RegisterLogsConsumer(logsSink)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	runtime.Gosched()
+	defer r.Shutdown(context.Background())
+	require.Equal(t, componenterror.ErrAlreadyStarted, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	expCfg := &splunkhecexporter.Config{
+		Endpoint: "http://" + addr + hecPath,
+		Token:    "access_token",
+		Source:   "test_source",
+		Index:    "test_index",
+	}
+	exp, err := splunkhecexporter.NewFactory().CreateLogsExporter(
+		context.Background(),
+		component.ExporterCreateParams{Logger: zap.NewNop()},
+		expCfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, testutil.WaitForPort(t, port))
+	defer exp.Shutdown(context.Background())
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	ill := ld.ResourceLogs().At(0).InstrumentationLibraryLogs()
+	ill.Resize(1)
+	lrs := ill.At(0).Logs()
+	lrs.Resize(1)
+	lr := lrs.At(0)
+	lr.SetTimestamp(pdata.TimestampUnixNano(time.Unix(1574092046, int64(11*time.Millisecond)).UnixNano()))
+	lr.Body().SetStringVal("the message")
+	lr.Attributes().InsertString(conventions.AttributeHostHostname, "myhost")
+	lr.Attributes().InsertString("custom", "value")
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+
+	lds := logsSink.AllLogs()
+	require.Len(t, lds, 1)
+	require.Equal(t, 1, lds[0].LogRecordCount())
+	got := lds[0].ResourceLogs().At(0)
+	token, ok := got.Resource().Attributes().Get(splunk.HecTokenLabel)
+	require.True(t, ok)
+	assert.Equal(t, "access_token", token.StringVal())
+
+	gotLr := got.InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, lr.Timestamp(), gotLr.Timestamp())
+	assert.Equal(t, "the message", gotLr.Body().StringVal())
+	for k, v := range map[string]string{
+		conventions.AttributeHostHostname: "myhost",
+		conventions.AttributeServiceName:  "test_source",
+		splunk.IndexLabel:                 "test_index",
+		"custom":                          "value",
+	} {
+		attr, ok := gotLr.Attributes().Get(k)
+		require.True(t, ok, k)
+		assert.Equal(t, v, attr.StringVal(), k)
+	}
+	assert.Empty(t, metricsSink.AllMetrics())
+
+	assert.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, componenterror.ErrAlreadyStopped, r.Shutdown(context.Background()))
+}
+
+func Test_splunkhecReceiver_handleReq(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+
+	logEvent := `{"time":1574092046.011,"host":"myhost","source":"mysource","event":"hello world","fields":{"k":"v"}}`
+	metricEvent := `{"time":1574092046.011,"host":"myhost","event":"metric","fields":{"metric_name:cpu":12.5,"region":"us"}}`
+
+	tests := []struct {
+		name           string
+		req            *http.Request
+		assertResponse func(t *testing.T, status int, body string)
+		assertSinks    func(t *testing.T, metrics *exportertest.SinkMetricsExporter, logs *exportertest.SinkLogsExporter)
+	}{
+		{
+			name: "incorrect_method",
+			req:  httptest.NewRequest("PUT", "http://localhost/services/collector", nil),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, string(invalidFormatRespBody), body)
+			},
+		},
+		{
+			name: "incorrect_content_encoding",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost/services/collector", strings.NewReader(logEvent))
+				req.Header.Set("Content-Encoding", "superzipper")
+				return req
+			}(),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusUnsupportedMediaType, status)
+				assert.Equal(t, string(invalidFormatRespBody), body)
+			},
+		},
+		{
+			name: "bad_data_in_body",
+			req:  httptest.NewRequest("POST", "http://localhost/services/collector", strings.NewReader("not json")),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, string(invalidFormatRespBody), body)
+			},
+		},
+		{
+			name: "empty_body",
+			req:  httptest.NewRequest("POST", "http://localhost/services/collector", bytes.NewReader(nil)),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, string(noDataRespBody), body)
+			},
+		},
+		{
+			name: "missing_event",
+			req:  httptest.NewRequest("POST", "http://localhost/services/collector", strings.NewReader(`{"host":"myhost"}`)),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, string(eventRequiredRespBody), body)
+			},
+		},
+		{
+			name: "blank_event",
+			req:  httptest.NewRequest("POST", "http://localhost/services/collector", strings.NewReader(`{"event":""}`)),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, string(eventBlankRespBody), body)
+			},
+		},
+		{
+			name: "mixed_events",
+			req:  httptest.NewRequest("POST", "http://localhost/services/collector/event", strings.NewReader(logEvent+"\n"+metricEvent+logEvent)),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusOK, status)
+				assert.Equal(t, string(okRespBody), body)
+			},
+			assertSinks: func(t *testing.T, metrics *exportertest.SinkMetricsExporter, logs *exportertest.SinkLogsExporter) {
+				assert.Equal(t, 2, logs.LogRecordsCount())
+				assert.Equal(t, 1, metrics.MetricsCount())
+			},
+		},
+		{
+			name: "msg_accepted_gzipped",
+			req: func() *http.Request {
+				var buf bytes.Buffer
+				gzipWriter := gzip.NewWriter(&buf)
+				_, err := gzipWriter.Write([]byte(metricEvent))
+				require.NoError(t, err)
+				require.NoError(t, gzipWriter.Close())
+				req := httptest.NewRequest("POST", "http://localhost/services/collector", &buf)
+				req.Header.Set("Content-Encoding", "gzip")
+				return req
+			}(),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusOK, status)
+				assert.Equal(t, string(okRespBody), body)
+			},
+			assertSinks: func(t *testing.T, metrics *exportertest.SinkMetricsExporter, logs *exportertest.SinkLogsExporter) {
+				assert.Equal(t, 0, logs.LogRecordsCount())
+				assert.Equal(t, 1, metrics.MetricsCount())
+			},
+		},
+		{
+			name: "bad_gzipped_msg",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost/services/collector", strings.NewReader(logEvent))
+				req.Header.Set("Content-Encoding", "gzip")
+				return req
+			}(),
+			assertResponse: func(t *testing.T, status int, body string) {
+				assert.Equal(t, http.StatusBadRequest, status)
+				assert.Equal(t, string(invalidFormatRespBody), body)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metricsSink := new(exportertest.SinkMetricsExporter)
+			logsSink := new(exportertest.SinkLogsExporter)
+			rcv := newReceiver(zap.NewNop(), *config)
+			rcv.RegisterMetricsConsumer(metricsSink)
+			rcv.RegisterLogsConsumer(logsSink)
+
+			w := httptest.NewRecorder()
+			rcv.handleReq(w, tt.req)
+
+			resp := w.Result()
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			tt.assertResponse(t, resp.StatusCode, string(respBytes))
+			if tt.assertSinks != nil {
+				tt.assertSinks(t, metricsSink, logsSink)
+			}
+		})
+	}
+}
+
+func Test_splunkhecReceiver_handleReq_missingPipeline(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	rcv := newReceiver(zap.NewNop(), *config)
+	rcv.RegisterMetricsConsumer(new(exportertest.SinkMetricsExporter))
+
+	w := httptest.NewRecorder()
+	rcv.handleReq(w, httptest.NewRequest("POST", "http://localhost/services/collector", strings.NewReader(`{"event":"a log"}`)))
+
+	resp := w.Result()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, string(invalidFormatRespBody), string(respBytes))
+}
+
+func Test_splunkhecReceiver_handleReq_nextConsumerError(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	sink := new(exportertest.SinkLogsExporter)
+	sink.SetConsumeLogError(errors.New("boom"))
+	rcv := newReceiver(zap.NewNop(), *config)
+	rcv.RegisterLogsConsumer(sink)
+
+	w := httptest.NewRecorder()
+	rcv.handleReq(w, httptest.NewRequest("POST", "http://localhost/services/collector", strings.NewReader(`{"event":"a log"}`)))
+
+	resp := w.Result()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, string(errInternalServerRespBody), string(respBytes))
+}
+
+func Test_splunkhecReceiver_handleReq_partialSuccess(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	logsSink := new(exportertest.SinkLogsExporter)
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	metricsSink.SetConsumeMetricsError(errors.New("boom"))
+	rcv := newReceiver(zap.NewNop(), *config)
+	rcv.RegisterLogsConsumer(logsSink)
+	rcv.RegisterMetricsConsumer(metricsSink)
+
+	body := `{"event":"a log"}{"event":"metric","fields":{"metric_name:cpu":1}}`
+	w := httptest.NewRecorder()
+	rcv.handleReq(w, httptest.NewRequest("POST", "http://localhost/services/collector", strings.NewReader(body)))
+
+	resp := w.Result()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	// The logs were accepted so the client must not retry.
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, string(partialSuccessRespBody), string(respBytes))
+	assert.Equal(t, 1, logsSink.LogRecordsCount())
+}
+
+func Test_splunkhecReceiver_handleReq_stringTime(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	sink := new(exportertest.SinkLogsExporter)
+	rcv := newReceiver(zap.NewNop(), *config)
+	rcv.RegisterLogsConsumer(sink)
+
+	w := httptest.NewRecorder()
+	rcv.handleReq(w, httptest.NewRequest("POST", "http://localhost/services/collector",
+		strings.NewReader(`{"time":"1574092046.011","event":"a log"}`)))
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	require.Equal(t, 1, sink.LogRecordsCount())
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0)
+	assert.Equal(t, pdata.TimestampUnixNano(1574092046011000000), lr.Timestamp())
+}
+
+func Test_splunkhecReceiver_handleRawReq(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = "localhost:0" // Actually not creating the endpoint
+	config.AccessTokenPassthrough = true
+	config.MaxRawLineSize = 64
+
+	tests := []struct {
+		name           string
+		req            *http.Request
+		wantStatus     int
+		wantBody       []byte
+		wantLogRecords int
+	}{
+		{
+			name:       "incorrect_method",
+			req:        httptest.NewRequest("GET", "http://localhost/services/collector/raw", nil),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   invalidFormatRespBody,
+		},
+		{
+			name:       "empty_body",
+			req:        httptest.NewRequest("POST", "http://localhost/services/collector/raw", strings.NewReader("\n\n")),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   noDataRespBody,
+		},
+		{
+			name: "lines",
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost/services/collector/raw?host=myhost&sourcetype=syslog",
+					strings.NewReader("first line\nsecond line\n"))
+				req.Header.Set("Authorization", "Splunk my_token")
+				return req
+			}(),
+			wantStatus:     http.StatusOK,
+			wantBody:       okRespBody,
+			wantLogRecords: 2,
+		},
+		{
+			name:       "line_too_long",
+			req:        httptest.NewRequest("POST", "http://localhost/services/collector/raw", strings.NewReader(strings.Repeat("a", 128)+"\n")),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   invalidFormatRespBody,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(exportertest.SinkLogsExporter)
+			rcv := newReceiver(zap.NewNop(), *config)
+			rcv.RegisterLogsConsumer(sink)
+
+			w := httptest.NewRecorder()
+			rcv.handleRawReq(w, tt.req)
+
+			resp := w.Result()
+			respBytes, err := ioutil.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, string(tt.wantBody), string(respBytes))
+			assert.Equal(t, tt.wantLogRecords, sink.LogRecordsCount())
+			if tt.wantLogRecords == 0 {
+				return
+			}
+
+			rl := sink.AllLogs()[0].ResourceLogs().At(0)
+			token, ok := rl.Resource().Attributes().Get(splunk.HecTokenLabel)
+			require.True(t, ok)
+			assert.Equal(t, "my_token", token.StringVal())
+			lr := rl.InstrumentationLibraryLogs().At(0).Logs().At(1)
+			assert.Equal(t, "second line", lr.Body().StringVal())
+			host, ok := lr.Attributes().Get(conventions.AttributeHostHostname)
+			require.True(t, ok)
+			assert.Equal(t, "myhost", host.StringVal())
+			sourcetype, ok := lr.Attributes().Get(splunk.SourcetypeLabel)
+			require.True(t, ok)
+			assert.Equal(t, "syslog", sourcetype.StringVal())
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
+)
+
+// splunkHecToLogData converts Splunk HEC events to pdata.Logs. The host,
+// source, sourcetype and index of each event are set as log record
+// attributes, using the same keys the Splunk HEC exporter reads them from.
+func splunkHecToLogData(logger *zap.Logger, events []*splunk.Event, accessToken string) pdata.Logs {
+	ld := pdata.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rl := rls.At(0)
+
+	if accessToken != "" {
+		resource := rl.Resource()
+		resource.InitEmpty()
+		resource.Attributes().InsertString(splunk.HecTokenLabel, accessToken)
+	}
+
+	ills := rl.InstrumentationLibraryLogs()
+	ills.Resize(1)
+	ill := ills.At(0)
+
+	lrs := ill.Logs()
+	lrs.Resize(len(events))
+	for i, event := range events {
+		lr := lrs.At(i)
+		lr.InitEmpty()
+
+		lr.SetTimestamp(epochSecondsToTimestamp(float64(event.Time)))
+		convertInterfaceToAttributeValue(logger, event.Event).CopyTo(lr.Body())
+
+		attrs := lr.Attributes()
+		attrs.InitEmptyWithCapacity(4 + len(event.Fields))
+		if event.Host != "" {
+			attrs.InsertString(conventions.AttributeHostHostname, event.Host)
+		}
+		if event.Source != "" {
+			attrs.InsertString(conventions.AttributeServiceName, event.Source)
+		}
+		if event.SourceType != "" {
+			attrs.InsertString(splunk.SourcetypeLabel, event.SourceType)
+		}
+		if event.Index != "" {
+			attrs.InsertString(splunk.IndexLabel, event.Index)
+		}
+		for key, val := range event.Fields {
+			attrs.Insert(key, convertInterfaceToAttributeValue(logger, val))
+		}
+		attrs.Sort()
+	}
+
+	return ld
+}
+
+// epochSecondsToTimestamp converts HEC <sec>.<ms> epoch times to
+// pdata.TimestampUnixNano. A zero time is kept as zero, meaning unknown.
+func epochSecondsToTimestamp(t float64) pdata.TimestampUnixNano {
+	return pdata.TimestampUnixNano(time.Duration(t * float64(time.Second)).Round(time.Millisecond))
+}
+
+func convertInterfaceToAttributeValue(logger *zap.Logger, originalValue interface{}) pdata.AttributeValue {
+	if originalValue == nil {
+		return pdata.NewAttributeValueNull()
+	}
+
+	switch value := originalValue.(type) {
+	case string:
+		return pdata.NewAttributeValueString(value)
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return pdata.NewAttributeValueInt(i)
+		}
+		if f, err := value.Float64(); err == nil {
+			return pdata.NewAttributeValueDouble(f)
+		}
+		return pdata.NewAttributeValueString(value.String())
+	case int64:
+		return pdata.NewAttributeValueInt(value)
+	case float64:
+		return pdata.NewAttributeValueDouble(value)
+	case bool:
+		return pdata.NewAttributeValueBool(value)
+	case map[string]interface{}:
+		mapValue := pdata.NewAttributeMap()
+		mapValue.InitEmptyWithCapacity(len(value))
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			mapValue.Insert(k, convertInterfaceToAttributeValue(logger, value[k]))
+		}
+		attrValue := pdata.NewAttributeValueMap()
+		attrValue.SetMapVal(mapValue)
+		return attrValue
+	case []interface{}:
+		arrValue := pdata.NewAnyValueArray()
+		for _, elt := range value {
+			arrValue.Append(convertInterfaceToAttributeValue(logger, elt))
+		}
+		attrValue := pdata.NewAttributeValueArray()
+		attrValue.SetArrayVal(arrValue)
+		return attrValue
+	default:
+		logger.Debug("Unsupported value conversion", zap.Any("value", originalValue))
+		return pdata.NewAttributeValueNull()
+	}
+}
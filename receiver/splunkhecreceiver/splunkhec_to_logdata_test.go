@@ -0,0 +1,143 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
+)
+
+func Test_SplunkHecToLogData(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       splunk.Event
+		accessToken string
+		output      pdata.Logs
+	}{
+		{
+			name: "happy_path",
+			event: splunk.Event{
+				Time:       0.123,
+				Host:       "localhost",
+				Source:     "mysource",
+				SourceType: "mysourcetype",
+				Index:      "myindex",
+				Event:      "value",
+				Fields: map[string]interface{}{
+					"foo": "bar",
+				},
+			},
+			output: func() pdata.Logs {
+				logsSlice := createLogsSlice("value")
+				return logsSlice
+			}(),
+		},
+		{
+			name: "numeric_and_structured_body",
+			event: splunk.Event{
+				Time:       0.123,
+				Host:       "localhost",
+				Source:     "mysource",
+				SourceType: "mysourcetype",
+				Index:      "myindex",
+				Event: map[string]interface{}{
+					"foos": []interface{}{"foo", "bar", "foobar"},
+					"int":  json.Number("12"),
+					"dbl":  json.Number("1.5"),
+					"bool": false,
+					"null": nil,
+				},
+				Fields: map[string]interface{}{
+					"foo": "bar",
+				},
+			},
+			output: func() pdata.Logs {
+				logsSlice := createLogsSlice("value")
+				arr := pdata.NewAnyValueArray()
+				arr.Append(pdata.NewAttributeValueString("foo"))
+				arr.Append(pdata.NewAttributeValueString("bar"))
+				arr.Append(pdata.NewAttributeValueString("foobar"))
+				arrVal := pdata.NewAttributeValueArray()
+				arrVal.SetArrayVal(arr)
+				attMap := pdata.NewAttributeMap()
+				attMap.Insert("bool", pdata.NewAttributeValueBool(false))
+				attMap.Insert("dbl", pdata.NewAttributeValueDouble(1.5))
+				attMap.Insert("foos", arrVal)
+				attMap.Insert("int", pdata.NewAttributeValueInt(12))
+				attMap.Insert("null", pdata.NewAttributeValueNull())
+				body := logsSlice.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body()
+				body.SetMapVal(attMap)
+				return logsSlice
+			}(),
+		},
+		{
+			name: "access_token",
+			event: splunk.Event{
+				Time:       0.123,
+				Host:       "localhost",
+				Source:     "mysource",
+				SourceType: "mysourcetype",
+				Index:      "myindex",
+				Event:      "value",
+				Fields: map[string]interface{}{
+					"foo": "bar",
+				},
+			},
+			accessToken: "mytoken",
+			output: func() pdata.Logs {
+				logsSlice := createLogsSlice("value")
+				resource := logsSlice.ResourceLogs().At(0).Resource()
+				resource.InitEmpty()
+				resource.Attributes().InsertString(splunk.HecTokenLabel, "mytoken")
+				return logsSlice
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := splunkHecToLogData(zap.NewNop(), []*splunk.Event{&tt.event}, tt.accessToken)
+			assert.EqualValues(t, tt.output, result)
+		})
+	}
+}
+
+func createLogsSlice(body string) pdata.Logs {
+	lrs := pdata.NewLogs()
+	lrs.ResourceLogs().Resize(1)
+	lr := lrs.ResourceLogs().At(0)
+	lr.InstrumentationLibraryLogs().Resize(1)
+	ill := lr.InstrumentationLibraryLogs().At(0)
+	ill.Logs().Resize(1)
+	logRecord := ill.Logs().At(0)
+
+	logRecord.SetTimestamp(pdata.TimestampUnixNano(123000000))
+	logRecord.Body().SetStringVal(body)
+	attrs := logRecord.Attributes()
+	attrs.InsertString("foo", "bar")
+	attrs.InsertString(conventions.AttributeHostHostname, "localhost")
+	attrs.InsertString(splunk.IndexLabel, "myindex")
+	attrs.InsertString(conventions.AttributeServiceName, "mysource")
+	attrs.InsertString(splunk.SourcetypeLabel, "mysourcetype")
+	attrs.Sort()
+
+	return lrs
+}
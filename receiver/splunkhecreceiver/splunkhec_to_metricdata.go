@@ -0,0 +1,181 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
+)
+
+// resourceKey identifies the resource a HEC metric event belongs to.
+type resourceKey struct {
+	host       string
+	source     string
+	sourceType string
+	index      string
+}
+
+// splunkHecToMetricsData converts Splunk HEC metric events to pdata.Metrics.
+// Every "metric_name:<name>" field of an event becomes a gauge named <name>,
+// the remaining fields become labels of the data point. Events are grouped
+// into resources by host, source, sourcetype and index. Returns the converted
+// data and the number of dropped data points.
+func splunkHecToMetricsData(logger *zap.Logger, events []*splunk.Event, accessToken string) (pdata.Metrics, int) {
+	numDroppedTimeSeries := 0
+	md := pdata.NewMetrics()
+	rms := md.ResourceMetrics()
+	resourceIndexes := map[resourceKey]int{}
+
+	for _, event := range events {
+		key := resourceKey{
+			host:       event.Host,
+			source:     event.Source,
+			sourceType: event.SourceType,
+			index:      event.Index,
+		}
+		idx, ok := resourceIndexes[key]
+		if !ok {
+			idx = rms.Len()
+			resourceIndexes[key] = idx
+			rms.Resize(idx + 1)
+			rm := rms.At(idx)
+			initResource(rm.Resource(), key, accessToken)
+			rm.InstrumentationLibraryMetrics().Resize(1)
+		}
+		metrics := rms.At(idx).InstrumentationLibraryMetrics().At(0).Metrics()
+
+		ts := epochSecondsToTimestamp(float64(event.Time))
+		if ts == 0 {
+			ts = pdata.TimestampUnixNano(time.Now().UnixNano())
+		}
+		labels := buildLabels(event.Fields)
+
+		values := event.GetMetricValues()
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			metric := pdata.NewMetric()
+			metric.InitEmpty()
+			metric.SetName(name)
+			if !setMetricValue(metric, values[name], ts, labels) {
+				logger.Debug(
+					"Unsupported metric value",
+					zap.String("metric", name),
+					zap.Any("value", values[name]))
+				numDroppedTimeSeries++
+				continue
+			}
+			metrics.Append(metric)
+		}
+	}
+
+	return md, numDroppedTimeSeries
+}
+
+func initResource(resource pdata.Resource, key resourceKey, accessToken string) {
+	resource.InitEmpty()
+	attrs := resource.Attributes()
+	if key.host != "" {
+		attrs.InsertString(conventions.AttributeHostHostname, key.host)
+	}
+	if key.source != "" {
+		attrs.InsertString(conventions.AttributeServiceName, key.source)
+	}
+	if key.sourceType != "" {
+		attrs.InsertString(splunk.SourcetypeLabel, key.sourceType)
+	}
+	if key.index != "" {
+		attrs.InsertString(splunk.IndexLabel, key.index)
+	}
+	if accessToken != "" {
+		attrs.InsertString(splunk.HecTokenLabel, accessToken)
+	}
+}
+
+// buildLabels converts all the non metric value fields of an event to labels.
+func buildLabels(fields map[string]interface{}) map[string]string {
+	labels := make(map[string]string, len(fields))
+	for k, v := range fields {
+		if strings.HasPrefix(k, splunk.HecMetricNamePrefix) {
+			continue
+		}
+		switch val := v.(type) {
+		case string:
+			labels[k] = val
+		case nil:
+			labels[k] = ""
+		default:
+			labels[k] = fmt.Sprint(val)
+		}
+	}
+	return labels
+}
+
+// setMetricValue sets the data of the metric to a single gauge point holding
+// the given value. Integer values produce an int gauge and all other numeric
+// values a double gauge. Returns false if the value is not numeric.
+func setMetricValue(metric pdata.Metric, value interface{}, ts pdata.TimestampUnixNano, labels map[string]string) bool {
+	var str string
+	switch val := value.(type) {
+	case json.Number:
+		str = val.String()
+	case string:
+		str = val
+	case float64:
+		str = strconv.FormatFloat(val, 'g', -1, 64)
+	default:
+		return false
+	}
+
+	if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+		metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		gauge := metric.IntGauge()
+		gauge.InitEmpty()
+		gauge.DataPoints().Resize(1)
+		dp := gauge.DataPoints().At(0)
+		dp.SetTimestamp(ts)
+		dp.SetValue(i)
+		dp.LabelsMap().InitFromMap(labels)
+		return true
+	}
+
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return false
+	}
+	metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+	gauge := metric.DoubleGauge()
+	gauge.InitEmpty()
+	gauge.DataPoints().Resize(1)
+	dp := gauge.DataPoints().At(0)
+	dp.SetTimestamp(ts)
+	dp.SetValue(f)
+	dp.LabelsMap().InitFromMap(labels)
+	return true
+}
@@ -0,0 +1,163 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/splunk"
+)
+
+func Test_splunkV2ToMetricsData(t *testing.T) {
+	// Timestamps for HEC are in seconds with millisecond precision.
+	sec := splunk.EventTime(1574092046)
+	nanos := pdata.TimestampUnixNano(time.Unix(1574092046, int64(11*time.Millisecond)).UnixNano())
+
+	buildDefaultEvent := func() *splunk.Event {
+		return &splunk.Event{
+			Time:       sec + 0.011,
+			Host:       "localhost",
+			Source:     "source",
+			SourceType: "sourcetype",
+			Index:      "index",
+			Event:      "metric",
+			Fields: map[string]interface{}{
+				"metric_name:single": json.Number("13"),
+				"k0":                 "v0",
+				"k1":                 "v1",
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		events      []*splunk.Event
+		accessToken string
+		assertFunc  func(t *testing.T, md pdata.Metrics)
+		wantDropped int
+	}{
+		{
+			name:   "int_gauge",
+			events: []*splunk.Event{buildDefaultEvent()},
+			assertFunc: func(t *testing.T, md pdata.Metrics) {
+				require.Equal(t, 1, md.ResourceMetrics().Len())
+				rm := md.ResourceMetrics().At(0)
+				attrs := rm.Resource().Attributes()
+				assert.Equal(t, 4, attrs.Len())
+				for k, v := range map[string]string{
+					conventions.AttributeHostHostname: "localhost",
+					conventions.AttributeServiceName:  "source",
+					splunk.SourcetypeLabel:            "sourcetype",
+					splunk.IndexLabel:                 "index",
+				} {
+					attr, ok := attrs.Get(k)
+					require.True(t, ok, k)
+					assert.Equal(t, v, attr.StringVal())
+				}
+
+				metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+				require.Equal(t, 1, metrics.Len())
+				m := metrics.At(0)
+				assert.Equal(t, "single", m.Name())
+				require.Equal(t, pdata.MetricDataTypeIntGauge, m.DataType())
+				dp := m.IntGauge().DataPoints().At(0)
+				assert.Equal(t, int64(13), dp.Value())
+				assert.Equal(t, nanos, dp.Timestamp())
+				assert.Equal(t, 2, dp.LabelsMap().Len())
+				v, ok := dp.LabelsMap().Get("k0")
+				require.True(t, ok)
+				assert.Equal(t, "v0", v.Value())
+			},
+		},
+		{
+			name: "double_gauges",
+			events: func() []*splunk.Event {
+				ev := buildDefaultEvent()
+				ev.Fields["metric_name:single"] = json.Number("13.13")
+				ev.Fields["metric_name:other"] = "1.5"
+				return []*splunk.Event{ev}
+			}(),
+			assertFunc: func(t *testing.T, md pdata.Metrics) {
+				metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+				require.Equal(t, 2, metrics.Len())
+				assert.Equal(t, "other", metrics.At(0).Name())
+				assert.Equal(t, 1.5, metrics.At(0).DoubleGauge().DataPoints().At(0).Value())
+				assert.Equal(t, "single", metrics.At(1).Name())
+				assert.Equal(t, 13.13, metrics.At(1).DoubleGauge().DataPoints().At(0).Value())
+			},
+		},
+		{
+			name: "grouped_by_resource",
+			events: func() []*splunk.Event {
+				ev0 := buildDefaultEvent()
+				ev1 := buildDefaultEvent()
+				ev2 := buildDefaultEvent()
+				ev2.Host = "otherhost"
+				return []*splunk.Event{ev0, ev1, ev2}
+			}(),
+			accessToken: "token",
+			assertFunc: func(t *testing.T, md pdata.Metrics) {
+				require.Equal(t, 2, md.ResourceMetrics().Len())
+				assert.Equal(t, 2, md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+				assert.Equal(t, 1, md.ResourceMetrics().At(1).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+				token, ok := md.ResourceMetrics().At(1).Resource().Attributes().Get(splunk.HecTokenLabel)
+				require.True(t, ok)
+				assert.Equal(t, "token", token.StringVal())
+			},
+		},
+		{
+			name: "invalid_value_dropped",
+			events: func() []*splunk.Event {
+				ev := buildDefaultEvent()
+				ev.Fields["metric_name:bad"] = "not a number"
+				ev.Fields["metric_name:worse"] = map[string]interface{}{}
+				return []*splunk.Event{ev}
+			}(),
+			assertFunc: func(t *testing.T, md pdata.Metrics) {
+				metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+				require.Equal(t, 1, metrics.Len())
+				assert.Equal(t, "single", metrics.At(0).Name())
+			},
+			wantDropped: 2,
+		},
+		{
+			name: "missing_time",
+			events: func() []*splunk.Event {
+				ev := buildDefaultEvent()
+				ev.Time = 0
+				return []*splunk.Event{ev}
+			}(),
+			assertFunc: func(t *testing.T, md pdata.Metrics) {
+				dp := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).IntGauge().DataPoints().At(0)
+				assert.NotZero(t, dp.Timestamp())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, numDropped := splunkHecToMetricsData(zap.NewNop(), tt.events, tt.accessToken)
+			assert.Equal(t, tt.wantDropped, numDropped)
+			tt.assertFunc(t, md)
+		})
+	}
+}
@@ -15,12 +15,40 @@
 package statsdreceiver
 
 import (
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/confignet"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
 // Config defines configuration for StatsD receiver.
 type Config struct {
 	configmodels.ReceiverSettings `mapstructure:",squash"`
 	NetAddr                       confignet.NetAddr `mapstructure:",squash"`
+
+	// MaxLineSize is the maximum size in bytes of a single StatsD message.
+	// Longer messages are dropped.
+	MaxLineSize int `mapstructure:"max_line_size"`
+
+	// IdleTimeout is the maximum duration that a connection will idle wait
+	// for new data. It is ignored by the "udp" and "unixgram" transports.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// AggregationInterval is the interval at which the aggregated metrics
+	// are flushed to the next consumer.
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval"`
+
+	// TimerHistogramMapping defines how timers, histograms and distributions
+	// are reported.
+	TimerHistogramMapping protocol.ObserverConfig `mapstructure:"timer_histogram_mapping"`
+}
+
+func (c *Config) validate() error {
+	if c.AggregationInterval <= 0 {
+		return fmt.Errorf("aggregation_interval must be positive, got %v", c.AggregationInterval)
+	}
+	return c.TimerHistogramMapping.Validate()
 }
@@ -17,6 +17,7 @@ package statsdreceiver
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +25,8 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -54,5 +57,12 @@ func TestLoadConfig(t *testing.T) {
 			Endpoint:  "localhost:12345",
 			Transport: "custom_transport",
 		},
+		MaxLineSize:         1024,
+		IdleTimeout:         5 * time.Second,
+		AggregationInterval: 70 * time.Second,
+		TimerHistogramMapping: protocol.ObserverConfig{
+			Type:    protocol.HistogramObserver,
+			Buckets: []float64{10, 100, 1000},
+		},
 	}, r1)
 }
@@ -16,12 +16,16 @@ package statsdreceiver
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
 )
 
 const (
@@ -29,6 +33,8 @@ const (
 	typeStr             = "statsd"
 	defaultBindEndpoint = "localhost:8125"
 	defaultTransport    = "udp"
+
+	defaultAggregationInterval = 60 * time.Second
 )
 
 // NewFactory creates a factory for the StatsD receiver.
@@ -50,6 +56,12 @@ func createDefaultConfig() configmodels.Receiver {
 			Endpoint:  defaultBindEndpoint,
 			Transport: defaultTransport,
 		},
+		MaxLineSize:         transport.MaxLineSizeDefault,
+		IdleTimeout:         transport.IdleTimeoutDefault,
+		AggregationInterval: defaultAggregationInterval,
+		TimerHistogramMapping: protocol.ObserverConfig{
+			Type: protocol.HistogramObserver,
+		},
 	}
 }
 
@@ -3,10 +3,8 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsd
 go 1.14
 
 require (
-	github.com/census-instrumentation/opencensus-proto v0.3.0
 	github.com/stretchr/testify v1.6.1
 	go.opencensus.io v0.22.4
 	go.opentelemetry.io/collector v0.11.1-0.20201006165100-07236c11fb27
 	go.uber.org/zap v1.16.0
-	google.golang.org/protobuf v1.25.0
 )
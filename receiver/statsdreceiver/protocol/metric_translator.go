@@ -0,0 +1,270 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	quantileLabel = "quantile"
+	countSuffix   = ".count"
+	sumSuffix     = ".sum"
+)
+
+// timeInterval is the aggregation interval the reported metrics cover.
+type timeInterval struct {
+	start pdata.TimestampUnixNano
+	end   pdata.TimestampUnixNano
+}
+
+// seriesState is the aggregation state of a single series.
+type seriesState interface {
+	// appendMetrics appends the metrics aggregated during the interval to
+	// the slice, returning whether the state must be kept for the next
+	// interval.
+	appendMetrics(metrics pdata.MetricSlice, observer ObserverConfig, interval timeInterval) bool
+}
+
+type metricState struct {
+	name   string
+	labels map[string]string
+}
+
+func newMetricState(parsedMetric *statsDMetric) metricState {
+	return metricState{
+		name:   parsedMetric.name,
+		labels: parsedMetric.labels(),
+	}
+}
+
+type counterState struct {
+	metricState
+	value    float64
+	isDouble bool
+}
+
+// appendMetrics reports the counter as a monotonic delta sum.
+func (s *counterState) appendMetrics(metrics pdata.MetricSlice, _ ObserverConfig, interval timeInterval) bool {
+	metric := newMetric(s.name)
+	if s.isDouble {
+		metric.SetDataType(pdata.MetricDataTypeDoubleSum)
+		sum := metric.DoubleSum()
+		sum.InitEmpty()
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		sum.SetIsMonotonic(true)
+		appendDoubleDataPoint(sum.DataPoints(), s.labels, s.value, interval)
+	} else {
+		metric.SetDataType(pdata.MetricDataTypeIntSum)
+		sum := metric.IntSum()
+		sum.InitEmpty()
+		sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+		sum.SetIsMonotonic(true)
+		appendIntDataPoint(sum.DataPoints(), s.labels, int64(s.value), interval)
+	}
+	metrics.Append(metric)
+	return false
+}
+
+type gaugeState struct {
+	metricState
+	value    float64
+	isDouble bool
+	updated  bool
+}
+
+// appendMetrics reports the last value of the gauge if it was updated during
+// the interval. The state is always kept so that later relative updates apply
+// to the last known value, as statsd gauges are never reset.
+func (s *gaugeState) appendMetrics(metrics pdata.MetricSlice, _ ObserverConfig, interval timeInterval) bool {
+	if !s.updated {
+		return true
+	}
+	s.updated = false
+
+	metric := newMetric(s.name)
+	if s.isDouble {
+		metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		gauge := metric.DoubleGauge()
+		gauge.InitEmpty()
+		appendDoubleDataPoint(gauge.DataPoints(), s.labels, s.value, interval)
+	} else {
+		metric.SetDataType(pdata.MetricDataTypeIntGauge)
+		gauge := metric.IntGauge()
+		gauge.InitEmpty()
+		appendIntDataPoint(gauge.DataPoints(), s.labels, int64(s.value), interval)
+	}
+	metrics.Append(metric)
+	return true
+}
+
+type observerState struct {
+	metricState
+	values  []float64
+	weights []float64
+	count   float64
+	sum     float64
+}
+
+// appendMetrics reports the observed values either as an explicit bucket
+// histogram or as a summary. The collector data model does not support
+// summaries yet, so a summary is reported as a double gauge with one data
+// point per quantile, distinguished by the "quantile" label, plus the
+// "<name>.count" and "<name>.sum" delta sums.
+func (s *observerState) appendMetrics(metrics pdata.MetricSlice, observer ObserverConfig, interval timeInterval) bool {
+	if observer.Type == SummaryObserver {
+		s.appendSummary(metrics, observer.Quantiles, interval)
+	} else {
+		metrics.Append(s.buildHistogram(observer.Buckets, interval))
+	}
+	return false
+}
+
+func (s *observerState) appendSummary(metrics pdata.MetricSlice, quantiles []float64, interval timeInterval) {
+	if len(quantiles) > 0 {
+		metric := newMetric(s.name)
+		metric.SetDataType(pdata.MetricDataTypeDoubleGauge)
+		gauge := metric.DoubleGauge()
+		gauge.InitEmpty()
+		for _, q := range quantiles {
+			labels := make(map[string]string, len(s.labels)+1)
+			for k, v := range s.labels {
+				labels[k] = v
+			}
+			labels[quantileLabel] = strconv.FormatFloat(q, 'f', -1, 64)
+			appendDoubleDataPoint(gauge.DataPoints(), labels, s.quantile(q), interval)
+		}
+		metrics.Append(metric)
+	}
+
+	count := newMetric(s.name + countSuffix)
+	count.SetDataType(pdata.MetricDataTypeIntSum)
+	countSum := count.IntSum()
+	countSum.InitEmpty()
+	countSum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	countSum.SetIsMonotonic(true)
+	appendIntDataPoint(countSum.DataPoints(), s.labels, int64(math.Round(s.count)), interval)
+	metrics.Append(count)
+
+	sum := newMetric(s.name + sumSuffix)
+	sum.SetDataType(pdata.MetricDataTypeDoubleSum)
+	sumSum := sum.DoubleSum()
+	sumSum.InitEmpty()
+	sumSum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	appendDoubleDataPoint(sumSum.DataPoints(), s.labels, s.sum, interval)
+	metrics.Append(sum)
+}
+
+// quantile returns the q-quantile of the observed values using the nearest
+// rank method, each value counting as many times as its sample rate weight.
+func (s *observerState) quantile(q float64) float64 {
+	if len(s.values) == 0 {
+		return 0
+	}
+	idx := make([]int, len(s.values))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return s.values[idx[i]] < s.values[idx[j]] })
+
+	rank := q * s.count
+	var cumulated float64
+	for _, i := range idx {
+		cumulated += s.weights[i]
+		if cumulated >= rank {
+			return s.values[i]
+		}
+	}
+	return s.values[idx[len(idx)-1]]
+}
+
+func (s *observerState) buildHistogram(buckets []float64, interval timeInterval) pdata.Metric {
+	weightedCounts := make([]float64, len(buckets)+1)
+	for i, v := range s.values {
+		// Buckets are upper bounds, the value belongs to the first bucket
+		// whose bound is greater than or equal to it.
+		idx := sort.SearchFloat64s(buckets, v)
+		weightedCounts[idx] += s.weights[i]
+	}
+	bucketCounts := make([]uint64, len(weightedCounts))
+	for i, c := range weightedCounts {
+		bucketCounts[i] = uint64(math.Round(c))
+	}
+
+	metric := newMetric(s.name)
+	metric.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+	histogram := metric.DoubleHistogram()
+	histogram.InitEmpty()
+	histogram.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+	dps := histogram.DataPoints()
+	dps.Resize(1)
+	dp := dps.At(0)
+	dp.SetStartTime(interval.start)
+	dp.SetTimestamp(interval.end)
+	dp.LabelsMap().InitFromMap(s.labels)
+	dp.SetCount(uint64(math.Round(s.count)))
+	dp.SetSum(s.sum)
+	dp.SetExplicitBounds(buckets)
+	dp.SetBucketCounts(bucketCounts)
+	return metric
+}
+
+type setState struct {
+	metricState
+	values map[string]struct{}
+}
+
+// appendMetrics reports the number of unique values seen during the interval
+// as an int gauge.
+func (s *setState) appendMetrics(metrics pdata.MetricSlice, _ ObserverConfig, interval timeInterval) bool {
+	metric := newMetric(s.name)
+	metric.SetDataType(pdata.MetricDataTypeIntGauge)
+	gauge := metric.IntGauge()
+	gauge.InitEmpty()
+	appendIntDataPoint(gauge.DataPoints(), s.labels, int64(len(s.values)), interval)
+	metrics.Append(metric)
+	return false
+}
+
+func newMetric(name string) pdata.Metric {
+	metric := pdata.NewMetric()
+	metric.InitEmpty()
+	metric.SetName(name)
+	return metric
+}
+
+func appendIntDataPoint(dps pdata.IntDataPointSlice, labels map[string]string, value int64, interval timeInterval) {
+	dp := pdata.NewIntDataPoint()
+	dp.InitEmpty()
+	dp.SetStartTime(interval.start)
+	dp.SetTimestamp(interval.end)
+	dp.LabelsMap().InitFromMap(labels)
+	dp.SetValue(value)
+	dps.Append(dp)
+}
+
+func appendDoubleDataPoint(dps pdata.DoubleDataPointSlice, labels map[string]string, value float64, interval timeInterval) {
+	dp := pdata.NewDoubleDataPoint()
+	dp.InitEmpty()
+	dp.SetStartTime(interval.start)
+	dp.SetTimestamp(interval.end)
+	dp.LabelsMap().InitFromMap(labels)
+	dp.SetValue(value)
+	dps.Append(dp)
+}
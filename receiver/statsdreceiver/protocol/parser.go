@@ -15,10 +15,17 @@
 package protocol
 
 import (
-	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 // Parser is something that can map input StatsD strings to OTLP Metric representations.
+// Parsed lines are aggregated in the parser until GetMetrics is called.
 type Parser interface {
-	Parse(in string) (*metricspb.Metric, error)
+	// Aggregate parses the given line and adds it to the state of the
+	// current aggregation interval.
+	Aggregate(line string) error
+
+	// GetMetrics returns the metrics aggregated since the previous call and
+	// starts a new aggregation interval.
+	GetMetrics() pdata.Metrics
 }
@@ -17,12 +17,14 @@ package protocol
 import (
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 var (
@@ -30,40 +32,292 @@ var (
 	errEmptyMetricValue = errors.New("empty metric value")
 )
 
+const (
+	counterType      = "c"
+	gaugeType        = "g"
+	timerType        = "ms"
+	histogramType    = "h"
+	distributionType = "d"
+	setType          = "s"
+)
+
 func getSupportedTypes() []string {
-	return []string{"c", "g"}
+	return []string{counterType, gaugeType, timerType, histogramType, distributionType, setType}
 }
 
-// StatsDParser supports the Parse method for parsing StatsD messages with Tags.
-type StatsDParser struct{}
+// ObserverType is the representation used for timers, histograms and
+// distributions.
+type ObserverType string
+
+const (
+	// HistogramObserver reports the observed values as an explicit bucket
+	// histogram.
+	HistogramObserver ObserverType = "histogram"
+	// SummaryObserver reports the configured quantiles of the observed values
+	// plus their count and sum.
+	SummaryObserver ObserverType = "summary"
+)
+
+// ObserverConfig defines how timers, histograms and distributions are
+// aggregated.
+type ObserverConfig struct {
+	// Type is either "histogram" or "summary".
+	Type ObserverType `mapstructure:"type"`
+	// Quantiles reported by the "summary" type, between 0 and 1. Defaults
+	// to the quantiles of DefaultObserverConfig when empty.
+	Quantiles []float64 `mapstructure:"quantiles"`
+	// Buckets are the explicit upper bounds used by the "histogram" type.
+	// Defaults to the buckets of DefaultObserverConfig when empty.
+	Buckets []float64 `mapstructure:"buckets"`
+}
+
+// DefaultObserverConfig returns the ObserverConfig used when none is set.
+func DefaultObserverConfig() ObserverConfig {
+	return ObserverConfig{
+		Type:      HistogramObserver,
+		Quantiles: []float64{0.5, 0.9, 0.95, 0.99},
+		Buckets:   []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+	}
+}
+
+// Validate checks if the observer configuration is valid.
+func (c ObserverConfig) Validate() error {
+	switch c.Type {
+	case SummaryObserver:
+		for _, q := range c.Quantiles {
+			if q < 0 || q > 1 {
+				return fmt.Errorf("quantile %v must be between 0 and 1", q)
+			}
+		}
+	case HistogramObserver:
+		if !sort.Float64sAreSorted(c.Buckets) {
+			return errors.New("histogram buckets must be sorted in increasing order")
+		}
+	default:
+		return fmt.Errorf("unsupported observer type %q", c.Type)
+	}
+	return nil
+}
+
+// StatsDParser supports the Aggregate method for parsing StatsD messages with
+// Tags. Parsed messages are aggregated per series, i.e. per metric name, type
+// and set of tags, until GetMetrics is called:
+//   - counters are summed, scaled by their sample rate, and reported as delta sums;
+//   - gauges report their last value when updated during the interval, values
+//     prefixed by "+" or "-" are added to the previous value of the gauge,
+//     which is kept across intervals;
+//   - timers, histograms and distributions are reported according to the
+//     ObserverConfig;
+//   - sets report the number of unique values observed.
+type StatsDParser struct {
+	sync.Mutex
+	observer ObserverConfig
+
+	lastIntervalTime time.Time
+	series           map[string]seriesState
+}
+
+var _ Parser = (*StatsDParser)(nil)
+
+// NewStatsDParser creates a StatsDParser using the given ObserverConfig for
+// timers, histograms and distributions.
+func NewStatsDParser(observer ObserverConfig) *StatsDParser {
+	defaults := DefaultObserverConfig()
+	if len(observer.Quantiles) == 0 {
+		observer.Quantiles = defaults.Quantiles
+	}
+	if len(observer.Buckets) == 0 {
+		observer.Buckets = defaults.Buckets
+	}
+	return &StatsDParser{
+		observer:         observer,
+		lastIntervalTime: timeNowFunc(),
+		series:           map[string]seriesState{},
+	}
+}
 
 type statsDMetric struct {
 	name             string
 	value            string
 	statsdMetricType string
-	metricType       metricspb.MetricDescriptor_Type
 	sampleRate       float64
-	labelKeys        []*metricspb.LabelKey
-	labelValues      []*metricspb.LabelValue
+	labelKeys        []string
+	labelValues      []string
 }
 
-var timeNowFunc = func() int64 {
-	return time.Now().Unix()
+// seriesKey identifies the series of the metric for aggregation purposes.
+func (m *statsDMetric) seriesKey() string {
+	var sb strings.Builder
+	sb.WriteString(m.name)
+	sb.WriteByte('|')
+	sb.WriteString(m.statsdMetricType)
+	labels := m.labels()
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteByte('|')
+		sb.WriteString(k)
+		sb.WriteByte(':')
+		sb.WriteString(labels[k])
+	}
+	return sb.String()
+}
+
+func (m *statsDMetric) labels() map[string]string {
+	labels := make(map[string]string, len(m.labelKeys))
+	for i, k := range m.labelKeys {
+		labels[k] = m.labelValues[i]
+	}
+	return labels
+}
+
+var timeNowFunc = func() time.Time {
+	return time.Now()
 }
 
-// Parse returns an OTLP metric representation of the input StatsD string.
-func (p *StatsDParser) Parse(line string) (*metricspb.Metric, error) {
+// Aggregate parses the StatsD line and adds it to the aggregation state.
+func (p *StatsDParser) Aggregate(line string) error {
 	parsedMetric, err := parseMessageToMetric(line)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	key := parsedMetric.seriesKey()
+	switch parsedMetric.statsdMetricType {
+	case counterType:
+		return p.aggregateCounter(key, parsedMetric)
+	case gaugeType:
+		return p.aggregateGauge(key, parsedMetric)
+	case timerType, histogramType, distributionType:
+		return p.aggregateObserver(key, parsedMetric)
+	case setType:
+		p.aggregateSet(key, parsedMetric)
+		return nil
+	}
+
+	return fmt.Errorf("unhandled metric type: %s", parsedMetric.statsdMetricType)
+}
+
+// GetMetrics returns the metrics aggregated since the previous call and
+// resets the aggregation state. Gauges keep their value so that later
+// relative updates apply to it, but are only reported when updated.
+func (p *StatsDParser) GetMetrics() pdata.Metrics {
+	p.Lock()
+	defer p.Unlock()
+
+	now := timeNowFunc()
+	interval := timeInterval{
+		start: pdata.TimestampUnixNano(p.lastIntervalTime.UnixNano()),
+		end:   pdata.TimestampUnixNano(now.UnixNano()),
+	}
+
+	md := pdata.NewMetrics()
+	rms := md.ResourceMetrics()
+	rms.Resize(1)
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+
+	keys := make([]string, 0, len(p.series))
+	for key := range p.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if keep := p.series[key].appendMetrics(metrics, p.observer, interval); !keep {
+			delete(p.series, key)
+		}
+	}
+
+	p.lastIntervalTime = now
+
+	return md
+}
+
+func (p *StatsDParser) aggregateCounter(key string, parsedMetric *statsDMetric) error {
+	value, isDouble, err := parseNumber(parsedMetric.value)
+	if err != nil {
+		return err
 	}
+	if parsedMetric.sampleRate > 0 {
+		value /= parsedMetric.sampleRate
+	}
+
+	state, ok := p.series[key].(*counterState)
+	if !ok {
+		state = &counterState{
+			metricState: newMetricState(parsedMetric),
+		}
+		p.series[key] = state
+	}
+	state.value += value
+	state.isDouble = state.isDouble || isDouble || state.value != math.Trunc(state.value)
+	return nil
+}
 
-	metricPoint, err := buildPoint(parsedMetric)
+func (p *StatsDParser) aggregateGauge(key string, parsedMetric *statsDMetric) error {
+	value, isDouble, err := parseNumber(parsedMetric.value)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return buildMetric(parsedMetric, metricPoint), nil
+	state, ok := p.series[key].(*gaugeState)
+	if !ok {
+		state = &gaugeState{
+			metricState: newMetricState(parsedMetric),
+		}
+		p.series[key] = state
+	}
+	if strings.HasPrefix(parsedMetric.value, "+") || strings.HasPrefix(parsedMetric.value, "-") {
+		state.value += value
+	} else {
+		state.value = value
+	}
+	state.isDouble = state.isDouble || isDouble
+	state.updated = true
+	return nil
+}
+
+func (p *StatsDParser) aggregateObserver(key string, parsedMetric *statsDMetric) error {
+	value, _, err := parseNumber(parsedMetric.value)
+	if err != nil {
+		return err
+	}
+	weight := 1.0
+	if parsedMetric.sampleRate > 0 {
+		weight /= parsedMetric.sampleRate
+	}
+
+	state, ok := p.series[key].(*observerState)
+	if !ok {
+		state = &observerState{
+			metricState: newMetricState(parsedMetric),
+		}
+		p.series[key] = state
+	}
+	state.values = append(state.values, value)
+	state.weights = append(state.weights, weight)
+	state.count += weight
+	state.sum += value * weight
+	return nil
+}
+
+func (p *StatsDParser) aggregateSet(key string, parsedMetric *statsDMetric) {
+	state, ok := p.series[key].(*setState)
+	if !ok {
+		state = &setState{
+			metricState: newMetricState(parsedMetric),
+			values:      map[string]struct{}{},
+		}
+		p.series[key] = state
+	}
+	state.values[parsedMetric.value] = struct{}{}
 }
 
 func parseMessageToMetric(line string) (*statsDMetric, error) {
@@ -95,12 +349,11 @@ func parseMessageToMetric(line string) (*statsDMetric, error) {
 
 	additionalParts := parts[2:]
 	for _, part := range additionalParts {
-		// TODO: Sample rate doesn't currently have a place to go in the protocol
 		if strings.HasPrefix(part, "@") {
 			sampleRateStr := strings.TrimPrefix(part, "@")
 
 			f, err := strconv.ParseFloat(sampleRateStr, 64)
-			if err != nil {
+			if err != nil || f <= 0 || f > 1 {
 				return nil, fmt.Errorf("parse sample rate: %s", sampleRateStr)
 			}
 
@@ -110,19 +363,16 @@ func parseMessageToMetric(line string) (*statsDMetric, error) {
 
 			tagSets := strings.Split(tagsStr, ",")
 
-			result.labelKeys = make([]*metricspb.LabelKey, 0, len(tagSets))
-			result.labelValues = make([]*metricspb.LabelValue, 0, len(tagSets))
+			result.labelKeys = make([]string, 0, len(tagSets))
+			result.labelValues = make([]string, 0, len(tagSets))
 
 			for _, tagSet := range tagSets {
 				tagParts := strings.Split(tagSet, ":")
 				if len(tagParts) != 2 {
 					return nil, fmt.Errorf("invalid tag format: %s", tagParts)
 				}
-				result.labelKeys = append(result.labelKeys, &metricspb.LabelKey{Key: tagParts[0]})
-				result.labelValues = append(result.labelValues, &metricspb.LabelValue{
-					Value:    tagParts[1],
-					HasValue: true,
-				})
+				result.labelKeys = append(result.labelKeys, tagParts[0])
+				result.labelValues = append(result.labelValues, tagParts[1])
 			}
 		} else {
 			return nil, fmt.Errorf("unrecognized message part: %s", part)
@@ -132,6 +382,19 @@ func parseMessageToMetric(line string) (*statsDMetric, error) {
 	return result, nil
 }
 
+// parseNumber parses a StatsD value, reporting whether it is a floating
+// point number.
+func parseNumber(value string) (float64, bool, error) {
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return float64(i), false, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("parse metric value string: %s", value)
+	}
+	return f, true, nil
+}
+
 func contains(slice []string, element string) bool {
 	for _, val := range slice {
 		if val == element {
@@ -140,78 +403,3 @@ func contains(slice []string, element string) bool {
 	}
 	return false
 }
-
-func buildMetric(metric *statsDMetric, point *metricspb.Point) *metricspb.Metric {
-	return &metricspb.Metric{
-		MetricDescriptor: &metricspb.MetricDescriptor{
-			Name:      metric.name,
-			Type:      metric.metricType,
-			LabelKeys: metric.labelKeys,
-		},
-		Timeseries: []*metricspb.TimeSeries{
-			{
-				LabelValues: metric.labelValues,
-				Points: []*metricspb.Point{
-					point,
-				},
-			},
-		},
-	}
-}
-
-func buildPoint(parsedMetric *statsDMetric) (*metricspb.Point, error) {
-	now := &timestamppb.Timestamp{
-		Seconds: timeNowFunc(),
-	}
-
-	switch parsedMetric.statsdMetricType {
-	case "c":
-		return buildGaugeOrCounterPoint(parsedMetric, now, func(parsedMetric *statsDMetric, isDouble bool) {
-			if isDouble {
-				parsedMetric.metricType = metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
-				return
-			}
-			parsedMetric.metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
-		})
-	case "g":
-		return buildGaugeOrCounterPoint(parsedMetric, now, func(parsedMetric *statsDMetric, isDouble bool) {
-			if isDouble {
-				parsedMetric.metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
-				return
-			}
-			parsedMetric.metricType = metricspb.MetricDescriptor_GAUGE_INT64
-		})
-	}
-
-	return nil, fmt.Errorf("unhandled metric type: %s", parsedMetric.statsdMetricType)
-}
-
-func buildGaugeOrCounterPoint(parsedMetric *statsDMetric, now *timestamppb.Timestamp,
-	metricTypeSetter func(parsedMetric *statsDMetric, isDouble bool)) (*metricspb.Point, error) {
-	var point *metricspb.Point
-	var isDouble bool
-
-	i, err := strconv.ParseInt(parsedMetric.value, 10, 64)
-	if err != nil {
-		f, err := strconv.ParseFloat(parsedMetric.value, 64)
-		if err != nil {
-			return nil, fmt.Errorf("parse metric value string: %s", parsedMetric.value)
-		}
-		point = &metricspb.Point{
-			Timestamp: now,
-			Value: &metricspb.Point_DoubleValue{
-				DoubleValue: f,
-			},
-		}
-		isDouble = true
-	} else {
-		point = &metricspb.Point{
-			Timestamp: now,
-			Value: &metricspb.Point_Int64Value{
-				Int64Value: i,
-			},
-		}
-	}
-	metricTypeSetter(parsedMetric, isDouble)
-	return point, nil
-}
@@ -17,27 +17,18 @@ package protocol
 import (
 	"errors"
 	"testing"
+	"time"
 
-	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/stretchr/testify/assert"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
-func Test_StatsDParser_Parse(t *testing.T) {
-	prevTimeNowFunc := timeNowFunc
-	timeNowFunc = func() int64 {
-		return 0
-	}
-	t.Cleanup(
-		func() {
-			timeNowFunc = prevTimeNowFunc
-		},
-	)
-
+func Test_ParseMessageToMetric(t *testing.T) {
 	tests := []struct {
 		name       string
 		input      string
-		wantMetric *metricspb.Metric
+		wantMetric *statsDMetric
 		err        error
 	}{
 		{
@@ -63,39 +54,11 @@ func Test_StatsDParser_Parse(t *testing.T) {
 		{
 			name:  "integer counter",
 			input: "test.metric:42|c",
-			wantMetric: testMetric("test.metric",
-				metricspb.MetricDescriptor_CUMULATIVE_INT64,
-				nil,
-				nil,
-				&metricspb.Point{
-					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
-					},
-					Value: &metricspb.Point_Int64Value{
-						Int64Value: 42,
-					},
-				}),
-		},
-		{
-			name:  "gracefully handle float counter value",
-			input: "test.metric:42.0|c",
-			wantMetric: testMetric("test.metric",
-				metricspb.MetricDescriptor_CUMULATIVE_DOUBLE,
-				nil,
-				nil,
-				&metricspb.Point{
-					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
-					},
-					Value: &metricspb.Point_DoubleValue{
-						DoubleValue: 42,
-					},
-				}),
-		},
-		{
-			name:  "invalid metric value",
-			input: "test.metric:42.abc|c",
-			err:   errors.New("parse metric value string: 42.abc"),
+			wantMetric: &statsDMetric{
+				name:             "test.metric",
+				value:            "42",
+				statsdMetricType: "c",
+			},
 		},
 		{
 			name:  "unhandled metric type",
@@ -105,83 +68,45 @@ func Test_StatsDParser_Parse(t *testing.T) {
 		{
 			name:  "counter metric with sample rate and tags",
 			input: "test.metric:42|c|@0.1|#key:value",
-			wantMetric: testMetric("test.metric",
-				metricspb.MetricDescriptor_CUMULATIVE_INT64,
-				[]*metricspb.LabelKey{
-					{
-						Key: "key",
-					},
-				},
-				[]*metricspb.LabelValue{
-					{
-						Value:    "value",
-						HasValue: true,
-					},
-				},
-				&metricspb.Point{
-					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
-					},
-					Value: &metricspb.Point_Int64Value{
-						Int64Value: 42,
-					},
-				}),
-		},
-		{
-			name:  "double gauge metric",
-			input: "test.gauge:42.0|g|@0.1|#key:value",
-			wantMetric: testMetric("test.gauge",
-				metricspb.MetricDescriptor_GAUGE_DOUBLE,
-				[]*metricspb.LabelKey{
-					{
-						Key: "key",
-					},
-				},
-				[]*metricspb.LabelValue{
-					{
-						Value:    "value",
-						HasValue: true,
-					},
-				},
-				&metricspb.Point{
-					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
-					},
-					Value: &metricspb.Point_DoubleValue{
-						DoubleValue: 42,
-					},
-				}),
-		},
-		{
-			name:  "int gauge metric",
-			input: "test.gauge:42|g|@0.1|#key:value",
-			wantMetric: testMetric("test.gauge",
-				metricspb.MetricDescriptor_GAUGE_INT64,
-				[]*metricspb.LabelKey{
-					{
-						Key: "key",
-					},
-				},
-				[]*metricspb.LabelValue{
-					{
-						Value:    "value",
-						HasValue: true,
-					},
-				},
-				&metricspb.Point{
-					Timestamp: &timestamppb.Timestamp{
-						Seconds: 0,
-					},
-					Value: &metricspb.Point_Int64Value{
-						Int64Value: 42,
-					},
-				}),
+			wantMetric: &statsDMetric{
+				name:             "test.metric",
+				value:            "42",
+				statsdMetricType: "c",
+				sampleRate:       0.1,
+				labelKeys:        []string{"key"},
+				labelValues:      []string{"value"},
+			},
+		},
+		{
+			name:  "timer metric",
+			input: "test.timer:320|ms|#key:value,key2:value2",
+			wantMetric: &statsDMetric{
+				name:             "test.timer",
+				value:            "320",
+				statsdMetricType: "ms",
+				labelKeys:        []string{"key", "key2"},
+				labelValues:      []string{"value", "value2"},
+			},
+		},
+		{
+			name:  "set metric",
+			input: "test.set:user42|s",
+			wantMetric: &statsDMetric{
+				name:             "test.set",
+				value:            "user42",
+				statsdMetricType: "s",
+			},
 		},
 		{
 			name:  "invalid sample rate value",
 			input: "test.metric:42|c|@1.0a",
 			err:   errors.New("parse sample rate: 1.0a"),
 		},
+		{
+			name:  "out of range sample rate value",
+			input: "test.metric:42|c|@2",
+			err:   errors.New("parse sample rate: 2"),
+		},
 		{
 			name:  "invalid tag format",
 			input: "test.metric:42|c|#key1",
@@ -196,38 +121,232 @@ func Test_StatsDParser_Parse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			p := &StatsDParser{}
-
-			got, err := p.Parse(tt.input)
+			got, err := parseMessageToMetric(tt.input)
 
 			if tt.err != nil {
-				assert.Equal(t, err, tt.err)
+				assert.Equal(t, tt.err, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, got, tt.wantMetric)
+				assert.Equal(t, tt.wantMetric, got)
 			}
 		})
 	}
 }
 
-func testMetric(metricName string,
-	metricType metricspb.MetricDescriptor_Type,
-	lableKeys []*metricspb.LabelKey,
-	labelValues []*metricspb.LabelValue,
-	point *metricspb.Point) *metricspb.Metric {
-	return &metricspb.Metric{
-		MetricDescriptor: &metricspb.MetricDescriptor{
-			Name:      metricName,
-			Type:      metricType,
-			LabelKeys: lableKeys,
-		},
-		Timeseries: []*metricspb.TimeSeries{
-			{
-				LabelValues: labelValues,
-				Points: []*metricspb.Point{
-					point,
-				},
+func Test_StatsDParser_Aggregate(t *testing.T) {
+	start := time.Unix(1000, 0)
+	end := time.Unix(1010, 0)
+	interval := timeInterval{
+		start: pdata.TimestampUnixNano(start.UnixNano()),
+		end:   pdata.TimestampUnixNano(end.UnixNano()),
+	}
+
+	tests := []struct {
+		name        string
+		input       []string
+		observer    ObserverConfig
+		wantMetrics func() pdata.MetricSlice
+		err         error
+	}{
+		{
+			name:  "invalid counter value",
+			input: []string{"test.metric:42.abc|c"},
+			err:   errors.New("parse metric value string: 42.abc"),
+		},
+		{
+			name:  "integer counters are summed",
+			input: []string{"test.metric:42|c|#key:value", "test.metric:8|c|#key:value", "test.metric:1|c|#key:other"},
+			wantMetrics: func() pdata.MetricSlice {
+				ms := pdata.NewMetricSlice()
+				(&counterState{metricState: metricState{name: "test.metric", labels: map[string]string{"key": "other"}}, value: 1}).
+					appendMetrics(ms, ObserverConfig{}, interval)
+				(&counterState{metricState: metricState{name: "test.metric", labels: map[string]string{"key": "value"}}, value: 50}).
+					appendMetrics(ms, ObserverConfig{}, interval)
+				return ms
+			},
+		},
+		{
+			name:  "counters are scaled by sample rate",
+			input: []string{"test.metric:42|c|@0.1", "test.metric:0.5|c"},
+			wantMetrics: func() pdata.MetricSlice {
+				ms := pdata.NewMetricSlice()
+				(&counterState{metricState: metricState{name: "test.metric", labels: map[string]string{}}, value: 420.5, isDouble: true}).
+					appendMetrics(ms, ObserverConfig{}, interval)
+				return ms
+			},
+		},
+		{
+			name:  "gauges keep the last value and apply increments",
+			input: []string{"test.gauge:42|g", "test.gauge:10|g", "test.gauge:+5|g", "test.gauge:-2.5|g"},
+			wantMetrics: func() pdata.MetricSlice {
+				ms := pdata.NewMetricSlice()
+				(&gaugeState{metricState: metricState{name: "test.gauge", labels: map[string]string{}}, value: 12.5, isDouble: true, updated: true}).
+					appendMetrics(ms, ObserverConfig{}, interval)
+				return ms
+			},
+		},
+		{
+			name:  "sets count unique values",
+			input: []string{"test.set:a|s", "test.set:b|s", "test.set:a|s"},
+			wantMetrics: func() pdata.MetricSlice {
+				ms := pdata.NewMetricSlice()
+				(&setState{metricState: metricState{name: "test.set", labels: map[string]string{}}, values: map[string]struct{}{"a": {}, "b": {}}}).
+					appendMetrics(ms, ObserverConfig{}, interval)
+				return ms
+			},
+		},
+		{
+			name:     "histograms as explicit bucket histogram",
+			input:    []string{"test.histogram:1|h", "test.histogram:5|h", "test.histogram:7|h|@0.5", "test.histogram:50|d"},
+			observer: ObserverConfig{Type: HistogramObserver, Buckets: []float64{5, 10}},
+			wantMetrics: func() pdata.MetricSlice {
+				ms := pdata.NewMetricSlice()
+				// "d" and "h" are distinct series.
+				m := newMetric("test.histogram")
+				m.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+				h := m.DoubleHistogram()
+				h.InitEmpty()
+				h.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+				h.DataPoints().Resize(1)
+				dp := h.DataPoints().At(0)
+				dp.SetStartTime(interval.start)
+				dp.SetTimestamp(interval.end)
+				dp.LabelsMap().InitFromMap(map[string]string{})
+				dp.SetCount(1)
+				dp.SetSum(50)
+				dp.SetExplicitBounds([]float64{5, 10})
+				dp.SetBucketCounts([]uint64{0, 0, 1})
+				ms.Append(m)
+
+				m = newMetric("test.histogram")
+				m.SetDataType(pdata.MetricDataTypeDoubleHistogram)
+				h = m.DoubleHistogram()
+				h.InitEmpty()
+				h.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+				h.DataPoints().Resize(1)
+				dp = h.DataPoints().At(0)
+				dp.SetStartTime(interval.start)
+				dp.SetTimestamp(interval.end)
+				dp.LabelsMap().InitFromMap(map[string]string{})
+				dp.SetCount(4)
+				dp.SetSum(1 + 5 + 14)
+				dp.SetExplicitBounds([]float64{5, 10})
+				dp.SetBucketCounts([]uint64{2, 2, 0})
+				ms.Append(m)
+
+				return ms
+			},
+		},
+		{
+			name:     "timers as summary",
+			input:    []string{"test.timer:1|ms", "test.timer:2|ms", "test.timer:3|ms", "test.timer:10|ms|@0.5"},
+			observer: ObserverConfig{Type: SummaryObserver, Quantiles: []float64{0.5, 0.9}},
+			wantMetrics: func() pdata.MetricSlice {
+				ms := pdata.NewMetricSlice()
+				m := newMetric("test.timer")
+				m.SetDataType(pdata.MetricDataTypeDoubleGauge)
+				g := m.DoubleGauge()
+				g.InitEmpty()
+				// The sampled value counts twice, out of a total of 5.
+				appendDoubleDataPoint(g.DataPoints(), map[string]string{"quantile": "0.5"}, 3, interval)
+				appendDoubleDataPoint(g.DataPoints(), map[string]string{"quantile": "0.9"}, 10, interval)
+				ms.Append(m)
+
+				m = newMetric("test.timer.count")
+				m.SetDataType(pdata.MetricDataTypeIntSum)
+				c := m.IntSum()
+				c.InitEmpty()
+				c.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+				c.SetIsMonotonic(true)
+				appendIntDataPoint(c.DataPoints(), map[string]string{}, 5, interval)
+				ms.Append(m)
+
+				m = newMetric("test.timer.sum")
+				m.SetDataType(pdata.MetricDataTypeDoubleSum)
+				sum := m.DoubleSum()
+				sum.InitEmpty()
+				sum.SetAggregationTemporality(pdata.AggregationTemporalityDelta)
+				appendDoubleDataPoint(sum.DataPoints(), map[string]string{}, 26, interval)
+				ms.Append(m)
+
+				return ms
 			},
 		},
 	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTimeNow(t, start)
+			p := NewStatsDParser(tt.observer)
+
+			var err error
+			for _, line := range tt.input {
+				if err = p.Aggregate(line); err != nil {
+					break
+				}
+			}
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+
+			setTimeNow(t, end)
+			md := p.GetMetrics()
+			require.Equal(t, 1, md.ResourceMetrics().Len())
+			got := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+			assert.Equal(t, tt.wantMetrics(), got)
+		})
+	}
+}
+
+func Test_StatsDParser_GetMetricsResetsState(t *testing.T) {
+	setTimeNow(t, time.Unix(1000, 0))
+	p := NewStatsDParser(DefaultObserverConfig())
+
+	require.NoError(t, p.Aggregate("test.counter:1|c"))
+	require.NoError(t, p.Aggregate("test.gauge:10|g"))
+	require.NoError(t, p.Aggregate("test.timer:10|ms"))
+	require.NoError(t, p.Aggregate("test.set:a|s"))
+	// counter, gauge, set and the timer histogram.
+	assert.Equal(t, 4, p.GetMetrics().MetricCount())
+
+	// Gauge increments apply to the value of the previous interval.
+	require.NoError(t, p.Aggregate("test.gauge:+1|g"))
+	md := p.GetMetrics()
+	require.Equal(t, 1, md.MetricCount())
+	metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, int64(11), metric.IntGauge().DataPoints().At(0).Value())
+
+	// Nothing was received during the interval, the idle gauge isn't reported
+	// but keeps its value.
+	assert.Equal(t, 0, p.GetMetrics().MetricCount())
+	assert.Len(t, p.series, 1)
+
+	require.NoError(t, p.Aggregate("test.gauge:-3|g"))
+	md = p.GetMetrics()
+	require.Equal(t, 1, md.MetricCount())
+	metric = md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, int64(8), metric.IntGauge().DataPoints().At(0).Value())
+}
+
+func Test_ObserverConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultObserverConfig().Validate())
+	assert.EqualError(t, ObserverConfig{Type: "other"}.Validate(), `unsupported observer type "other"`)
+	assert.NoError(t, ObserverConfig{Type: SummaryObserver, Quantiles: []float64{0, 0.5, 1}}.Validate())
+	assert.EqualError(t, ObserverConfig{Type: SummaryObserver, Quantiles: []float64{1.5}}.Validate(), "quantile 1.5 must be between 0 and 1")
+	assert.NoError(t, ObserverConfig{Type: HistogramObserver}.Validate())
+	assert.EqualError(t, ObserverConfig{Type: HistogramObserver, Buckets: []float64{2, 1}}.Validate(), "histogram buckets must be sorted in increasing order")
+}
+
+func setTimeNow(t *testing.T, now time.Time) {
+	prevTimeNowFunc := timeNowFunc
+	timeNowFunc = func() time.Time {
+		return now
+	}
+	t.Cleanup(
+		func() {
+			timeNowFunc = prevTimeNowFunc
+		},
+	)
 }
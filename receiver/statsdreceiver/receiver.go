@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
@@ -42,6 +43,8 @@ type statsdReceiver struct {
 	parser       protocol.Parser
 	nextConsumer consumer.MetricsConsumer
 
+	done      chan struct{}
+	flushDone chan struct{}
 	startOnce sync.Once
 	stopOnce  sync.Once
 }
@@ -60,6 +63,10 @@ func New(
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
 
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
 	server, err := buildTransportServer(config)
 	if err != nil {
 		return nil, err
@@ -71,22 +78,28 @@ func New(
 		nextConsumer: nextConsumer,
 		server:       server,
 		reporter:     newReporter(config.Name(), logger),
-		parser:       &protocol.StatsDParser{},
+		parser:       protocol.NewStatsDParser(config.TimerHistogramMapping),
+		done:         make(chan struct{}),
 	}
 	return r, nil
 }
 
 func buildTransportServer(config Config) (transport.Server, error) {
-	// TODO: Add TCP/unix socket transport implementations
 	switch strings.ToLower(config.NetAddr.Transport) {
 	case "", "udp":
-		return transport.NewUDPServer(config.NetAddr.Endpoint)
+		return transport.NewUDPServer(config.NetAddr.Endpoint, config.MaxLineSize)
+	case "unixgram":
+		return transport.NewUnixgramServer(config.NetAddr.Endpoint, config.MaxLineSize)
+	case "tcp":
+		return transport.NewTCPServer(config.NetAddr.Endpoint, config.IdleTimeout, config.MaxLineSize)
+	case "unix":
+		return transport.NewUnixServer(config.NetAddr.Endpoint, config.IdleTimeout, config.MaxLineSize)
 	}
 
 	return nil, fmt.Errorf("unsupported transport %q for receiver %q", config.NetAddr.Transport, config.Name())
 }
 
-// StartMetricsReception starts a UDP server that can process StatsD messages.
+// StartMetricsReception starts a server that can process StatsD messages.
 func (r *statsdReceiver) Start(_ context.Context, host component.Host) error {
 	r.Lock()
 	defer r.Unlock()
@@ -95,16 +108,53 @@ func (r *statsdReceiver) Start(_ context.Context, host component.Host) error {
 	r.startOnce.Do(func() {
 		err = nil
 		go func() {
-			err = r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter)
-			if err != nil {
-				host.ReportFatalError(err)
+			if err := r.server.ListenAndServe(r.parser, r.reporter); err != nil {
+				select {
+				case <-r.done:
+					// The error is expected, the server was closed on shutdown.
+				default:
+					host.ReportFatalError(err)
+				}
 			}
 		}()
+		r.flushDone = make(chan struct{})
+		go r.flushLoop()
 	})
 
 	return err
 }
 
+// flushLoop periodically sends the aggregated metrics to the next consumer
+// until the receiver is shut down, flushing a last time before returning.
+func (r *statsdReceiver) flushLoop() {
+	defer close(r.flushDone)
+
+	ticker := time.NewTicker(r.config.AggregationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.flush()
+		case <-r.done:
+			r.flush()
+			return
+		}
+	}
+}
+
+func (r *statsdReceiver) flush() {
+	md := r.parser.GetMetrics()
+	if md.MetricCount() == 0 {
+		return
+	}
+	if err := r.nextConsumer.ConsumeMetrics(context.Background(), md); err != nil {
+		r.logger.Debug(
+			"StatsD receiver failed to push aggregated metrics into pipeline",
+			zap.String("receiver", r.config.Name()),
+			zap.Error(err))
+	}
+}
+
 // StopMetricsReception stops the StatsD receiver.
 func (r *statsdReceiver) Shutdown(context.Context) error {
 	r.Lock()
@@ -112,7 +162,13 @@ func (r *statsdReceiver) Shutdown(context.Context) error {
 
 	var err = componenterror.ErrAlreadyStopped
 	r.stopOnce.Do(func() {
+		close(r.done)
 		err = r.server.Close()
+
+		// Wait for the final flush if the receiver was started.
+		if r.flushDone != nil {
+			<-r.flushDone
+		}
 	})
 	return err
 }
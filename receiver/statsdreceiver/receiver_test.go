@@ -21,6 +21,7 @@ import (
 	"runtime"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -28,9 +29,9 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/testutil"
-	"go.opentelemetry.io/collector/translator/internaldata"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
@@ -66,10 +67,36 @@ func Test_statsdreceiver_New(t *testing.T) {
 			name: "empty endpoint",
 			args: args{
 				config: Config{
-					ReceiverSettings: defaultConfig.ReceiverSettings,
+					ReceiverSettings:      defaultConfig.ReceiverSettings,
+					AggregationInterval:   defaultConfig.AggregationInterval,
+					TimerHistogramMapping: defaultConfig.TimerHistogramMapping,
+				},
+				nextConsumer: exportertest.NewNopMetricsExporter(),
+			},
+		},
+		{
+			name: "invalid aggregation interval",
+			args: args{
+				config: Config{
+					ReceiverSettings:      defaultConfig.ReceiverSettings,
+					NetAddr:               defaultConfig.NetAddr,
+					TimerHistogramMapping: defaultConfig.TimerHistogramMapping,
+				},
+				nextConsumer: exportertest.NewNopMetricsExporter(),
+			},
+			wantErr: errors.New("aggregation_interval must be positive, got 0s"),
+		},
+		{
+			name: "invalid timer histogram mapping",
+			args: args{
+				config: Config{
+					ReceiverSettings:    defaultConfig.ReceiverSettings,
+					NetAddr:             defaultConfig.NetAddr,
+					AggregationInterval: defaultConfig.AggregationInterval,
 				},
 				nextConsumer: exportertest.NewNopMetricsExporter(),
 			},
+			wantErr: errors.New("unsupported observer type \"\""),
 		},
 		{
 			name: "unsupported transport",
@@ -80,11 +107,30 @@ func Test_statsdreceiver_New(t *testing.T) {
 						Endpoint:  "localhost:8125",
 						Transport: "unknown",
 					},
+					AggregationInterval:   defaultConfig.AggregationInterval,
+					TimerHistogramMapping: defaultConfig.TimerHistogramMapping,
 				},
 				nextConsumer: exportertest.NewNopMetricsExporter(),
 			},
 			wantErr: errors.New("unsupported transport \"unknown\" for receiver \"statsd\""),
 		},
+		{
+			name: "negative idle timeout",
+			args: args{
+				config: Config{
+					ReceiverSettings: defaultConfig.ReceiverSettings,
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:8125",
+						Transport: "tcp",
+					},
+					IdleTimeout:           -1 * time.Second,
+					AggregationInterval:   defaultConfig.AggregationInterval,
+					TimerHistogramMapping: defaultConfig.TimerHistogramMapping,
+				},
+				nextConsumer: exportertest.NewNopMetricsExporter(),
+			},
+			wantErr: errors.New("invalid idle timeout: -1s"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -123,11 +169,25 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 				return c
 			},
 		},
+		{
+			name: "tcp",
+			configFn: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.NetAddr.Transport = "tcp"
+				return cfg
+			},
+			clientFn: func(t *testing.T) *client.StatsD {
+				c, err := client.NewStatsD(client.TCP, host, port)
+				require.NoError(t, err)
+				return c
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := tt.configFn()
 			cfg.NetAddr.Endpoint = addr
+			cfg.AggregationInterval = 100 * time.Millisecond
 			sink := new(exportertest.SinkMetricsExporter)
 			rcv, err := New(zap.NewNop(), *cfg, sink)
 			require.NoError(t, err)
@@ -153,15 +213,19 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 
 			mr.WaitAllOnMetricsProcessedCalls()
 
+			// Wait for the aggregated metrics to be flushed.
+			require.Eventually(t, func() bool {
+				return len(sink.AllMetrics()) > 0
+			}, 5*time.Second, 10*time.Millisecond)
+
 			mdd := sink.AllMetrics()
 			require.Len(t, mdd, 1)
-			ocmd := internaldata.MetricsToOC(mdd[0])
-			require.Len(t, ocmd, 1)
-			require.Len(t, ocmd[0].Metrics, 1)
-			metric := ocmd[0].Metrics[0]
-			assert.Equal(t, statsdMetric.Name, metric.GetMetricDescriptor().GetName())
-			tss := metric.GetTimeseries()
-			require.Equal(t, 1, len(tss))
+			require.Equal(t, 1, mdd[0].MetricCount())
+			metric := mdd[0].ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, statsdMetric.Name, metric.Name())
+			require.Equal(t, pdata.MetricDataTypeIntSum, metric.DataType())
+			assert.Equal(t, pdata.AggregationTemporalityDelta, metric.IntSum().AggregationTemporality())
+			assert.Equal(t, int64(42), metric.IntSum().DataPoints().At(0).Value())
 
 			assert.NoError(t, r.Shutdown(context.Background()))
 			assert.Equal(t, componenterror.ErrAlreadyStopped, r.Shutdown(context.Background()))
@@ -18,12 +18,16 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 )
 
 // StatsD defines the properties of a StatsD connection.
 type StatsD struct {
 	Host string
 	Port int
+	// Path of the socket, used instead of Host and Port by the Unix
+	// transports.
+	Path string
 	Conn io.Writer
 }
 
@@ -35,6 +39,10 @@ const (
 	TCP Transport = iota
 	// UDP Transport
 	UDP
+	// Unix Transport, using a stream socket
+	Unix
+	// Unixgram Transport, using a datagram socket
+	Unixgram
 )
 
 // NewStatsD creates a new StatsD instance to support the need for testing
@@ -52,19 +60,35 @@ func NewStatsD(transport Transport, host string, port int) (*StatsD, error) {
 	return statsd, nil
 }
 
+// NewStatsDUnix creates a new StatsD instance connected to the socket at the
+// given path. As NewStatsD it is intended for testing purposes only.
+func NewStatsDUnix(transport Transport, path string) (*StatsD, error) {
+	statsd := &StatsD{
+		Path: path,
+	}
+	err := statsd.connect(transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return statsd, nil
+}
+
 // connect populates the StatsD.Conn
 func (s *StatsD) connect(transport Transport) error {
 	if cl, ok := s.Conn.(io.Closer); ok {
 		cl.Close()
 	}
 
-	address := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	address := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
 
 	var err error
 	switch transport {
 	case TCP:
-		// TODO: implement TCP support
-		return fmt.Errorf("TCP unsupported")
+		s.Conn, err = net.Dial("tcp", address)
+		if err != nil {
+			return err
+		}
 	case UDP:
 		var udpAddr *net.UDPAddr
 		udpAddr, err = net.ResolveUDPAddr("udp", address)
@@ -75,6 +99,16 @@ func (s *StatsD) connect(transport Transport) error {
 		if err != nil {
 			return err
 		}
+	case Unix:
+		s.Conn, err = net.Dial("unix", s.Path)
+		if err != nil {
+			return err
+		}
+	case Unixgram:
+		s.Conn, err = net.Dial("unixgram", s.Path)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown transport: %d", transport)
 	}
@@ -92,9 +126,10 @@ func (s *StatsD) Disconnect() error {
 	return err
 }
 
-// SendMetric sends the input metric to the StatsD connection.
+// SendMetric sends the input metric to the StatsD connection, terminated by
+// a newline so that it is framed on stream transports.
 func (s *StatsD) SendMetric(metric Metric) error {
-	_, err := fmt.Fprint(s.Conn, metric.String())
+	_, err := fmt.Fprintln(s.Conn, metric.String())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,159 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+// maxUDPPacketSize is the max size for an udp packet body (assuming ipv6).
+const maxUDPPacketSize = 65527
+
+// packetServer is a transport.Server for datagram oriented transports, each
+// datagram holding one or more newline separated messages.
+type packetServer struct {
+	transportName string
+	packetConn    net.PacketConn
+	maxLineSize   int
+	reporter      Reporter
+}
+
+var _ (Server) = (*packetServer)(nil)
+
+// NewUDPServer creates a transport.Server using UDP as its transport.
+func NewUDPServer(addr string, maxLineSize int) (Server, error) {
+	return newPacketServer("udp", "UDP", addr, maxLineSize)
+}
+
+// NewUnixgramServer creates a transport.Server using a Unix datagram socket,
+// created at the given path, as its transport.
+func NewUnixgramServer(path string, maxLineSize int) (Server, error) {
+	return newPacketServer("unixgram", "Unixgram", path, maxLineSize)
+}
+
+func newPacketServer(network, transportName, addr string, maxLineSize int) (Server, error) {
+	maxLineSize, err := validateMaxLineSize(maxLineSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if network == "unixgram" {
+		if err = removeStaleSocket(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	packetConn, err := net.ListenPacket(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	u := packetServer{
+		transportName: transportName,
+		packetConn:    packetConn,
+		maxLineSize:   maxLineSize,
+	}
+	return &u, nil
+}
+
+func (u *packetServer) ListenAndServe(
+	parser protocol.Parser,
+	reporter Reporter,
+) error {
+	if parser == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	u.reporter = reporter
+
+	bufSize := maxUDPPacketSize
+	if u.maxLineSize > bufSize {
+		bufSize = u.maxLineSize
+	}
+	buf := make([]byte, bufSize)
+	for {
+		n, _, err := u.packetConn.ReadFrom(buf)
+		if n > 0 {
+			bufCopy := make([]byte, n)
+			copy(bufCopy, buf)
+			u.handlePacket(parser, bufCopy)
+		}
+		if err != nil {
+			u.reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
+				u.transportName,
+				u.packetConn.LocalAddr(),
+				err)
+			if netErr, ok := err.(net.Error); ok {
+				if netErr.Temporary() {
+					continue
+				}
+			}
+			return err
+		}
+	}
+}
+
+func (u *packetServer) Close() error {
+	err := u.packetConn.Close()
+	// Unlike stream listeners, Unix datagram sockets are not removed from the
+	// file system when closed.
+	if addr, ok := u.packetConn.LocalAddr().(*net.UnixAddr); ok && addr.Name != "" {
+		if rmErr := os.Remove(addr.Name); rmErr != nil && !os.IsNotExist(rmErr) && err == nil {
+			err = rmErr
+		}
+	}
+	return err
+}
+
+func (u *packetServer) handlePacket(
+	p protocol.Parser,
+	data []byte,
+) {
+	ctx := u.reporter.OnDataReceived(context.Background())
+	var numReceivedMessages, numInvalidMessages int
+	buf := bytes.NewBuffer(data)
+	for {
+		bytes, err := buf.ReadBytes((byte)('\n'))
+		if err == io.EOF {
+			if len(bytes) == 0 {
+				// Completed without errors.
+				break
+			}
+		}
+		line := strings.TrimSpace(string(bytes))
+		if line != "" {
+			numReceivedMessages++
+			if len(line) > u.maxLineSize {
+				numInvalidMessages++
+				u.reporter.OnTranslationError(ctx, fmt.Errorf("%w: %d bytes", errLineTooLong, len(line)))
+				continue
+			}
+			if err := p.Aggregate(line); err != nil {
+				numInvalidMessages++
+				u.reporter.OnTranslationError(ctx, err)
+			}
+		}
+	}
+
+	u.reporter.OnMetricsProcessed(ctx, numReceivedMessages, numInvalidMessages, nil)
+}
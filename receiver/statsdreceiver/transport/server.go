@@ -17,30 +17,66 @@ package transport
 import (
 	"context"
 	"errors"
-
-	"go.opentelemetry.io/collector/consumer"
+	"fmt"
+	"os"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
+const (
+	// MaxLineSizeDefault is the default maximum size in bytes of a message.
+	MaxLineSizeDefault = 64 * 1024
+	// IdleTimeoutDefault is the default timeout for idle connections of
+	// stream oriented transports.
+	IdleTimeoutDefault = 30 * time.Second
+)
+
 var (
 	errNilListenAndServeParameters = errors.New("no parameter of ListenAndServe can be nil")
+	errLineTooLong                 = errors.New("message exceeds the max line size")
 )
 
+func validateMaxLineSize(maxLineSize int) (int, error) {
+	if maxLineSize < 0 {
+		return 0, fmt.Errorf("invalid max line size: %d", maxLineSize)
+	}
+	if maxLineSize == 0 {
+		return MaxLineSizeDefault, nil
+	}
+	return maxLineSize, nil
+}
+
+// removeStaleSocket removes the Unix socket left at path by a previous run
+// that didn't shut down cleanly, binding to it failing otherwise. Files that
+// are not sockets are left untouched.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
+
 // Server abstracts the type of transport being used and offer an
 // interface to handle serving clients over that transport.
 type Server interface {
 	// ListenAndServe is a blocking call that starts to listen for client messages
-	// on the specific transport, and prepares the message to be processed by
-	// the Parser and passed to the next consumer.
+	// on the specific transport, and passes the messages to the Parser to be
+	// aggregated.
 	ListenAndServe(
 		p protocol.Parser,
-		mc consumer.MetricsConsumer,
 		r Reporter,
 	) error
 
 	// Close stops any running ListenAndServe, however, it waits for any
-	// data already received to be parsed and sent to the next consumer.
+	// data already received to be aggregated by the Parser.
 	Close() error
 }
 
@@ -58,10 +94,11 @@ type Reporter interface {
 	// passed to it should be the ones returned by OnDataReceived.
 	OnTranslationError(ctx context.Context, err error)
 
-	// OnMetricsProcessed is called when the received data is passed to next
-	// consumer on the pipeline. The context passed to it should be the
-	// one returned by OnDataReceived. The error should be error returned by
-	// the next consumer - the reporter is expected to handle nil error too.
+	// OnMetricsProcessed is called when the received data is aggregated by
+	// the parser or passed to the next consumer on the pipeline. The context
+	// passed to it should be the one returned by OnDataReceived. The error
+	// should be error returned by the next consumer - the reporter is
+	// expected to handle nil error too.
 	OnMetricsProcessed(
 		ctx context.Context,
 		numReceivedMessages int,
@@ -15,65 +15,102 @@
 package transport
 
 import (
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/testutil"
-	"go.opentelemetry.io/collector/translator/internaldata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport/client"
 )
 
 func Test_Server_ListenAndServe(t *testing.T) {
+	socketDir, err := ioutil.TempDir("", "statsd")
+	require.NoError(t, err)
+	defer os.RemoveAll(socketDir)
+
 	tests := []struct {
 		name          string
+		addrFn        func(t *testing.T) string
 		buildServerFn func(addr string) (Server, error)
-		buildClientFn func(host string, port int) (*client.StatsD, error)
+		buildClientFn func(addr string) (*client.StatsD, error)
 	}{
 		{
-			name: "udp",
+			name:   "udp",
+			addrFn: testutil.GetAvailableLocalAddress,
 			buildServerFn: func(addr string) (Server, error) {
-				return NewUDPServer(addr)
+				return NewUDPServer(addr, 0)
 			},
-			buildClientFn: func(host string, port int) (*client.StatsD, error) {
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
 				return client.NewStatsD(client.UDP, host, port)
 			},
 		},
+		{
+			name:   "tcp",
+			addrFn: testutil.GetAvailableLocalAddress,
+			buildServerFn: func(addr string) (Server, error) {
+				return NewTCPServer(addr, 0, 0)
+			},
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
+				return client.NewStatsD(client.TCP, host, port)
+			},
+		},
+		{
+			name: "unixgram",
+			addrFn: func(t *testing.T) string {
+				return filepath.Join(socketDir, "unixgram.sock")
+			},
+			buildServerFn: func(addr string) (Server, error) {
+				return NewUnixgramServer(addr, 0)
+			},
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				return client.NewStatsDUnix(client.Unixgram, addr)
+			},
+		},
+		{
+			name: "unix",
+			addrFn: func(t *testing.T) string {
+				return filepath.Join(socketDir, "unix.sock")
+			},
+			buildServerFn: func(addr string) (Server, error) {
+				return NewUnixServer(addr, 0, 0)
+			},
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				return client.NewStatsDUnix(client.Unix, addr)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			addr := testutil.GetAvailableLocalAddress(t)
+			addr := tt.addrFn(t)
 			srv, err := tt.buildServerFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, srv)
 
-			host, portStr, err := net.SplitHostPort(addr)
-			require.NoError(t, err)
-			port, err := strconv.Atoi(portStr)
-			require.NoError(t, err)
-
-			mc := new(exportertest.SinkMetricsExporter)
-			p := &protocol.StatsDParser{}
-			require.NoError(t, err)
+			p := protocol.NewStatsDParser(protocol.DefaultObserverConfig())
 			mr := NewMockReporter(1)
 
 			wgListenAndServe := sync.WaitGroup{}
 			wgListenAndServe.Add(1)
 			go func() {
 				defer wgListenAndServe.Done()
-				assert.Error(t, srv.ListenAndServe(p, mc, mr))
+				assert.Error(t, srv.ListenAndServe(p, mr))
 			}()
 
 			runtime.Gosched()
 
-			gc, err := tt.buildClientFn(host, port)
+			gc, err := tt.buildClientFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, gc)
 
@@ -95,16 +132,144 @@ func Test_Server_ListenAndServe(t *testing.T) {
 
 			wgListenAndServe.Wait()
 
-			mdd := mc.AllMetrics()
-			require.Len(t, mdd, 1)
-			ocmd := internaldata.MetricsToOC(mdd[0])
-			require.Len(t, ocmd, 1)
-			require.Len(t, ocmd[0].Metrics, 1)
-			metric := ocmd[0].Metrics[0]
-			assert.Equal(t, "test.metric", metric.GetMetricDescriptor().GetName())
-
-			// require.Equal(t, 1, len(mc.md))
-			// assert.Equal(t, "test.metric", mc.md[0].Metrics[0].GetMetricDescriptor().GetName())
+			md := p.GetMetrics()
+			require.Equal(t, 1, md.MetricCount())
+			metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, "test.metric", metric.Name())
 		})
 	}
 }
+
+func Test_Server_InvalidSettings(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	_, err := NewUDPServer(addr, -1)
+	assert.EqualError(t, err, "invalid max line size: -1")
+
+	_, err = NewTCPServer(addr, -1*time.Second, 0)
+	assert.EqualError(t, err, "invalid idle timeout: -1s")
+
+	_, err = NewTCPServer(addr, 0, -1)
+	assert.EqualError(t, err, "invalid max line size: -1")
+}
+
+func Test_Server_StaleSocket(t *testing.T) {
+	socketDir, err := ioutil.TempDir("", "statsd")
+	require.NoError(t, err)
+	defer os.RemoveAll(socketDir)
+
+	// A listener that is not closed through the server leaves its socket
+	// behind, as a crashed process would.
+	path := filepath.Join(socketDir, "unix.sock")
+	ln, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, ln.Close())
+
+	srv, err := NewUnixServer(path, 0, 0)
+	require.NoError(t, err)
+	assert.NoError(t, srv.Close())
+
+	pc, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+	require.NoError(t, pc.Close())
+
+	srv, err = NewUnixgramServer(path, 0)
+	require.NoError(t, err)
+	assert.NoError(t, srv.Close())
+
+	// Other files are never removed.
+	regularFile := filepath.Join(socketDir, "regular")
+	require.NoError(t, ioutil.WriteFile(regularFile, nil, 0600))
+	_, err = NewUnixServer(regularFile, 0, 0)
+	assert.EqualError(t, err, regularFile+" exists and is not a socket")
+	assert.FileExists(t, regularFile)
+}
+
+func Test_PacketServer_MaxLineSize(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewUDPServer(addr, 20)
+	require.NoError(t, err)
+
+	p := protocol.NewStatsDParser(protocol.DefaultObserverConfig())
+	mr := NewMockReporter(1)
+	go srv.ListenAndServe(p, mr)
+	defer srv.Close()
+
+	conn, err := net.Dial("udp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("test.metric:42|c\ntest.very.long.metric:42|c\n"))
+	require.NoError(t, err)
+
+	mr.WaitAllOnMetricsProcessedCalls()
+
+	md := p.GetMetrics()
+	require.Equal(t, 1, md.MetricCount())
+	metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "test.metric", metric.Name())
+}
+
+func Test_StreamServer_MaxLineSize(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 0, 20)
+	require.NoError(t, err)
+
+	p := protocol.NewStatsDParser(protocol.DefaultObserverConfig())
+	// Both lines are received by a single read and reported together.
+	mr := NewMockReporter(1)
+	go srv.ListenAndServe(p, mr)
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("test.metric:42|c\ntest.very.long.metric:42|c\n"))
+	require.NoError(t, err)
+
+	mr.WaitAllOnMetricsProcessedCalls()
+
+	// The server closes the connection after a line exceeding the max size.
+	assertConnClosed(t, conn)
+
+	md := p.GetMetrics()
+	require.Equal(t, 1, md.MetricCount())
+	metric := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "test.metric", metric.Name())
+}
+
+func Test_StreamServer_IdleTimeout(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 50*time.Millisecond, 0)
+	require.NoError(t, err)
+
+	p := protocol.NewStatsDParser(protocol.DefaultObserverConfig())
+	go srv.ListenAndServe(p, NewMockReporter(0))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The server closes the idle connection.
+	assertConnClosed(t, conn)
+}
+
+// assertConnClosed checks that the connection is closed by the server, either
+// gracefully or by a reset if some data was not read.
+func assertConnClosed(t *testing.T, conn net.Conn) {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err := conn.Read(make([]byte, 1))
+	require.Error(t, err)
+	if netErr, ok := err.(net.Error); ok {
+		assert.False(t, netErr.Timeout(), "connection not closed by the server")
+	}
+}
+
+func splitHostPort(t *testing.T, addr string) (string, int) {
+	host, portStr, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
@@ -0,0 +1,253 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+// streamServer is a transport.Server for connection oriented transports,
+// messages being separated by newlines.
+type streamServer struct {
+	transportName string
+	ln            net.Listener
+	// mu guards closed so that no connection handler is added to wg once
+	// Close started waiting on it.
+	mu          sync.Mutex
+	closed      bool
+	wg          sync.WaitGroup
+	idleTimeout time.Duration
+	maxLineSize int
+	reporter    Reporter
+}
+
+var _ (Server) = (*streamServer)(nil)
+
+// NewTCPServer creates a transport.Server using TCP as its transport.
+func NewTCPServer(addr string, idleTimeout time.Duration, maxLineSize int) (Server, error) {
+	return newStreamServer("tcp", "TCP", addr, idleTimeout, maxLineSize)
+}
+
+// NewUnixServer creates a transport.Server using a Unix stream socket,
+// created at the given path, as its transport.
+func NewUnixServer(path string, idleTimeout time.Duration, maxLineSize int) (Server, error) {
+	return newStreamServer("unix", "Unix", path, idleTimeout, maxLineSize)
+}
+
+func newStreamServer(
+	network string,
+	transportName string,
+	addr string,
+	idleTimeout time.Duration,
+	maxLineSize int,
+) (Server, error) {
+	if idleTimeout < 0 {
+		return nil, fmt.Errorf("invalid idle timeout: %v", idleTimeout)
+	}
+	if idleTimeout == 0 {
+		idleTimeout = IdleTimeoutDefault
+	}
+
+	maxLineSize, err := validateMaxLineSize(maxLineSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if network == "unix" {
+		if err = removeStaleSocket(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := streamServer{
+		transportName: transportName,
+		ln:            ln,
+		idleTimeout:   idleTimeout,
+		maxLineSize:   maxLineSize,
+	}
+	return &s, nil
+}
+
+func (s *streamServer) ListenAndServe(
+	parser protocol.Parser,
+	reporter Reporter,
+) error {
+	if parser == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	acceptedConnMap := make(map[net.Conn]struct{})
+	connMapMtx := &sync.Mutex{}
+
+	s.reporter = reporter
+	var err error
+	for {
+		conn, acceptErr := s.ln.Accept()
+		if acceptErr == nil {
+			s.mu.Lock()
+			if s.closed {
+				s.mu.Unlock()
+				conn.Close()
+				continue
+			}
+			s.wg.Add(1)
+			s.mu.Unlock()
+
+			connMapMtx.Lock()
+			acceptedConnMap[conn] = struct{}{}
+			connMapMtx.Unlock()
+			go func(c net.Conn) {
+				s.handleConnection(parser, c)
+				connMapMtx.Lock()
+				delete(acceptedConnMap, c)
+				connMapMtx.Unlock()
+				s.wg.Done()
+			}(conn)
+			continue
+		}
+
+		if netErr, ok := acceptErr.(net.Error); ok {
+			s.reporter.OnDebugf(
+				"%s Transport (%s) - Accept (temporary=%v) net.Error: %v",
+				s.transportName,
+				s.ln.Addr().String(),
+				netErr.Temporary(),
+				netErr)
+			if netErr.Temporary() {
+				continue
+			}
+		}
+
+		err = acceptErr
+		break
+	}
+
+	s.reporter.OnDebugf(
+		"%s Transport (%s) exiting Accept loop error: %v",
+		s.transportName,
+		s.ln.Addr().String(),
+		err)
+
+	// Close any lingering connection
+	connMapMtx.Lock()
+	for conn := range acceptedConnMap {
+		conn.Close()
+	}
+	connMapMtx.Unlock()
+
+	return err
+}
+
+func (s *streamServer) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	err := s.ln.Close()
+	s.wg.Wait()
+	return err
+}
+
+// handleConnection aggregates the messages received on the connection until
+// it is closed or idle. The messages are reported in batches, one per read
+// from the connection, instead of one by one.
+func (s *streamServer) handleConnection(
+	p protocol.Parser,
+	conn net.Conn,
+) {
+	defer conn.Close()
+
+	var ctx context.Context
+	var numReceivedMessages, numInvalidMessages int
+	report := func() {
+		if ctx == nil {
+			return
+		}
+		s.reporter.OnMetricsProcessed(ctx, numReceivedMessages, numInvalidMessages, nil)
+		ctx = nil
+		numReceivedMessages, numInvalidMessages = 0, 0
+	}
+	defer report()
+
+	// ReadSlice fails with bufio.ErrBufferFull on lines longer than the
+	// buffer, one extra byte being needed for the line terminator.
+	reader := bufio.NewReaderSize(conn, s.maxLineSize+1)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(s.idleTimeout)); err != nil {
+			s.reporter.OnDebugf(
+				"%s Transport (%s) - conn.SetDeadLine error: %v",
+				s.transportName,
+				s.ln.Addr(),
+				err)
+			return
+		}
+
+		// reader.ReadSlice call below will block until either:
+		//
+		// * a '\n' char is read
+		// * the connection is closed (either by client or server)
+		// * an idle timeout happens (see call to conn.SetDeadline above)
+		data, err := reader.ReadSlice('\n')
+		line := strings.TrimSpace(string(data))
+		if line != "" {
+			if ctx == nil {
+				ctx = s.reporter.OnDataReceived(context.Background())
+			}
+			numReceivedMessages++
+			if err == bufio.ErrBufferFull || len(line) > s.maxLineSize {
+				// The rest of the line can't be framed, the protocol doesn't
+				// account for returning errors so close the connection.
+				numInvalidMessages++
+				s.reporter.OnTranslationError(ctx, errLineTooLong)
+				return
+			}
+			if aggErr := p.Aggregate(line); aggErr != nil {
+				numInvalidMessages++
+				s.reporter.OnTranslationError(ctx, aggErr)
+			}
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				// Timeouts end here so idle connections are purged.
+				s.reporter.OnDebugf(
+					"%s Transport (%s) - error: %v",
+					s.transportName,
+					s.ln.Addr(),
+					err)
+			}
+			return
+		}
+
+		// Everything read from the connection so far was aggregated.
+		if reader.Buffered() == 0 {
+			report()
+		}
+	}
+}